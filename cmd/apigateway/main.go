@@ -131,6 +131,8 @@ type Route struct {
 	Permissions   []string
 	Tags          []string
 	RegexPriority int
+	// ResponseBuffering is left to the Kong default unless the route sets it, a streamed response turns it off.
+	ResponseBuffering *bool
 }
 
 // Security represents security settings in Swagger
@@ -140,7 +142,8 @@ type Security struct {
 
 // ExtraKey represents extra values in Swagger
 type ExtraKey struct {
-	Service *string `json:"service,omitempty"`
+	Service           *string `json:"service,omitempty"`
+	ResponseBuffering *bool   `json:"responseBuffering,omitempty"`
 }
 
 // Operation represents an operation in Swagger
@@ -221,6 +224,8 @@ func parseSwaggerRoutes(file []byte) ([]Route, error) {
 				Tags:          operation.Tags,
 				AuthBearer:    isAuthBearer,
 				RegexPriority: 10,
+
+				ResponseBuffering: operation.ExtraKey.ResponseBuffering,
 			}
 
 			for existingRouteName, route := range routeMap {
@@ -400,6 +405,8 @@ func createKongRoute(ctx context.Context, route Route) (*Route, error) {
 		Methods       []string `json:"methods"`
 		Tags          []string `json:"tags"`
 		RegexPriority int      `json:"regex_priority"`
+
+		ResponseBuffering *bool `json:"response_buffering,omitempty"`
 	}
 
 	payload := request{
@@ -408,6 +415,8 @@ func createKongRoute(ctx context.Context, route Route) (*Route, error) {
 		Methods:       []string{route.Method},
 		Tags:          route.Tags,
 		RegexPriority: route.RegexPriority,
+
+		ResponseBuffering: route.ResponseBuffering,
 	}
 
 	jsonPayload, err := json.Marshal(payload)
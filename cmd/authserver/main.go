@@ -16,8 +16,10 @@ import (
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/aclservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/auditservice"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/authservice"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/otpservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/passwordservice"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/permissionservice"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/roleservice"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
@@ -83,6 +85,10 @@ func main() {
 	authCache := authrepository.NewAuthCache(log, conf.Redis, cache)
 	tokenService := authservice.New(log, conf.Jwt, authCache, nil, nil)
 
+	if err = conf.OTP.Validate(); err != nil {
+		log.Fatal(logger.Internal, logger.Startup, err.Error(), nil)
+		return
+	}
 	otpCache := authrepository.NewOTPCache(log, conf.Redis, cache)
 	otpCacheService := otpservice.NewOTPCache(conf.OTP, otpCache)
 
@@ -92,17 +98,22 @@ func main() {
 	oauthService := oauth.New(log, conf.Oauth, clientProvider)
 
 	permissionService := permissionservice.New()
+	auditLogService := auditservice.New()
+	passwordService := passwordservice.NewHistoryService(conf.Password)
+
+	aclCache := authrepository.NewACLCache(log, conf.Redis, cache)
+	aclCacheService := aclservice.NewACLCacheService(conf.ACL, aclCache)
+	aclService := aclservice.New(userClient, aclCacheService)
 
 	roleCache := authrepository.NewRoleCache(log, conf.Redis, cache)
 	roleCacheService := roleservice.NewRoleCacheService(roleCache)
-	roleService := roleservice.New(roleCacheService)
-
-	aclService := aclservice.New(userClient)
+	roleService := roleservice.New(roleCacheService, aclCacheService)
 
 	healthHandler := handler.NewHealthHandler(trans)
-	authHandler := handler.NewAuthHandler(conf, trans, userClient, tokenService, otpCacheService, queue, oauthService, aclService, uowFactory)
+	authHandler := handler.NewAuthHandler(conf, trans, userClient, tokenService, otpCacheService, queue, oauthService, aclService, auditLogService, passwordService, uowFactory)
 	roleHandler := handler.NewRoleHandler(trans, roleService, uowFactory)
 	permissionHandler := handler.NewPermissionHandler(trans, permissionService, uowFactory)
+	auditLogHandler := handler.NewAuditLogHandler(trans, auditLogService, uowFactory)
 
 	// Init router
 	router, err := routes.NewRouter(log, conf, trans, *healthHandler)
@@ -110,7 +121,7 @@ func main() {
 		return
 	}
 
-	router = router.NewAuthRouter(*authHandler, *roleHandler, *permissionHandler, authCache)
+	router = router.NewAuthRouter(*authHandler, *roleHandler, *permissionHandler, *auditLogHandler, authCache)
 
 	listenAddr := fmt.Sprintf("%s:%s", conf.Auth.URL, conf.Auth.Port)
 	server := &http.Server{
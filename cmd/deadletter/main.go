@@ -0,0 +1,117 @@
+//go:build !test
+
+package main
+
+import (
+	"fmt"
+	"github.com/mohsenabedy91/polyglot-sentences/cmd/setup"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/spf13/cobra"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+var deadLetterCmd = &cobra.Command{Use: "deadletter"}
+
+func main() {
+	if err := deadLetterCmd.Execute(); err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+}
+
+const defaultListLimit = 20
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use:   "list [queue]",
+	Short: "Inspect the dead-letter queue of a queue",
+	Long:  `Print the messages that ran out of attempts with the error of their last attempt, the messages stay in the dead-letter queue.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		driver, log := connect()
+		defer driver.Close()
+
+		deadLetters, err := driver.DeadLetters(args[0], limit)
+		if err != nil {
+			log.Fatal(logger.Queue, logger.RabbitMQDeadLetter, fmt.Sprintf("Inspecting dead letters failed: %v", err), nil)
+			return
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(writer, "DEAD LETTERED AT\tATTEMPTS\tERROR\tBODY")
+		for _, deadLetter := range deadLetters {
+			_, _ = fmt.Fprintf(
+				writer,
+				"%s\t%d\t%s\t%s\n",
+				deadLetter.DeadLetteredAt.Format(time.RFC3339),
+				deadLetter.Attempts,
+				deadLetter.Error,
+				deadLetter.Body,
+			)
+		}
+		_ = writer.Flush()
+	},
+}
+
+// replayCmd represents the replay command
+var replayCmd = &cobra.Command{
+	Use:   "replay [queue]",
+	Short: "Replay the dead-letter queue of a queue",
+	Long:  `Move the dead-lettered messages back to their queue with fresh attempts, every message is replayed unless a limit is set.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		driver, log := connect()
+		defer driver.Close()
+
+		replayed, err := driver.Replay(args[0], limit)
+		if err != nil {
+			log.Fatal(logger.Queue, logger.RabbitMQDeadLetter, fmt.Sprintf("Replay failed after %d messages: %v", replayed, err), nil)
+			return
+		}
+
+		log.Info(logger.Queue, logger.RabbitMQDeadLetter, fmt.Sprintf("%d messages replayed to %s", replayed, args[0]), nil)
+	},
+}
+
+// deadLetterDriver is a queue driver keeping the dead letters outside the process, the memory driver keeps them
+// in the process that produced them so they can not be reached from here.
+type deadLetterDriver interface {
+	Close()
+	DeadLetters(name string, limit int) ([]messagebroker.DeadLetter, error)
+	Replay(name string, limit int) (int, error)
+}
+
+// connect opens the queue driver selected by QUEUE_DRIVER like the services do.
+func connect() (deadLetterDriver, logger.Logger) {
+	configProvider := &config.Config{}
+	conf := configProvider.GetConfig()
+	log := logger.NewLogger("deadletter", conf.Log)
+
+	if conf.Queue.Driver == config.QueueDriverMemory {
+		log.Fatal(logger.Queue, logger.Startup, "The dead letters of the memory driver only live in the process that produced them", nil)
+	}
+
+	queue, err := setup.InitializeQueue(log, conf)
+	if err != nil {
+		log.Fatal(logger.Queue, logger.Startup, fmt.Sprintf("Failed to connect to the queue, error: %v", err), nil)
+	}
+
+	driver, ok := queue.Driver.(deadLetterDriver)
+	if !ok {
+		log.Fatal(logger.Queue, logger.Startup, fmt.Sprintf("The %s driver keeps no dead letters", conf.Queue.Driver), nil)
+	}
+
+	return driver, log
+}
+
+var limit int
+
+func init() {
+	listCmd.Flags().IntVarP(&limit, "limit", "l", defaultListLimit, "Number of messages to print")
+	replayCmd.Flags().IntVarP(&limit, "limit", "l", 0, "Number of messages to replay, every message when it is not set")
+	deadLetterCmd.AddCommand(listCmd, replayCmd)
+}
@@ -3,9 +3,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres"
+	repository "github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/authrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/redis"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/redis/authrepository"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/aclservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/permissionservice"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
 	"github.com/spf13/cobra"
 	"log"
@@ -61,9 +67,82 @@ var downCmd = &cobra.Command{
 	},
 }
 
+// permissionsCmd represents the migrate command
+var permissionsCmd = &cobra.Command{
+	Use:   "permissions",
+	Short: "Sync the permission registry into the database",
+	Long:  `Upsert the permissions declared in domain.PermissionRegistry, report the orphan ones and optionally grant the permissions to the roles the registry declares for them when they lack them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		configProvider := &config.Config{}
+		conf := configProvider.GetConfig()
+		log := logger.NewLogger("migration", conf.Log)
+
+		ctx := context.Background()
+		if err := postgres.InitClient(ctx, log, conf); err != nil {
+			log.Fatal(logger.Database, logger.MigrationPermissions, fmt.Sprintf("Permission sync failed: %v", err), nil)
+			return
+		}
+		defer func() {
+			if err := postgres.Close(); err != nil {
+				log.Error(logger.Database, logger.MigrationPermissions, fmt.Sprintf("Failed to close database: %v", err), nil)
+			}
+		}()
+
+		uow := repository.NewUnitOfWork(log, postgres.Get())
+		if err := uow.BeginTx(ctx); err != nil {
+			log.Fatal(logger.Database, logger.MigrationPermissions, fmt.Sprintf("Permission sync failed: %v", err), nil)
+			return
+		}
+
+		report, err := permissionservice.New().Sync(uow, grant)
+		if err != nil {
+			_ = uow.Rollback()
+			log.Fatal(logger.Database, logger.MigrationPermissions, fmt.Sprintf("Permission sync failed: %v", err), nil)
+			return
+		}
+
+		if err = uow.Commit(); err != nil {
+			log.Fatal(logger.Database, logger.MigrationPermissions, fmt.Sprintf("Permission sync failed: %v", err), nil)
+			return
+		}
+
+		// the grant changes what the holders of the roles may do, their cached access is dropped like on a role change
+		if len(report.Granted) > 0 {
+			cache, cacheErr := redis.New(log, conf)
+			if cacheErr != nil {
+				log.Fatal(logger.Cache, logger.MigrationPermissions, fmt.Sprintf("Flushing the cached access failed: %v", cacheErr), nil)
+				return
+			}
+			defer func() {
+				_ = cache.Close()
+			}()
+
+			aclCacheService := aclservice.NewACLCacheService(conf.ACL, authrepository.NewACLCache(log, conf.Redis, cache))
+			if err = aclCacheService.Flush(ctx); err != nil {
+				log.Fatal(logger.Cache, logger.MigrationPermissions, fmt.Sprintf("Flushing the cached access failed: %v", err), nil)
+				return
+			}
+		}
+
+		for _, key := range report.Orphans {
+			log.Warn(logger.Database, logger.MigrationPermissions, fmt.Sprintf("Permission %s is not declared in the registry", key), nil)
+		}
+
+		log.Info(logger.Database, logger.MigrationPermissions, fmt.Sprintf(
+			"Permissions synced successfully, created: %v, updated: %v, orphans: %v, granted to: %v",
+			report.Created,
+			report.Updated,
+			report.Orphans,
+			report.Granted,
+		), nil)
+	},
+}
+
 var step int
+var grant bool
 
 func init() {
 	downCmd.Flags().IntVarP(&step, "step", "s", defaultMigrationStep, "Number of migrations to revert")
-	migrateCmd.AddCommand(upCmd, downCmd)
+	permissionsCmd.Flags().BoolVarP(&grant, "grant", "g", false, "Grant the permissions to the roles the registry declares for them when they lack them")
+	migrateCmd.AddCommand(upCmd, downCmd, permissionsCmd)
 }
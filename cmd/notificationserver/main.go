@@ -4,10 +4,12 @@ package main
 
 import (
 	"github.com/mohsenabedy91/polyglot-sentences/cmd/setup"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/email"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/grpc/client"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/event/authevent"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/event/userevent"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
 	"os"
 	"os/signal"
@@ -30,10 +32,26 @@ func main() {
 	userClient := client.NewUserClient(log, conf.UserManagement)
 	defer userClient.Close()
 
+	sendEmailOTP := authevent.NewSendEmailOTP(queue)
+	sendWelcome := authevent.NewSendWelcome(queue, userClient)
+	sendResetPasswordLink := authevent.NewSendResetPasswordLink(queue)
+	sendInvitation := userevent.NewSendInvitation(queue)
+	sendDataExport := userevent.NewSendDataExport(queue)
+
+	// every email is checked against the suppression list and recorded as a delivery
+	emailSender := email.NewTracked(log, email.New(log, conf), conf.Email.Driver, userClient)
+	sendEmailOTP.SetEmailSender(emailSender)
+	sendWelcome.SetEmailSender(emailSender)
+	sendResetPasswordLink.SetEmailSender(emailSender)
+	sendInvitation.SetEmailSender(emailSender)
+	sendDataExport.SetEmailSender(emailSender)
+
 	messagebroker.RegisterEvents(
-		authevent.NewSendEmailOTP(queue),
-		authevent.NewSendWelcome(queue, userClient),
-		authevent.NewSendResetPasswordLink(queue),
+		sendEmailOTP,
+		sendWelcome,
+		sendResetPasswordLink,
+		sendInvitation,
+		sendDataExport,
 		// add new queues here
 		// ...
 	)
@@ -43,4 +61,6 @@ func main() {
 	<-signalCh
 
 	log.Info(logger.Internal, logger.Shutdown, "Shutdown Server ...", nil)
+
+	setup.ShutdownQueue(log, conf, queue)
 }
@@ -0,0 +1,66 @@
+//go:build !test
+
+package main
+
+import (
+	"context"
+	"github.com/mohsenabedy91/polyglot-sentences/cmd/setup"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres"
+	repository "github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/userrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/outboxservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// main publishes the messages stored in the outbox by the other services, more than one relay can run
+// at the same time since every relay skips the messages locked by the others.
+func main() {
+	configProvider := &config.Config{}
+	conf := configProvider.GetConfig()
+	log := logger.NewLogger("outbox-relay", conf.Log)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func() {
+		if err := postgres.Close(); err != nil {
+			log.Fatal(logger.Database, logger.Startup, err.Error(), nil)
+		}
+	}()
+	postgresDB, err := setup.InitializeDatabase(ctx, log, conf)
+	if err != nil {
+		log.Fatal(logger.Database, logger.Startup, err.Error(), nil)
+		return
+	}
+	uowFactory := func() port.OutboxUnitOfWork {
+		return repository.NewUnitOfWork(log, postgresDB)
+	}
+
+	queue, err := setup.InitializeQueue(log, conf)
+	if err != nil {
+		return
+	}
+	defer queue.Driver.Close()
+
+	relay := outboxservice.NewRelay(log, conf.Outbox, queue.Driver)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		relay.Run(ctx, uowFactory)
+	}()
+
+	log.Info(logger.Queue, logger.Startup, "Outbox relay started", nil)
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	<-signalCh
+
+	log.Info(logger.Internal, logger.Shutdown, "Shutdown Outbox Relay ...", nil)
+
+	cancel()
+	<-done
+}
@@ -6,9 +6,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/localstorage"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/minio"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/redis"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/redis/queuerepository"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
 )
 
@@ -19,16 +24,75 @@ func InitializeDatabase(ctx context.Context, log logger.Logger, conf config.Conf
 	return postgres.Get(), nil
 }
 
+// InitializeQueue returns the queue on the driver selected by QUEUE_DRIVER, RabbitMQ unless it is set to memory or redis.
+// The processed messages are remembered in Redis, or in the process with the memory driver.
 func InitializeQueue(log logger.Logger, conf config.Config) (*messagebroker.Queue, error) {
 	queue := messagebroker.NewQueue(log, conf)
 
-	driver, err := messagebroker.NewRabbitMQ(conf.RabbitMQ.URL, log)
+	if conf.Queue.Driver == config.QueueDriverMemory {
+		queue.Driver = messagebroker.NewMemory(log, messagebroker.NewRetryPolicy(conf.Queue))
+		queue.Processed = messagebroker.NewMemoryProcessedMessages()
+		return queue, nil
+	}
+
+	client, err := redis.New(log, conf)
 	if err != nil {
 		log.Fatal(logger.Queue, logger.Startup, fmt.Sprintf("Failed to setup queue, error: %v", err), nil)
 		return nil, err
 	}
+	queue.Processed = queuerepository.NewProcessedMessageCache(log, conf.Redis, conf.Queue, client)
 
-	queue.Driver = driver
+	switch conf.Queue.Driver {
+	case config.QueueDriverRedis:
+		queue.Driver = messagebroker.NewRedisStream(log, client, conf)
+	case config.QueueDriverRabbitMQ:
+		driver, rabbitErr := messagebroker.NewRabbitMQ(conf.RabbitMQ, messagebroker.NewRetryPolicy(conf.Queue), log)
+		if rabbitErr != nil {
+			log.Fatal(logger.Queue, logger.Startup, fmt.Sprintf("Failed to setup queue, error: %v", rabbitErr), nil)
+			return nil, rabbitErr
+		}
+		queue.Driver = driver
+	default:
+		err = fmt.Errorf("unknown queue driver: %s", conf.Queue.Driver)
+		log.Fatal(logger.Queue, logger.Startup, err.Error(), nil)
+		return nil, err
+	}
 
 	return queue, nil
 }
+
+// ShutdownQueue stops the consumers of the queue and gives the messages in hand CONSUMER_SHUTDOWN_TIMEOUT_SECOND
+// to finish, the handlers still running after it are canceled.
+func ShutdownQueue(log logger.Logger, conf config.Config, queue *messagebroker.Queue) {
+	ctx, cancel := context.WithTimeout(context.Background(), conf.Consumer.ShutdownTimeoutSecond)
+	defer cancel()
+
+	if err := queue.Driver.Shutdown(ctx); err != nil {
+		log.Error(logger.Queue, logger.Shutdown, fmt.Sprintf("Consumers stopped before finishing their messages: %v", err), nil)
+		return
+	}
+
+	log.Info(logger.Queue, logger.Shutdown, "Consumers finished their messages", nil)
+}
+
+// InitializeObjectStorage returns the storage selected by STORAGE_DRIVER, MinIO unless it is set to local.
+func InitializeObjectStorage(ctx context.Context, log logger.Logger, conf config.Config) (port.ObjectStorage, error) {
+	switch conf.Storage.Driver {
+	case config.StorageDriverLocal:
+		storage, err := localstorage.NewLocalStorage(log, conf.Storage)
+		if err != nil {
+			return nil, err
+		}
+		return storage, nil
+	case config.StorageDriverMinio:
+		client, err := minio.NewMinioClient(ctx, log, conf.Minio)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	default:
+		err := fmt.Errorf("unknown storage driver: %s", conf.Storage.Driver)
+		log.Fatal(logger.Storage, logger.Startup, err.Error(), nil)
+		return nil, err
+	}
+}
@@ -6,15 +6,33 @@ import (
 	"context"
 	"fmt"
 	"github.com/mohsenabedy91/polyglot-sentences/cmd/setup"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/avatar"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/email"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/grpc/server"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/handler"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/routes"
-	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/minio"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/localstorage"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres"
 	repository "github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/userrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/redis"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/redis/authrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/redis/notificationrepository"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/event/userevent"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/aclservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/emaildeliveryservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/emailtemplateservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/invitationservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/notificationservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/passwordservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/preferenceservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/sentenceservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/uploadservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/userdataservice"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/userservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/views"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
 	"google.golang.org/grpc"
@@ -49,13 +67,87 @@ func main() {
 		return repository.NewUnitOfWork(log, postgresDB)
 	}
 
+	cache, err := redis.New(log, conf)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if cacheCloseErr := cache.Close(); cacheCloseErr != nil {
+			log.Fatal(logger.Cache, logger.Startup, cacheCloseErr.Error(), nil)
+		}
+	}()
+
+	queue, err := setup.InitializeQueue(log, conf)
+	if err != nil {
+		return
+	}
+	defer queue.Driver.Close()
+
 	trans := translation.NewTranslation(conf.App)
 	trans.GetLocalizer(conf.App.Locale)
 
+	// the access CheckAccess caches is dropped here when the roles of a user change or the user is erased
+	aclCacheService := aclservice.NewACLCacheService(conf.ACL, authrepository.NewACLCache(log, conf.Redis, cache))
+
 	userService := userservice.New(log)
+	invitationService := invitationservice.New(conf.Invitation, aclCacheService)
+	passwordService := passwordservice.NewHistoryService(conf.Password)
+	userDataService := userdataservice.New(aclCacheService)
+	if err = conf.Unsubscribe.Validate(); err != nil {
+		log.Fatal(logger.Internal, logger.Startup, err.Error(), nil)
+		return
+	}
+	preferenceService := preferenceservice.New(conf.Unsubscribe)
+	deliveryService := emaildeliveryservice.New()
+	sentenceService := sentenceservice.New()
+	// the notifications reach the streams through Redis, whichever instance holds the stream of the user
+	notificationService := notificationservice.New(log, notificationrepository.NewBroadcaster(log, conf.Redis, cache))
 
-	httpServer := startHTTPServer(ctx, log, conf, trans, userService, uowFactory)
-	grpcServer := startGRPCServer(conf, log, userService, uowFactory)
+	objectStorage, err := setup.InitializeObjectStorage(ctx, log, conf)
+	if err != nil {
+		log.Fatal(logger.Internal, logger.Startup, err.Error(), nil)
+		return
+	}
+
+	avatarStore := avatar.NewStore(conf.Avatar, objectStorage)
+	uploadSessionService := uploadservice.New(conf.Upload, objectStorage)
+
+	messagebroker.RegisterEvents(
+		userevent.NewExportUserData(queue, uowFactory, userDataService, objectStorage),
+		userevent.NewDeleteDataExport(queue, objectStorage),
+		userevent.NewEraseUserData(queue, uowFactory, userService, userDataService, objectStorage, avatarStore),
+		userevent.NewRemoveUserFiles(queue, uowFactory, userService, objectStorage, avatarStore),
+		userevent.NewRemoveAvatar(queue, uowFactory, userService, avatarStore),
+		userevent.NewAbandonUploadSession(queue, uowFactory, uploadSessionService),
+	)
+
+	httpServer := startHTTPServer(
+		log,
+		conf,
+		trans,
+		queue,
+		userService,
+		invitationService,
+		passwordService,
+		userDataService,
+		uowFactory,
+		objectStorage,
+		avatarStore,
+		uploadSessionService,
+		preferenceService,
+		deliveryService,
+		notificationService,
+		sentenceService,
+	)
+	grpcServer := startGRPCServer(
+		conf,
+		log,
+		userService,
+		preferenceService,
+		deliveryService,
+		notificationService,
+		uowFactory,
+	)
 
 	signalCh := make(chan os.Signal, 1)
 	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
@@ -63,6 +155,7 @@ func main() {
 
 	log.Info(logger.Internal, logger.Shutdown, "Shutdown Servers ...", nil)
 
+	setup.ShutdownQueue(log, conf, queue)
 	grpcServer.GracefulStop()
 	shutdownHTTPServer(ctx, httpServer, log, conf)
 }
@@ -71,9 +164,19 @@ func startGRPCServer(
 	conf config.Config,
 	log logger.Logger,
 	userService *userservice.UserService,
+	preferenceService *preferenceservice.Service,
+	deliveryService *emaildeliveryservice.Service,
+	notificationService *notificationservice.Service,
 	uowFactory func() port.UserUnitOfWork,
 ) *grpc.Server {
-	s := server.NewUserGRPCServer(conf.UserManagement, userService, uowFactory)
+	s := server.NewUserGRPCServer(
+		conf.UserManagement,
+		userService,
+		preferenceService,
+		deliveryService,
+		notificationService,
+		uowFactory,
+	)
 	grpcServer, err := s.StartUserGRPCServer()
 	if err != nil {
 		log.Fatal(logger.Internal, logger.Startup, err.Error(), nil)
@@ -85,20 +188,39 @@ func startGRPCServer(
 }
 
 func startHTTPServer(
-	ctx context.Context,
 	log logger.Logger,
 	conf config.Config,
 	trans translation.Translator,
+	queue *messagebroker.Queue,
 	userService *userservice.UserService,
+	invitationService *invitationservice.Service,
+	passwordService *passwordservice.HistoryService,
+	userDataService *userdataservice.Service,
 	uowFactory func() port.UserUnitOfWork,
+	objectStorage port.ObjectStorage,
+	avatarStore *avatar.Store,
+	uploadSessionService *uploadservice.Service,
+	preferenceService *preferenceservice.Service,
+	deliveryService *emaildeliveryservice.Service,
+	notificationService *notificationservice.Service,
+	sentenceService *sentenceservice.Service,
 ) *http.Server {
-	minioClient, err := minio.NewMinioClient(ctx, log, conf.Minio)
-	if err != nil {
-		log.Fatal(logger.Internal, logger.Startup, err.Error(), nil)
-		return nil
-	}
-
-	userHandler := handler.NewUserHandler(trans, userService, uowFactory, minioClient)
+	userHandler := handler.NewUserHandler(trans, userService, invitationService, userDataService, queue, uowFactory, objectStorage, avatarStore)
+	invitationHandler := handler.NewUserInvitationHandler(conf, trans, invitationService, passwordService, queue, uowFactory)
+	uploadSessionHandler := handler.NewUploadSessionHandler(trans, uploadSessionService, queue, uowFactory)
+	emailTemplateHandler := handler.NewEmailTemplateHandler(
+		trans,
+		emailtemplateservice.New(views.EmailTemplates(), conf.App, trans),
+	)
+	preferenceHandler := handler.NewNotificationPreferenceHandler(trans, preferenceService, uowFactory)
+	deliveryHandler := handler.NewEmailDeliveryHandler(
+		trans,
+		deliveryService,
+		email.NewSendGridWebhook(log, conf.Email.Webhook),
+		uowFactory,
+	)
+	notificationHandler := handler.NewNotificationHandler(conf.Stream, trans, notificationService, uowFactory)
+	sentenceHandler := handler.NewSentenceHandler(trans, sentenceService, uowFactory)
 	healthHandler := handler.NewHealthHandler(trans)
 
 	// Init router
@@ -108,13 +230,27 @@ func startHTTPServer(
 		return nil
 	}
 
-	router = router.NewUserRouter(*userHandler)
+	router = router.NewUserRouter(
+		*userHandler,
+		*invitationHandler,
+		*uploadSessionHandler,
+		*emailTemplateHandler,
+		*preferenceHandler,
+		*deliveryHandler,
+		*notificationHandler,
+		*sentenceHandler,
+	)
+	if storage, ok := objectStorage.(*localstorage.Storage); ok {
+		router = router.NewStorageRouter(*handler.NewStorageHandler(trans, storage))
+	}
 
 	listenAddr := fmt.Sprintf("%s:%s", conf.UserManagement.HTTPUrl, conf.UserManagement.HTTPPort)
 	httpServer := &http.Server{
 		Addr:    listenAddr,
 		Handler: router.Engine.Handler(),
 	}
+	// the streams stay open until the client leaves, they are ended so the shutdown does not wait for them
+	httpServer.RegisterOnShutdown(notificationHandler.Close)
 	log.Info(logger.Internal, logger.Startup, "Starting the HTTP server", map[logger.ExtraKey]interface{}{
 		logger.ListeningAddress: httpServer.Addr,
 	})
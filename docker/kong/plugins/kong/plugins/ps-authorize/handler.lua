@@ -80,6 +80,11 @@ function PsAuthorizeHandler:access(conf)
     kong.service.request.set_header('jti', data.jti)
     kong.service.request.set_header('exp', data.exp)
     kong.service.request.set_header('userID', data.id)
+    if type(data.permissions) == "table" then
+        kong.service.request.set_header('permissions', table.concat(data.permissions, ","))
+    else
+        kong.service.request.clear_header('permissions')
+    end
 end
 
 return PsAuthorizeHandler
@@ -4,7 +4,9 @@ local predefined_permissions = {
     "NONE", "CREATE_USER", "READ_USER", "UPDATE_USER", "DELETE_USER",
     "CREATE_ROLE", "READ_ROLE", "UPDATE_ROLE", "DELETE_ROLE",
     "READ_PERMISSION", "SYNC_ROLES_WITH_USER", "READ_USER_ROLES",
-    "SYNC_PERMISSIONS_WITH_ROLE", "READ_ROLE_PERMISSIONS"
+    "SYNC_PERMISSIONS_WITH_ROLE", "READ_ROLE_PERMISSIONS", "READ_AUDIT_LOG",
+    "ERASE_USER", "PREVIEW_EMAIL_TEMPLATE", "MANAGE_EMAIL_SUPPRESSION",
+    "UPDATE_SENTENCE", "UPDATE_OWN_SENTENCE", "DELETE_SENTENCE", "DELETE_OWN_SENTENCE"
 }
 
 local predefined_permissions_description = "Available permissions: " .. table.concat(predefined_permissions, ", ")
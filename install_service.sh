@@ -92,6 +92,13 @@ run_migrations() {
         echo "Failed to run migrations."
         exit 1
     fi
+
+    echo "Syncing permissions..."
+    go run cmd/migration/main.go permissions --grant
+    if [ $? -ne 0 ]; then
+        echo "Failed to sync permissions."
+        exit 1
+    fi
 }
 
 # Function to wait for Kong to be ready
@@ -0,0 +1,126 @@
+package avatar
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+)
+
+const jpegQuality = 90
+
+// Image is an encoded avatar ready to be stored under Key.
+type Image struct {
+	Key         string
+	ContentType string
+	Data        []byte
+}
+
+// Processed holds the re-encoded avatar and its square thumbnails.
+type Processed struct {
+	Original   Image
+	Thumbnails []Image
+}
+
+// Process validates an uploaded avatar and prepares it for storage. The type is sniffed from the content,
+// the declared one is ignored. Decoding and encoding again drops every metadata block, EXIF included,
+// after the EXIF orientation of a JPEG has been applied to the pixels. Objects are named by the SHA-256
+// of the encoded original, so uploading the same picture twice yields the same keys.
+func Process(reader io.Reader, conf config.Avatar) (*Processed, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, conf.MaxSize+1))
+	if err != nil {
+		return nil, serviceerror.NewServerError()
+	}
+	if int64(len(data)) > conf.MaxSize {
+		return nil, tooLarge(conf)
+	}
+
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+	default:
+		return nil, serviceerror.New(serviceerror.AvatarInvalid)
+	}
+
+	// the header is checked before decoding so a small file can not claim a huge canvas
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, serviceerror.New(serviceerror.AvatarInvalid)
+	}
+	if imageConfig.Width > conf.MaxDimension || imageConfig.Height > conf.MaxDimension {
+		return nil, tooLarge(conf)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, serviceerror.New(serviceerror.AvatarInvalid)
+	}
+
+	if contentType == "image/jpeg" {
+		img = orient(img, jpegOrientation(data))
+	}
+
+	// GIFs are stored as PNG, only the first frame is kept
+	ext, outputType := ".png", "image/png"
+	if contentType == "image/jpeg" {
+		ext, outputType = ".jpg", "image/jpeg"
+	}
+
+	original, err := encode(img, outputType)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(original)
+	key := domain.AvatarPrefix + hex.EncodeToString(sum[:]) + ext
+
+	processed := &Processed{
+		Original: Image{Key: key, ContentType: outputType, Data: original},
+	}
+	for _, size := range conf.ThumbnailSizes {
+		thumbnail, encodeErr := encode(thumbnail(img, size), outputType)
+		if encodeErr != nil {
+			return nil, encodeErr
+		}
+
+		processed.Thumbnails = append(processed.Thumbnails, Image{
+			Key:         domain.AvatarThumbnailKey(key, size),
+			ContentType: outputType,
+			Data:        thumbnail,
+		})
+	}
+
+	return processed, nil
+}
+
+func encode(img image.Image, contentType string) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+
+	var err error
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(buffer, img, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		err = png.Encode(buffer, img)
+	}
+	if err != nil {
+		return nil, serviceerror.NewServerError()
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// tooLarge reports the size limit in MB rounded up, a limit under 1 MB is shown as 1 rather than 0.
+func tooLarge(conf config.Avatar) error {
+	return serviceerror.New(serviceerror.AvatarTooLarge, map[string]interface{}{
+		"maxSize":      (conf.MaxSize + 1<<20 - 1) >> 20,
+		"maxDimension": conf.MaxDimension,
+	})
+}
@@ -0,0 +1,148 @@
+package avatar_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/avatar"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/require"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+var conf = config.Avatar{
+	MaxSize:        1 << 20,
+	MaxDimension:   512,
+	ThumbnailSizes: []int{16, 32},
+}
+
+func newImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 255 / width), G: uint8(y * 255 / height), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	buffer := new(bytes.Buffer)
+	require.NoError(t, png.Encode(buffer, img))
+	return buffer.Bytes()
+}
+
+// encodeJPEG encodes the image and inserts an EXIF segment carrying the orientation right after the SOI marker.
+func encodeJPEG(t *testing.T, img image.Image, orientation uint16) []byte {
+	buffer := new(bytes.Buffer)
+	require.NoError(t, jpeg.Encode(buffer, img, nil))
+	data := buffer.Bytes()
+
+	tiff := new(bytes.Buffer)
+	tiff.WriteString("MM")
+	_ = binary.Write(tiff, binary.BigEndian, uint16(42))
+	_ = binary.Write(tiff, binary.BigEndian, uint32(8))
+	_ = binary.Write(tiff, binary.BigEndian, uint16(1))
+	_ = binary.Write(tiff, binary.BigEndian, []uint16{0x0112, 3})
+	_ = binary.Write(tiff, binary.BigEndian, uint32(1))
+	_ = binary.Write(tiff, binary.BigEndian, []uint16{orientation, 0})
+	_ = binary.Write(tiff, binary.BigEndian, uint32(0))
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	return append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+}
+
+func errorMessage(t *testing.T, err error) serviceerror.ErrorMessage {
+	require.Error(t, err)
+	require.IsType(t, &serviceerror.ServiceError{}, err)
+	return err.(*serviceerror.ServiceError).GetErrorMessage()
+}
+
+func TestProcess_PNG(t *testing.T) {
+	data := encodePNG(t, newImage(80, 40))
+
+	processed, err := avatar.Process(bytes.NewReader(data), conf)
+	require.NoError(t, err)
+
+	require.Equal(t, "image/png", processed.Original.ContentType)
+	require.True(t, strings.HasPrefix(processed.Original.Key, domain.AvatarPrefix))
+	require.True(t, strings.HasSuffix(processed.Original.Key, ".png"))
+
+	require.Len(t, processed.Thumbnails, 2)
+	for i, size := range conf.ThumbnailSizes {
+		thumbnail := processed.Thumbnails[i]
+		require.Equal(t, domain.AvatarThumbnailKey(processed.Original.Key, size), thumbnail.Key)
+
+		img, err := png.Decode(bytes.NewReader(thumbnail.Data))
+		require.NoError(t, err)
+		require.Equal(t, image.Rect(0, 0, size, size), img.Bounds())
+	}
+
+	again, err := avatar.Process(bytes.NewReader(data), conf)
+	require.NoError(t, err)
+	require.Equal(t, processed.Original.Key, again.Original.Key)
+}
+
+func TestProcess_JPEG(t *testing.T) {
+	data := encodeJPEG(t, newImage(60, 30), 6)
+	require.True(t, bytes.Contains(data, []byte("Exif")))
+
+	processed, err := avatar.Process(bytes.NewReader(data), conf)
+	require.NoError(t, err)
+
+	require.Equal(t, "image/jpeg", processed.Original.ContentType)
+	require.True(t, strings.HasSuffix(processed.Original.Key, ".jpg"))
+	require.False(t, bytes.Contains(processed.Original.Data, []byte("Exif")))
+
+	// orientation 6 is a clockwise rotation, the landscape picture is stored as a portrait one
+	img, err := jpeg.Decode(bytes.NewReader(processed.Original.Data))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 30, 60), img.Bounds())
+}
+
+func TestProcess_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "Plain text", data: []byte("this is not an image")},
+		{name: "HTML", data: []byte("<html><body><img src=x onerror=alert(1)></body></html>")},
+		{name: "Truncated PNG", data: encodePNG(t, newImage(20, 20))[:40]},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			processed, err := avatar.Process(bytes.NewReader(test.data), conf)
+			require.Nil(t, processed)
+			require.Equal(t, serviceerror.AvatarInvalid, errorMessage(t, err))
+		})
+	}
+}
+
+func TestProcess_TooLarge(t *testing.T) {
+	t.Run("Size", func(t *testing.T) {
+		small := conf
+		small.MaxSize = 64
+
+		processed, err := avatar.Process(bytes.NewReader(encodePNG(t, newImage(40, 40))), small)
+		require.Nil(t, processed)
+		require.Equal(t, serviceerror.AvatarTooLarge, errorMessage(t, err))
+		require.Equal(t, int64(1), err.(*serviceerror.ServiceError).GetAttributes()["maxSize"])
+	})
+
+	t.Run("Dimension", func(t *testing.T) {
+		processed, err := avatar.Process(bytes.NewReader(encodePNG(t, newImage(conf.MaxDimension+1, 1))), conf)
+		require.Nil(t, processed)
+		require.Equal(t, serviceerror.AvatarTooLarge, errorMessage(t, err))
+	})
+}
@@ -0,0 +1,62 @@
+package avatar
+
+import (
+	"image"
+	"image/draw"
+)
+
+// thumbnail crops the centered square of the image and scales it to size x size pixels.
+func thumbnail(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	x0 := bounds.Min.X + (bounds.Dx()-side)/2
+	y0 := bounds.Min.Y + (bounds.Dy()-side)/2
+
+	return resize(toRGBA(img), image.Rect(x0, y0, x0+side, y0+side), size, size)
+}
+
+// resize scales the src area of the image to width x height pixels. Every destination pixel is the average
+// of the source pixels it covers, when enlarging it covers a single pixel so the result is a nearest neighbour.
+func resize(img *image.RGBA, src image.Rectangle, width, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		sy0 := src.Min.Y + y*src.Dy()/height
+		sy1 := max(src.Min.Y+(y+1)*src.Dy()/height, sy0+1)
+
+		for x := 0; x < width; x++ {
+			sx0 := src.Min.X + x*src.Dx()/width
+			sx1 := max(src.Min.X+(x+1)*src.Dx()/width, sx0+1)
+
+			var r, g, b, a, count uint64
+			for sy := sy0; sy < sy1; sy++ {
+				offset := img.PixOffset(sx0, sy)
+				for sx := sx0; sx < sx1; sx++ {
+					r += uint64(img.Pix[offset])
+					g += uint64(img.Pix[offset+1])
+					b += uint64(img.Pix[offset+2])
+					a += uint64(img.Pix[offset+3])
+					offset += 4
+					count++
+				}
+			}
+
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / count)
+			dst.Pix[offset+1] = uint8(g / count)
+			dst.Pix[offset+2] = uint8(b / count)
+			dst.Pix[offset+3] = uint8(a / count)
+		}
+	}
+
+	return dst
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok {
+		return rgba
+	}
+
+	rgba := image.NewRGBA(img.Bounds())
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	return rgba
+}
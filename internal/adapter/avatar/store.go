@@ -0,0 +1,107 @@
+package avatar
+
+import (
+	"bytes"
+	"context"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"mime/multipart"
+)
+
+// Store keeps processed avatars in the object storage and hands out presigned links to them.
+type Store struct {
+	conf    config.Avatar
+	storage port.ObjectStorage
+}
+
+// NewStore creates a new avatar store instance
+func NewStore(conf config.Avatar, storage port.ObjectStorage) *Store {
+	return &Store{
+		conf:    conf,
+		storage: storage,
+	}
+}
+
+// Upload processes the uploaded file and stores it with its thumbnails, the returned key is what the user row keeps.
+func (r *Store) Upload(ctx context.Context, file *multipart.FileHeader) (string, error) {
+	if file.Size > r.conf.MaxSize {
+		return "", tooLarge(r.conf)
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return "", serviceerror.NewServerError()
+	}
+	defer func(reader multipart.File) {
+		_ = reader.Close()
+	}(reader)
+
+	processed, err := Process(reader, r.conf)
+	if err != nil {
+		return "", err
+	}
+
+	for _, img := range append([]Image{processed.Original}, processed.Thumbnails...) {
+		if err = r.storage.Put(ctx, img.Key, bytes.NewReader(img.Data), int64(len(img.Data)), img.ContentType); err != nil {
+			return "", serviceerror.NewServerError()
+		}
+	}
+
+	return processed.Original.Key, nil
+}
+
+// Remove deletes a stored avatar and its thumbnails, avatars kept outside the storage are ignored.
+func (r *Store) Remove(ctx context.Context, avatar string) error {
+	if !domain.IsStoredAvatar(avatar) {
+		return nil
+	}
+
+	for _, key := range r.keys(avatar) {
+		if err := r.storage.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// URLs returns presigned links of the avatar and its thumbnails, nil when the user has no avatar.
+// Avatars kept outside the storage, like the picture of a Google account, are returned as they are.
+func (r *Store) URLs(ctx context.Context, avatar *string) *domain.AvatarURLs {
+	if avatar == nil || *avatar == "" {
+		return nil
+	}
+	if !domain.IsStoredAvatar(*avatar) {
+		return &domain.AvatarURLs{Original: *avatar}
+	}
+
+	original, err := r.storage.Presign(ctx, *avatar, r.conf.URLExpireSecond)
+	if err != nil {
+		return nil
+	}
+
+	urls := &domain.AvatarURLs{
+		Original:   original,
+		Thumbnails: make(map[int]string, len(r.conf.ThumbnailSizes)),
+	}
+	for _, size := range r.conf.ThumbnailSizes {
+		thumbnail, presignErr := r.storage.Presign(ctx, domain.AvatarThumbnailKey(*avatar, size), r.conf.URLExpireSecond)
+		if presignErr != nil {
+			continue
+		}
+		urls.Thumbnails[size] = thumbnail
+	}
+
+	return urls
+}
+
+func (r *Store) keys(avatar string) []string {
+	keys := []string{avatar}
+	for _, size := range r.conf.ThumbnailSizes {
+		keys = append(keys, domain.AvatarThumbnailKey(avatar, size))
+	}
+
+	return keys
+}
@@ -0,0 +1,87 @@
+package avatar_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/avatar"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/localstorage"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"mime/multipart"
+	"strings"
+	"testing"
+)
+
+func newStore(t *testing.T) (*avatar.Store, *localstorage.Storage) {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Info", logger.Storage, logger.Startup, mock.Anything, mock.Anything).Return()
+
+	storage, err := localstorage.NewLocalStorage(mockLogger, config.Storage{
+		LocalPath:   t.TempDir(),
+		LocalURL:    "http://localhost:2535/storage",
+		LocalSecret: "Qm7Tz2Vw9KcR4nXb8LsHd1YpF6GeJ3Ua",
+	})
+	require.NoError(t, err)
+
+	return avatar.NewStore(conf, storage), storage
+}
+
+func fileHeader(t *testing.T, data []byte) *multipart.FileHeader {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("avatar", "avatar.png")
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	form, err := multipart.NewReader(body, writer.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = form.RemoveAll()
+	})
+
+	return form.File["avatar"][0]
+}
+
+func TestStore(t *testing.T) {
+	store, storage := newStore(t)
+	ctx := context.Background()
+
+	key, err := store.Upload(ctx, fileHeader(t, encodePNG(t, newImage(40, 40))))
+	require.NoError(t, err)
+	require.True(t, domain.IsStoredAvatar(key))
+
+	keys, err := storage.List(ctx, domain.AvatarPrefix)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		key,
+		domain.AvatarThumbnailKey(key, 16),
+		domain.AvatarThumbnailKey(key, 32),
+	}, keys)
+
+	urls := store.URLs(ctx, &key)
+	require.NotNil(t, urls)
+	require.True(t, strings.HasPrefix(urls.Original, "http://localhost:2535/storage/"+key+"?"))
+	require.Len(t, urls.Thumbnails, 2)
+	require.Contains(t, urls.Thumbnails[16], domain.AvatarThumbnailKey(key, 16))
+
+	require.NoError(t, store.Remove(ctx, key))
+	keys, err = storage.List(ctx, domain.AvatarPrefix)
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
+
+func TestStore_URLs(t *testing.T) {
+	store, _ := newStore(t)
+
+	require.Nil(t, store.URLs(context.Background(), nil))
+	require.Nil(t, store.URLs(context.Background(), helper.StringPtr("")))
+
+	external := "https://lh3.googleusercontent.com/a/photo.jpg"
+	require.Equal(t, &domain.AvatarURLs{Original: external}, store.URLs(context.Background(), &external))
+}
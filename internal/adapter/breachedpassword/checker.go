@@ -0,0 +1,111 @@
+package breachedpassword
+
+import (
+	"bufio"
+	"crypto/sha1"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const prefixLength = 5
+
+// bundled holds the upper-case SHA-1 hashes of common passwords, one per line
+//
+//go:embed breached.txt
+var bundled string
+
+// Checker implements port.BreachedPasswordChecker using the k-anonymity layout of Have I Been Pwned:
+// the first five characters of the SHA-1 hash select a range, which is searched for the remaining suffix.
+// The bundled list is always searched, a directory of range files named <PREFIX>.txt holding
+// "SUFFIX:COUNT" lines can be added through config.Password.BreachedListPath.
+type Checker struct {
+	enabled bool
+	dir     string
+	ranges  map[string]map[string]struct{}
+}
+
+func New(conf config.Password) (*Checker, error) {
+	if conf.BreachedListPath != "" {
+		info, err := os.Stat(conf.BreachedListPath)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			return nil, errors.New("breached password list path must be a directory of range files")
+		}
+	}
+
+	ranges := make(map[string]map[string]struct{})
+	for _, line := range strings.Split(bundled, "\n") {
+		hash, _, _ := strings.Cut(strings.TrimSpace(line), ":")
+		if len(hash) <= prefixLength {
+			continue
+		}
+
+		hash = strings.ToUpper(hash)
+		prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+		if ranges[prefix] == nil {
+			ranges[prefix] = make(map[string]struct{})
+		}
+		ranges[prefix][suffix] = struct{}{}
+	}
+
+	return &Checker{
+		enabled: conf.BreachedCheck,
+		dir:     conf.BreachedListPath,
+		ranges:  ranges,
+	}, nil
+}
+
+func (r *Checker) IsBreached(password string) (bool, error) {
+	if !r.enabled {
+		return false, nil
+	}
+
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+
+	if _, ok := r.ranges[prefix][suffix]; ok {
+		return true, nil
+	}
+
+	if r.dir == "" {
+		return false, nil
+	}
+
+	return r.searchRange(prefix, suffix)
+}
+
+func (r *Checker) searchRange(prefix string, suffix string) (bool, error) {
+	file, err := os.Open(filepath.Join(r.dir, prefix+".txt"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		candidate, count, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		// padded entries of the range API carry a zero count and are not real breaches
+		if count == "0" {
+			continue
+		}
+		if strings.EqualFold(candidate, suffix) {
+			return true, nil
+		}
+	}
+
+	return false, scanner.Err()
+}
@@ -0,0 +1,73 @@
+package breachedpassword_test
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/breachedpassword"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sha1Hash(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func TestChecker_IsBreached(t *testing.T) {
+	t.Run("Bundled list", func(t *testing.T) {
+		checker, err := breachedpassword.New(config.Password{BreachedCheck: true})
+		require.NoError(t, err)
+
+		breached, err := checker.IsBreached("P@ssw0rd")
+		require.NoError(t, err)
+		require.True(t, breached)
+
+		breached, err = checker.IsBreached("Vq7#mTz!pL2x")
+		require.NoError(t, err)
+		require.False(t, breached)
+	})
+
+	t.Run("Range files directory", func(t *testing.T) {
+		dir := t.TempDir()
+		hash := sha1Hash("Corr3ct-Horse")
+		padded := sha1Hash("Batt3ry-Staple")
+
+		content := hash[5:] + ":42\n" + strings.Repeat("0", 35) + ":0\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, hash[:5]+".txt"), []byte(content), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, padded[:5]+".txt"), []byte(padded[5:]+":0\n"), 0o600))
+
+		checker, err := breachedpassword.New(config.Password{BreachedCheck: true, BreachedListPath: dir})
+		require.NoError(t, err)
+
+		breached, err := checker.IsBreached("Corr3ct-Horse")
+		require.NoError(t, err)
+		require.True(t, breached)
+
+		breached, err = checker.IsBreached("Batt3ry-Staple")
+		require.NoError(t, err)
+		require.False(t, breached)
+
+		breached, err = checker.IsBreached("Vq7#mTz!pL2x")
+		require.NoError(t, err)
+		require.False(t, breached)
+	})
+
+	t.Run("Disabled check", func(t *testing.T) {
+		checker, err := breachedpassword.New(config.Password{BreachedCheck: false})
+		require.NoError(t, err)
+
+		breached, err := checker.IsBreached("P@ssw0rd")
+		require.NoError(t, err)
+		require.False(t, breached)
+	})
+
+	t.Run("Invalid list path", func(t *testing.T) {
+		checker, err := breachedpassword.New(config.Password{BreachedCheck: true, BreachedListPath: filepath.Join(t.TempDir(), "missing")})
+		require.Error(t, err)
+		require.Nil(t, checker)
+	})
+}
@@ -15,5 +15,20 @@ const (
 )
 
 const (
-	UserSuccessCreate = "user.success.created"
+	UserSuccessCreate             = "user.success.created"
+	UserSuccessInvitationResent   = "user.success.invitationResent"
+	UserSuccessInvitationRevoked  = "user.success.invitationRevoked"
+	UserSuccessInvitationAccepted = "user.success.invitationAccepted"
+	UserSuccessExportRequested    = "user.success.exportRequested"
+	UserSuccessErasureRequested   = "user.success.erasureRequested"
+	UserSuccessPreferencesUpdated = "user.success.preferencesUpdated"
+	UserSuccessUnsubscribed       = "user.success.unsubscribed"
+
+	UserSuccessEmailSuppressionDeleted = "user.success.emailSuppressionDeleted"
+	UserSuccessNotificationsRead       = "user.success.notificationsRead"
+)
+
+const (
+	SentenceSuccessUpdated = "sentence.success.updated"
+	SentenceSuccessDeleted = "sentence.success.deleted"
 )
@@ -0,0 +1,26 @@
+package email
+
+import (
+	"fmt"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"os"
+)
+
+// New returns the sender selected by EMAIL_DRIVER, SendGrid unless it is set to smtp, file or console.
+func New(log logger.Logger, conf config.Config) port.EmailSender {
+	switch conf.Email.Driver {
+	case config.EmailDriverSMTP:
+		return NewSMTP(log, conf.Email)
+	case config.EmailDriverFile:
+		return NewFile(log, conf.Email)
+	case config.EmailDriverConsole:
+		return NewConsole(log, conf.Email, os.Stdout)
+	case config.EmailDriverSendGrid, "":
+		return NewSender(log, conf.SendGrid)
+	default:
+		log.Fatal(logger.Email, logger.Startup, fmt.Sprintf("unknown email driver: %s", conf.Email.Driver), nil)
+		return NewSender(log, conf.SendGrid)
+	}
+}
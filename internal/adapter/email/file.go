@@ -0,0 +1,90 @@
+package email
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// File keeps the emails instead of sending them, either as .eml files in a directory, which any mail client
+// opens, or printed to a writer.
+type File struct {
+	log  logger.Logger
+	conf config.Email
+
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewFile writes every email to its own file under conf.FilePath, the directory is created on the first email.
+func NewFile(log logger.Logger, conf config.Email) *File {
+	return &File{
+		log:  log,
+		conf: conf,
+	}
+}
+
+// NewConsole prints every email to out.
+func NewConsole(log logger.Logger, conf config.Email, out io.Writer) *File {
+	return &File{
+		log:  log,
+		conf: conf,
+		out:  out,
+	}
+}
+
+func (r *File) Send(email domain.Email) error {
+	extra := map[logger.ExtraKey]interface{}{
+		"To":      email.To,
+		"Address": r.conf.FromAddress,
+		"Subject": email.Subject,
+	}
+
+	msg, err := message(r.conf.FromName, r.conf.FromAddress, email)
+	if err == nil {
+		if r.out != nil {
+			err = r.print(msg)
+		} else {
+			var path string
+			path, err = r.write(msg)
+			extra["Path"] = path
+		}
+	}
+	if err != nil {
+		r.log.Error(logger.Email, logger.FileSendEmail, err.Error(), extra)
+		return serviceerror.New(serviceerror.FailedSendEmail)
+	}
+
+	r.log.Info(logger.Email, logger.FileSendEmail, "Email sent successfully", extra)
+	return nil
+}
+
+func (r *File) print(msg []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err := fmt.Fprintf(r.out, "%s\r\n\r\n", msg)
+	return err
+}
+
+// write stores the message in a file named after the time it was sent, so the directory lists them in order.
+func (r *File) write(msg []byte) (string, error) {
+	if err := os.MkdirAll(r.conf.FilePath, 0755); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(
+		r.conf.FilePath,
+		fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), uuid.NewString()),
+	)
+
+	return path, os.WriteFile(path, msg, 0644)
+}
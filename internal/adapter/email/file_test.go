@@ -0,0 +1,155 @@
+package email_test
+
+import (
+	"bytes"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/email"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFile_Send(t *testing.T) {
+	conf := config.Email{
+		Driver:      config.EmailDriverFile,
+		FromName:    "Polyglot Sentences",
+		FromAddress: "support@polyglot-sentences.com",
+		FilePath:    filepath.Join(t.TempDir(), "emails"),
+	}
+
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Info", logger.Email, logger.FileSendEmail, "Email sent successfully", mock.Anything).Return()
+
+	sender := email.NewFile(mockLogger, conf)
+	require.NoError(t, sender.Send(domain.Email{To: "john@example.com", Name: "John Doe", Subject: "Your code", HTML: "<p>123456</p>"}))
+	require.NoError(t, sender.Send(domain.Email{To: "jane@example.com", Name: "Jane Doe", Subject: "Your code", HTML: "<p>654321</p>"}))
+
+	files, err := filepath.Glob(filepath.Join(conf.FilePath, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	file, err := os.Open(files[0])
+	require.NoError(t, err)
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+
+	parsed, err := mail.ReadMessage(file)
+	require.NoError(t, err)
+	require.Equal(t, `"John Doe" <john@example.com>`, parsed.Header.Get("To"))
+	require.Equal(t, "Your code", parsed.Header.Get("Subject"))
+
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	require.NoError(t, err)
+	require.Equal(t, "<p>123456</p>", string(body))
+
+	mockLogger.AssertExpectations(t)
+}
+
+func TestConsole_Send(t *testing.T) {
+	conf := config.Email{
+		Driver:      config.EmailDriverConsole,
+		FromName:    "Polyglot Sentences",
+		FromAddress: "support@polyglot-sentences.com",
+	}
+
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Info", logger.Email, logger.FileSendEmail, "Email sent successfully", mock.Anything).Return()
+
+	var out bytes.Buffer
+	sender := email.NewConsole(mockLogger, conf, &out)
+	require.NoError(t, sender.Send(domain.Email{To: "john@example.com", Name: "John Doe", Subject: "Your code", HTML: "<p>123456</p>"}))
+
+	parsed, err := mail.ReadMessage(&out)
+	require.NoError(t, err)
+	require.Equal(t, `"Polyglot Sentences" <support@polyglot-sentences.com>`, parsed.Header.Get("From"))
+	require.Equal(t, "Your code", parsed.Header.Get("Subject"))
+
+	mockLogger.AssertExpectations(t)
+}
+
+func TestConsole_Send_Unsubscribe(t *testing.T) {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Info", logger.Email, logger.FileSendEmail, "Email sent successfully", mock.Anything).Return()
+
+	content := domain.Email{To: "john@example.com", Name: "John Doe", Subject: "Welcome", HTML: "<p>Welcome</p>"}
+	content.SetUnsubscribe("https://polyglot-sentences.com/en/v1/notifications/unsubscribe?token=abc")
+
+	var out bytes.Buffer
+	sender := email.NewConsole(mockLogger, config.Email{FromAddress: "support@polyglot-sentences.com"}, &out)
+	require.NoError(t, sender.Send(content))
+
+	parsed, err := mail.ReadMessage(&out)
+	require.NoError(t, err)
+	require.Equal(t, "<https://polyglot-sentences.com/en/v1/notifications/unsubscribe?token=abc>", parsed.Header.Get("List-Unsubscribe"))
+	require.Equal(t, "List-Unsubscribe=One-Click", parsed.Header.Get("List-Unsubscribe-Post"))
+
+	mockLogger.AssertExpectations(t)
+}
+
+func TestConsole_Send_Alternative(t *testing.T) {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Info", logger.Email, logger.FileSendEmail, "Email sent successfully", mock.Anything).Return()
+
+	var out bytes.Buffer
+	sender := email.NewConsole(mockLogger, config.Email{FromAddress: "support@polyglot-sentences.com"}, &out)
+	require.NoError(t, sender.Send(domain.Email{
+		To:      "john@example.com",
+		Name:    "John Doe",
+		Subject: "Your code",
+		HTML:    "<p>123456</p>",
+		Text:    "123456",
+	}))
+
+	parsed, err := mail.ReadMessage(&out)
+	require.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	var parts []string
+	for _, contentType := range []string{"text/plain; charset=UTF-8", "text/html; charset=UTF-8"} {
+		part, partErr := reader.NextPart()
+		require.NoError(t, partErr)
+		require.Equal(t, contentType, part.Header.Get("Content-Type"))
+
+		// the reader decodes the quoted-printable parts by itself
+		body, readErr := io.ReadAll(part)
+		require.NoError(t, readErr)
+		parts = append(parts, string(body))
+	}
+	require.Equal(t, []string{"123456", "<p>123456</p>"}, parts)
+
+	mockLogger.AssertExpectations(t)
+}
+
+func TestNew(t *testing.T) {
+	mockLogger := new(logger.MockLogger)
+
+	tests := []struct {
+		driver string
+		sender interface{}
+	}{
+		{driver: config.EmailDriverSendGrid, sender: &email.SendGrid{}},
+		{driver: config.EmailDriverSMTP, sender: &email.SMTP{}},
+		{driver: config.EmailDriverFile, sender: &email.File{}},
+		{driver: config.EmailDriverConsole, sender: &email.File{}},
+	}
+	for _, test := range tests {
+		t.Run(test.driver, func(t *testing.T) {
+			sender := email.New(mockLogger, config.Config{Email: config.Email{Driver: test.driver}})
+			require.IsType(t, test.sender, sender)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+)
+
+// message renders the email as a MIME message the way it is handed to a mail server, an email with a text part
+// is sent as multipart/alternative with the text first, so clients prefer the HTML part when they can show it.
+func message(fromName string, fromAddress string, email domain.Email) ([]byte, error) {
+	from := mail.Address{Name: fromName, Address: fromAddress}
+	recipient := mail.Address{Name: email.Name, Address: email.To}
+
+	domainName := "localhost"
+	if at := strings.LastIndex(fromAddress, "@"); at != -1 {
+		domainName = fromAddress[at+1:]
+	}
+
+	messageID := email.ID
+	if messageID == "" {
+		messageID = uuid.NewString()
+	}
+
+	var buf bytes.Buffer
+	headers := [][2]string{
+		{"From", from.String()},
+		{"To", recipient.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", email.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%s@%s>", messageID, domainName)},
+		{"MIME-Version", "1.0"},
+	}
+	names := make([]string, 0, len(email.Headers))
+	for name := range email.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		headers = append(headers, [2]string{name, email.Headers[name]})
+	}
+	for _, header := range headers {
+		buf.WriteString(header[0] + ": " + header[1] + "\r\n")
+	}
+
+	if email.Text == "" {
+		buf.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+		buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		if err := writeQuotedPrintable(&buf, email.HTML); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	writer := multipart.NewWriter(&buf)
+	buf.WriteString("Content-Type: multipart/alternative; boundary=" + writer.Boundary() + "\r\n\r\n")
+	for _, part := range [][2]string{{"text/plain", email.Text}, {"text/html", email.HTML}} {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part[0] + "; charset=UTF-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err = writeQuotedPrintable(partWriter, part[1]); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, content string) error {
+	writer := quotedprintable.NewWriter(w)
+	if _, err := writer.Write([]byte(content)); err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
@@ -1,6 +1,7 @@
 package email
 
 import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
 	"github.com/sendgrid/rest"
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
 	"github.com/stretchr/testify/mock"
@@ -10,8 +11,8 @@ type MockSendGrid struct {
 	mock.Mock
 }
 
-func (r *MockSendGrid) Send(to string, name string, subject string, body string) error {
-	args := r.Called(to, name, subject, body)
+func (r *MockSendGrid) Send(email domain.Email) error {
+	args := r.Called(email)
 	return args.Error(0)
 }
 
@@ -2,6 +2,7 @@ package email
 
 import (
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
 	"github.com/sendgrid/rest"
@@ -32,25 +33,34 @@ func (r *SendGrid) SetClient(client SendGridClient) {
 	r.client = client
 }
 
-func (r *SendGrid) Send(to string, name string, subject string, body string) error {
+func (r *SendGrid) Send(email domain.Email) error {
 	message := mail.NewV3Mail()
 	message.SetFrom(mail.NewEmail(r.conf.Name, r.conf.Address))
-	message.Subject = subject
+	message.Subject = email.Subject
+	for name, value := range email.Headers {
+		message.SetHeader(name, value)
+	}
 
 	personalization := mail.NewPersonalization()
-	personalization.AddTos(mail.NewEmail(name, to))
+	personalization.AddTos(mail.NewEmail(email.Name, email.To))
+	if email.ID != "" {
+		personalization.SetCustomArg(deliveryIDArg, email.ID)
+	}
 
-	content := mail.NewContent("text/html", body)
-	message.AddContent(content)
+	// SendGrid expects the plaintext content before the HTML one
+	if email.Text != "" {
+		message.AddContent(mail.NewContent("text/plain", email.Text))
+	}
+	message.AddContent(mail.NewContent("text/html", email.HTML))
 
 	message.AddPersonalizations(personalization)
 
 	response, err := r.client.Send(message)
 
 	extra := map[logger.ExtraKey]interface{}{
-		"To":       to,
+		"To":       email.To,
 		"Address":  r.conf.Address,
-		"Body":     body,
+		"Body":     email.HTML,
 		"Response": response,
 	}
 	if err != nil {
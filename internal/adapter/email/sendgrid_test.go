@@ -4,6 +4,7 @@ import (
 	"github.com/go-faker/faker/v4"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/email"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
 	"github.com/sendgrid/rest"
@@ -38,7 +39,7 @@ func TestSend_Successful(t *testing.T) {
 	subject := faker.Word()
 	body := faker.Sentence()
 
-	err := sender.Send(to, name, subject, body)
+	err := sender.Send(domain.Email{To: to, Name: name, Subject: subject, HTML: body})
 	require.NoError(t, err)
 
 	mockLogger.AssertExpectations(t)
@@ -69,7 +70,7 @@ func TestSend_Failure(t *testing.T) {
 	subject := faker.Word()
 	body := faker.Sentence()
 
-	err := sender.Send(to, name, subject, body)
+	err := sender.Send(domain.Email{To: to, Name: name, Subject: subject, HTML: body})
 	require.Error(t, err)
 	require.Equal(t, mockError, err)
 
@@ -112,7 +113,7 @@ func TestSend_LoggingSuccess(t *testing.T) {
 	}
 	mockLogger.On("Info", logger.SendGrid, logger.SendGridSendEmail, "Email sent successfully", extra).Return()
 
-	err := sender.Send(to, name, subject, body)
+	err := sender.Send(domain.Email{To: to, Name: name, Subject: subject, HTML: body})
 	require.NoError(t, err)
 
 	mockLogger.AssertExpectations(t)
@@ -150,7 +151,7 @@ func TestSend_LoggingFailure(t *testing.T) {
 	}
 	mockLogger.On("Error", logger.SendGrid, logger.SendGridSendEmail, mockError.Error(), extra).Return()
 
-	err := sender.Send(to, name, subject, body)
+	err := sender.Send(domain.Email{To: to, Name: name, Subject: subject, HTML: body})
 	require.Error(t, err)
 	require.Equal(t, mockError, err)
 
@@ -0,0 +1,135 @@
+package email
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/sendgrid/sendgrid-go/helpers/eventwebhook"
+	"strconv"
+	"time"
+)
+
+// deliveryIDArg is the custom argument the delivery UUID is sent with, SendGrid adds it to every event.
+const deliveryIDArg = "delivery_id"
+
+type sendGridEvent struct {
+	Email      string `json:"email"`
+	Event      string `json:"event"`
+	Type       string `json:"type"`
+	Reason     string `json:"reason"`
+	DeliveryID string `json:"delivery_id"`
+}
+
+// SendGridWebhook implements port.EmailWebhook for the signed event webhook of SendGrid.
+type SendGridWebhook struct {
+	log       logger.Logger
+	conf      config.EmailWebhook
+	publicKey *ecdsa.PublicKey
+}
+
+// NewSendGridWebhook parses the verification key, without a valid key every request is rejected.
+func NewSendGridWebhook(log logger.Logger, conf config.EmailWebhook) *SendGridWebhook {
+	webhook := &SendGridWebhook{
+		log:  log,
+		conf: conf,
+	}
+
+	publicKey, err := parsePublicKey(conf.PublicKey)
+	if err != nil {
+		log.Warn(logger.SendGrid, logger.Startup, "The event webhook verification key is invalid: "+err.Error(), nil)
+		return webhook
+	}
+	webhook.publicKey = publicKey
+
+	return webhook
+}
+
+// Parse verifies the signature of the payload and returns the events about deliveries, bounces and spam
+// reports. A hard bounce and a spam report suppress the address, a blocked email bounces without it.
+func (r *SendGridWebhook) Parse(payload []byte, signature string, timestamp string) ([]domain.EmailEvent, error) {
+	if !r.verify(payload, signature, timestamp) {
+		return nil, serviceerror.New(serviceerror.InvalidWebhookSignature)
+	}
+
+	var sendGridEvents []sendGridEvent
+	if err := json.Unmarshal(payload, &sendGridEvents); err != nil {
+		return nil, serviceerror.New(serviceerror.InvalidRequestBody)
+	}
+
+	events := make([]domain.EmailEvent, 0, len(sendGridEvents))
+	for _, sendGridEvent := range sendGridEvents {
+		event := domain.EmailEvent{
+			DeliveryUUID: sendGridEvent.DeliveryID,
+			Email:        sendGridEvent.Email,
+			Reason:       sendGridEvent.Reason,
+		}
+
+		switch sendGridEvent.Event {
+		case "delivered":
+			event.Status = domain.EmailDeliveryStatusDelivered
+		case "bounce":
+			event.Status = domain.EmailDeliveryStatusBounced
+			if sendGridEvent.Type != "blocked" {
+				event.Suppression = domain.EmailSuppressionReasonBounce
+			}
+		case "spamreport":
+			event.Status = domain.EmailDeliveryStatusSpamReported
+			event.Suppression = domain.EmailSuppressionReasonSpamReport
+		default:
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (r *SendGridWebhook) verify(payload []byte, signature string, timestamp string) bool {
+	if r.publicKey == nil || signature == "" {
+		return false
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	if age := time.Since(time.Unix(seconds, 0)); age > r.conf.ToleranceSecond || age < -r.conf.ToleranceSecond {
+		return false
+	}
+
+	verified, err := eventwebhook.VerifySignature(r.publicKey, payload, signature, timestamp)
+
+	return err == nil && verified
+}
+
+// parsePublicKey does what eventwebhook.ConvertPublicKeyBase64ToECDSA does without panicking on a key that is
+// not an ECDSA one.
+func parsePublicKey(base64PublicKey string) (*ecdsa.PublicKey, error) {
+	if base64PublicKey == "" {
+		return nil, errors.New("the key is not set")
+	}
+
+	der, err := base64.StdEncoding.DecodeString(base64PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+
+	ecdsaPublicKey, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("the key is not an ECDSA key")
+	}
+
+	return ecdsaPublicKey, nil
+}
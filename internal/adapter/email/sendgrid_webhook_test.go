@@ -0,0 +1,112 @@
+package email_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/email"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const webhookPayload = `[
+	{"email":"john@example.com","event":"delivered","delivery_id":"6f1c1a62-57a8-4c47-9f39-7f0f3b8d1c11"},
+	{"email":"jane@example.com","event":"bounce","type":"bounce","reason":"550 5.1.1 unknown user","delivery_id":"0b8f3c3e-2d0a-4f5e-8f73-0a1c6d2b9e42"},
+	{"email":"joe@example.com","event":"bounce","type":"blocked","reason":"IP blocked"},
+	{"email":"jim@example.com","event":"spamreport"},
+	{"email":"john@example.com","event":"open"}
+]`
+
+func newWebhookKey(t *testing.T) (*ecdsa.PrivateKey, config.EmailWebhook) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+
+	return privateKey, config.EmailWebhook{
+		PublicKey:       base64.StdEncoding.EncodeToString(der),
+		ToleranceSecond: 5 * time.Minute,
+	}
+}
+
+func sign(t *testing.T, privateKey *ecdsa.PrivateKey, payload string, timestamp string) string {
+	hash := sha256.Sum256([]byte(timestamp + payload))
+	signature, err := ecdsa.SignASN1(rand.Reader, privateKey, hash[:])
+	require.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString(signature)
+}
+
+func TestSendGridWebhook_Parse(t *testing.T) {
+	privateKey, conf := newWebhookKey(t)
+	webhook := email.NewSendGridWebhook(new(logger.MockLogger), conf)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	t.Run("Parse signed events", func(t *testing.T) {
+		events, err := webhook.Parse([]byte(webhookPayload), sign(t, privateKey, webhookPayload, timestamp), timestamp)
+
+		require.NoError(t, err)
+		require.Equal(t, []domain.EmailEvent{
+			{
+				DeliveryUUID: "6f1c1a62-57a8-4c47-9f39-7f0f3b8d1c11",
+				Email:        "john@example.com",
+				Status:       domain.EmailDeliveryStatusDelivered,
+			},
+			{
+				DeliveryUUID: "0b8f3c3e-2d0a-4f5e-8f73-0a1c6d2b9e42",
+				Email:        "jane@example.com",
+				Status:       domain.EmailDeliveryStatusBounced,
+				Reason:       "550 5.1.1 unknown user",
+				Suppression:  domain.EmailSuppressionReasonBounce,
+			},
+			{
+				Email:  "joe@example.com",
+				Status: domain.EmailDeliveryStatusBounced,
+				Reason: "IP blocked",
+			},
+			{
+				Email:       "jim@example.com",
+				Status:      domain.EmailDeliveryStatusSpamReported,
+				Suppression: domain.EmailSuppressionReasonSpamReport,
+			},
+		}, events)
+	})
+
+	t.Run("Parse tampered payload", func(t *testing.T) {
+		signature := sign(t, privateKey, webhookPayload, timestamp)
+
+		_, err := webhook.Parse([]byte(`[{"email":"john@example.com","event":"spamreport"}]`), signature, timestamp)
+
+		require.Equal(t, serviceerror.New(serviceerror.InvalidWebhookSignature), err)
+	})
+
+	t.Run("Parse old timestamp", func(t *testing.T) {
+		old := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+
+		_, err := webhook.Parse([]byte(webhookPayload), sign(t, privateKey, webhookPayload, old), old)
+
+		require.Equal(t, serviceerror.New(serviceerror.InvalidWebhookSignature), err)
+	})
+
+	t.Run("Parse without a verification key", func(t *testing.T) {
+		mockLogger := new(logger.MockLogger)
+		mockLogger.On("Warn", logger.SendGrid, logger.Startup, mock.Anything, mock.Anything).Return()
+
+		_, err := email.NewSendGridWebhook(mockLogger, config.EmailWebhook{ToleranceSecond: time.Minute}).
+			Parse([]byte(webhookPayload), sign(t, privateKey, webhookPayload, timestamp), timestamp)
+
+		require.Equal(t, serviceerror.New(serviceerror.InvalidWebhookSignature), err)
+		mockLogger.AssertExpectations(t)
+	})
+}
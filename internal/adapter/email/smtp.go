@@ -0,0 +1,100 @@
+package email
+
+import (
+	"crypto/tls"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+type SMTP struct {
+	log  logger.Logger
+	conf config.Email
+}
+
+func NewSMTP(log logger.Logger, conf config.Email) *SMTP {
+	return &SMTP{
+		log:  log,
+		conf: conf,
+	}
+}
+
+func (r *SMTP) Send(email domain.Email) error {
+	extra := map[logger.ExtraKey]interface{}{
+		"To":      email.To,
+		"Address": r.conf.FromAddress,
+		"Host":    r.conf.SMTP.Host,
+	}
+
+	msg, err := message(r.conf.FromName, r.conf.FromAddress, email)
+	if err == nil {
+		err = r.deliver(email.To, msg)
+	}
+	if err != nil {
+		r.log.Error(logger.Email, logger.SMTPSendEmail, err.Error(), extra)
+		return serviceerror.New(serviceerror.FailedSendEmail)
+	}
+
+	r.log.Info(logger.Email, logger.SMTPSendEmail, "Email sent successfully", extra)
+	return nil
+}
+
+// deliver hands the message to the mail server, the whole conversation has to finish within the timeout.
+func (r *SMTP) deliver(to string, msg []byte) error {
+	addr := net.JoinHostPort(r.conf.SMTP.Host, strconv.Itoa(r.conf.SMTP.Port))
+	conn, err := net.DialTimeout("tcp", addr, r.conf.SMTP.TimeoutSecond)
+	if err != nil {
+		return err
+	}
+	if err = conn.SetDeadline(time.Now().Add(r.conf.SMTP.TimeoutSecond)); err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, r.conf.SMTP.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer func(client *smtp.Client) {
+		_ = client.Close()
+	}(client)
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: r.conf.SMTP.Host}); err != nil {
+			return err
+		}
+	}
+
+	if r.conf.SMTP.Username != "" {
+		auth := smtp.PlainAuth("", r.conf.SMTP.Username, r.conf.SMTP.Password, r.conf.SMTP.Host)
+		if err = client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err = client.Mail(r.conf.FromAddress); err != nil {
+		return err
+	}
+	if err = client.Rcpt(to); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = writer.Write(msg); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
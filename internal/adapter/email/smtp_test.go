@@ -0,0 +1,186 @@
+package email_test
+
+import (
+	"bufio"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/email"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+// smtpServer is an in-process mail server that accepts every message, or rejects the recipients when reject is set.
+type smtpServer struct {
+	listener net.Listener
+	reject   bool
+	messages chan smtpMessage
+}
+
+type smtpMessage struct {
+	from string
+	to   string
+	data string
+}
+
+func newSMTPServer(t *testing.T, reject bool) *smtpServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	server := &smtpServer{
+		listener: listener,
+		reject:   reject,
+		messages: make(chan smtpMessage, 1),
+	}
+	go server.serve()
+
+	return server
+}
+
+func (r *smtpServer) conf() config.Email {
+	addr := r.listener.Addr().(*net.TCPAddr)
+	return config.Email{
+		Driver:      config.EmailDriverSMTP,
+		FromName:    "Polyglot Sentences",
+		FromAddress: "support@polyglot-sentences.com",
+		SMTP: config.SMTP{
+			Host:          addr.IP.String(),
+			Port:          addr.Port,
+			TimeoutSecond: time.Second,
+		},
+	}
+}
+
+func (r *smtpServer) serve() {
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			return
+		}
+		go r.handle(conn)
+	}
+}
+
+func (r *smtpServer) handle(conn net.Conn) {
+	defer func(conn net.Conn) {
+		_ = conn.Close()
+	}(conn)
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		_, _ = io.WriteString(conn, line+"\r\n")
+	}
+
+	var msg smtpMessage
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			msg.from = strings.Trim(strings.TrimPrefix(command, "MAIL FROM:"), "<>")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			if r.reject {
+				reply("550 mailbox unavailable")
+				continue
+			}
+			msg.to = strings.Trim(strings.TrimPrefix(command, "RCPT TO:"), "<>")
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, dataErr := reader.ReadString('\n')
+				if dataErr != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			msg.data = data.String()
+			r.messages <- msg
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTP_Send(t *testing.T) {
+	server := newSMTPServer(t, false)
+
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Info", logger.Email, logger.SMTPSendEmail, "Email sent successfully", mock.Anything).Return()
+
+	sender := email.NewSMTP(mockLogger, server.conf())
+	err := sender.Send(domain.Email{To: "john@example.com", Name: "John Doe", Subject: "Welcome to Polyglot", HTML: "<p>Hello John</p>"})
+	require.NoError(t, err)
+
+	msg := <-server.messages
+	require.Equal(t, "support@polyglot-sentences.com", msg.from)
+	require.Equal(t, "john@example.com", msg.to)
+
+	parsed, err := mail.ReadMessage(strings.NewReader(msg.data))
+	require.NoError(t, err)
+	require.Equal(t, `"Polyglot Sentences" <support@polyglot-sentences.com>`, parsed.Header.Get("From"))
+	require.Equal(t, `"John Doe" <john@example.com>`, parsed.Header.Get("To"))
+	require.Equal(t, "Welcome to Polyglot", parsed.Header.Get("Subject"))
+	require.Equal(t, "text/html; charset=UTF-8", parsed.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	require.NoError(t, err)
+	require.Equal(t, "<p>Hello John</p>", strings.TrimSpace(string(body)))
+
+	mockLogger.AssertExpectations(t)
+}
+
+func TestSMTP_Send_Rejected(t *testing.T) {
+	server := newSMTPServer(t, true)
+
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", logger.Email, logger.SMTPSendEmail, mock.Anything, mock.Anything).Return()
+
+	sender := email.NewSMTP(mockLogger, server.conf())
+	err := sender.Send(domain.Email{To: "john@example.com", Name: "John Doe", Subject: "Welcome to Polyglot", HTML: "<p>Hello John</p>"})
+	require.Equal(t, serviceerror.New(serviceerror.FailedSendEmail), err)
+
+	mockLogger.AssertExpectations(t)
+}
+
+func TestSMTP_Send_Unreachable(t *testing.T) {
+	server := newSMTPServer(t, false)
+	conf := server.conf()
+	require.NoError(t, server.listener.Close())
+
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", logger.Email, logger.SMTPSendEmail, mock.Anything, mock.Anything).Return()
+
+	sender := email.NewSMTP(mockLogger, conf)
+	err := sender.Send(domain.Email{To: "john@example.com", Name: "John Doe", Subject: "Welcome to Polyglot", HTML: "<p>Hello John</p>"})
+	require.Equal(t, serviceerror.New(serviceerror.FailedSendEmail), err)
+
+	mockLogger.AssertExpectations(t)
+}
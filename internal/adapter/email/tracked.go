@@ -0,0 +1,97 @@
+package email
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"time"
+)
+
+// trackTimeout bounds every call to the delivery service, sending an email is never held up longer by it.
+const trackTimeout = 5 * time.Second
+
+// Tracked sends the emails through sender and records every one of them as a delivery, an address on the
+// suppression list gets no email. When the suppression list cannot be reached the email is sent anyway,
+// a lost one-time code is worse than one more email to a dead address.
+type Tracked struct {
+	log      logger.Logger
+	sender   port.EmailSender
+	provider string
+	client   port.EmailDeliveryClient
+}
+
+// NewTracked wraps sender, provider is the name stored with every delivery.
+func NewTracked(log logger.Logger, sender port.EmailSender, provider string, client port.EmailDeliveryClient) *Tracked {
+	return &Tracked{
+		log:      log,
+		sender:   sender,
+		provider: provider,
+		client:   client,
+	}
+}
+
+func (r *Tracked) Send(email domain.Email) error {
+	extra := map[logger.ExtraKey]interface{}{
+		"To":      email.To,
+		"Subject": email.Subject,
+	}
+
+	suppressed, err := r.isSuppressed(email.To)
+	if err != nil {
+		r.log.Warn(logger.Email, logger.SendEmail, "Checking the suppression list failed: "+err.Error(), extra)
+	}
+
+	deliveryUUID := uuid.New()
+	email.ID = deliveryUUID.String()
+	delivery := domain.EmailDelivery{
+		Base:     domain.Base{UUID: deliveryUUID},
+		Email:    email.To,
+		Subject:  email.Subject,
+		Provider: r.provider,
+	}
+
+	if suppressed {
+		delivery.Status = domain.EmailDeliveryStatusSuppressed
+		r.record(delivery)
+
+		r.log.Info(logger.Email, logger.SendEmail, "The address is suppressed, the email is not sent", extra)
+		return nil
+	}
+
+	// recorded before sending, the provider may report the delivery before Send returns
+	delivery.Status = domain.EmailDeliveryStatusSent
+	r.record(delivery)
+
+	if err = r.sender.Send(email); err != nil {
+		delivery.Status = domain.EmailDeliveryStatusFailed
+		delivery.Reason = err.Error()
+		r.record(delivery)
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *Tracked) isSuppressed(email string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), trackTimeout)
+	defer cancel()
+
+	return r.client.IsEmailSuppressed(ctx, email)
+}
+
+// record logs a delivery it cannot store, the email is sent or skipped either way.
+func (r *Tracked) record(delivery domain.EmailDelivery) {
+	ctx, cancel := context.WithTimeout(context.Background(), trackTimeout)
+	defer cancel()
+
+	if err := r.client.RecordEmailDelivery(ctx, delivery); err != nil {
+		r.log.Warn(logger.Email, logger.SendEmail, "Recording the delivery failed: "+err.Error(), map[logger.ExtraKey]interface{}{
+			"To":       delivery.Email,
+			"Delivery": delivery.Base.UUID,
+			"Status":   delivery.Status,
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package email_test
+
+import (
+	"errors"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/email"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/grpc/client"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func withStatus(status domain.EmailDeliveryStatusType) interface{} {
+	return mock.MatchedBy(func(delivery domain.EmailDelivery) bool {
+		return delivery.Status == status && delivery.Email == "john@example.com" && delivery.Provider == "sendgrid"
+	})
+}
+
+func TestTracked_Send(t *testing.T) {
+	content := domain.Email{To: "john@example.com", Name: "John Doe", Subject: "Your code", HTML: "<p>123456</p>"}
+
+	t.Run("Send success", func(t *testing.T) {
+		mockLogger := new(logger.MockLogger)
+		mockSender := new(email.MockSendGrid)
+		mockClient := new(client.MockUserClient)
+
+		mockClient.On("IsEmailSuppressed", mock.Anything, "john@example.com").Return(false, nil)
+		mockClient.On("RecordEmailDelivery", mock.Anything, withStatus(domain.EmailDeliveryStatusSent)).Return(nil)
+		mockSender.On("Send", mock.MatchedBy(func(sent domain.Email) bool {
+			return sent.ID != "" && sent.To == content.To
+		})).Return(nil)
+
+		err := email.NewTracked(mockLogger, mockSender, "sendgrid", mockClient).Send(content)
+
+		require.NoError(t, err)
+		mockSender.AssertExpectations(t)
+		mockClient.AssertExpectations(t)
+
+		sent := mockSender.Calls[0].Arguments.Get(0).(domain.Email)
+		recorded := mockClient.Calls[1].Arguments.Get(1).(domain.EmailDelivery)
+		require.Equal(t, recorded.Base.UUID.String(), sent.ID)
+	})
+
+	t.Run("Send suppressed", func(t *testing.T) {
+		mockLogger := new(logger.MockLogger)
+		mockSender := new(email.MockSendGrid)
+		mockClient := new(client.MockUserClient)
+
+		mockClient.On("IsEmailSuppressed", mock.Anything, "john@example.com").Return(true, nil)
+		mockClient.On("RecordEmailDelivery", mock.Anything, withStatus(domain.EmailDeliveryStatusSuppressed)).Return(nil)
+		mockLogger.On("Info", logger.Email, logger.SendEmail, mock.Anything, mock.Anything).Return()
+
+		err := email.NewTracked(mockLogger, mockSender, "sendgrid", mockClient).Send(content)
+
+		require.NoError(t, err)
+		mockSender.AssertNotCalled(t, "Send", mock.Anything)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Send failure", func(t *testing.T) {
+		mockLogger := new(logger.MockLogger)
+		mockSender := new(email.MockSendGrid)
+		mockClient := new(client.MockUserClient)
+
+		sendErr := serviceerror.New(serviceerror.FailedSendEmail)
+		mockClient.On("IsEmailSuppressed", mock.Anything, "john@example.com").Return(false, nil)
+		mockClient.On("RecordEmailDelivery", mock.Anything, withStatus(domain.EmailDeliveryStatusSent)).Return(nil)
+		mockClient.On("RecordEmailDelivery", mock.Anything, withStatus(domain.EmailDeliveryStatusFailed)).Return(nil)
+		mockSender.On("Send", mock.Anything).Return(sendErr)
+
+		err := email.NewTracked(mockLogger, mockSender, "sendgrid", mockClient).Send(content)
+
+		require.Equal(t, sendErr, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Send when the suppression list is unreachable", func(t *testing.T) {
+		mockLogger := new(logger.MockLogger)
+		mockSender := new(email.MockSendGrid)
+		mockClient := new(client.MockUserClient)
+
+		unavailable := errors.New("connection refused")
+		mockClient.On("IsEmailSuppressed", mock.Anything, "john@example.com").Return(false, unavailable)
+		mockClient.On("RecordEmailDelivery", mock.Anything, mock.Anything).Return(unavailable)
+		mockLogger.On("Warn", logger.Email, logger.SendEmail, mock.Anything, mock.Anything).Return()
+		mockSender.On("Send", mock.Anything).Return(nil)
+
+		err := email.NewTracked(mockLogger, mockSender, "sendgrid", mockClient).Send(content)
+
+		require.NoError(t, err)
+		mockSender.AssertExpectations(t)
+		mockLogger.AssertNumberOfCalls(t, "Warn", 2)
+	})
+}
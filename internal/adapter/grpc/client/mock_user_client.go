@@ -63,3 +63,30 @@ func (r *MockUserClient) UpdatePassword(ctx context.Context, ID uint64, password
 	args := r.Called(ctx, ID, password)
 	return args.Error(0)
 }
+
+func (r *MockUserClient) GetNotificationPreference(
+	ctx context.Context,
+	email string,
+	category domain.NotificationCategoryType,
+) (*domain.NotificationPreference, string, error) {
+	args := r.Called(ctx, email, category)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.NotificationPreference), args.String(1), args.Error(2)
+	}
+	return nil, args.String(1), args.Error(2)
+}
+
+func (r *MockUserClient) IsEmailSuppressed(ctx context.Context, email string) (bool, error) {
+	args := r.Called(ctx, email)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *MockUserClient) RecordEmailDelivery(ctx context.Context, delivery domain.EmailDelivery) error {
+	args := r.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (r *MockUserClient) SendNotification(ctx context.Context, notification domain.Notification) error {
+	args := r.Called(ctx, notification)
+	return args.Error(0)
+}
@@ -14,9 +14,12 @@ import (
 )
 
 type UserClient struct {
-	log               logger.Logger
-	conn              *grpc.ClientConn
-	userServiceClient userpb.UserServiceClient
+	log                                 logger.Logger
+	conn                                *grpc.ClientConn
+	userServiceClient                   userpb.UserServiceClient
+	notificationPreferenceServiceClient userpb.NotificationPreferenceServiceClient
+	emailDeliveryServiceClient          userpb.EmailDeliveryServiceClient
+	notificationServiceClient           userpb.NotificationServiceClient
 }
 
 func NewUserClient(log logger.Logger, conf config.UserManagement) *UserClient {
@@ -29,9 +32,12 @@ func NewUserClient(log logger.Logger, conf config.UserManagement) *UserClient {
 
 	client := userpb.NewUserServiceClient(conn)
 	return &UserClient{
-		conn:              conn,
-		log:               log,
-		userServiceClient: client,
+		conn:                                conn,
+		log:                                 log,
+		userServiceClient:                   client,
+		notificationPreferenceServiceClient: userpb.NewNotificationPreferenceServiceClient(conn),
+		emailDeliveryServiceClient:          userpb.NewEmailDeliveryServiceClient(conn),
+		notificationServiceClient:           userpb.NewNotificationServiceClient(conn),
 	}
 }
 
@@ -204,3 +210,77 @@ func (r UserClient) UpdatePassword(ctx context.Context, ID uint64, password stri
 	}
 	return nil
 }
+
+// GetNotificationPreference returns the preference of the user with the email for the category and the token of
+// the link that unsubscribes the user from it.
+func (r UserClient) GetNotificationPreference(
+	ctx context.Context,
+	email string,
+	category domain.NotificationCategoryType,
+) (*domain.NotificationPreference, string, error) {
+	req := userpb.GetNotificationPreferenceRequest{Email: email, Category: string(category)}
+	resp, err := r.notificationPreferenceServiceClient.Get(ctx, &req)
+	if err != nil {
+		r.log.Error(logger.UserManagement, logger.API, err.Error(), map[logger.ExtraKey]interface{}{
+			logger.RequestBody: &req,
+		})
+		return nil, "", serviceerror.ExtractFromGrpcError(err)
+	}
+
+	return &domain.NotificationPreference{
+		Category: category,
+		Enabled:  resp.GetEnabled(),
+	}, resp.GetUnsubscribeToken(), nil
+}
+
+func (r UserClient) IsEmailSuppressed(ctx context.Context, email string) (bool, error) {
+	req := userpb.IsEmailSuppressedRequest{Email: email}
+	resp, err := r.emailDeliveryServiceClient.IsSuppressed(ctx, &req)
+	if err != nil {
+		r.log.Error(logger.UserManagement, logger.API, err.Error(), map[logger.ExtraKey]interface{}{
+			logger.RequestBody: &req,
+		})
+		return false, serviceerror.ExtractFromGrpcError(err)
+	}
+
+	return resp.GetSuppressed(), nil
+}
+
+func (r UserClient) RecordEmailDelivery(ctx context.Context, delivery domain.EmailDelivery) error {
+	req := userpb.RecordEmailDeliveryRequest{
+		UUID:     delivery.Base.UUID.String(),
+		Email:    delivery.Email,
+		Subject:  delivery.Subject,
+		Provider: delivery.Provider,
+		Status:   string(delivery.Status),
+		Reason:   delivery.Reason,
+	}
+	_, err := r.emailDeliveryServiceClient.Record(ctx, &req)
+	if err != nil {
+		r.log.Error(logger.UserManagement, logger.API, err.Error(), map[logger.ExtraKey]interface{}{
+			logger.RequestBody: &req,
+		})
+		return serviceerror.ExtractFromGrpcError(err)
+	}
+	return nil
+}
+
+func (r UserClient) SendNotification(ctx context.Context, notification domain.Notification) error {
+	req := userpb.SendNotificationRequest{
+		UserId: notification.UserID,
+		Type:   string(notification.Type),
+		Title:  notification.Title,
+		Body:   notification.Body,
+	}
+	if notification.Link != nil {
+		req.Link = *notification.Link
+	}
+	_, err := r.notificationServiceClient.Send(ctx, &req)
+	if err != nil {
+		r.log.Error(logger.UserManagement, logger.API, err.Error(), map[logger.ExtraKey]interface{}{
+			logger.RequestBody: &req,
+		})
+		return serviceerror.ExtractFromGrpcError(err)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/google/uuid"
 	userpb "github.com/mohsenabedy91/polyglot-sentences/internal/adapter/grpc/proto/user"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
@@ -18,21 +19,36 @@ import (
 
 type Server struct {
 	userpb.UnimplementedUserServiceServer
-	conf        config.UserManagement
-	userService port.UserService
-	uowFactory  func() port.UserUnitOfWork
+	userpb.UnimplementedNotificationPreferenceServiceServer
+	userpb.UnimplementedEmailDeliveryServiceServer
+	userpb.UnimplementedNotificationServiceServer
+	conf                config.UserManagement
+	userService         port.UserService
+	preferenceService   port.NotificationPreferenceService
+	deliveryService     port.EmailDeliveryService
+	notificationService port.NotificationService
+	uowFactory          func() port.UserUnitOfWork
 }
 
 func NewUserGRPCServer(
 	conf config.UserManagement,
 	userService port.UserService,
+	preferenceService port.NotificationPreferenceService,
+	deliveryService port.EmailDeliveryService,
+	notificationService port.NotificationService,
 	uowFactory func() port.UserUnitOfWork,
 ) *Server {
 	return &Server{
-		UnimplementedUserServiceServer: userpb.UnimplementedUserServiceServer{},
-		conf:                           conf,
-		userService:                    userService,
-		uowFactory:                     uowFactory,
+		UnimplementedUserServiceServer:                   userpb.UnimplementedUserServiceServer{},
+		UnimplementedNotificationPreferenceServiceServer: userpb.UnimplementedNotificationPreferenceServiceServer{},
+		UnimplementedEmailDeliveryServiceServer:          userpb.UnimplementedEmailDeliveryServiceServer{},
+		UnimplementedNotificationServiceServer:           userpb.UnimplementedNotificationServiceServer{},
+		conf:                                             conf,
+		userService:                                      userService,
+		preferenceService:                                preferenceService,
+		deliveryService:                                  deliveryService,
+		notificationService:                              notificationService,
+		uowFactory:                                       uowFactory,
 	}
 }
 
@@ -46,6 +62,9 @@ func (r Server) StartUserGRPCServer() (*grpc.Server, error) {
 	grpcServer := grpc.NewServer()
 
 	userpb.RegisterUserServiceServer(grpcServer, r)
+	userpb.RegisterNotificationPreferenceServiceServer(grpcServer, r)
+	userpb.RegisterEmailDeliveryServiceServer(grpcServer, r)
+	userpb.RegisterNotificationServiceServer(grpcServer, r)
 
 	if err = grpcServer.Serve(listener); err != nil {
 		return nil, err
@@ -419,3 +438,186 @@ func (r Server) UpdatePassword(ctx context.Context, req *userpb.UpdatePasswordRe
 
 	return nil, nil
 }
+
+func (r Server) Get(
+	ctx context.Context,
+	req *userpb.GetNotificationPreferenceRequest,
+) (*userpb.NotificationPreferenceResponse, error) {
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		var se *serviceerror.ServiceError
+		if errors.As(err, &se) {
+			return nil, serviceerror.ConvertToGrpcError(se)
+		}
+		return nil, status.Errorf(codes.Internal, "unexpected error: %v", err)
+	}
+
+	preference, token, err := r.preferenceService.GetByEmail(
+		uowFactory,
+		req.GetEmail(),
+		domain.NotificationCategoryType(req.GetCategory()),
+	)
+	if err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			var se *serviceerror.ServiceError
+			if errors.As(err, &se) {
+				return nil, serviceerror.ConvertToGrpcError(se)
+			}
+		}
+		var se *serviceerror.ServiceError
+		if errors.As(err, &se) {
+			return nil, serviceerror.ConvertToGrpcError(se)
+		}
+		return nil, status.Errorf(codes.Internal, "unexpected error: %v", err)
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		var se *serviceerror.ServiceError
+		if errors.As(err, &se) {
+			return nil, serviceerror.ConvertToGrpcError(se)
+		}
+		return nil, status.Errorf(codes.Internal, "unexpected error: %v", err)
+	}
+
+	return &userpb.NotificationPreferenceResponse{
+		Enabled:          preference.Enabled,
+		UnsubscribeToken: token,
+	}, nil
+}
+
+func (r Server) IsSuppressed(
+	ctx context.Context,
+	req *userpb.IsEmailSuppressedRequest,
+) (*userpb.IsEmailSuppressedResponse, error) {
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		var se *serviceerror.ServiceError
+		if errors.As(err, &se) {
+			return nil, serviceerror.ConvertToGrpcError(se)
+		}
+		return nil, status.Errorf(codes.Internal, "unexpected error: %v", err)
+	}
+
+	suppressed, err := r.deliveryService.IsSuppressed(uowFactory, req.GetEmail())
+	if err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			var se *serviceerror.ServiceError
+			if errors.As(err, &se) {
+				return nil, serviceerror.ConvertToGrpcError(se)
+			}
+		}
+		var se *serviceerror.ServiceError
+		if errors.As(err, &se) {
+			return nil, serviceerror.ConvertToGrpcError(se)
+		}
+		return nil, status.Errorf(codes.Internal, "unexpected error: %v", err)
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		var se *serviceerror.ServiceError
+		if errors.As(err, &se) {
+			return nil, serviceerror.ConvertToGrpcError(se)
+		}
+		return nil, status.Errorf(codes.Internal, "unexpected error: %v", err)
+	}
+
+	return &userpb.IsEmailSuppressedResponse{Suppressed: suppressed}, nil
+}
+
+func (r Server) Record(ctx context.Context, req *userpb.RecordEmailDeliveryRequest) (*emptypb.Empty, error) {
+	deliveryUUID, err := uuid.Parse(req.GetUUID())
+	if err != nil {
+		return nil, serviceerror.ConvertToGrpcError(serviceerror.New(serviceerror.InvalidRequestBody))
+	}
+
+	uowFactory := r.uowFactory()
+	if err = uowFactory.BeginTx(ctx); err != nil {
+		var se *serviceerror.ServiceError
+		if errors.As(err, &se) {
+			return nil, serviceerror.ConvertToGrpcError(se)
+		}
+		return nil, status.Errorf(codes.Internal, "unexpected error: %v", err)
+	}
+
+	if err = r.deliveryService.Record(uowFactory, domain.EmailDelivery{
+		Base:     domain.Base{UUID: deliveryUUID},
+		Email:    req.GetEmail(),
+		Subject:  req.GetSubject(),
+		Provider: req.GetProvider(),
+		Status:   domain.EmailDeliveryStatusType(req.GetStatus()),
+		Reason:   req.GetReason(),
+	}); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			var se *serviceerror.ServiceError
+			if errors.As(err, &se) {
+				return nil, serviceerror.ConvertToGrpcError(se)
+			}
+		}
+		var se *serviceerror.ServiceError
+		if errors.As(err, &se) {
+			return nil, serviceerror.ConvertToGrpcError(se)
+		}
+		return nil, status.Errorf(codes.Internal, "unexpected error: %v", err)
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		var se *serviceerror.ServiceError
+		if errors.As(err, &se) {
+			return nil, serviceerror.ConvertToGrpcError(se)
+		}
+		return nil, status.Errorf(codes.Internal, "unexpected error: %v", err)
+	}
+
+	return nil, nil
+}
+
+// Send stores the notification and publishes it once the transaction is committed, so a stream never shows
+// a notification that is not stored.
+func (r Server) Send(ctx context.Context, req *userpb.SendNotificationRequest) (*emptypb.Empty, error) {
+	notification := domain.Notification{
+		UserID: req.GetUserId(),
+		Type:   domain.NotificationType(req.GetType()),
+		Title:  req.GetTitle(),
+		Body:   req.GetBody(),
+	}
+	if req.GetLink() != "" {
+		link := req.GetLink()
+		notification.Link = &link
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		var se *serviceerror.ServiceError
+		if errors.As(err, &se) {
+			return nil, serviceerror.ConvertToGrpcError(se)
+		}
+		return nil, status.Errorf(codes.Internal, "unexpected error: %v", err)
+	}
+
+	stored, err := r.notificationService.Send(uowFactory, notification)
+	if err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			var se *serviceerror.ServiceError
+			if errors.As(err, &se) {
+				return nil, serviceerror.ConvertToGrpcError(se)
+			}
+		}
+		var se *serviceerror.ServiceError
+		if errors.As(err, &se) {
+			return nil, serviceerror.ConvertToGrpcError(se)
+		}
+		return nil, status.Errorf(codes.Internal, "unexpected error: %v", err)
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		var se *serviceerror.ServiceError
+		if errors.As(err, &se) {
+			return nil, serviceerror.ConvertToGrpcError(se)
+		}
+		return nil, status.Errorf(codes.Internal, "unexpected error: %v", err)
+	}
+
+	r.notificationService.Publish(ctx, *stored)
+
+	return nil, nil
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/requests"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
+	"net/http"
+)
+
+// AuditLogHandler represents the HTTP handler for audit log requests
+type AuditLogHandler struct {
+	trans           translation.Translator
+	auditLogService port.AuditLogService
+	uowFactory      func() port.AuthUnitOfWork
+}
+
+// NewAuditLogHandler creates a new AuditLogHandler instance
+func NewAuditLogHandler(
+	trans translation.Translator,
+	auditLogService port.AuditLogService,
+	uowFactory func() port.AuthUnitOfWork,
+) *AuditLogHandler {
+	return &AuditLogHandler{
+		trans:           trans,
+		auditLogService: auditLogService,
+		uowFactory:      uowFactory,
+	}
+}
+
+// List godoc
+// @x-kong {"service": "auth-service"}
+// @Security AuthBearer[READ_AUDIT_LOG]
+// @Summary List of Audit Logs
+// @Description return a paginated list of audit logs, newest first
+// @Tags ACL
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param request query requests.AuditLogList false "Audit log filters"
+// @Success 200 {object} presenter.Response{data=[]presenter.AuditLog,meta=presenter.Pagination} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID get_language_v1_audit_logs
+// @Router /{language}/v1/audit-logs [get]
+func (r AuditLogHandler) List(ctx *gin.Context) {
+	var req requests.AuditLogList
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	filter := req.ToFilter()
+	auditLogs, total, err := r.auditLogService.List(uowFactory, filter)
+	if err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Payload(
+		presenter.ToAuditLogCollection(auditLogs),
+	).Meta(presenter.Pagination{
+		Page:    filter.Page,
+		PerPage: filter.PerPage,
+		Total:   total,
+	}).Echo(http.StatusOK)
+}
+
+// auditActor describes the caller of the current request for the audit log, userID is nil for anonymous requests.
+func auditActor(ctx *gin.Context, userID *uint64) domain.AuditActor {
+	return domain.AuditActor{
+		UserID:    userID,
+		IP:        ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+	}
+}
+
+// recordAudit writes the audit entry in its own unit of work, for actions not backed by an auth transaction.
+func recordAudit(ctx *gin.Context, auditLogService port.AuditLogService, uowFactory func() port.AuthUnitOfWork, auditLog domain.AuditLog) error {
+	uow := uowFactory()
+	if err := uow.BeginTx(ctx); err != nil {
+		return err
+	}
+
+	if err := auditLogService.Record(uow, auditLog); err != nil {
+		if rErr := uow.Rollback(); rErr != nil {
+			return rErr
+		}
+		return err
+	}
+
+	return uow.Commit()
+}
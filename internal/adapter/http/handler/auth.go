@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/constant"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/requests"
@@ -11,6 +12,7 @@ import (
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/event/authevent"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/outboxservice"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/claim"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/oauth"
@@ -31,6 +33,8 @@ type AuthHandler struct {
 	queue           *messagebroker.Queue
 	oauthService    oauth.GoogleService
 	aclService      port.ACLService
+	auditLogService port.AuditLogService
+	passwordService port.PasswordHistoryService
 	uowFactory      func() port.AuthUnitOfWork
 }
 
@@ -44,6 +48,8 @@ func NewAuthHandler(
 	queue *messagebroker.Queue,
 	oauthService oauth.GoogleService,
 	aclService port.ACLService,
+	auditLogService port.AuditLogService,
+	passwordService port.PasswordHistoryService,
 	uowFactory func() port.AuthUnitOfWork,
 ) *AuthHandler {
 	return &AuthHandler{
@@ -55,6 +61,8 @@ func NewAuthHandler(
 		queue:           queue,
 		oauthService:    oauthService,
 		aclService:      aclService,
+		auditLogService: auditLogService,
+		passwordService: passwordService,
 		uowFactory:      uowFactory,
 	}
 }
@@ -90,7 +98,7 @@ func (r AuthHandler) Register(ctx *gin.Context) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		hashedPass, hashErr = helper.HashPassword(req.Password, r.conf.Password.BcryptCost)
+		hashedPass, hashErr = helper.HashPassword(req.Password, passwordParams(r.conf.Password))
 	}()
 
 	if err := r.userClient.IsEmailUnique(ctx.Request.Context(), req.Email); err != nil {
@@ -136,18 +144,35 @@ func (r AuthHandler) Register(ctx *gin.Context) {
 		return
 	}
 
-	if err = uowFactory.Commit(); err != nil {
+	if err = r.passwordService.Remember(uowFactory, user.Base.ID, hashedPass); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
 		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
 		return
 	}
 
 	message := authevent.SendEmailOTPDto{
-		To:       user.Email,
-		Name:     user.GetFullName(),
-		OTP:      otp,
-		Language: ctx.Param("language"),
+		To:        user.Email,
+		Name:      user.GetFullName(),
+		OTP:       otp,
+		Signature: r.otpCacheService.SignLink(user.Email, otp),
+		Language:  ctx.Param("language"),
+	}
+	if err = authevent.NewSendEmailOTP(r.queue).Enqueue(ctx, uowFactory.OutboxRepository(), message); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
 	}
-	authevent.NewSendEmailOTP(r.queue).Publish(message)
 
 	presenter.NewResponse(ctx, r.trans).Message(constant.AuthSuccessRegisteredUser).Echo(http.StatusCreated)
 }
@@ -195,12 +220,16 @@ func (r AuthHandler) EmailOTPResend(ctx *gin.Context) {
 
 	// TODO add rate limit
 	message := authevent.SendEmailOTPDto{
-		To:       user.Email,
-		Name:     user.GetFullName(),
-		OTP:      otp,
-		Language: ctx.Param("language"),
+		To:        user.Email,
+		Name:      user.GetFullName(),
+		OTP:       otp,
+		Signature: r.otpCacheService.SignLink(user.Email, otp),
+		Language:  ctx.Param("language"),
+	}
+	if err = outboxservice.Publish(ctx, r.uowFactory(), authevent.NewSendEmailOTP(r.queue), message); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
 	}
-	authevent.NewSendEmailOTP(r.queue).Publish(message)
 
 	presenter.NewResponse(ctx, r.trans).Message(constant.AuthSuccessEmailOTPSent).Echo(http.StatusOK)
 }
@@ -232,12 +261,48 @@ func (r AuthHandler) EmailOTPVerify(ctx *gin.Context) {
 		return
 	}
 
-	if err := r.userClient.VerifiedEmail(ctx.Request.Context(), req.Email); err != nil {
+	r.verifyEmail(ctx, req.Email)
+}
+
+// EmailLinkVerify godoc
+// @x-kong {"service": "auth-service"}
+// @Summary EmailLinkVerify
+// @Description Verify User via the signed link sent in the verification email then logged-in user
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param request body requests.AuthEmailLinkVerify true "EmailLinkVerify request"
+// @Success 200 {object} presenter.Response{data=presenter.Token} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID post_language_v1_auth_email_link_verify
+// @Router /{language}/v1/auth/email-link/verify [post]
+func (r AuthHandler) EmailLinkVerify(ctx *gin.Context) {
+	var req requests.AuthEmailLinkVerify
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	if err := r.otpCacheService.ValidateLink(ctx.Request.Context(), req.Email, req.Signature); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	r.verifyEmail(ctx, req.Email)
+}
+
+// verifyEmail marks the email as verified, consumes the OTP and logs the user in.
+// It is shared by the OTP and link verification flows, so either one invalidates the other.
+func (r AuthHandler) verifyEmail(ctx *gin.Context, email string) {
+	if err := r.userClient.VerifiedEmail(ctx.Request.Context(), email); err != nil {
 		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
 		return
 	}
 
-	user, err := r.userClient.GetByEmail(ctx.Request.Context(), req.Email)
+	user, err := r.userClient.GetByEmail(ctx.Request.Context(), email)
 	if err != nil {
 		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
 		return
@@ -255,10 +320,11 @@ func (r AuthHandler) EmailOTPVerify(ctx *gin.Context) {
 		return
 	}
 
+	correlationID := domain.CorrelationID(ctx)
 	go func() {
-		ctxWithTimeout, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+		ctxWithTimeout, cancel := context.WithTimeout(domain.WithCorrelationID(context.Background(), correlationID), 6*time.Second)
 		defer cancel()
-		_ = r.otpCacheService.Used(ctxWithTimeout, req.Email)
+		_ = r.otpCacheService.Used(ctxWithTimeout, email)
 
 		if !user.WelcomeMessageSent {
 			message := authevent.SendWelcomeDto{
@@ -267,7 +333,7 @@ func (r AuthHandler) EmailOTPVerify(ctx *gin.Context) {
 				Name:     user.GetFullName(),
 				Language: ctx.Param("language"),
 			}
-			authevent.NewSendWelcome(r.queue, r.userClient).Publish(message)
+			_ = outboxservice.Publish(ctxWithTimeout, r.uowFactory(), authevent.NewSendWelcome(r.queue, r.userClient), message)
 		}
 
 		if err = r.userClient.UpdateLastLoginTime(ctxWithTimeout, user.Base.ID); err != nil {
@@ -324,12 +390,16 @@ func (r AuthHandler) Login(ctx *gin.Context) {
 
 		// TODO add rate limit
 		message := authevent.SendEmailOTPDto{
-			To:       user.Email,
-			Name:     user.GetFullName(),
-			OTP:      otp,
-			Language: ctx.Param("language"),
+			To:        user.Email,
+			Name:      user.GetFullName(),
+			OTP:       otp,
+			Signature: r.otpCacheService.SignLink(user.Email, otp),
+			Language:  ctx.Param("language"),
+		}
+		if err = outboxservice.Publish(ctx, r.uowFactory(), authevent.NewSendEmailOTP(r.queue), message); err != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+			return
 		}
-		authevent.NewSendEmailOTP(r.queue).Publish(message)
 
 		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(
 			serviceerror.New(serviceerror.UserUnVerified),
@@ -345,26 +415,60 @@ func (r AuthHandler) Login(ctx *gin.Context) {
 	}
 
 	if ok := helper.CheckPasswordHash(req.Password, *user.Password); !ok {
+		// a failed attempt is reported as invalid credentials even when it could not be audited
+		_ = recordAudit(ctx, r.auditLogService, r.uowFactory, domain.NewAuditLog(
+			auditActor(ctx, nil),
+			domain.AuditActionUserLoginFailed,
+			domain.AuditTargetUser,
+			&user.Base.UUID,
+			nil,
+			nil,
+		))
+
 		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(
 			serviceerror.New(serviceerror.CredentialInvalid),
 		).Echo()
 		return
 	}
 
+	correlationID := domain.CorrelationID(ctx)
 	go func() {
-		ctxWithTimeout, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctxWithTimeout, cancel := context.WithTimeout(domain.WithCorrelationID(context.Background(), correlationID), 5*time.Second)
 		defer cancel()
 		if err = r.userClient.UpdateLastLoginTime(ctxWithTimeout, user.Base.ID); err != nil {
 			return
 		}
 	}()
 
+	if params := passwordParams(r.conf.Password); helper.PasswordNeedsRehash(*user.Password, params) {
+		go func(password string) {
+			hashedPassword, hashErr := helper.HashPassword(password, params)
+			if hashErr != nil {
+				return
+			}
+
+			ctxWithTimeout, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = r.userClient.UpdatePassword(ctxWithTimeout, user.Base.ID, hashedPassword)
+		}(req.Password)
+	}
+
 	token, err := r.tokenService.GenerateToken(user.Base.UUID.String())
 	if err != nil {
 		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
 		return
 	}
 
+	// the token is issued already, a login that could not be audited still succeeds, the repository logs the failure
+	_ = recordAudit(ctx, r.auditLogService, r.uowFactory, domain.NewAuditLog(
+		auditActor(ctx, &user.Base.ID),
+		domain.AuditActionUserLoggedIn,
+		domain.AuditTargetUser,
+		&user.Base.UUID,
+		nil,
+		nil,
+	))
+
 	result := presenter.ToTokenResource(token)
 
 	presenter.NewResponse(ctx, r.trans).Payload(result).Echo()
@@ -467,8 +571,9 @@ func (r AuthHandler) Google(ctx *gin.Context) {
 		}
 	}
 
+	correlationID := domain.CorrelationID(ctx)
 	go func() {
-		ctxWithTimeout, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctxWithTimeout, cancel := context.WithTimeout(domain.WithCorrelationID(context.Background(), correlationID), 5*time.Second)
 		defer cancel()
 		if !user.WelcomeMessageSent {
 			message := authevent.SendWelcomeDto{
@@ -477,7 +582,7 @@ func (r AuthHandler) Google(ctx *gin.Context) {
 				Name:     user.GetFullName(),
 				Language: ctx.Param("language"),
 			}
-			authevent.NewSendWelcome(r.queue, r.userClient).Publish(message)
+			_ = outboxservice.Publish(ctxWithTimeout, r.uowFactory(), authevent.NewSendWelcome(r.queue, r.userClient), message)
 		}
 		if err = r.userClient.UpdateLastLoginTime(ctxWithTimeout, user.Base.ID); err != nil {
 			return
@@ -548,16 +653,17 @@ func (r AuthHandler) ForgetPassword(ctx *gin.Context) {
 		return
 	}
 
-	go func() {
-		// TODO add rate limit
-		message := authevent.SendResetPasswordLinkDto{
-			To:       user.Email,
-			Name:     user.GetFullName(),
-			OTP:      otp,
-			Language: ctx.Param("language"),
-		}
-		authevent.NewSendResetPasswordLink(r.queue).Publish(message)
-	}()
+	// TODO add rate limit
+	message := authevent.SendResetPasswordLinkDto{
+		To:       user.Email,
+		Name:     user.GetFullName(),
+		OTP:      otp,
+		Language: ctx.Param("language"),
+	}
+	if err = outboxservice.Publish(ctx, r.uowFactory(), authevent.NewSendResetPasswordLink(r.queue), message); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
 
 	presenter.NewResponse(ctx, r.trans).Message(constant.AuthSuccessForgetPassword).Echo(http.StatusOK)
 }
@@ -593,7 +699,7 @@ func (r AuthHandler) ResetPassword(ctx *gin.Context) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		hashPassword, hashedErr = helper.HashPassword(req.Password, r.conf.Password.BcryptCost)
+		hashPassword, hashedErr = helper.HashPassword(req.Password, passwordParams(r.conf.Password))
 	}()
 
 	if err := r.otpCacheService.ValidateForgetPassword(ctx.Request.Context(), req.Email, req.Token); err != nil {
@@ -614,13 +720,68 @@ func (r AuthHandler) ResetPassword(ctx *gin.Context) {
 		return
 	}
 
+	if err = binding.Validator.ValidateStruct(req.ToUserPassword(user)); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
 	wg.Wait()
 	if hashedErr != nil {
 		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(hashedErr).Echo()
 		return
 	}
 
+	uowFactory := r.uowFactory()
+	if err = uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = r.passwordService.CheckReuse(uowFactory, user.Base.ID, user.Password, req.Password); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = r.passwordService.Remember(uowFactory, user.Base.ID, hashPassword); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = r.auditLogService.Record(uowFactory, domain.NewAuditLog(
+		auditActor(ctx, &user.Base.ID),
+		domain.AuditActionUserPasswordReset,
+		domain.AuditTargetUser,
+		&user.Base.UUID,
+		nil,
+		nil,
+	)); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	// the user service is updated last, a failure up to here leaves the password, its history and the audit as they were
 	if err = r.userClient.UpdatePassword(ctx.Request.Context(), user.Base.ID, hashPassword); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = uowFactory.Commit(); err != nil {
 		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
 		return
 	}
@@ -679,7 +840,7 @@ func (r AuthHandler) Authorize(ctx *gin.Context) {
 		return
 	}
 
-	isAllowed, userID, err := r.aclService.CheckAccess(ctx.Request.Context(), uowFactory, userUUID, req.RequiredPermissions...)
+	isAllowed, userID, grantedPermissions, err := r.aclService.CheckAccess(ctx.Request.Context(), uowFactory, userUUID, req.RequiredPermissions...)
 	if err != nil {
 		if rErr := uowFactory.Rollback(); rErr != nil {
 			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
@@ -702,11 +863,23 @@ func (r AuthHandler) Authorize(ctx *gin.Context) {
 	}
 
 	data := presenter.Authorize{
-		Authorized: isAllowed,
-		JTI:        claim.GetJTIFromGinContext(ctx),
-		EXP:        claim.GetExpFromGinContext(ctx),
-		ID:         userID,
+		Authorized:  isAllowed,
+		JTI:         claim.GetJTIFromGinContext(ctx),
+		EXP:         claim.GetExpFromGinContext(ctx),
+		ID:          userID,
+		Permissions: grantedPermissions,
 	}
 
 	presenter.NewResponse(ctx, r.trans).Payload(data).Echo(http.StatusOK)
 }
+
+// passwordParams maps the password config onto the Argon2id costs used to hash new passwords.
+func passwordParams(conf config.Password) helper.Argon2Params {
+	return helper.Argon2Params{
+		Memory:      conf.Argon2Memory,
+		Iterations:  conf.Argon2Iterations,
+		Parallelism: conf.Argon2Parallelism,
+		SaltLength:  conf.Argon2SaltLength,
+		KeyLength:   conf.Argon2KeyLength,
+	}
+}
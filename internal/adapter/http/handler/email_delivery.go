@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/constant"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/requests"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
+	"github.com/sendgrid/sendgrid-go/helpers/eventwebhook"
+	"io"
+	"net/http"
+)
+
+// webhookMaxBodySize caps the payload of an event webhook, SendGrid posts its events in batches far below it.
+const webhookMaxBodySize int64 = 4 << 20
+
+// EmailDeliveryHandler represents the HTTP handler for the email provider events and the suppression list
+type EmailDeliveryHandler struct {
+	trans           translation.Translator
+	deliveryService port.EmailDeliveryService
+	webhook         port.EmailWebhook
+	uowFactory      func() port.UserUnitOfWork
+}
+
+// NewEmailDeliveryHandler creates a new EmailDeliveryHandler instance
+func NewEmailDeliveryHandler(
+	trans translation.Translator,
+	deliveryService port.EmailDeliveryService,
+	webhook port.EmailWebhook,
+	uowFactory func() port.UserUnitOfWork,
+) *EmailDeliveryHandler {
+	return &EmailDeliveryHandler{
+		trans:           trans,
+		deliveryService: deliveryService,
+		webhook:         webhook,
+		uowFactory:      uowFactory,
+	}
+}
+
+// Webhook godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Summary SendGrid Event Webhook
+// @Description receive the delivered, bounce and spam report events of the signed SendGrid event webhook, hard bounces and spam reports add the address to the suppression list
+// @Tags Email
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param X-Twilio-Email-Event-Webhook-Signature header string true "base64 ECDSA signature of the timestamp and the payload"
+// @Param X-Twilio-Email-Event-Webhook-Timestamp header string true "unix time the payload was signed at"
+// @Success 204 "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Invalid signature"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID post_language_v1_email_webhooks_sendgrid
+// @Router /{language}/v1/email/webhooks/sendgrid [post]
+func (r EmailDeliveryHandler) Webhook(ctx *gin.Context) {
+	payload, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, webhookMaxBodySize))
+	if err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(
+			serviceerror.New(serviceerror.InvalidRequestBody),
+		).Echo()
+		return
+	}
+
+	events, err := r.webhook.Parse(
+		payload,
+		ctx.GetHeader(eventwebhook.VerificationHTTPHeader),
+		ctx.GetHeader(eventwebhook.TimestampHTTPHeader),
+	)
+	if err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err = uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = r.deliveryService.HandleEvents(uowFactory, events); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Echo(http.StatusNoContent)
+}
+
+// ListSuppressions godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer[MANAGE_EMAIL_SUPPRESSION]
+// @Summary List of Email Suppressions
+// @Description return a paginated list of the addresses no email is sent to, newest first
+// @Tags Email
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param request query requests.EmailSuppressionList false "Email suppression filters"
+// @Success 200 {object} presenter.Response{data=[]presenter.EmailSuppression,meta=presenter.Pagination} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID get_language_v1_email_suppressions
+// @Router /{language}/v1/email/suppressions [get]
+func (r EmailDeliveryHandler) ListSuppressions(ctx *gin.Context) {
+	var req requests.EmailSuppressionList
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	filter := req.ToFilter()
+	suppressions, total, err := r.deliveryService.ListSuppressions(uowFactory, filter)
+	if err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Payload(
+		presenter.ToEmailSuppressionCollection(suppressions),
+	).Meta(presenter.Pagination{
+		Page:    filter.Page,
+		PerPage: filter.PerPage,
+		Total:   total,
+	}).Echo(http.StatusOK)
+}
+
+// DeleteSuppression godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer[MANAGE_EMAIL_SUPPRESSION]
+// @Summary Delete Email Suppression
+// @Description remove an address from the suppression list, emails are sent to it again
+// @Tags Email
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param suppressionID path string true "suppression id should be uuid"
+// @Success 200 {object} presenter.Response{message=string} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 404 {object} presenter.Error "Not found"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID delete_language_v1_email_suppressions_suppressionID
+// @Router /{language}/v1/email/suppressions/{suppressionID} [delete]
+func (r EmailDeliveryHandler) DeleteSuppression(ctx *gin.Context) {
+	var req requests.EmailSuppressionUUIDUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err := r.deliveryService.DeleteSuppression(uowFactory, uuid.MustParse(req.UUIDStr)); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err := uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Message(constant.UserSuccessEmailSuppressionDeleted).Echo(http.StatusOK)
+}
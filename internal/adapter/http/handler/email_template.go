@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/requests"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
+	"net/http"
+)
+
+// EmailTemplateHandler represents the HTTP handler for email template requests
+type EmailTemplateHandler struct {
+	trans           translation.Translator
+	templateService port.EmailTemplateService
+}
+
+// NewEmailTemplateHandler creates a new EmailTemplateHandler instance
+func NewEmailTemplateHandler(trans translation.Translator, templateService port.EmailTemplateService) *EmailTemplateHandler {
+	return &EmailTemplateHandler{
+		trans:           trans,
+		templateService: templateService,
+	}
+}
+
+// List godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer[PREVIEW_EMAIL_TEMPLATE]
+// @Summary List of Email Templates
+// @Description return the names of the email templates
+// @Tags Email Template
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Success 200 {object} presenter.Response{data=[]string} "Successful response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID get_language_v1_email_templates
+// @Router /{language}/v1/email-templates [get]
+func (r EmailTemplateHandler) List(ctx *gin.Context) {
+	presenter.NewResponse(ctx, r.trans).Payload(r.templateService.Templates()).Echo(http.StatusOK)
+}
+
+// Preview godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer[PREVIEW_EMAIL_TEMPLATE]
+// @Summary Preview Email Template
+// @Description render an email template in the language with its sample data, the html and text formats return that part alone
+// @Tags Email Template
+// @Accept json
+// @Produce json,html,plain
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param name path string true "template name" default(verify_email)
+// @Param request query requests.EmailTemplatePreviewFormat false "Preview format"
+// @Success 200 {object} presenter.Response{data=presenter.EmailTemplatePreview} "Successful response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 404 {object} presenter.Error "Not found"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID get_language_v1_email_templates_name_preview
+// @Router /{language}/v1/email-templates/{name}/preview [get]
+func (r EmailTemplateHandler) Preview(ctx *gin.Context) {
+	var req requests.EmailTemplatePreview
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	var format requests.EmailTemplatePreviewFormat
+	if err := ctx.ShouldBindQuery(&format); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	email, err := r.templateService.Preview(req.Name, ctx.Param("language"))
+	if err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	switch format.Format {
+	case "html":
+		ctx.Data(http.StatusOK, "text/html; charset=utf-8", []byte(email.HTML))
+	case "text":
+		ctx.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(email.Text))
+	default:
+		presenter.NewResponse(ctx, r.trans).Payload(presenter.ToEmailTemplatePreviewResource(email)).Echo(http.StatusOK)
+	}
+}
@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/constant"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/requests"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// NotificationHandler represents the HTTP handler for the in-app notification requests
+type NotificationHandler struct {
+	conf                config.NotificationStream
+	trans               translation.Translator
+	notificationService port.NotificationService
+	uowFactory          func() port.UserUnitOfWork
+
+	closing   chan struct{}
+	closeOnce *sync.Once
+}
+
+// NewNotificationHandler creates a new NotificationHandler instance
+func NewNotificationHandler(
+	conf config.NotificationStream,
+	trans translation.Translator,
+	notificationService port.NotificationService,
+	uowFactory func() port.UserUnitOfWork,
+) *NotificationHandler {
+	return &NotificationHandler{
+		conf:                conf,
+		trans:               trans,
+		notificationService: notificationService,
+		uowFactory:          uowFactory,
+		closing:             make(chan struct{}),
+		closeOnce:           new(sync.Once),
+	}
+}
+
+// Close ends the open streams, the clients reconnect to another instance while this one shuts down.
+func (r NotificationHandler) Close() {
+	r.closeOnce.Do(func() {
+		close(r.closing)
+	})
+}
+
+// List godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer
+// @Summary List of Notifications
+// @Description return a paginated list of the in-app notifications of the user based on Authorization, newest first
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param request query requests.NotificationList false "Notification filters"
+// @Success 200 {object} presenter.Response{data=[]presenter.Notification,meta=presenter.Pagination} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID get_language_v1_users_profile_notifications
+// @Router /{language}/v1/users/profile/notifications [get]
+func (r NotificationHandler) List(ctx *gin.Context) {
+	var header requests.Header
+	if err := ctx.ShouldBindHeader(&header); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	var req requests.NotificationList
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	filter := req.ToFilter()
+	notifications, total, err := r.notificationService.List(uowFactory, header.UserID, filter)
+	if err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Payload(
+		presenter.ToNotificationCollection(notifications),
+	).Meta(presenter.Pagination{
+		Page:    filter.Page,
+		PerPage: filter.PerPage,
+		Total:   total,
+	}).Echo(http.StatusOK)
+}
+
+// UnreadCount godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer
+// @Summary Unread Notification Count
+// @Description return how many in-app notifications of the user based on Authorization are unread
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Success 200 {object} presenter.Response{data=presenter.NotificationUnreadCount} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID get_language_v1_users_profile_notifications_unread_count
+// @Router /{language}/v1/users/profile/notifications/unread-count [get]
+func (r NotificationHandler) UnreadCount(ctx *gin.Context) {
+	var header requests.Header
+	if err := ctx.ShouldBindHeader(&header); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	count, err := r.notificationService.CountUnread(uowFactory, header.UserID)
+	if err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Payload(
+		presenter.NotificationUnreadCount{Count: count},
+	).Echo(http.StatusOK)
+}
+
+// MarkRead godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer
+// @Summary Mark Notifications Read
+// @Description mark the given in-app notifications of the user based on Authorization as read, all of them when no id is given, and return how many are left unread
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param request body requests.MarkNotificationsRead false "Mark notifications read request"
+// @Success 200 {object} presenter.Response{message=string,data=presenter.NotificationUnreadCount} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID post_language_v1_users_profile_notifications_read
+// @Router /{language}/v1/users/profile/notifications/read [post]
+func (r NotificationHandler) MarkRead(ctx *gin.Context) {
+	var header requests.Header
+	if err := ctx.ShouldBindHeader(&header); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	var req requests.MarkNotificationsRead
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+			return
+		}
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	count, err := r.notificationService.MarkRead(uowFactory, header.UserID, req.ToUUIDs())
+	if err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Payload(
+		presenter.NotificationUnreadCount{Count: count},
+	).Message(constant.UserSuccessNotificationsRead).Echo(http.StatusOK)
+}
+
+// Stream godoc
+// @x-kong {"service": "user-management-http-service", "responseBuffering": false}
+// @Security AuthBearer
+// @Summary Notification Stream
+// @Description Server-Sent Events stream of the user based on Authorization, an unread-count event is sent first and a notification event for every new notification, an idle stream gets a comment every few seconds
+// @Tags User
+// @Produce text/event-stream
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Success 200 {object} presenter.Notification "notification event"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID get_language_v1_users_profile_notifications_stream
+// @Router /{language}/v1/users/profile/notifications/stream [get]
+func (r NotificationHandler) Stream(ctx *gin.Context) {
+	var header requests.Header
+	if err := ctx.ShouldBindHeader(&header); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx.Request.Context())
+	defer cancel()
+
+	// subscribed before counting, a notification sent in between is counted or streamed, never lost
+	notifications, err := r.notificationService.Subscribe(streamCtx, header.UserID)
+	if err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err = uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	count, err := r.notificationService.CountUnread(uowFactory, header.UserID)
+	if err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+
+	ctx.SSEvent("unread-count", presenter.NotificationUnreadCount{Count: count})
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(r.conf.HeartbeatSecond)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-streamCtx.Done():
+			return
+		case <-r.closing:
+			return
+		case notification, ok := <-notifications:
+			if !ok {
+				return
+			}
+			ctx.SSEvent("notification", presenter.ToNotificationResource(&notification))
+			ctx.Writer.Flush()
+		case <-heartbeat.C:
+			if _, err = ctx.Writer.WriteString(": heartbeat\n\n"); err != nil {
+				return
+			}
+			ctx.Writer.Flush()
+		}
+	}
+}
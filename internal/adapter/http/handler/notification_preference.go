@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/constant"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/requests"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
+	"net/http"
+)
+
+// NotificationPreferenceHandler represents the HTTP handler for notification preference requests
+type NotificationPreferenceHandler struct {
+	trans             translation.Translator
+	preferenceService port.NotificationPreferenceService
+	uowFactory        func() port.UserUnitOfWork
+}
+
+// NewNotificationPreferenceHandler creates a new NotificationPreferenceHandler instance
+func NewNotificationPreferenceHandler(
+	trans translation.Translator,
+	preferenceService port.NotificationPreferenceService,
+	uowFactory func() port.UserUnitOfWork,
+) *NotificationPreferenceHandler {
+	return &NotificationPreferenceHandler{
+		trans:             trans,
+		preferenceService: preferenceService,
+		uowFactory:        uowFactory,
+	}
+}
+
+// List godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer
+// @Summary Notification Preferences
+// @Description Get the notification preferences of the user based on Authorization, essential categories are always enabled
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Success 200 {object} presenter.Response{data=[]presenter.NotificationPreference} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID get_language_v1_users_profile_notification_preferences
+// @Router /{language}/v1/users/profile/notification-preferences [get]
+func (r NotificationPreferenceHandler) List(ctx *gin.Context) {
+	var header requests.Header
+	if err := ctx.ShouldBindHeader(&header); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	preferences, err := r.preferenceService.List(uowFactory, header.UserID)
+	if err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Payload(
+		presenter.ToNotificationPreferenceCollection(preferences),
+	).Echo(http.StatusOK)
+}
+
+// Update godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer
+// @Summary Update Notification Preferences
+// @Description Turn the notification categories of the user on or off, the security category cannot be turned off
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param request body requests.UpdateNotificationPreferences true "Update notification preferences request"
+// @Success 200 {object} presenter.Response{message=string,data=[]presenter.NotificationPreference} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID put_language_v1_users_profile_notification_preferences
+// @Router /{language}/v1/users/profile/notification-preferences [put]
+func (r NotificationPreferenceHandler) Update(ctx *gin.Context) {
+	var header requests.Header
+	if err := ctx.ShouldBindHeader(&header); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	var req requests.UpdateNotificationPreferences
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	preferences, err := r.preferenceService.Update(uowFactory, header.UserID, req.ToNotificationPreferencesDomain())
+	if err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Payload(
+		presenter.ToNotificationPreferenceCollection(preferences),
+	).Message(constant.UserSuccessPreferencesUpdated).Echo(http.StatusOK)
+}
+
+// ConfirmUnsubscribe godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Summary Confirm Unsubscribe
+// @Description Check the signed link sent in the emails and return the notification category it turns off, the link in the email footer opens it and nothing is changed until the token is posted to the unsubscribe endpoint
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param token query string true "unsubscribe token of the email"
+// @Success 200 {object} presenter.Response{data=presenter.UnsubscribeConfirmation} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID get_language_v1_notifications_unsubscribe
+// @Router /{language}/v1/notifications/unsubscribe [get]
+func (r NotificationPreferenceHandler) ConfirmUnsubscribe(ctx *gin.Context) {
+	var req requests.Unsubscribe
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	category, err := r.preferenceService.VerifyUnsubscribe(req.Token)
+	if err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Payload(
+		presenter.ToUnsubscribeConfirmationResource(category, req.Token),
+	).Echo(http.StatusOK)
+}
+
+// Unsubscribe godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Summary Unsubscribe
+// @Description Turn off the notification category of the signed link sent in the emails, mail clients call it for the one-click unsubscribe of RFC 8058 and the confirmation page after the link was opened
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param token query string true "unsubscribe token of the email"
+// @Success 200 {object} presenter.Response{message=string} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 404 {object} presenter.Error "Not found"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID post_language_v1_notifications_unsubscribe
+// @Router /{language}/v1/notifications/unsubscribe [post]
+func (r NotificationPreferenceHandler) Unsubscribe(ctx *gin.Context) {
+	var req requests.Unsubscribe
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if _, err := r.preferenceService.Unsubscribe(uowFactory, req.Token); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err := uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Message(constant.UserSuccessUnsubscribed).Echo(http.StatusOK)
+}
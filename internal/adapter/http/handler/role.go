@@ -2,6 +2,7 @@ package handler
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/constant"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/requests"
@@ -73,8 +74,9 @@ func (r RoleHandler) Create(ctx *gin.Context) {
 		},
 		Title:       req.Title,
 		Description: req.Description,
+		Parent:      parentRole(req.ParentID),
 	}
-	if err := r.roleService.Create(uowFactory, role); err != nil {
+	if err := r.roleService.Create(uowFactory, role, auditActor(ctx, &header.UserID)); err != nil {
 		if rErr := uowFactory.Rollback(); rErr != nil {
 			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
 			return
@@ -188,7 +190,7 @@ func (r RoleHandler) List(ctx *gin.Context) {
 // @x-kong {"service": "auth-service"}
 // @Security AuthBearer[UPDATE_ROLE]
 // @Summary Update a Role
-// @Description Update a Role
+// @Description Update a Role, an omitted parentID keeps the current parent and an empty one detaches it
 // @Tags Role
 // @Accept json
 // @Produce json
@@ -232,8 +234,15 @@ func (r RoleHandler) Update(ctx *gin.Context) {
 		},
 		Title:       req.Title,
 		Description: req.Description,
-	}
-	if err := r.roleService.Update(ctx.Request.Context(), uowFactory, role, roleReq.UUIDStr); err != nil {
+		Parent:      parentRole(req.ParentID),
+	}
+	if err := r.roleService.Update(
+		ctx.Request.Context(),
+		uowFactory,
+		role,
+		roleReq.UUIDStr,
+		auditActor(ctx, &header.UserID),
+	); err != nil {
 		if rErr := uowFactory.Rollback(); rErr != nil {
 			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
 			return
@@ -287,7 +296,13 @@ func (r RoleHandler) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if err := r.roleService.Delete(uowFactory, roleReq.UUIDStr, header.UserID); err != nil {
+	if err := r.roleService.Delete(
+		ctx.Request.Context(),
+		uowFactory,
+		roleReq.UUIDStr,
+		header.UserID,
+		auditActor(ctx, &header.UserID),
+	); err != nil {
 		if rErr := uowFactory.Rollback(); rErr != nil {
 			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
 			return
@@ -354,6 +369,57 @@ func (r RoleHandler) GetPermissions(ctx *gin.Context) {
 	).Echo(http.StatusOK)
 }
 
+// GetPermissionSources godoc
+// @x-kong {"service": "auth-service"}
+// @Security AuthBearer[READ_ROLE_PERMISSIONS]
+// @Summary Get Permission Sources
+// @Description get effective permissions for a Role and the role in the hierarchy granting each of them
+// @Tags Role
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param roleID path string true "role id should be uuid"
+// @Success 200 {object} presenter.Response{data=[]presenter.PermissionSource} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 404 {object} presenter.Error "Not found"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID get_language_v1_roles_roleID_permissions_sources
+// @Router /{language}/v1/roles/{roleID}/permissions/sources [get]
+func (r RoleHandler) GetPermissionSources(ctx *gin.Context) {
+	var roleReq requests.RoleUUIDUri
+	if err := ctx.ShouldBindUri(&roleReq); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	sources, err := r.roleService.GetPermissionSources(uowFactory, roleReq.UUIDStr)
+	if err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Payload(
+		presenter.ToPermissionSourceCollection(sources),
+	).Echo(http.StatusOK)
+}
+
 // SyncPermissions godoc
 // @x-kong {"service": "auth-service"}
 // @Security AuthBearer[SYNC_PERMISSIONS_WITH_ROLE]
@@ -373,6 +439,12 @@ func (r RoleHandler) GetPermissions(ctx *gin.Context) {
 // @ID put_language_v1_roles_roleID_permissions
 // @Router /{language}/v1/roles/{roleID}/permissions [put]
 func (r RoleHandler) SyncPermissions(ctx *gin.Context) {
+	var header requests.Header
+	if err := ctx.ShouldBindHeader(&header); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
 	var roleReq requests.RoleUUIDUri
 	if err := ctx.ShouldBindUri(&roleReq); err != nil {
 		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
@@ -391,7 +463,13 @@ func (r RoleHandler) SyncPermissions(ctx *gin.Context) {
 		return
 	}
 
-	if err := r.roleService.SyncPermissions(uowFactory, roleReq.UUIDStr, req.Permissions); err != nil {
+	if err := r.roleService.SyncPermissions(
+		ctx.Request.Context(),
+		uowFactory,
+		roleReq.UUIDStr,
+		req.Permissions,
+		auditActor(ctx, &header.UserID),
+	); err != nil {
 		if rErr := uowFactory.Rollback(); rErr != nil {
 			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
 			return
@@ -407,3 +485,19 @@ func (r RoleHandler) SyncPermissions(ctx *gin.Context) {
 
 	presenter.NewResponse(ctx, r.trans).Message(constant.RoleSuccessSyncPermissions).Echo(http.StatusOK)
 }
+
+func parentRole(parentID *string) *domain.Role {
+	if parentID == nil {
+		return nil
+	}
+
+	if *parentID == "" {
+		return &domain.Role{}
+	}
+
+	return &domain.Role{
+		Base: domain.Base{
+			UUID: uuid.MustParse(*parentID),
+		},
+	}
+}
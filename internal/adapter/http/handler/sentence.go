@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/constant"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/requests"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
+	"net/http"
+)
+
+// SentenceHandler represents the HTTP handler for sentence-related requests
+type SentenceHandler struct {
+	trans           translation.Translator
+	sentenceService port.SentenceService
+	uowFactory      func() port.UserUnitOfWork
+}
+
+// NewSentenceHandler creates a new SentenceHandler instance
+func NewSentenceHandler(
+	trans translation.Translator,
+	sentenceService port.SentenceService,
+	uowFactory func() port.UserUnitOfWork,
+) *SentenceHandler {
+	return &SentenceHandler{
+		trans:           trans,
+		sentenceService: sentenceService,
+		uowFactory:      uowFactory,
+	}
+}
+
+// Update godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer[UPDATE_SENTENCE, UPDATE_OWN_SENTENCE]
+// @Summary Update Sentence
+// @Description update the text and the level of a sentence, UPDATE_OWN_SENTENCE only allows the sentences the user created
+// @Tags Sentence
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param sentenceID path string true "sentence id should be uuid"
+// @Param request body requests.UpdateSentence true "Update sentence request"
+// @Success 200 {object} presenter.Response{message=string} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 403 {object} presenter.Error "Forbidden"
+// @Failure 404 {object} presenter.Error "Not found"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID put_language_v1_sentences_sentenceID
+// @Router /{language}/v1/sentences/{sentenceID} [put]
+func (r SentenceHandler) Update(ctx *gin.Context) {
+	var header requests.Header
+	if err := ctx.ShouldBindHeader(&header); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	var uri requests.SentenceUUIDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	var req requests.UpdateSentence
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	sentence := req.ToSentenceDomain(uuid.MustParse(uri.UUIDStr))
+	if err := r.sentenceService.Update(uowFactory, header.Access(), sentence); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err := uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Message(constant.SentenceSuccessUpdated).Echo(http.StatusOK)
+}
+
+// Delete godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer[DELETE_SENTENCE, DELETE_OWN_SENTENCE]
+// @Summary Delete Sentence
+// @Description delete a sentence, DELETE_OWN_SENTENCE only allows the sentences the user created
+// @Tags Sentence
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param sentenceID path string true "sentence id should be uuid"
+// @Success 200 {object} presenter.Response{message=string} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 403 {object} presenter.Error "Forbidden"
+// @Failure 404 {object} presenter.Error "Not found"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID delete_language_v1_sentences_sentenceID
+// @Router /{language}/v1/sentences/{sentenceID} [delete]
+func (r SentenceHandler) Delete(ctx *gin.Context) {
+	var header requests.Header
+	if err := ctx.ShouldBindHeader(&header); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	var uri requests.SentenceUUIDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err := r.sentenceService.Delete(uowFactory, header.Access(), uuid.MustParse(uri.UUIDStr)); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err := uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Message(constant.SentenceSuccessDeleted).Echo(http.StatusOK)
+}
@@ -23,14 +23,35 @@ var StatusCodeMapping = map[serviceerror.ErrorMessage]int{
 	serviceerror.EmailRegistered:   http.StatusConflict,
 	serviceerror.CredentialInvalid: http.StatusUnauthorized,
 	serviceerror.UserLogout:        http.StatusUnauthorized,
+	serviceerror.PasswordReused:    http.StatusUnprocessableEntity,
 	// OTP
-	serviceerror.InvalidOTP: http.StatusBadRequest,
-	serviceerror.OTPExpired: http.StatusUnauthorized,
+	serviceerror.InvalidOTP:              http.StatusBadRequest,
+	serviceerror.OTPExpired:              http.StatusUnauthorized,
+	serviceerror.InvalidVerificationLink: http.StatusBadRequest,
 	// Token
 	serviceerror.InvalidToken: http.StatusUnauthorized,
 	serviceerror.TokenExpired: http.StatusUnauthorized,
 	// Validation
 	serviceerror.InvalidRequestBody: http.StatusBadRequest,
 	// Role
-	serviceerror.RoleExisted: http.StatusConflict,
+	serviceerror.RoleExisted:          http.StatusConflict,
+	serviceerror.RoleHierarchyCycle:   http.StatusConflict,
+	serviceerror.RoleHierarchyTooDeep: http.StatusBadRequest,
+	// Invitation
+	serviceerror.InvitationInvalid: http.StatusBadRequest,
+	serviceerror.InvitationExpired: http.StatusGone,
+	// Notification preference
+	serviceerror.NotificationCategoryEssential: http.StatusUnprocessableEntity,
+	serviceerror.InvalidUnsubscribeLink:        http.StatusBadRequest,
+	// Email delivery
+	serviceerror.InvalidWebhookSignature: http.StatusUnauthorized,
+	// Avatar
+	serviceerror.AvatarInvalid:  http.StatusUnsupportedMediaType,
+	serviceerror.AvatarTooLarge: http.StatusRequestEntityTooLarge,
+	// Upload
+	serviceerror.UploadContentTypeNotAllowed: http.StatusUnsupportedMediaType,
+	serviceerror.UploadTooLarge:              http.StatusRequestEntityTooLarge,
+	serviceerror.UploadSessionExpired:        http.StatusGone,
+	serviceerror.UploadMissing:               http.StatusConflict,
+	serviceerror.UploadChecksumMismatch:      http.StatusUnprocessableEntity,
 }
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/localstorage"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// StorageHandler serves the files of the local object storage through the links it presigns,
+// it is only routed when STORAGE_DRIVER is local.
+type StorageHandler struct {
+	trans   translation.Translator
+	storage *localstorage.Storage
+}
+
+func NewStorageHandler(trans translation.Translator, storage *localstorage.Storage) *StorageHandler {
+	return &StorageHandler{
+		trans:   trans,
+		storage: storage,
+	}
+}
+
+// Download streams the file of a presigned link, the link is rejected once it has expired.
+func (r StorageHandler) Download(ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+	if !r.storage.Verify(key, ctx.Request.URL.Query()) {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(serviceerror.New(serviceerror.PermissionDenied)).Echo()
+		return
+	}
+
+	reader, err := r.storage.Get(ctx.Request.Context(), key)
+	if err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+	defer func(reader io.ReadCloser) {
+		_ = reader.Close()
+	}(reader)
+
+	modTime := time.Time{}
+	if file, ok := reader.(*os.File); ok {
+		if info, statErr := file.Stat(); statErr == nil {
+			modTime = info.ModTime()
+		}
+	}
+
+	if seeker, ok := reader.(io.ReadSeeker); ok {
+		http.ServeContent(ctx.Writer, ctx.Request, path.Base(key), modTime, seeker)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+	_, _ = io.Copy(ctx.Writer, reader)
+}
+
+// Upload stores the body of a request sent to a link returned by PresignPut, the Content-Type and
+// Content-Length of the request have to be the signed ones like for a MinIO presigned upload.
+func (r StorageHandler) Upload(ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+	contentType := ctx.GetHeader("Content-Type")
+	size := ctx.Request.ContentLength
+	if !r.storage.VerifyPut(key, ctx.Request.URL.Query(), contentType, size) {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(serviceerror.New(serviceerror.PermissionDenied)).Echo()
+		return
+	}
+
+	if err := r.storage.Put(ctx.Request.Context(), key, io.LimitReader(ctx.Request.Body, size), size, contentType); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(serviceerror.NewServerError()).Echo()
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
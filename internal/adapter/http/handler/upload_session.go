@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/requests"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/event/userevent"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
+	"net/http"
+)
+
+// UploadSessionHandler represents the HTTP handler for direct-to-storage upload requests
+type UploadSessionHandler struct {
+	trans                translation.Translator
+	uploadSessionService port.UploadSessionService
+	queue                *messagebroker.Queue
+	uowFactory           func() port.UserUnitOfWork
+}
+
+// NewUploadSessionHandler creates a new UploadSessionHandler instance
+func NewUploadSessionHandler(
+	trans translation.Translator,
+	uploadSessionService port.UploadSessionService,
+	queue *messagebroker.Queue,
+	uowFactory func() port.UserUnitOfWork,
+) *UploadSessionHandler {
+	return &UploadSessionHandler{
+		trans:                trans,
+		uploadSessionService: uploadSessionService,
+		queue:                queue,
+		uowFactory:           uowFactory,
+	}
+}
+
+// Create godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer
+// @Summary Create Upload Session
+// @Description Open an upload session and get a presigned link to PUT the file straight to the storage, the link only accepts the declared content type and size.
+// @Description The upload has to be completed before the file can be used, sessions that are never completed are removed after the link expires.
+// @Tags Upload
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param request body requests.CreateUploadSession true "Upload session request"
+// @Success 201 {object} presenter.Response{data=presenter.UploadSession} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 413 {object} presenter.Error "File too large"
+// @Failure 415 {object} presenter.Error "Content type not allowed"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID post_language_v1_users_uploads
+// @Router /{language}/v1/users/uploads [post]
+func (r UploadSessionHandler) Create(ctx *gin.Context) {
+	var header requests.Header
+	if err := ctx.ShouldBindHeader(&header); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	var req requests.CreateUploadSession
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	session, link, err := r.uploadSessionService.Create(ctx, uowFactory, req.ToUploadSessionDomain(header.UserID))
+	if err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	event := userevent.NewAbandonUploadSession(r.queue, r.uowFactory, r.uploadSessionService)
+	if err = event.Enqueue(ctx, uowFactory.OutboxRepository(), userevent.AbandonUploadSessionDto{
+		SessionUUID: session.Base.UUID.String(),
+	}); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Payload(
+		presenter.ToUploadSessionResource(session).SetUploadURL(link, session),
+	).Echo(http.StatusCreated)
+}
+
+// Complete godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer
+// @Summary Complete Upload Session
+// @Description Check the uploaded file against the declared size and checksum, a mismatching file is removed and has to be uploaded again
+// @Tags Upload
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param uploadID path string true "upload id should be uuid"
+// @Success 200 {object} presenter.Response{data=presenter.UploadSession} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 404 {object} presenter.Error "Not found"
+// @Failure 409 {object} presenter.Error "File not uploaded yet"
+// @Failure 410 {object} presenter.Error "Upload link expired"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error or checksum mismatch"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID post_language_v1_users_uploads_uploadID_complete
+// @Router /{language}/v1/users/uploads/{uploadID}/complete [post]
+func (r UploadSessionHandler) Complete(ctx *gin.Context) {
+	var header requests.Header
+	if err := ctx.ShouldBindHeader(&header); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	var uploadReq requests.UploadSessionUUIDUri
+	if err := ctx.ShouldBindUri(&uploadReq); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	session, err := r.uploadSessionService.Complete(ctx, uowFactory, header.UserID, uploadReq.UUIDStr)
+	if err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Payload(
+		presenter.ToUploadSessionResource(session),
+	).Echo()
+}
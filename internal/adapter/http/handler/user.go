@@ -1,41 +1,52 @@
 package handler
 
 import (
-	"fmt"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/avatar"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/constant"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/requests"
-	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/minio"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/event/userevent"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/outboxservice"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
-	"mime/multipart"
 	"net/http"
-	"os"
-	"strings"
 )
 
 // UserHandler represents the HTTP handler for user-related requests
 type UserHandler struct {
-	trans       translation.Translator
-	userService port.UserService
-	uowFactory  func() port.UserUnitOfWork
-	minioClient *minio.Client
+	trans             translation.Translator
+	userService       port.UserService
+	invitationService port.UserInvitationService
+	userDataService   port.UserDataService
+	queue             *messagebroker.Queue
+	uowFactory        func() port.UserUnitOfWork
+	objectStorage     port.ObjectStorage
+	avatarStore       *avatar.Store
 }
 
 // NewUserHandler creates a new UserHandler instance
 func NewUserHandler(
 	trans translation.Translator,
 	userService port.UserService,
+	invitationService port.UserInvitationService,
+	userDataService port.UserDataService,
+	queue *messagebroker.Queue,
 	uowFactory func() port.UserUnitOfWork,
-	minioClient *minio.Client,
+	objectStorage port.ObjectStorage,
+	avatarStore *avatar.Store,
 ) *UserHandler {
 	return &UserHandler{
-		trans:       trans,
-		userService: userService,
-		uowFactory:  uowFactory,
-		minioClient: minioClient,
+		trans:             trans,
+		userService:       userService,
+		invitationService: invitationService,
+		userDataService:   userDataService,
+		queue:             queue,
+		uowFactory:        uowFactory,
+		objectStorage:     objectStorage,
+		avatarStore:       avatarStore,
 	}
 }
 
@@ -83,15 +94,15 @@ func (r UserHandler) Profile(ctx *gin.Context) {
 	}
 
 	presenter.NewResponse(ctx, r.trans).Payload(
-		presenter.ToUserResource(user),
+		r.toUserResource(ctx, user),
 	).Echo()
 }
 
 // Create godoc
 // @x-kong {"service": "user-management-http-service"}
-// @Security AuthBearer[CREATE_USER]
+// @Security AuthBearer[CREATE_USER, SYNC_ROLES_WITH_USER]
 // @Summary Create user
-// @Description Create user
+// @Description Create an unverified user with the given roles and email an invitation to set the password, giving roles requires SYNC_ROLES_WITH_USER besides CREATE_USER and without roles the user gets the USER role
 // @Tags User
 // @Accept json
 // @Produce plain
@@ -133,28 +144,40 @@ func (r UserHandler) Create(ctx *gin.Context) {
 		return
 	}
 
-	url, uploadFileErr := r.handleFileUpload(ctx, req.Avatar)
-	if uploadFileErr != nil {
-		presenter.NewResponse(ctx, r.trans).Error(uploadFileErr).Echo(http.StatusInternalServerError)
+	avatarKey, uploadErr := r.avatarStore.Upload(ctx.Request.Context(), req.Avatar)
+	if uploadErr != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(uploadErr).Echo()
 		return
 	}
 
 	user := req.ToUserDomain()
 	user.Modifier.CreatedBy = &header.UserID
-	user.Avatar = &url
+	user.Avatar = &avatarKey
 
-	if _, err := r.userService.Create(uowFactory, user); err != nil {
+	createdUser, token, err := r.invitationService.Invite(ctx.Request.Context(), uowFactory, user, req.RoleIDs, header.Access())
+	if err != nil {
 		if rErr := uowFactory.Rollback(); rErr != nil {
 			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
 			return
 		}
-		presenter.NewResponse(ctx, r.trans).Error(err).Echo()
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
 		return
 	}
 
-	// TODO send verify Email
+	if err = enqueueInvitation(ctx, r.queue, uowFactory.OutboxRepository(), createdUser, token, ctx.Param("language")); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
 
-	if err := uowFactory.Commit(); err != nil {
+	if err = uowFactory.Commit(); err != nil {
 		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
 		return
 	}
@@ -200,9 +223,14 @@ func (r UserHandler) List(ctx *gin.Context) {
 		return
 	}
 
-	presenter.NewResponse(ctx, r.trans).Payload(
-		presenter.ToUserCollection(users),
-	).Echo()
+	var collection []presenter.User
+	for _, user := range users {
+		if resource := r.toUserResource(ctx, user); resource != nil {
+			collection = append(collection, *resource)
+		}
+	}
+
+	presenter.NewResponse(ctx, r.trans).Payload(collection).Echo()
 }
 
 // Get godoc
@@ -251,27 +279,176 @@ func (r UserHandler) Get(ctx *gin.Context) {
 	}
 
 	presenter.NewResponse(ctx, r.trans).Payload(
-		presenter.ToUserResource(user),
+		r.toUserResource(ctx, user),
 	).Echo()
 }
 
-func (r UserHandler) handleFileUpload(ctx *gin.Context, file *multipart.FileHeader) (string, error) {
-	filePath := fmt.Sprintf("/tmp/%s", file.Filename)
-	if err := ctx.SaveUploadedFile(file, filePath); err != nil {
-		return "", err
+// Export godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer
+// @Summary Export Profile Data
+// @Description Queue an export of all the data of the user, a time-limited download link of the ZIP archive is emailed to the user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Success 202 {object} presenter.Response{message=string} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID post_language_v1_users_profile_export
+// @Router /{language}/v1/users/profile/export [post]
+func (r UserHandler) Export(ctx *gin.Context) {
+	var header requests.Header
+	if err := ctx.ShouldBindHeader(&header); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
 	}
 
-	defer func(name string) {
-		_ = os.Remove(name)
-	}(filePath)
+	event := userevent.NewExportUserData(r.queue, r.uowFactory, r.userDataService, r.objectStorage)
+	if err := outboxservice.Publish(ctx, r.uowFactory(), event, userevent.ExportUserDataDto{
+		UserID:   header.UserID,
+		Language: ctx.Param("language"),
+	}); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
 
-	fileTypeSegment := strings.Split(file.Filename, ".")
-	uniqueFileName := fmt.Sprintf("%s.%s", uuid.New().String(), fileTypeSegment[len(fileTypeSegment)-1])
+	presenter.NewResponse(ctx, r.trans).Message(constant.UserSuccessExportRequested).Echo(http.StatusAccepted)
+}
 
-	url, uploadFileErr := r.minioClient.UploadFile(ctx.Request.Context(), uniqueFileName, filePath, file.Header.Get("Content-Type"))
-	if uploadFileErr != nil {
-		return "", uploadFileErr
+// Erase godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer[ERASE_USER]
+// @Summary Erase User
+// @Description Queue the erasure of the personal data of a user, the account is anonymized and kept so the records it created stay valid
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param userID path string true "user id should be uuid"
+// @Success 202 {object} presenter.Response{message=string} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID post_language_v1_users_userID_erasure
+// @Router /{language}/v1/users/{userID}/erasure [post]
+func (r UserHandler) Erase(ctx *gin.Context) {
+	var header requests.Header
+	if err := ctx.ShouldBindHeader(&header); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	var userReq requests.UserUUIDUri
+	if err := ctx.ShouldBindUri(&userReq); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
 	}
 
-	return url, nil
+	event := userevent.NewEraseUserData(r.queue, r.uowFactory, r.userService, r.userDataService, r.objectStorage, r.avatarStore)
+	if err := outboxservice.Publish(ctx, r.uowFactory(), event, userevent.EraseUserDataDto{
+		UserUUID:  userReq.UUIDStr,
+		ErasedBy:  header.UserID,
+		IP:        ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+	}); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Message(constant.UserSuccessErasureRequested).Echo(http.StatusAccepted)
+}
+
+// UpdateAvatar godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer
+// @Summary Update Avatar
+// @Description Replace the avatar of the user, the image is validated, stripped of its metadata and stored with its thumbnails
+// @Tags User
+// @Accept mpfd
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param avatar formData file true "Avatar image, JPEG, PNG or GIF"
+// @Success 200 {object} presenter.Response{data=presenter.User} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 413 {object} presenter.Error "Avatar too large"
+// @Failure 415 {object} presenter.Error "Unsupported avatar type"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID put_language_v1_users_profile_avatar
+// @Router /{language}/v1/users/profile/avatar [put]
+func (r UserHandler) UpdateAvatar(ctx *gin.Context) {
+	var header requests.Header
+	if err := ctx.ShouldBindHeader(&header); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	var req requests.UpdateAvatarRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	avatarKey, err := r.avatarStore.Upload(ctx.Request.Context(), req.Avatar)
+	if err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err = uowFactory.BeginTx(ctx); err != nil {
+		r.removeUploadedAvatar(ctx, avatarKey)
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	user, err := r.userService.GetByID(uowFactory, header.UserID)
+	if err == nil {
+		err = r.userService.UpdateAvatar(uowFactory, user.Base.ID, avatarKey)
+	}
+
+	// the replaced avatar is removed later by a job that keeps it if another user shares the same picture
+	if err == nil && user.Avatar != nil && *user.Avatar != avatarKey {
+		err = userevent.NewRemoveAvatar(r.queue, r.uowFactory, r.userService, r.avatarStore).Enqueue(
+			ctx,
+			uowFactory.OutboxRepository(),
+			userevent.RemoveAvatarDto{Avatar: *user.Avatar},
+		)
+	}
+	if err != nil {
+		rErr := uowFactory.Rollback()
+		r.removeUploadedAvatar(ctx, avatarKey)
+		if rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		r.removeUploadedAvatar(ctx, avatarKey)
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	user.Avatar = &avatarKey
+	presenter.NewResponse(ctx, r.trans).Payload(
+		r.toUserResource(ctx, user),
+	).Echo()
+}
+
+// removeUploadedAvatar queues the removal of an avatar uploaded for a change that was not saved, the job keeps
+// it if a user has it after all, so an uncertain commit or another user with the same picture is safe.
+func (r UserHandler) removeUploadedAvatar(ctx *gin.Context, avatarKey string) {
+	event := userevent.NewRemoveAvatar(r.queue, r.uowFactory, r.userService, r.avatarStore)
+	_ = outboxservice.Publish(ctx, r.uowFactory(), event, userevent.RemoveAvatarDto{Avatar: avatarKey})
+}
+
+func (r UserHandler) toUserResource(ctx *gin.Context, user *domain.User) *presenter.User {
+	return presenter.ToUserResource(user).SetAvatar(r.avatarStore.URLs(ctx.Request.Context(), user.Avatar))
 }
@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/constant"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/requests"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/event/userevent"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
+	"net/http"
+)
+
+// UserInvitationHandler represents the HTTP handler for user invitation requests
+type UserInvitationHandler struct {
+	conf              config.Config
+	trans             translation.Translator
+	invitationService port.UserInvitationService
+	passwordService   port.PasswordHistoryService
+	queue             *messagebroker.Queue
+	uowFactory        func() port.UserUnitOfWork
+}
+
+// NewUserInvitationHandler creates a new UserInvitationHandler instance
+func NewUserInvitationHandler(
+	conf config.Config,
+	trans translation.Translator,
+	invitationService port.UserInvitationService,
+	passwordService port.PasswordHistoryService,
+	queue *messagebroker.Queue,
+	uowFactory func() port.UserUnitOfWork,
+) *UserInvitationHandler {
+	return &UserInvitationHandler{
+		conf:              conf,
+		trans:             trans,
+		invitationService: invitationService,
+		passwordService:   passwordService,
+		queue:             queue,
+		uowFactory:        uowFactory,
+	}
+}
+
+// Resend godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer[CREATE_USER]
+// @Summary Resend Invitation
+// @Description Send a new set-password link to an invited user, the previous link stops working
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param userID path string true "user id should be uuid"
+// @Success 200 {object} presenter.Response{message=string} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 404 {object} presenter.Error "Not found"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID post_language_v1_users_userID_invitation_resend
+// @Router /{language}/v1/users/{userID}/invitation/resend [post]
+func (r UserInvitationHandler) Resend(ctx *gin.Context) {
+	var userReq requests.UserUUIDUri
+	if err := ctx.ShouldBindUri(&userReq); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	invitation, token, err := r.invitationService.Resend(uowFactory, userReq.UUIDStr)
+	if err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = enqueueInvitation(ctx, r.queue, uowFactory.OutboxRepository(), invitation.User, token, ctx.Param("language")); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Message(constant.UserSuccessInvitationResent).Echo(http.StatusOK)
+}
+
+// Revoke godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Security AuthBearer[CREATE_USER]
+// @Summary Revoke Invitation
+// @Description Revoke the pending invitation of a user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param userID path string true "user id should be uuid"
+// @Success 200 {object} presenter.Response{message=string} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 401 {object} presenter.Error "Unauthorized"
+// @Failure 404 {object} presenter.Error "Not found"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID delete_language_v1_users_userID_invitation
+// @Router /{language}/v1/users/{userID}/invitation [delete]
+func (r UserInvitationHandler) Revoke(ctx *gin.Context) {
+	var header requests.Header
+	if err := ctx.ShouldBindHeader(&header); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	var userReq requests.UserUUIDUri
+	if err := ctx.ShouldBindUri(&userReq); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err := r.invitationService.Revoke(uowFactory, userReq.UUIDStr, header.UserID); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err := uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Message(constant.UserSuccessInvitationRevoked).Echo(http.StatusOK)
+}
+
+// Accept godoc
+// @x-kong {"service": "user-management-http-service"}
+// @Summary Accept Invitation
+// @Description Set the password of an invited user and activate the account, the token can be used once
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param language path string true "language 2 abbreviations" default(en)
+// @Param request body requests.AcceptInvitation true "Accept invitation request"
+// @Success 200 {object} presenter.Response{message=string} "Successful response"
+// @Failure 400 {object} presenter.Error "Failed response"
+// @Failure 410 {object} presenter.Error "Invitation expired"
+// @Failure 422 {object} presenter.Response{validationErrors=[]presenter.ValidationError} "Validation error"
+// @Failure 500 {object} presenter.Error "Internal server error"
+// @ID post_language_v1_users_invitations_accept
+// @Router /{language}/v1/users/invitations/accept [post]
+func (r UserInvitationHandler) Accept(ctx *gin.Context) {
+	var req requests.AcceptInvitation
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	uowFactory := r.uowFactory()
+	if err := uowFactory.BeginTx(ctx); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	invitation, err := r.invitationService.Open(uowFactory, req.Token)
+	if err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = binding.Validator.ValidateStruct(req.ToInvitedPassword(invitation.User)); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans).Validation(err).Echo(http.StatusUnprocessableEntity)
+		return
+	}
+
+	if err = r.passwordService.CheckReuse(uowFactory, invitation.UserID, invitation.User.Password, req.Password); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	hashPassword, err := helper.HashPassword(req.Password, passwordParams(r.conf.Password))
+	if err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = r.invitationService.Accept(uowFactory, invitation, hashPassword); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = r.passwordService.Remember(uowFactory, invitation.UserID, hashPassword); err != nil {
+		if rErr := uowFactory.Rollback(); rErr != nil {
+			presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(rErr).Echo()
+			return
+		}
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	if err = uowFactory.Commit(); err != nil {
+		presenter.NewResponse(ctx, r.trans, StatusCodeMapping).Error(err).Echo()
+		return
+	}
+
+	presenter.NewResponse(ctx, r.trans).Message(constant.UserSuccessInvitationAccepted).Echo(http.StatusOK)
+}
+
+func enqueueInvitation(
+	ctx context.Context,
+	queue *messagebroker.Queue,
+	outbox port.OutboxRepository,
+	user *domain.User,
+	token string,
+	language string,
+) error {
+	return userevent.NewSendInvitation(queue).Enqueue(ctx, outbox, userevent.SendInvitationDto{
+		To:       user.Email,
+		Name:     user.GetFullName(),
+		Token:    token,
+		Language: language,
+	})
+}
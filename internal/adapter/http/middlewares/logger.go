@@ -16,15 +16,24 @@ type bodyLogWriter struct {
 }
 
 func (r bodyLogWriter) Write(b []byte) (int, error) {
-	r.body.Write(b)
+	if !r.isStream() {
+		r.body.Write(b)
+	}
 	return r.ResponseWriter.Write(b)
 }
 
 func (r bodyLogWriter) WriteString(s string) (int, error) {
-	r.body.WriteString(s)
+	if !r.isStream() {
+		r.body.WriteString(s)
+	}
 	return r.ResponseWriter.WriteString(s)
 }
 
+// isStream reports whether the response is an event stream, it stays open so its body is not kept for the log.
+func (r bodyLogWriter) isStream() bool {
+	return strings.HasPrefix(r.Header().Get("Content-Type"), "text/event-stream")
+}
+
 func DefaultStructuredLogger(log logger.Logger) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		if strings.Contains(ctx.Request.URL.Path, "/swagger") {
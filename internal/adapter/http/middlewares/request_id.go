@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+)
+
+// RequestID keeps the request id set by the gateway, or generates one, and carries it as the correlation id
+// of the messages the request produces.
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(config.RequestIDHeaderKey)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+
+		ctx.Set(domain.CorrelationIDKey, requestID)
+		ctx.Header(config.RequestIDHeaderKey, requestID)
+
+		ctx.Next()
+	}
+}
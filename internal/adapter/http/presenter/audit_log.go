@@ -0,0 +1,74 @@
+package presenter
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"time"
+)
+
+type AuditLogChange struct {
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+type AuditLog struct {
+	ID         string                    `json:"id" example:"8f4a1582-6a67-4d85-950b-2d17049c7385"`
+	ActorID    *string                   `json:"actorID,omitempty" example:"8f4a1582-6a67-4d85-950b-2d17049c7385"`
+	Action     string                    `json:"action" example:"ROLE_UPDATED"`
+	TargetType string                    `json:"targetType" example:"role"`
+	TargetID   *string                   `json:"targetID,omitempty" example:"8f4a1582-6a67-4d85-950b-2d17049c7385"`
+	Changes    map[string]AuditLogChange `json:"changes,omitempty"`
+	IP         string                    `json:"ip,omitempty" example:"127.0.0.1"`
+	UserAgent  string                    `json:"userAgent,omitempty" example:"Mozilla/5.0"`
+	CreatedAt  string                    `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+}
+
+type Pagination struct {
+	Page    uint64 `json:"page" example:"1"`
+	PerPage uint64 `json:"perPage" example:"20"`
+	Total   uint64 `json:"total" example:"100"`
+}
+
+func PrepareAuditLog(auditLog *domain.AuditLog) *AuditLog {
+	if auditLog == nil || auditLog.Base.UUID == uuid.Nil {
+		return nil
+	}
+
+	result := &AuditLog{
+		ID:         auditLog.Base.UUID.String(),
+		Action:     string(auditLog.Action),
+		TargetType: string(auditLog.TargetType),
+		IP:         auditLog.Actor.IP,
+		UserAgent:  auditLog.Actor.UserAgent,
+		CreatedAt:  auditLog.Base.CreatedAt.Format(time.RFC3339),
+	}
+
+	if auditLog.Actor.UserUUID != uuid.Nil {
+		actorID := auditLog.Actor.UserUUID.String()
+		result.ActorID = &actorID
+	}
+	if auditLog.TargetUUID != nil {
+		targetID := auditLog.TargetUUID.String()
+		result.TargetID = &targetID
+	}
+	if len(auditLog.Changes) > 0 {
+		result.Changes = make(map[string]AuditLogChange, len(auditLog.Changes))
+		for field, change := range auditLog.Changes {
+			result.Changes[field] = AuditLogChange{Before: change.Before, After: change.After}
+		}
+	}
+
+	return result
+}
+
+func ToAuditLogCollection(auditLogs []*domain.AuditLog) []AuditLog {
+	var response []AuditLog
+	for _, auditLog := range auditLogs {
+		result := PrepareAuditLog(auditLog)
+		if result != nil {
+			response = append(response, *result)
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,100 @@
+package presenter_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPrepareAuditLog(t *testing.T) {
+	auditUUID := uuid.MustParse("8f4a1582-6a67-4d85-950b-2d17049c7385")
+	actorUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	targetUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		auditLog       *domain.AuditLog
+		expectedResult *presenter.AuditLog
+	}{
+		{
+			name:           "Nil AuditLog",
+			auditLog:       nil,
+			expectedResult: nil,
+		},
+		{
+			name: "Valid AuditLog",
+			auditLog: &domain.AuditLog{
+				Base: domain.Base{UUID: auditUUID, CreatedAt: createdAt},
+				Actor: domain.AuditActor{
+					UserUUID:  actorUUID,
+					IP:        "127.0.0.1",
+					UserAgent: "Mozilla/5.0",
+				},
+				Action:     domain.AuditActionRoleUpdated,
+				TargetType: domain.AuditTargetRole,
+				TargetUUID: &targetUUID,
+				Changes: map[string]domain.AuditChange{
+					"title": {Before: "Staff", After: "Member"},
+				},
+			},
+			expectedResult: &presenter.AuditLog{
+				ID:         auditUUID.String(),
+				ActorID:    helper.StringPtr(actorUUID.String()),
+				Action:     "ROLE_UPDATED",
+				TargetType: "role",
+				TargetID:   helper.StringPtr(targetUUID.String()),
+				Changes: map[string]presenter.AuditLogChange{
+					"title": {Before: "Staff", After: "Member"},
+				},
+				IP:        "127.0.0.1",
+				UserAgent: "Mozilla/5.0",
+				CreatedAt: "2024-01-02T03:04:05Z",
+			},
+		},
+		{
+			name: "System AuditLog without actor and target",
+			auditLog: &domain.AuditLog{
+				Base:       domain.Base{UUID: auditUUID, CreatedAt: createdAt},
+				Action:     domain.AuditActionPermissionRegistrySync,
+				TargetType: domain.AuditTargetPermission,
+			},
+			expectedResult: &presenter.AuditLog{
+				ID:         auditUUID.String(),
+				Action:     "PERMISSION_REGISTRY_SYNCED",
+				TargetType: "permission",
+				CreatedAt:  "2024-01-02T03:04:05Z",
+			},
+		},
+		{
+			name:           "Invalid AuditLog with uuid equal nil",
+			auditLog:       &domain.AuditLog{Action: domain.AuditActionRoleCreated},
+			expectedResult: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := presenter.PrepareAuditLog(test.auditLog)
+			require.Equal(t, test.expectedResult, result)
+		})
+	}
+}
+
+func TestToAuditLogCollection(t *testing.T) {
+	auditLogs := []*domain.AuditLog{
+		{Base: domain.Base{UUID: uuid.New()}, Action: domain.AuditActionRoleCreated, TargetType: domain.AuditTargetRole},
+		nil,
+		{Base: domain.Base{UUID: uuid.New()}, Action: domain.AuditActionRoleDeleted, TargetType: domain.AuditTargetRole},
+	}
+
+	result := presenter.ToAuditLogCollection(auditLogs)
+
+	require.Len(t, result, 2)
+	require.Equal(t, "ROLE_CREATED", result[0].Action)
+	require.Equal(t, "ROLE_DELETED", result[1].Action)
+}
@@ -1,5 +1,7 @@
 package presenter
 
+import "github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+
 type Token struct {
 	AccessToken *string `json:"accessToken,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9"`
 }
@@ -11,8 +13,9 @@ func ToTokenResource(token *string) *Token {
 }
 
 type Authorize struct {
-	Authorized bool   `json:"authorized"`
-	JTI        string `json:"jti"`
-	EXP        int64  `json:"exp"`
-	ID         uint64 `json:"id"`
+	Authorized  bool                       `json:"authorized"`
+	JTI         string                     `json:"jti"`
+	EXP         int64                      `json:"exp"`
+	ID          uint64                     `json:"id"`
+	Permissions []domain.PermissionKeyType `json:"permissions"`
 }
@@ -0,0 +1,33 @@
+package presenter
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"time"
+)
+
+type EmailSuppression struct {
+	ID          string `json:"id" example:"8f4a1582-6a67-4d85-950b-2d17049c7385"`
+	Email       string `json:"email" example:"john.doe@example.com"`
+	Reason      string `json:"reason" example:"BOUNCE"`
+	Description string `json:"description,omitempty" example:"550 5.1.1 The email account does not exist"`
+	CreatedAt   string `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+}
+
+func ToEmailSuppressionResource(suppression *domain.EmailSuppression) EmailSuppression {
+	return EmailSuppression{
+		ID:          suppression.Base.UUID.String(),
+		Email:       suppression.Email,
+		Reason:      string(suppression.Reason),
+		Description: suppression.Description,
+		CreatedAt:   suppression.Base.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ToEmailSuppressionCollection(suppressions []*domain.EmailSuppression) []EmailSuppression {
+	response := make([]EmailSuppression, 0, len(suppressions))
+	for _, suppression := range suppressions {
+		response = append(response, ToEmailSuppressionResource(suppression))
+	}
+
+	return response
+}
@@ -0,0 +1,32 @@
+package presenter_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestToEmailSuppressionCollection(t *testing.T) {
+	suppressionUUID := uuid.New()
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	collection := presenter.ToEmailSuppressionCollection([]*domain.EmailSuppression{{
+		Base:        domain.Base{UUID: suppressionUUID, CreatedAt: createdAt},
+		Email:       "john.doe@example.com",
+		Reason:      domain.EmailSuppressionReasonBounce,
+		Description: "550 5.1.1 The email account does not exist",
+	}})
+
+	require.Equal(t, []presenter.EmailSuppression{{
+		ID:          suppressionUUID.String(),
+		Email:       "john.doe@example.com",
+		Reason:      "BOUNCE",
+		Description: "550 5.1.1 The email account does not exist",
+		CreatedAt:   "2024-01-01T00:00:00Z",
+	}}, collection)
+
+	require.Empty(t, presenter.ToEmailSuppressionCollection(nil))
+}
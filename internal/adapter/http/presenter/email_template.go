@@ -0,0 +1,19 @@
+package presenter
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+)
+
+type EmailTemplatePreview struct {
+	Subject string `json:"subject" example:"Verify Your Email for Polyglot Sentences"`
+	HTML    string `json:"html" example:"<!DOCTYPE html><html lang=\"en\" dir=\"ltr\">...</html>"`
+	Text    string `json:"text" example:"Dear John Doe, ..."`
+}
+
+func ToEmailTemplatePreviewResource(email domain.Email) EmailTemplatePreview {
+	return EmailTemplatePreview{
+		Subject: email.Subject,
+		HTML:    email.HTML,
+		Text:    email.Text,
+	}
+}
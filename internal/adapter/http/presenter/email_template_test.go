@@ -0,0 +1,24 @@
+package presenter_test
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestToEmailTemplatePreviewResource(t *testing.T) {
+	resource := presenter.ToEmailTemplatePreviewResource(domain.Email{
+		To:      "john.doe@example.com",
+		Name:    "John Doe",
+		Subject: "Welcome",
+		HTML:    "<p>Hello</p>",
+		Text:    "Hello",
+	})
+
+	require.Equal(t, presenter.EmailTemplatePreview{
+		Subject: "Welcome",
+		HTML:    "<p>Hello</p>",
+		Text:    "Hello",
+	}, resource)
+}
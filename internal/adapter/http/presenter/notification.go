@@ -0,0 +1,48 @@
+package presenter
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"time"
+)
+
+type Notification struct {
+	ID        string  `json:"id" example:"8f4a1582-6a67-4d85-950b-2d17049c7385"`
+	Type      string  `json:"type" example:"STREAK_AT_RISK"`
+	Title     string  `json:"title" example:"Your streak is at risk"`
+	Body      string  `json:"body" example:"Practice one sentence today to keep your 12 day streak."`
+	Link      *string `json:"link,omitempty" example:"https://polyglot-sentences.local/practice"`
+	Read      bool    `json:"read" example:"false"`
+	ReadAt    *string `json:"readAt,omitempty" example:"2024-01-01T00:00:00Z"`
+	CreatedAt string  `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+}
+
+type NotificationUnreadCount struct {
+	Count uint64 `json:"count" example:"3"`
+}
+
+func ToNotificationResource(notification *domain.Notification) Notification {
+	response := Notification{
+		ID:        notification.Base.UUID.String(),
+		Type:      string(notification.Type),
+		Title:     notification.Title,
+		Body:      notification.Body,
+		Link:      notification.Link,
+		Read:      notification.ReadAt != nil,
+		CreatedAt: notification.Base.CreatedAt.Format(time.RFC3339),
+	}
+	if notification.ReadAt != nil {
+		readAt := notification.ReadAt.Format(time.RFC3339)
+		response.ReadAt = &readAt
+	}
+
+	return response
+}
+
+func ToNotificationCollection(notifications []*domain.Notification) []Notification {
+	response := make([]Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		response = append(response, ToNotificationResource(notification))
+	}
+
+	return response
+}
@@ -0,0 +1,41 @@
+package presenter
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+)
+
+type NotificationPreference struct {
+	Category  string `json:"category" example:"PRODUCT"`
+	Enabled   bool   `json:"enabled" example:"true"`
+	Essential bool   `json:"essential" example:"false"`
+}
+
+func ToNotificationPreferenceResource(preference domain.NotificationPreference) NotificationPreference {
+	return NotificationPreference{
+		Category:  string(preference.Category),
+		Enabled:   preference.Enabled,
+		Essential: preference.Category.IsEssential(),
+	}
+}
+
+func ToNotificationPreferenceCollection(preferences []domain.NotificationPreference) []NotificationPreference {
+	var response []NotificationPreference
+	for _, preference := range preferences {
+		response = append(response, ToNotificationPreferenceResource(preference))
+	}
+
+	return response
+}
+
+// UnsubscribeConfirmation is what the unsubscribe link turns off, posting the token back confirms it.
+type UnsubscribeConfirmation struct {
+	Category string `json:"category" example:"PRODUCT"`
+	Token    string `json:"token" example:"8f4a1582-6a67-4d85-950b-2d17049c7385.PRODUCT.9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+}
+
+func ToUnsubscribeConfirmationResource(category domain.NotificationCategoryType, token string) UnsubscribeConfirmation {
+	return UnsubscribeConfirmation{
+		Category: string(category),
+		Token:    token,
+	}
+}
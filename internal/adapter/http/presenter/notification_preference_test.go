@@ -0,0 +1,27 @@
+package presenter_test
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestToNotificationPreferenceCollection(t *testing.T) {
+	resources := presenter.ToNotificationPreferenceCollection([]domain.NotificationPreference{
+		{UserID: 10, Category: domain.NotificationCategorySecurity, Enabled: true},
+		{UserID: 10, Category: domain.NotificationCategoryProduct, Enabled: false},
+	})
+
+	require.Equal(t, []presenter.NotificationPreference{
+		{Category: "SECURITY", Enabled: true, Essential: true},
+		{Category: "PRODUCT", Enabled: false, Essential: false},
+	}, resources)
+}
+
+func TestToUnsubscribeConfirmationResource(t *testing.T) {
+	require.Equal(t, presenter.UnsubscribeConfirmation{
+		Category: "PRODUCT",
+		Token:    "token",
+	}, presenter.ToUnsubscribeConfirmationResource(domain.NotificationCategoryProduct, "token"))
+}
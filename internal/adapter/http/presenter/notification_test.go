@@ -0,0 +1,57 @@
+package presenter_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestToNotificationCollection(t *testing.T) {
+	unreadUUID := uuid.New()
+	readUUID := uuid.New()
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	readAt := time.Date(2024, 1, 2, 8, 30, 0, 0, time.UTC)
+
+	collection := presenter.ToNotificationCollection([]*domain.Notification{
+		{
+			Base:  domain.Base{UUID: unreadUUID, CreatedAt: createdAt},
+			Type:  domain.NotificationTypeAssignment,
+			Title: "New assignment",
+			Body:  "Your teacher assigned 10 sentences.",
+			Link:  helper.StringPtr("https://polyglot-sentences.local/assignments/1"),
+		},
+		{
+			Base:   domain.Base{UUID: readUUID, CreatedAt: createdAt},
+			Type:   domain.NotificationTypeRoleChanged,
+			Title:  "Your role changed",
+			Body:   "You are an admin now.",
+			ReadAt: &readAt,
+		},
+	})
+
+	require.Equal(t, []presenter.Notification{
+		{
+			ID:        unreadUUID.String(),
+			Type:      "ASSIGNMENT",
+			Title:     "New assignment",
+			Body:      "Your teacher assigned 10 sentences.",
+			Link:      helper.StringPtr("https://polyglot-sentences.local/assignments/1"),
+			CreatedAt: "2024-01-01T00:00:00Z",
+		},
+		{
+			ID:        readUUID.String(),
+			Type:      "ROLE_CHANGED",
+			Title:     "Your role changed",
+			Body:      "You are an admin now.",
+			Read:      true,
+			ReadAt:    helper.StringPtr("2024-01-02T08:30:00Z"),
+			CreatedAt: "2024-01-01T00:00:00Z",
+		},
+	}, collection)
+
+	require.Empty(t, presenter.ToNotificationCollection(nil))
+}
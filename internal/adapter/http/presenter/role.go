@@ -10,9 +10,17 @@ type Role struct {
 	Title       string       `json:"title" example:"Admin"`
 	Description string       `json:"description,omitempty" example:"Admin description"`
 	IsDefault   bool         `json:"isDefault,omitempty" example:"true"`
+	Parent      *Role        `json:"parent,omitempty"`
 	Permissions []Permission `json:"permissions,omitempty"`
 }
 
+type PermissionSource struct {
+	Permission Permission `json:"permission"`
+	Role       Role       `json:"role"`
+	Inherited  bool       `json:"inherited" example:"true"`
+	Depth      int        `json:"depth" example:"1"`
+}
+
 func PrepareRole(role *domain.Role) *Role {
 	if role == nil || role.Base.UUID == uuid.Nil {
 		return nil
@@ -23,6 +31,7 @@ func PrepareRole(role *domain.Role) *Role {
 		Title:       role.Title,
 		Description: role.Description,
 		IsDefault:   role.IsDefault,
+		Parent:      PrepareRole(role.Parent),
 		Permissions: ToPermissionCollection(role.Permissions),
 	}
 }
@@ -42,3 +51,26 @@ func ToRoleCollection(roles []*domain.Role) []Role {
 
 	return response
 }
+
+func ToPermissionSourceCollection(sources []domain.PermissionSource) []PermissionSource {
+	var response []PermissionSource
+	for _, source := range sources {
+		permission := PreparePermission(source.Permission)
+		role := PrepareRole(source.Role)
+		if permission == nil || role == nil {
+			continue
+		}
+
+		response = append(response, PermissionSource{
+			Permission: *permission,
+			Role: Role{
+				ID:    role.ID,
+				Title: role.Title,
+			},
+			Inherited: source.IsInherited(),
+			Depth:     source.Depth,
+		})
+	}
+
+	return response
+}
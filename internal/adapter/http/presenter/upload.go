@@ -0,0 +1,57 @@
+package presenter
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type UploadSession struct {
+	ID          string     `json:"id" example:"8f4a1582-6a67-4d85-950b-2d17049c7385"`
+	Purpose     string     `json:"purpose" example:"SENTENCE_AUDIO"`
+	Key         string     `json:"key" example:"uploads/sentence_audio/8f4a1582-6a67-4d85-950b-2d17049c7385"`
+	Status      string     `json:"status" example:"PENDING"`
+	ExpiresAt   time.Time  `json:"expiresAt" example:"2024-08-20T10:15:00Z"`
+	CompletedAt *time.Time `json:"completedAt,omitempty" example:"2024-08-20T10:05:00Z"`
+	Upload      *Upload    `json:"upload,omitempty"`
+}
+
+// Upload tells the client how to send the file, the headers are part of the signature and have to be sent as they are.
+type Upload struct {
+	URL     string            `json:"url" example:"http://localhost:9000/images/uploads/staging/sentence_audio/8f4a1582-6a67-4d85-950b-2d17049c7385?X-Amz-Signature=..."`
+	Method  string            `json:"method" example:"PUT"`
+	Headers map[string]string `json:"headers"`
+}
+
+func ToUploadSessionResource(session *domain.UploadSession) *UploadSession {
+	if session == nil {
+		return nil
+	}
+
+	return &UploadSession{
+		ID:          session.Base.UUID.String(),
+		Purpose:     string(session.Purpose),
+		Key:         session.Key,
+		Status:      string(session.Status),
+		ExpiresAt:   session.ExpiresAt,
+		CompletedAt: session.CompletedAt,
+	}
+}
+
+// SetUploadURL attaches the presigned upload link, it is only returned when the session is created.
+func (r *UploadSession) SetUploadURL(link string, session *domain.UploadSession) *UploadSession {
+	if r == nil {
+		return r
+	}
+
+	r.Upload = &Upload{
+		URL:    link,
+		Method: http.MethodPut,
+		Headers: map[string]string{
+			"Content-Type":   session.ContentType,
+			"Content-Length": strconv.FormatInt(session.Size, 10),
+		},
+	}
+	return r
+}
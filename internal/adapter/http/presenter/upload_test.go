@@ -0,0 +1,48 @@
+package presenter_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/presenter"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestToUploadSessionResource(t *testing.T) {
+	require.Nil(t, presenter.ToUploadSessionResource(nil))
+
+	sessionUUID := uuid.MustParse("2b1ef850-5b3a-441e-bd26-33f50e527b7a")
+	expiresAt := time.Date(2024, 8, 20, 10, 15, 0, 0, time.UTC)
+	session := &domain.UploadSession{
+		Base:        domain.Base{ID: 1, UUID: sessionUUID},
+		Purpose:     domain.UploadPurposeSentenceAudio,
+		Key:         domain.UploadKey(domain.UploadPurposeSentenceAudio, sessionUUID),
+		ContentType: "audio/mpeg",
+		Size:        482133,
+		Status:      domain.UploadSessionStatusPending,
+		ExpiresAt:   expiresAt,
+	}
+
+	resource := presenter.ToUploadSessionResource(session)
+	require.Equal(t, &presenter.UploadSession{
+		ID:        "2b1ef850-5b3a-441e-bd26-33f50e527b7a",
+		Purpose:   "SENTENCE_AUDIO",
+		Key:       "uploads/sentence_audio/2b1ef850-5b3a-441e-bd26-33f50e527b7a",
+		Status:    "PENDING",
+		ExpiresAt: expiresAt,
+	}, resource)
+
+	resource.SetUploadURL("http://localhost:9000/upload", session)
+	require.Equal(t, &presenter.Upload{
+		URL:    "http://localhost:9000/upload",
+		Method: "PUT",
+		Headers: map[string]string{
+			"Content-Type":   "audio/mpeg",
+			"Content-Length": "482133",
+		},
+	}, resource.Upload)
+
+	var nilResource *presenter.UploadSession
+	require.Nil(t, nilResource.SetUploadURL("http://localhost:9000/upload", session))
+}
@@ -11,6 +11,12 @@ type User struct {
 	LastName  *string `json:"lastName,omitempty" example:"doe"`
 	Email     string  `json:"email,omitempty" example:"john.doe@gmail.com"`
 	Status    string  `json:"status,omitempty" example:"ACTIVE"`
+	Avatar    *Avatar `json:"avatar,omitempty"`
+}
+
+type Avatar struct {
+	Original   string         `json:"original" example:"http://localhost:9000/images/avatars/9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08.png?X-Amz-Signature=..."`
+	Thumbnails map[int]string `json:"thumbnails,omitempty"`
 }
 
 func PrepareUser(user *domain.User) *User {
@@ -27,6 +33,19 @@ func PrepareUser(user *domain.User) *User {
 	}
 }
 
+// SetAvatar attaches the download links of the avatar, they are resolved by the handler since they are signed per request.
+func (r *User) SetAvatar(urls *domain.AvatarURLs) *User {
+	if r == nil || urls == nil {
+		return r
+	}
+
+	r.Avatar = &Avatar{
+		Original:   urls.Original,
+		Thumbnails: urls.Thumbnails,
+	}
+	return r
+}
+
 func ToUserResource(user *domain.User) *User {
 	return PrepareUser(user)
 }
@@ -233,3 +233,31 @@ func TestToUserCollection(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_SetAvatar(t *testing.T) {
+	user := &domain.User{
+		Base:  domain.Base{UUID: uuid.MustParse("2b1ef850-5b3a-441e-bd26-33f50e527b7a")},
+		Email: "john.doe@gmail.com",
+	}
+
+	t.Run("Nil urls", func(t *testing.T) {
+		result := presenter.ToUserResource(user).SetAvatar(nil)
+		require.Nil(t, result.Avatar)
+	})
+
+	t.Run("Nil user", func(t *testing.T) {
+		result := presenter.ToUserResource(nil).SetAvatar(&domain.AvatarURLs{Original: "https://example.com/avatar.png"})
+		require.Nil(t, result)
+	})
+
+	t.Run("Avatar with thumbnails", func(t *testing.T) {
+		result := presenter.ToUserResource(user).SetAvatar(&domain.AvatarURLs{
+			Original:   "https://example.com/avatar.png",
+			Thumbnails: map[int]string{64: "https://example.com/avatar_64.png"},
+		})
+		require.Equal(t, &presenter.Avatar{
+			Original:   "https://example.com/avatar.png",
+			Thumbnails: map[int]string{64: "https://example.com/avatar_64.png"},
+		}, result.Avatar)
+	})
+}
@@ -0,0 +1,61 @@
+package requests
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"time"
+)
+
+type AuditLogList struct {
+	Page       uint64  `form:"page" binding:"omitempty,min=1" example:"1"`
+	PerPage    uint64  `form:"perPage" binding:"omitempty,min=1,max=100" example:"20"`
+	ActorID    *string `form:"actorID" binding:"omitempty,uuid" example:"8f4a1582-6a67-4d85-950b-2d17049c7385"`
+	Action     *string `form:"action" binding:"omitempty,max=64" example:"ROLE_UPDATED"`
+	TargetType *string `form:"targetType" binding:"omitempty,max=32" example:"role"`
+	TargetID   *string `form:"targetID" binding:"omitempty,uuid" example:"8f4a1582-6a67-4d85-950b-2d17049c7385"`
+	From       *string `form:"from" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2024-01-01T00:00:00Z"`
+	To         *string `form:"to" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2024-12-31T23:59:59Z"`
+}
+
+// ToFilter converts the validated query into a domain.AuditLogFilter, the page size defaults to 20.
+func (r AuditLogList) ToFilter() domain.AuditLogFilter {
+	filter := domain.AuditLogFilter{
+		Page:    r.Page,
+		PerPage: r.PerPage,
+	}
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PerPage == 0 {
+		filter.PerPage = 20
+	}
+
+	if r.ActorID != nil {
+		actorUUID := uuid.MustParse(*r.ActorID)
+		filter.ActorUUID = &actorUUID
+	}
+	if r.Action != nil {
+		action := domain.AuditAction(*r.Action)
+		filter.Action = &action
+	}
+	if r.TargetType != nil {
+		targetType := domain.AuditTargetType(*r.TargetType)
+		filter.TargetType = &targetType
+	}
+	if r.TargetID != nil {
+		targetUUID := uuid.MustParse(*r.TargetID)
+		filter.TargetUUID = &targetUUID
+	}
+	if r.From != nil {
+		if from, err := time.Parse(time.RFC3339, *r.From); err == nil {
+			filter.From = &from
+		}
+	}
+	if r.To != nil {
+		if to, err := time.Parse(time.RFC3339, *r.To); err == nil {
+			filter.To = &to
+		}
+	}
+
+	return filter
+}
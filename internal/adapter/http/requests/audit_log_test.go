@@ -0,0 +1,50 @@
+package requests_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/requests"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestAuditLogList_ToFilter(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		filter := requests.AuditLogList{}.ToFilter()
+
+		require.Equal(t, domain.AuditLogFilter{Page: 1, PerPage: 20}, filter)
+	})
+
+	t.Run("all filters", func(t *testing.T) {
+		actorUUID := uuid.New()
+		targetUUID := uuid.New()
+
+		filter := requests.AuditLogList{
+			Page:       2,
+			PerPage:    50,
+			ActorID:    helper.StringPtr(actorUUID.String()),
+			Action:     helper.StringPtr("ROLE_UPDATED"),
+			TargetType: helper.StringPtr("role"),
+			TargetID:   helper.StringPtr(targetUUID.String()),
+			From:       helper.StringPtr("2024-01-01T00:00:00Z"),
+			To:         helper.StringPtr("2024-12-31T23:59:59Z"),
+		}.ToFilter()
+
+		action := domain.AuditActionRoleUpdated
+		targetType := domain.AuditTargetRole
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+		require.Equal(t, domain.AuditLogFilter{
+			ActorUUID:  &actorUUID,
+			Action:     &action,
+			TargetType: &targetType,
+			TargetUUID: &targetUUID,
+			From:       &from,
+			To:         &to,
+			Page:       2,
+			PerPage:    50,
+		}, filter)
+	})
+}
@@ -6,7 +6,7 @@ type AuthRegister struct {
 	FirstName         *string `json:"firstName" binding:"required,regex_alpha,min=2,max=64" example:"john"`
 	LastName          *string `json:"lastName" binding:"required,regex_alpha,min=2,max=64" example:"doe"`
 	Email             string  `json:"email" binding:"required,email" example:"john.doe@gmail.com"`
-	Password          string  `json:"password" binding:"required,min=8,max=64,password_complexity" example:"QWer123!@#"`
+	Password          string  `json:"password" binding:"required,max=64,password_min,password_complexity,password_repeat,password_personal,password_breached" example:"QWer123!@#"`
 	ConfirmedPassword string  `json:"confirmedPassword" binding:"required,eqfield=Password" example:"QWer123!@#"`
 }
 
@@ -21,7 +21,7 @@ func (r AuthRegister) ToUserDomain() domain.User {
 
 type AuthLogin struct {
 	Email    string `json:"email" binding:"required,email" example:"john.doe@gmail.com"`
-	Password string `json:"password" binding:"required,password_complexity" example:"QWer123!@#"`
+	Password string `json:"password" binding:"required,max=64" example:"QWer123!@#"`
 }
 
 type AuthEmailOTPResend struct {
@@ -33,6 +33,11 @@ type AuthEmailOTPVerify struct {
 	Token string `json:"token" binding:"required,token_length" example:"123456"`
 }
 
+type AuthEmailLinkVerify struct {
+	Email     string `json:"email" binding:"required,email" example:"john.doe@gmail.com"`
+	Signature string `json:"signature" binding:"required,hexadecimal,len=64" example:"3f9a0c5e8b7d41f2a6e0c9d8b7a6f5e4d3c2b1a0f9e8d7c6b5a4f3e2d1c0b9a8"`
+}
+
 type GoogleAuth struct {
 	Email       string `json:"email" binding:"required,email" example:"john.doe@gmail.com"`
 	AccessToken string `json:"accessToken" binding:"required" example:"123456789"`
@@ -45,10 +50,21 @@ type ForgetPassword struct {
 type ResetPassword struct {
 	Email             string `json:"email" binding:"required,email" example:"john@doe.com"`
 	Token             string `json:"token" binding:"required,token_length" example:"123456"`
-	Password          string `json:"password" binding:"required,password_complexity" example:"QWer123!@#"`
+	Password          string `json:"password" binding:"required,max=64,password_min,password_complexity,password_repeat,password_personal,password_breached" example:"QWer123!@#"`
 	ConfirmedPassword string `json:"confirmedPassword" binding:"required,eqfield=Password" example:"QWer123!@#"`
 }
 
+// ToUserPassword pairs the password with the user being reset, the request carries no name
+// for password_personal to check against.
+func (r ResetPassword) ToUserPassword(user *domain.User) UserPassword {
+	return UserPassword{
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Password:  r.Password,
+	}
+}
+
 type AuthorizeRequest struct {
 	RequiredPermissions []domain.PermissionKeyType `json:"requiredPermissions"`
 }
@@ -87,3 +87,19 @@ func TestAuthRegister_ToUserDomain(t *testing.T) {
 		})
 	}
 }
+
+func TestResetPassword_ToUserPassword(t *testing.T) {
+	req := requests.ResetPassword{Email: "john.doe@gmail.com", Password: "QWer123!@#", ConfirmedPassword: "QWer123!@#"}
+	user := &domain.User{
+		FirstName: helper.StringPtr("John"),
+		LastName:  helper.StringPtr("Doe"),
+		Email:     "john.doe@gmail.com",
+	}
+
+	require.Equal(t, requests.UserPassword{
+		Email:     "john.doe@gmail.com",
+		FirstName: helper.StringPtr("John"),
+		LastName:  helper.StringPtr("Doe"),
+		Password:  "QWer123!@#",
+	}, req.ToUserPassword(user))
+}
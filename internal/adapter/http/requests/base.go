@@ -1,7 +1,28 @@
 package requests
 
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"strings"
+)
+
 type Header struct {
-	UserID uint64 `header:"userID" binding:"required,number"`
-	JTI    string `header:"jti" binding:"required,uuid"`
-	EXP    int64  `header:"exp" binding:"required"`
+	UserID      uint64 `header:"userID" binding:"required,number"`
+	JTI         string `header:"jti" binding:"required,uuid"`
+	EXP         int64  `header:"exp" binding:"required"`
+	Permissions string `header:"permissions"`
+}
+
+// Access returns the user with the permissions the gateway confirmed out of the ones the route requires.
+func (r Header) Access() domain.UserAccess {
+	access := domain.UserAccess{
+		UserID: r.UserID,
+	}
+
+	for _, permission := range strings.Split(r.Permissions, ",") {
+		if permission = strings.TrimSpace(permission); permission != "" {
+			access.PermissionKeys = append(access.PermissionKeys, domain.PermissionKeyType(permission))
+		}
+	}
+
+	return access
 }
@@ -0,0 +1,46 @@
+package requests_test
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/requests"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestHeader_Access(t *testing.T) {
+	tests := []struct {
+		name           string
+		header         requests.Header
+		expectedResult domain.UserAccess
+	}{
+		{
+			name: "Header with permissions",
+			header: requests.Header{
+				UserID:      1,
+				Permissions: "UPDATE_SENTENCE, UPDATE_OWN_SENTENCE",
+			},
+			expectedResult: domain.UserAccess{
+				UserID: 1,
+				PermissionKeys: []domain.PermissionKeyType{
+					domain.PermissionKeyUpdateSentence,
+					domain.PermissionKeyUpdateOwnSentence,
+				},
+			},
+		},
+		{
+			name: "Header without permissions",
+			header: requests.Header{
+				UserID: 1,
+			},
+			expectedResult: domain.UserAccess{
+				UserID: 1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedResult, test.header.Access())
+		})
+	}
+}
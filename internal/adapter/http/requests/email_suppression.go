@@ -0,0 +1,32 @@
+package requests
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+)
+
+type EmailSuppressionList struct {
+	Page    uint64  `form:"page" binding:"omitempty,min=1" example:"1"`
+	PerPage uint64  `form:"perPage" binding:"omitempty,min=1,max=100" example:"20"`
+	Email   *string `form:"email" binding:"omitempty,email" example:"john.doe@example.com"`
+}
+
+// ToFilter converts the validated query into a domain.EmailSuppressionFilter, the page size defaults to 20.
+func (r EmailSuppressionList) ToFilter() domain.EmailSuppressionFilter {
+	filter := domain.EmailSuppressionFilter{
+		Email:   r.Email,
+		Page:    r.Page,
+		PerPage: r.PerPage,
+	}
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PerPage == 0 {
+		filter.PerPage = 20
+	}
+
+	return filter
+}
+
+type EmailSuppressionUUIDUri struct {
+	UUIDStr string `uri:"suppressionID" binding:"required,uuid" example:"8f4a1582-6a67-4d85-950b-2d17049c7385"`
+}
@@ -0,0 +1,31 @@
+package requests_test
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/requests"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestEmailSuppressionList_ToFilter(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		filter := requests.EmailSuppressionList{}.ToFilter()
+
+		require.Equal(t, domain.EmailSuppressionFilter{Page: 1, PerPage: 20}, filter)
+	})
+
+	t.Run("email", func(t *testing.T) {
+		filter := requests.EmailSuppressionList{
+			Page:    3,
+			PerPage: 10,
+			Email:   helper.StringPtr("john.doe@example.com"),
+		}.ToFilter()
+
+		require.Equal(t, domain.EmailSuppressionFilter{
+			Email:   helper.StringPtr("john.doe@example.com"),
+			Page:    3,
+			PerPage: 10,
+		}, filter)
+	})
+}
@@ -0,0 +1,9 @@
+package requests
+
+type EmailTemplatePreview struct {
+	Name string `uri:"name" binding:"required,max=64" example:"verify_email"`
+}
+
+type EmailTemplatePreviewFormat struct {
+	Format string `form:"format" binding:"omitempty,oneof=json html text" example:"html"`
+}
@@ -0,0 +1,43 @@
+package requests
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+)
+
+type NotificationList struct {
+	Page    uint64 `form:"page" binding:"omitempty,min=1" example:"1"`
+	PerPage uint64 `form:"perPage" binding:"omitempty,min=1,max=100" example:"20"`
+	Unread  bool   `form:"unread" example:"true"`
+}
+
+// ToFilter converts the validated query into a domain.NotificationFilter, the page size defaults to 20.
+func (r NotificationList) ToFilter() domain.NotificationFilter {
+	filter := domain.NotificationFilter{
+		UnreadOnly: r.Unread,
+		Page:       r.Page,
+		PerPage:    r.PerPage,
+	}
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PerPage == 0 {
+		filter.PerPage = 20
+	}
+
+	return filter
+}
+
+// MarkNotificationsRead marks the given notifications as read, all of them when NotificationIDs is empty.
+type MarkNotificationsRead struct {
+	NotificationIDs []string `json:"notificationIDs" binding:"omitempty,max=100,dive,uuid" example:"8f4a1582-6a67-4d85-950b-2d17049c7385"`
+}
+
+func (r MarkNotificationsRead) ToUUIDs() []uuid.UUID {
+	notificationUUIDs := make([]uuid.UUID, 0, len(r.NotificationIDs))
+	for _, notificationID := range r.NotificationIDs {
+		notificationUUIDs = append(notificationUUIDs, uuid.MustParse(notificationID))
+	}
+
+	return notificationUUIDs
+}
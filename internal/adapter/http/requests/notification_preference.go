@@ -0,0 +1,30 @@
+package requests
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+)
+
+type NotificationPreference struct {
+	Category string `json:"category" binding:"required,oneof=SECURITY PRODUCT LEARNING_REMINDER" example:"PRODUCT"`
+	Enabled  *bool  `json:"enabled" binding:"required" example:"false"`
+}
+
+type UpdateNotificationPreferences struct {
+	Preferences []NotificationPreference `json:"preferences" binding:"required,min=1,dive"`
+}
+
+func (r UpdateNotificationPreferences) ToNotificationPreferencesDomain() []domain.NotificationPreference {
+	preferences := make([]domain.NotificationPreference, 0, len(r.Preferences))
+	for _, preference := range r.Preferences {
+		preferences = append(preferences, domain.NotificationPreference{
+			Category: domain.NotificationCategoryType(preference.Category),
+			Enabled:  *preference.Enabled,
+		})
+	}
+
+	return preferences
+}
+
+type Unsubscribe struct {
+	Token string `form:"token" binding:"required,max=255" example:"8f4a1582-6a67-4d85-950b-2d17049c7385.PRODUCT.9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+}
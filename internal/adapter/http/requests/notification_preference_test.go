@@ -0,0 +1,24 @@
+package requests_test
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/requests"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestUpdateNotificationPreferences_ToNotificationPreferencesDomain(t *testing.T) {
+	enabled := true
+	disabled := false
+	req := requests.UpdateNotificationPreferences{
+		Preferences: []requests.NotificationPreference{
+			{Category: "PRODUCT", Enabled: &disabled},
+			{Category: "LEARNING_REMINDER", Enabled: &enabled},
+		},
+	}
+
+	require.Equal(t, []domain.NotificationPreference{
+		{Category: domain.NotificationCategoryProduct, Enabled: false},
+		{Category: domain.NotificationCategoryLearningReminder, Enabled: true},
+	}, req.ToNotificationPreferencesDomain())
+}
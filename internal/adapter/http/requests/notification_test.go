@@ -0,0 +1,32 @@
+package requests_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/requests"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNotificationList_ToFilter(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		filter := requests.NotificationList{}.ToFilter()
+
+		require.Equal(t, domain.NotificationFilter{Page: 1, PerPage: 20}, filter)
+	})
+
+	t.Run("unread", func(t *testing.T) {
+		filter := requests.NotificationList{Page: 2, PerPage: 50, Unread: true}.ToFilter()
+
+		require.Equal(t, domain.NotificationFilter{UnreadOnly: true, Page: 2, PerPage: 50}, filter)
+	})
+}
+
+func TestMarkNotificationsRead_ToUUIDs(t *testing.T) {
+	notificationUUID := uuid.New()
+
+	require.Equal(t, []uuid.UUID{notificationUUID}, requests.MarkNotificationsRead{
+		NotificationIDs: []string{notificationUUID.String()},
+	}.ToUUIDs())
+	require.Empty(t, requests.MarkNotificationsRead{}.ToUUIDs())
+}
@@ -0,0 +1,24 @@
+package requests
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+)
+
+type SentenceUUIDUri struct {
+	UUIDStr string `uri:"sentenceID" binding:"required,uuid" example:"8f4a1582-6a67-4d85-950b-2d17049c7385"`
+}
+
+type UpdateSentence struct {
+	Text  string `json:"text" binding:"required,max=1024" example:"I have been living here for ten years."`
+	Level string `json:"level" binding:"required,oneof=EASY NORMAL HARD" example:"NORMAL"`
+}
+
+// ToSentenceDomain converts the validated body into the domain.Sentence it changes.
+func (r UpdateSentence) ToSentenceDomain(sentenceUUID uuid.UUID) domain.Sentence {
+	return domain.Sentence{
+		Base:  domain.Base{UUID: sentenceUUID},
+		Text:  r.Text,
+		Level: domain.SentenceLevelType(r.Level),
+	}
+}
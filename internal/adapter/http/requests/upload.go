@@ -0,0 +1,26 @@
+package requests
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+)
+
+type UploadSessionUUIDUri struct {
+	UUIDStr string `uri:"uploadID" binding:"required,uuid" example:"8f4a1582-6a67-4d85-950b-2d17049c7385"`
+}
+
+type CreateUploadSession struct {
+	Purpose     string `json:"purpose" binding:"required,oneof=SENTENCE_AUDIO IMPORT_FILE" example:"SENTENCE_AUDIO"`
+	ContentType string `json:"contentType" binding:"required,max=255" example:"audio/mpeg"`
+	Size        int64  `json:"size" binding:"required,gt=0" example:"482133"`
+	Checksum    string `json:"checksum" binding:"required,hexadecimal,len=64" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+}
+
+func (r CreateUploadSession) ToUploadSessionDomain(userID uint64) domain.UploadSession {
+	return domain.UploadSession{
+		UserID:      userID,
+		Purpose:     domain.UploadPurposeType(r.Purpose),
+		ContentType: r.ContentType,
+		Size:        r.Size,
+		Checksum:    r.Checksum,
+	}
+}
@@ -10,6 +10,7 @@ type CreateUserRequest struct {
 	LastName  *string               `form:"lastName" binding:"required,regex_alpha,min=2,max=64" example:"Doe"`
 	Email     string                `form:"email" binding:"required,email" example:"john.doe@gmail.com"`
 	Avatar    *multipart.FileHeader `form:"avatar" binding:"required" swaggerignore:"true"`
+	RoleIDs   []string              `form:"roleIDs" binding:"omitempty,dive,uuid" example:"8f4a1582-6a67-4d85-950b-2d17049c7385"`
 }
 
 func (r CreateUserRequest) ToUserDomain() domain.User {
@@ -23,3 +24,32 @@ func (r CreateUserRequest) ToUserDomain() domain.User {
 type UserUUIDUri struct {
 	UUIDStr string `uri:"userID" binding:"required,uuid" example:"8f4a1582-6a67-4d85-950b-2d17049c7385"`
 }
+
+type AcceptInvitation struct {
+	Token             string `json:"token" binding:"required,hexadecimal,len=64" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	Password          string `json:"password" binding:"required,max=64,password_min,password_complexity,password_repeat,password_breached" example:"QWer123!@#"`
+	ConfirmedPassword string `json:"confirmedPassword" binding:"required,eqfield=Password" example:"QWer123!@#"`
+}
+
+// ToInvitedPassword pairs the password with the invited user, the request carries no email or name
+// for password_personal to check against.
+func (r AcceptInvitation) ToInvitedPassword(user *domain.User) UserPassword {
+	return UserPassword{
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Password:  r.Password,
+	}
+}
+
+// UserPassword checks a password against the email and name of the user it is set for.
+type UserPassword struct {
+	Email     string
+	FirstName *string
+	LastName  *string
+	Password  string `binding:"password_personal"`
+}
+
+type UpdateAvatarRequest struct {
+	Avatar *multipart.FileHeader `form:"avatar" binding:"required" swaggerignore:"true"`
+}
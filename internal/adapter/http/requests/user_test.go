@@ -94,3 +94,19 @@ func TestCreateUserRequest_ToUserDomain(t *testing.T) {
 		})
 	}
 }
+
+func TestAcceptInvitation_ToInvitedPassword(t *testing.T) {
+	req := requests.AcceptInvitation{Password: "QWer123!@#", ConfirmedPassword: "QWer123!@#"}
+	user := &domain.User{
+		FirstName: helper.StringPtr("John"),
+		LastName:  helper.StringPtr("Doe"),
+		Email:     "john.doe@gmail.com",
+	}
+
+	require.Equal(t, requests.UserPassword{
+		Email:     "john.doe@gmail.com",
+		FirstName: helper.StringPtr("John"),
+		LastName:  helper.StringPtr("Doe"),
+		Password:  "QWer123!@#",
+	}, req.ToInvitedPassword(user))
+}
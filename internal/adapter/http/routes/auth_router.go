@@ -11,6 +11,7 @@ func (r *Router) NewAuthRouter(
 	authHandler handler.AuthHandler,
 	roleHandler handler.RoleHandler,
 	permissionHandler handler.PermissionHandler,
+	auditLogHandler handler.AuditLogHandler,
 	authCache port.AuthCache,
 ) *Router {
 	r.Engine.POST("authorize", middlewares.Authentication(r.conf.Jwt, r.trans, authCache), authHandler.Authorize)
@@ -22,6 +23,7 @@ func (r *Router) NewAuthRouter(
 			auth.POST("register", authHandler.Register)
 			auth.POST("email-otp/resend", authHandler.EmailOTPResend)
 			auth.POST("email-otp/verify", authHandler.EmailOTPVerify)
+			auth.POST("email-link/verify", authHandler.EmailLinkVerify)
 			auth.POST("login", authHandler.Login)
 			auth.POST("google", authHandler.Google)
 			auth.POST("forget-password", authHandler.ForgetPassword)
@@ -38,10 +40,12 @@ func (r *Router) NewAuthRouter(
 			role.DELETE(":roleID", roleHandler.Delete)
 
 			role.GET(":roleID/permissions", roleHandler.GetPermissions)
+			role.GET(":roleID/permissions/sources", roleHandler.GetPermissionSources)
 			role.PUT(":roleID/permissions", roleHandler.SyncPermissions)
 		}
 
 		v1.GET("permissions", permissionHandler.List)
+		v1.GET("audit-logs", auditLogHandler.List)
 	}
 
 	return &Router{
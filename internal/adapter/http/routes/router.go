@@ -41,6 +41,7 @@ func NewRouter(
 	RegisterPrometheus(log)
 
 	router.Use(middlewares.Prometheus())
+	router.Use(middlewares.RequestID())
 	router.Use(gin.Logger(), gin.CustomRecovery(middlewares.ErrorHandler(trans)))
 	router.Use(middlewares.DefaultStructuredLogger(log))
 
@@ -85,4 +86,9 @@ func RegisterPrometheus(log logger.Logger) {
 	if err != nil {
 		log.Error(logger.Prometheus, logger.Startup, err.Error(), nil)
 	}
+
+	err = prometheus.Register(metrics.CacheCall)
+	if err != nil {
+		log.Error(logger.Prometheus, logger.Startup, err.Error(), nil)
+	}
 }
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/http/handler"
+)
+
+// NewStorageRouter serves the files of the local object storage, STORAGE_LOCAL_URL has to point to this route
+func (r *Router) NewStorageRouter(storageHandler handler.StorageHandler) *Router {
+	r.Engine.GET("storage/*key", storageHandler.Download)
+	r.Engine.PUT("storage/*key", storageHandler.Upload)
+
+	return &Router{
+		Engine: r.Engine,
+		log:    r.log,
+		conf:   r.conf,
+		trans:  r.trans,
+	}
+}
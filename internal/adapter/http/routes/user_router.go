@@ -6,15 +6,59 @@ import (
 )
 
 // NewUserRouter creates a new HTTP router
-func (r *Router) NewUserRouter(userHandler handler.UserHandler) *Router {
+func (r *Router) NewUserRouter(
+	userHandler handler.UserHandler,
+	invitationHandler handler.UserInvitationHandler,
+	uploadSessionHandler handler.UploadSessionHandler,
+	emailTemplateHandler handler.EmailTemplateHandler,
+	preferenceHandler handler.NotificationPreferenceHandler,
+	deliveryHandler handler.EmailDeliveryHandler,
+	notificationHandler handler.NotificationHandler,
+	sentenceHandler handler.SentenceHandler,
+) *Router {
 	v1 := r.Engine.Group(":language/v1", middlewares.LocaleMiddleware(r.trans))
 	{
 		user := v1.Group("users")
 		{
 			user.GET("profile", userHandler.Profile)
+			user.PUT("profile/avatar", userHandler.UpdateAvatar)
+			user.POST("profile/export", userHandler.Export)
+			user.GET("profile/notification-preferences", preferenceHandler.List)
+			user.PUT("profile/notification-preferences", preferenceHandler.Update)
+			user.GET("profile/notifications", notificationHandler.List)
+			user.GET("profile/notifications/unread-count", notificationHandler.UnreadCount)
+			user.POST("profile/notifications/read", notificationHandler.MarkRead)
+			user.GET("profile/notifications/stream", notificationHandler.Stream)
 			user.POST("", userHandler.Create)
 			user.GET("", userHandler.List)
 			user.GET(":userID", userHandler.Get)
+			user.POST(":userID/erasure", userHandler.Erase)
+
+			user.POST("invitations/accept", invitationHandler.Accept)
+			user.POST(":userID/invitation/resend", invitationHandler.Resend)
+			user.DELETE(":userID/invitation", invitationHandler.Revoke)
+
+			user.POST("uploads", uploadSessionHandler.Create)
+			user.POST("uploads/:uploadID/complete", uploadSessionHandler.Complete)
+		}
+
+		v1.GET("notifications/unsubscribe", preferenceHandler.ConfirmUnsubscribe)
+		v1.POST("notifications/unsubscribe", preferenceHandler.Unsubscribe)
+
+		v1.GET("email-templates", emailTemplateHandler.List)
+		v1.GET("email-templates/:name/preview", emailTemplateHandler.Preview)
+
+		email := v1.Group("email")
+		{
+			email.POST("webhooks/sendgrid", deliveryHandler.Webhook)
+			email.GET("suppressions", deliveryHandler.ListSuppressions)
+			email.DELETE("suppressions/:suppressionID", deliveryHandler.DeleteSuppression)
+		}
+
+		sentence := v1.Group("sentences")
+		{
+			sentence.PUT(":sentenceID", sentenceHandler.Update)
+			sentence.DELETE(":sentenceID", sentenceHandler.Delete)
 		}
 	}
 
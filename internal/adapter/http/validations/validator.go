@@ -1,28 +1,59 @@
 package validations
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/breachedpassword"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
 	"log"
+	"reflect"
 	"regexp"
+	"strconv"
+	"strings"
 	"unicode"
 )
 
+// minPersonalLength is the shortest name or email part PasswordPersonal looks for, shorter ones match too often
+const minPersonalLength = 3
+
 func RegisterValidator(conf config.Config) error {
 	if val, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		if err := val.RegisterValidation("regex_alpha", RegexAlpha, true); err != nil {
+		checker, err := breachedpassword.New(conf.Password)
+		if err != nil {
+			return err
+		}
+
+		if err = val.RegisterValidation("regex_alpha", RegexAlpha, true); err != nil {
+			return err
+		}
+		if err = val.RegisterValidation("password_complexity", func(fl validator.FieldLevel) bool {
+			return PasswordComplexity(fl, conf.Password)
+		}, true); err != nil {
+			return err
+		}
+		if err = val.RegisterValidation("max_repeat", MaxRepeat, true); err != nil {
 			return err
 		}
-		if err := val.RegisterValidation("password_complexity", PasswordComplexity, true); err != nil {
+		if err = val.RegisterValidation("password_personal", PasswordPersonal, true); err != nil {
 			return err
 		}
-		if err := val.RegisterValidation("token_length", func(fl validator.FieldLevel) bool {
+		if err = val.RegisterValidation("password_breached", func(fl validator.FieldLevel) bool {
+			return PasswordBreached(fl, checker)
+		}, true); err != nil {
+			return err
+		}
+		// aliases keep the configured limits as the tag param, so the translated messages can show them
+		val.RegisterAlias("password_min", fmt.Sprintf("min=%d", conf.Password.MinLength))
+		val.RegisterAlias("password_repeat", fmt.Sprintf("max_repeat=%d", conf.Password.MaxRepeat))
+
+		if err = val.RegisterValidation("token_length", func(fl validator.FieldLevel) bool {
 			return TokenLength(fl, conf.OTP.Digits)
 		}, true); err != nil {
 			return err
 		}
-		if err := val.RegisterValidation("role_title", RoleTitle, true); err != nil {
+		if err = val.RegisterValidation("role_title", RoleTitle, true); err != nil {
 			return err
 		}
 	}
@@ -45,9 +76,9 @@ func RegexAlpha(field validator.FieldLevel) bool {
 	return res
 }
 
-// PasswordComplexity This Go code defines a function to validate the complexity of a password based on certain criteria such as
-// length, presence of uppercase letters, lowercase letters, digits, and special characters.
-func PasswordComplexity(field validator.FieldLevel) bool {
+// PasswordComplexity This Go code defines a function to validate the complexity of a password based on the character
+// classes the password policy requires: uppercase letters, lowercase letters, digits and special characters.
+func PasswordComplexity(field validator.FieldLevel, conf config.Password) bool {
 	var (
 		hasUpper   bool
 		hasLower   bool
@@ -66,13 +97,112 @@ func PasswordComplexity(field validator.FieldLevel) bool {
 				hasLower = true
 			case unicode.IsDigit(char):
 				hasDigit = true
-			case unicode.IsPunct(char) || unicode.IsSpace(char):
+			case unicode.IsPunct(char) || unicode.IsSymbol(char) || unicode.IsSpace(char):
 				hasSpecial = true
 			}
 		}
 	}
 
-	return hasUpper && hasLower && hasDigit && hasSpecial
+	return (hasUpper || !conf.RequireUpper) &&
+		(hasLower || !conf.RequireLower) &&
+		(hasDigit || !conf.RequireDigit) &&
+		(hasSpecial || !conf.RequireSpecial)
+}
+
+// MaxRepeat rejects values repeating the same character more than the tag param times in a row, zero disables it.
+func MaxRepeat(field validator.FieldLevel) bool {
+	value, ok := field.Field().Interface().(string)
+	if !ok {
+		return false
+	}
+
+	limit, err := strconv.Atoi(field.Param())
+	if err != nil {
+		log.Print(err.Error())
+		return false
+	}
+	if limit <= 0 {
+		return true
+	}
+
+	var (
+		previous rune
+		count    int
+	)
+	for _, char := range value {
+		if char == previous {
+			count++
+		} else {
+			previous, count = char, 1
+		}
+		if count > limit {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PasswordPersonal rejects passwords containing the email local part, first name or last name
+// sent in the same request.
+func PasswordPersonal(field validator.FieldLevel) bool {
+	value, ok := field.Field().Interface().(string)
+	if !ok {
+		return false
+	}
+
+	parent := reflect.Indirect(field.Parent())
+	if parent.Kind() != reflect.Struct {
+		return true
+	}
+
+	password := strings.ToLower(value)
+	for _, name := range []string{"Email", "FirstName", "LastName"} {
+		personal := stringValue(parent.FieldByName(name))
+		if name == "Email" {
+			personal, _, _ = strings.Cut(personal, "@")
+		}
+
+		if len([]rune(personal)) >= minPersonalLength && strings.Contains(password, strings.ToLower(personal)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PasswordBreached rejects passwords found in the breached password list.
+// A failing lookup is logged and lets the password through, so an unreadable list never blocks sign-ups.
+func PasswordBreached(field validator.FieldLevel, checker port.BreachedPasswordChecker) bool {
+	value, ok := field.Field().Interface().(string)
+	if !ok {
+		return false
+	}
+
+	breached, err := checker.IsBreached(value)
+	if err != nil {
+		log.Print(err.Error())
+		return true
+	}
+
+	return !breached
+}
+
+func stringValue(value reflect.Value) string {
+	if !value.IsValid() {
+		return ""
+	}
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.String {
+		return ""
+	}
+
+	return strings.TrimSpace(value.String())
 }
 
 func TokenLength(field validator.FieldLevel, length int8) bool {
@@ -9,11 +9,22 @@ import (
 	"testing"
 )
 
+var strictPolicy = config.Password{
+	MinLength:      10,
+	RequireUpper:   true,
+	RequireLower:   true,
+	RequireDigit:   true,
+	RequireSpecial: true,
+	MaxRepeat:      2,
+	BreachedCheck:  true,
+}
+
 func TestRegisterValidator(t *testing.T) {
 	conf := config.Config{
 		OTP: config.OTP{
 			Digits: 6,
 		},
+		Password: strictPolicy,
 	}
 
 	registerValidatorErr := validations.RegisterValidator(conf)
@@ -52,6 +63,42 @@ func TestRegisterValidator(t *testing.T) {
 			fieldValue:    "invalid password",
 			expectedValid: false,
 		},
+		{
+			name:          "PasswordMin valid",
+			tag:           "password_min",
+			fieldValue:    "Password12",
+			expectedValid: true,
+		},
+		{
+			name:          "PasswordMin invalid",
+			tag:           "password_min",
+			fieldValue:    "Password1",
+			expectedValid: false,
+		},
+		{
+			name:          "PasswordRepeat valid",
+			tag:           "password_repeat",
+			fieldValue:    "Paassword1!",
+			expectedValid: true,
+		},
+		{
+			name:          "PasswordRepeat invalid",
+			tag:           "password_repeat",
+			fieldValue:    "Paaassword1!",
+			expectedValid: false,
+		},
+		{
+			name:          "PasswordBreached valid",
+			tag:           "password_breached",
+			fieldValue:    "Vq7#mTz!pL2x",
+			expectedValid: true,
+		},
+		{
+			name:          "PasswordBreached invalid",
+			tag:           "password_breached",
+			fieldValue:    "P@ssw0rd",
+			expectedValid: false,
+		},
 		{
 			name:          "TokenLength valid",
 			tag:           "token_length",
@@ -196,7 +243,9 @@ func TestPasswordComplexity(t *testing.T) {
 	}
 
 	validate := validator.New()
-	registerErr := validate.RegisterValidation("password_complexity", validations.PasswordComplexity)
+	registerErr := validate.RegisterValidation("password_complexity", func(fl validator.FieldLevel) bool {
+		return validations.PasswordComplexity(fl, strictPolicy)
+	})
 	require.NoError(t, registerErr)
 
 	for _, test := range tests {
@@ -207,6 +256,124 @@ func TestPasswordComplexity(t *testing.T) {
 			require.Error(t, err, "Input: %s", test.input)
 		}
 	}
+
+	t.Run("Relaxed policy only checks required classes", func(t *testing.T) {
+		relaxed := validator.New()
+		require.NoError(t, relaxed.RegisterValidation("password_complexity", func(fl validator.FieldLevel) bool {
+			return validations.PasswordComplexity(fl, config.Password{RequireLower: true, RequireDigit: true})
+		}))
+
+		require.NoError(t, relaxed.Var("password1", "password_complexity"))
+		require.Error(t, relaxed.Var("password", "password_complexity"))
+	})
+}
+
+func TestMaxRepeat(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         interface{}
+		tag           string
+		expectedValid bool
+	}{
+		{
+			name:          "Valid repeats within limit",
+			input:         "aabbcc",
+			tag:           "max_repeat=2",
+			expectedValid: true,
+		},
+		{
+			name:          "Invalid repeats over limit",
+			input:         "abbbc",
+			tag:           "max_repeat=2",
+			expectedValid: false,
+		},
+		{
+			name:          "Valid disabled limit",
+			input:         "aaaaaa",
+			tag:           "max_repeat=0",
+			expectedValid: true,
+		},
+		{
+			name:          "Invalid unexpected value",
+			input:         func() {},
+			tag:           "max_repeat=2",
+			expectedValid: false,
+		},
+	}
+
+	validate := validator.New()
+	registerErr := validate.RegisterValidation("max_repeat", validations.MaxRepeat)
+	require.NoError(t, registerErr)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validate.Var(test.input, test.tag)
+			if test.expectedValid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestPasswordPersonal(t *testing.T) {
+	type request struct {
+		FirstName *string
+		LastName  *string
+		Email     string
+		Password  string `validate:"password_personal"`
+	}
+
+	firstName := "John"
+	lastName := "Al"
+
+	tests := []struct {
+		name          string
+		input         request
+		expectedValid bool
+	}{
+		{
+			name:          "Valid unrelated password",
+			input:         request{FirstName: &firstName, LastName: &lastName, Email: "jdoe@example.com", Password: "Vq7#mTz!pL2x"},
+			expectedValid: true,
+		},
+		{
+			name:          "Invalid password contains first name",
+			input:         request{FirstName: &firstName, Email: "jdoe@example.com", Password: "myJOHN!2024"},
+			expectedValid: false,
+		},
+		{
+			name:          "Invalid password contains email local part",
+			input:         request{Email: "jdoe@example.com", Password: "Jdoe#2024x"},
+			expectedValid: false,
+		},
+		{
+			name:          "Valid short last name is ignored",
+			input:         request{LastName: &lastName, Email: "jdoe@example.com", Password: "Always#2024"},
+			expectedValid: true,
+		},
+		{
+			name:          "Valid without personal fields",
+			input:         request{Password: "Vq7#mTz!pL2x"},
+			expectedValid: true,
+		},
+	}
+
+	validate := validator.New()
+	registerErr := validate.RegisterValidation("password_personal", validations.PasswordPersonal)
+	require.NoError(t, registerErr)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validate.Struct(test.input)
+			if test.expectedValid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
 }
 
 func TestTokenLength(t *testing.T) {
@@ -0,0 +1,248 @@
+package localstorage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Storage keeps objects as files under a root directory, it implements port.ObjectStorage for local
+// development and tests. Presigned links point to LocalURL and are checked by Verify before serving a file.
+type Storage struct {
+	log  logger.Logger
+	conf config.Storage
+	root string
+}
+
+func NewLocalStorage(log logger.Logger, conf config.Storage) (*Storage, error) {
+	if err := conf.Validate(); err != nil {
+		log.Error(logger.Storage, logger.Startup, err.Error(), nil)
+		return nil, err
+	}
+
+	root, err := filepath.Abs(conf.LocalPath)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(root, 0o750); err != nil {
+		log.Error(logger.Storage, logger.Startup, err.Error(), nil)
+		return nil, err
+	}
+
+	log.Info(logger.Storage, logger.Startup, fmt.Sprintf("Storing files in %s", root), nil)
+
+	return &Storage{
+		log:  log,
+		conf: conf,
+		root: root,
+	}, nil
+}
+
+// Put writes the content of the reader as key. The content is written to a temporary file first,
+// so a reader never sees a partially written object.
+func (r *Storage) Put(_ context.Context, key string, reader io.Reader, _ int64, _ string) error {
+	filePath, err := r.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(filePath), 0o750); err != nil {
+		r.log.Error(logger.Storage, logger.StorageWrite, err.Error(), map[logger.ExtraKey]interface{}{"key": key})
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		r.log.Error(logger.Storage, logger.StorageWrite, err.Error(), map[logger.ExtraKey]interface{}{"key": key})
+		return err
+	}
+	defer func(name string) {
+		_ = os.Remove(name)
+	}(file.Name())
+
+	if _, err = io.Copy(file, reader); err != nil {
+		_ = file.Close()
+		r.log.Error(logger.Storage, logger.StorageWrite, err.Error(), map[logger.ExtraKey]interface{}{"key": key})
+		return err
+	}
+	if err = file.Close(); err != nil {
+		r.log.Error(logger.Storage, logger.StorageWrite, err.Error(), map[logger.ExtraKey]interface{}{"key": key})
+		return err
+	}
+
+	if err = os.Rename(file.Name(), filePath); err != nil {
+		r.log.Error(logger.Storage, logger.StorageWrite, err.Error(), map[logger.ExtraKey]interface{}{"key": key})
+		return err
+	}
+
+	return nil
+}
+
+// Get opens the object for reading, a missing object is reported as serviceerror.RecordNotFound.
+// The returned reader is an *os.File, so it can be served with http.ServeContent.
+func (r *Storage) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	filePath, err := r.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, serviceerror.New(serviceerror.RecordNotFound)
+		}
+
+		r.log.Error(logger.Storage, logger.StorageRead, err.Error(), map[logger.ExtraKey]interface{}{"key": key})
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Delete removes the object, removing a missing object is not an error.
+func (r *Storage) Delete(_ context.Context, key string) error {
+	filePath, err := r.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		r.log.Error(logger.Storage, logger.StorageRemove, err.Error(), map[logger.ExtraKey]interface{}{"key": key})
+		return err
+	}
+
+	return nil
+}
+
+// Copy writes a copy of the object to dstKey, a missing object is reported as serviceerror.RecordNotFound.
+func (r *Storage) Copy(ctx context.Context, srcKey string, dstKey string) error {
+	reader, err := r.Get(ctx, srcKey)
+	if err != nil {
+		return err
+	}
+	defer func(reader io.ReadCloser) {
+		_ = reader.Close()
+	}(reader)
+
+	return r.Put(ctx, dstKey, reader, -1, "")
+}
+
+// Presign returns a link to the object under LocalURL that stops working after expiry.
+func (r *Storage) Presign(_ context.Context, key string, expiry time.Duration) (string, error) {
+	if _, err := r.path(key); err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", r.sign(http.MethodGet, key, expires))
+
+	return r.link(key, query), nil
+}
+
+// PresignPut returns an upload link under LocalURL that stops working after expiry,
+// the content type and size are signed so VerifyPut rejects any other upload.
+func (r *Storage) PresignPut(_ context.Context, key string, expiry time.Duration, contentType string, size int64) (string, error) {
+	if _, err := r.path(key); err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+	sizeStr := strconv.FormatInt(size, 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("contentType", contentType)
+	query.Set("size", sizeStr)
+	query.Set("signature", r.sign(http.MethodPut, key, expires, contentType, sizeStr))
+
+	return r.link(key, query), nil
+}
+
+// Verify checks the expires and signature query parameters of a link returned by Presign.
+func (r *Storage) Verify(key string, query url.Values) bool {
+	return r.verify(query, http.MethodGet, key, query.Get("expires"))
+}
+
+// VerifyPut checks a link returned by PresignPut, the upload has to declare the signed content type and size.
+func (r *Storage) VerifyPut(key string, query url.Values, contentType string, size int64) bool {
+	if contentType != query.Get("contentType") || strconv.FormatInt(size, 10) != query.Get("size") {
+		return false
+	}
+
+	return r.verify(query, http.MethodPut, key, query.Get("expires"), contentType, query.Get("size"))
+}
+
+func (r *Storage) verify(query url.Values, parts ...string) bool {
+	expiresAt, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return false
+	}
+
+	return hmac.Equal([]byte(r.sign(parts...)), []byte(query.Get("signature")))
+}
+
+func (r *Storage) link(key string, query url.Values) string {
+	return strings.TrimSuffix(r.conf.LocalURL, "/") + "/" + (&url.URL{Path: key}).EscapedPath() + "?" + query.Encode()
+}
+
+// List returns the keys of every object whose key starts with prefix.
+func (r *Storage) List(_ context.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.WalkDir(r.root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".upload-") {
+			return nil
+		}
+
+		relative, err := filepath.Rel(r.root, filePath)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(relative); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.Error(logger.Storage, logger.StorageList, err.Error(), map[logger.ExtraKey]interface{}{"prefix": prefix})
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// path maps the key to a file under the root, keys escaping the root are rejected.
+func (r *Storage) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if key == "" || strings.HasSuffix(key, "/") || cleaned != "/"+key {
+		return "", serviceerror.New(serviceerror.RecordNotFound)
+	}
+
+	return filepath.Join(r.root, filepath.FromSlash(cleaned)), nil
+}
+
+func (r *Storage) sign(parts ...string) string {
+	mac := hmac.New(sha256.New, []byte(r.conf.LocalSecret))
+	mac.Write([]byte(strings.Join(parts, ":")))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
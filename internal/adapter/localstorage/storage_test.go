@@ -0,0 +1,178 @@
+package localstorage_test
+
+import (
+	"context"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/localstorage"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newStorage(t *testing.T) *localstorage.Storage {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Info", logger.Storage, logger.Startup, mock.Anything, mock.Anything).Return()
+
+	storage, err := localstorage.NewLocalStorage(mockLogger, config.Storage{
+		Driver:      config.StorageDriverLocal,
+		LocalPath:   t.TempDir(),
+		LocalURL:    "http://localhost:2535/storage/",
+		LocalSecret: "Qm7Tz2Vw9KcR4nXb8LsHd1YpF6GeJ3Ua",
+	})
+	require.NoError(t, err)
+
+	return storage
+}
+
+func TestNewLocalStorage_ShortSecret(t *testing.T) {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", logger.Storage, logger.Startup, mock.Anything, mock.Anything).Return()
+
+	_, err := localstorage.NewLocalStorage(mockLogger, config.Storage{
+		Driver:      config.StorageDriverLocal,
+		LocalPath:   t.TempDir(),
+		LocalURL:    "http://localhost:2535/storage/",
+		LocalSecret: "secret",
+	})
+	require.Error(t, err)
+}
+
+func put(t *testing.T, storage *localstorage.Storage, key string, content string) {
+	require.NoError(t, storage.Put(context.Background(), key, strings.NewReader(content), int64(len(content)), "text/plain"))
+}
+
+func TestStorage_PutGet(t *testing.T) {
+	storage := newStorage(t)
+	put(t, storage, "avatars/abc.png", "first")
+	put(t, storage, "avatars/abc.png", "second")
+
+	reader, err := storage.Get(context.Background(), "avatars/abc.png")
+	require.NoError(t, err)
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, "second", string(content))
+}
+
+func TestStorage_GetNotFound(t *testing.T) {
+	storage := newStorage(t)
+
+	reader, err := storage.Get(context.Background(), "avatars/missing.png")
+	require.Nil(t, reader)
+	require.Error(t, err)
+	require.Equal(t, serviceerror.RecordNotFound, err.(*serviceerror.ServiceError).GetErrorMessage())
+}
+
+func TestStorage_Copy(t *testing.T) {
+	storage := newStorage(t)
+	put(t, storage, "uploads/staging/import_file/abc", "first")
+
+	require.NoError(t, storage.Copy(context.Background(), "uploads/staging/import_file/abc", "uploads/import_file/abc"))
+	put(t, storage, "uploads/staging/import_file/abc", "second")
+
+	reader, err := storage.Get(context.Background(), "uploads/import_file/abc")
+	require.NoError(t, err)
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, "first", string(content))
+
+	err = storage.Copy(context.Background(), "uploads/staging/import_file/missing", "uploads/import_file/missing")
+	require.Error(t, err)
+	require.Equal(t, serviceerror.RecordNotFound, err.(*serviceerror.ServiceError).GetErrorMessage())
+}
+
+func TestStorage_InvalidKey(t *testing.T) {
+	storage := newStorage(t)
+
+	for _, key := range []string{"", "../outside.txt", "avatars/../../outside.txt", "/absolute.txt", "avatars/", "avatars//abc.png"} {
+		t.Run(key, func(t *testing.T) {
+			err := storage.Put(context.Background(), key, strings.NewReader("content"), 7, "text/plain")
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestStorage_ListDelete(t *testing.T) {
+	storage := newStorage(t)
+	put(t, storage, "exports/user-1/a.zip", "a")
+	put(t, storage, "exports/user-1/b.zip", "b")
+	put(t, storage, "exports/user-2/c.zip", "c")
+
+	keys, err := storage.List(context.Background(), "exports/user-1/")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"exports/user-1/a.zip", "exports/user-1/b.zip"}, keys)
+
+	require.NoError(t, storage.Delete(context.Background(), "exports/user-1/a.zip"))
+	require.NoError(t, storage.Delete(context.Background(), "exports/user-1/a.zip"))
+
+	keys, err = storage.List(context.Background(), "exports/")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"exports/user-1/b.zip", "exports/user-2/c.zip"}, keys)
+}
+
+func TestStorage_PresignVerify(t *testing.T) {
+	storage := newStorage(t)
+
+	link, err := storage.Presign(context.Background(), "avatars/abc.png", time.Minute)
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	require.Equal(t, "/storage/avatars/abc.png", parsed.Path)
+
+	query := parsed.Query()
+	require.True(t, storage.Verify("avatars/abc.png", query))
+	require.False(t, storage.Verify("avatars/other.png", query))
+
+	tampered := parsed.Query()
+	tampered.Set("signature", strings.Repeat("0", len(query.Get("signature"))))
+	require.False(t, storage.Verify("avatars/abc.png", tampered))
+
+	expired := parsed.Query()
+	expired.Set("expires", strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10))
+	require.False(t, storage.Verify("avatars/abc.png", expired))
+
+	expired.Set("expires", "invalid")
+	require.False(t, storage.Verify("avatars/abc.png", expired))
+}
+
+func TestStorage_PresignPutVerifyPut(t *testing.T) {
+	storage := newStorage(t)
+
+	link, err := storage.PresignPut(context.Background(), "uploads/import_file/abc", time.Minute, "text/csv", 42)
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	require.Equal(t, "/storage/uploads/import_file/abc", parsed.Path)
+
+	query := parsed.Query()
+	require.True(t, storage.VerifyPut("uploads/import_file/abc", query, "text/csv", 42))
+	require.False(t, storage.VerifyPut("uploads/import_file/abc", query, "application/zip", 42))
+	require.False(t, storage.VerifyPut("uploads/import_file/abc", query, "text/csv", 43))
+	require.False(t, storage.VerifyPut("uploads/import_file/other", query, "text/csv", 42))
+
+	// a download link can not be used to upload
+	download, err := storage.Presign(context.Background(), "uploads/import_file/abc", time.Minute)
+	require.NoError(t, err)
+	parsed, err = url.Parse(download)
+	require.NoError(t, err)
+	downloadQuery := parsed.Query()
+	downloadQuery.Set("contentType", "text/csv")
+	downloadQuery.Set("size", "42")
+	require.False(t, storage.VerifyPut("uploads/import_file/abc", downloadQuery, "text/csv", 42))
+}
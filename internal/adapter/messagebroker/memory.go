@@ -0,0 +1,351 @@
+package messagebroker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"sync"
+	"time"
+)
+
+// ErrMemoryClosed is returned by the memory driver once it is closed.
+var ErrMemoryClosed = errors.New("memory queue is closed")
+
+// Memory is an in-process driver. It delivers the messages only to the consumers registered in the same process,
+// so it suits tests and running the services without a broker; the messages are lost when the process stops.
+// The consumers of a queue compete for its messages, a failed message is retried with the retry policy and
+// moved to the dead letters of its queue once it runs out of attempts, like it is on RabbitMQ.
+// There is no broker to prefetch from, so only the workers of the consumer options are used.
+type Memory struct {
+	log   logger.Logger
+	retry RetryPolicy
+
+	handlers       context.Context
+	cancelHandlers context.CancelFunc
+
+	mu          sync.Mutex
+	ready       *sync.Cond
+	queues      map[string][]memoryMessage
+	deadLetters map[string][]DeadLetter
+	timers      map[*time.Timer]struct{}
+	unfinished  int
+	idle        chan struct{}
+	draining    bool
+	closed      bool
+	consumers   sync.WaitGroup
+}
+
+type memoryMessage struct {
+	body    []byte
+	attempt int
+}
+
+func NewMemory(log logger.Logger, retry RetryPolicy) *Memory {
+	idle := make(chan struct{})
+	close(idle)
+	handlers, cancelHandlers := context.WithCancel(context.Background())
+
+	memory := &Memory{
+		log:            log,
+		retry:          retry,
+		handlers:       handlers,
+		cancelHandlers: cancelHandlers,
+		queues:         make(map[string][]memoryMessage),
+		deadLetters:    make(map[string][]DeadLetter),
+		timers:         make(map[*time.Timer]struct{}),
+		idle:           idle,
+	}
+	memory.ready = sync.NewCond(&memory.mu)
+
+	return memory
+}
+
+// Close drops the waiting and delayed messages and waits for the consumers to finish the messages they hold.
+func (r *Memory) Close() {
+	r.mu.Lock()
+	r.closed = true
+	for timer := range r.timers {
+		timer.Stop()
+	}
+	r.timers = nil
+	r.ready.Broadcast()
+	r.mu.Unlock()
+
+	r.consumers.Wait()
+	r.cancelHandlers()
+}
+
+func (r *Memory) Produce(name string, msg interface{}, delaySeconds int64) error {
+	message, err := json.Marshal(msg)
+	if err != nil {
+		r.log.Error(logger.Queue, logger.MemoryQueue, fmt.Sprintf("Error marshalling value: %v", err), nil)
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return ErrMemoryClosed
+	}
+
+	r.begin()
+	r.schedule(name, memoryMessage{body: message}, time.Duration(delaySeconds)*time.Second)
+
+	return nil
+}
+
+// RegisterConsumer starts the workers of a consumer of the queue, they compete for its messages
+// with the workers of every other consumer of the queue.
+func (r *Memory) RegisterConsumer(
+	name string,
+	options port.ConsumerOptions,
+	callback func(ctx context.Context, message []byte) error,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed || r.draining {
+		return ErrMemoryClosed
+	}
+
+	for i := 0; i < max(options.Workers, 1); i++ {
+		r.consumers.Add(1)
+		go func() {
+			defer r.consumers.Done()
+
+			for {
+				message, ok := r.next(name)
+				if !ok {
+					return
+				}
+				r.handle(name, message, callback)
+			}
+		}()
+	}
+
+	return nil
+}
+
+// Shutdown stops handing out messages and waits for the workers to finish the ones they hold,
+// the waiting and delayed messages stay in the queues until Close.
+func (r *Memory) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	r.draining = true
+	r.ready.Broadcast()
+	r.mu.Unlock()
+
+	if err := waitWithContext(ctx, &r.consumers); err != nil {
+		r.cancelHandlers()
+		return err
+	}
+
+	return nil
+}
+
+// Wait blocks until every produced message is consumed or dead-lettered, including the delayed ones,
+// or until ctx is done. A queue without consumers keeps it waiting.
+func (r *Memory) Wait(ctx context.Context) error {
+	r.mu.Lock()
+	idle := r.idle
+	r.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// DeadLetters returns at most limit messages that ran out of attempts on the queue of name.
+func (r *Memory) DeadLetters(name string, limit int) ([]DeadLetter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deadLetters := r.deadLetters[name]
+	if limit < len(deadLetters) {
+		deadLetters = deadLetters[:limit]
+	}
+
+	return append([]DeadLetter(nil), deadLetters...), nil
+}
+
+// Replay moves at most limit dead letters of name back to the queue with fresh attempts,
+// a limit below one replays every message. It returns the number of replayed messages.
+func (r *Memory) Replay(name string, limit int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return 0, ErrMemoryClosed
+	}
+
+	deadLetters := r.deadLetters[name]
+	if limit < 1 || limit > len(deadLetters) {
+		limit = len(deadLetters)
+	}
+
+	for _, deadLetter := range deadLetters[:limit] {
+		r.begin()
+		r.schedule(name, memoryMessage{body: domain.EnvelopeWithAttempt(deadLetter.Body, 0)}, 0)
+	}
+	r.deadLetters[name] = deadLetters[limit:]
+
+	return limit, nil
+}
+
+// next takes the first message of the queue, waiting for one to arrive. It reports false once the driver
+// is draining or closed.
+func (r *Memory) next(name string) (memoryMessage, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for len(r.queues[name]) == 0 && !r.closed && !r.draining {
+		r.ready.Wait()
+	}
+	if r.closed || r.draining {
+		return memoryMessage{}, false
+	}
+
+	message := r.queues[name][0]
+	r.queues[name] = r.queues[name][1:]
+
+	return message, true
+}
+
+func (r *Memory) handle(name string, message memoryMessage, callback func(ctx context.Context, message []byte) error) {
+	extra := map[logger.ExtraKey]interface{}{
+		logger.QueueName: name,
+		logger.Body:      string(message.body),
+	}
+
+	consumeErr := callback(r.handlers, message.body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if consumeErr == nil {
+		r.finish()
+		return
+	}
+
+	r.log.Error(logger.Queue, logger.MemoryQueue, fmt.Sprintf("Error Consume message: %v", consumeErr), extra)
+
+	attempt, delay, exhausted := r.retry.Next(message.attempt, consumeErr)
+	body := domain.EnvelopeWithAttempt(message.body, attempt)
+	if exhausted {
+		r.deadLetters[name] = append(r.deadLetters[name], DeadLetter{
+			Body:           body,
+			Attempts:       attempt,
+			Error:          consumeErr.Error(),
+			DeadLetteredAt: time.Now(),
+		})
+		r.finish()
+
+		r.log.Warn(logger.Queue, logger.MemoryQueueDeadLetter, fmt.Sprintf("Message moved to the dead letters after %d attempts", attempt), extra)
+		return
+	}
+
+	if r.closed {
+		r.finish()
+		return
+	}
+
+	r.schedule(name, memoryMessage{body: body, attempt: attempt}, delay)
+
+	r.log.Info(logger.Queue, logger.MemoryQueueRetry, fmt.Sprintf("Message retried in %s, attempt %d", delay, attempt), extra)
+}
+
+// schedule appends the message to the queue after the delay, the caller holds the lock.
+func (r *Memory) schedule(name string, message memoryMessage, delay time.Duration) {
+	if delay <= 0 {
+		r.queues[name] = append(r.queues[name], message)
+		r.ready.Broadcast()
+		return
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if r.closed {
+			return
+		}
+		delete(r.timers, timer)
+		r.queues[name] = append(r.queues[name], message)
+		r.ready.Broadcast()
+	})
+	r.timers[timer] = struct{}{}
+}
+
+// begin counts a message that is not consumed yet, the caller holds the lock.
+func (r *Memory) begin() {
+	if r.unfinished == 0 {
+		r.idle = make(chan struct{})
+	}
+	r.unfinished++
+}
+
+// finish counts a message out once it is consumed or dead-lettered, the caller holds the lock.
+func (r *Memory) finish() {
+	r.unfinished--
+	if r.unfinished == 0 {
+		close(r.idle)
+	}
+}
+
+// MemoryProcessedMessages is the processed message cache of the memory driver, it remembers the messages
+// for the lifetime of the process, true marks a processed message and false a claimed one.
+type MemoryProcessedMessages struct {
+	mu        sync.Mutex
+	processed map[string]bool
+}
+
+func NewMemoryProcessedMessages() *MemoryProcessedMessages {
+	return &MemoryProcessedMessages{
+		processed: make(map[string]bool),
+	}
+}
+
+func (r *MemoryProcessedMessages) Claim(_ context.Context, queue string, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.processed[queue+":"+id]; ok {
+		return false, nil
+	}
+
+	r.processed[queue+":"+id] = false
+	return true, nil
+}
+
+func (r *MemoryProcessedMessages) Release(_ context.Context, queue string, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.processed[queue+":"+id] {
+		delete(r.processed, queue+":"+id)
+	}
+	return nil
+}
+
+func (r *MemoryProcessedMessages) IsProcessed(_ context.Context, queue string, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.processed[queue+":"+id], nil
+}
+
+func (r *MemoryProcessedMessages) MarkProcessed(_ context.Context, queue string, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.processed[queue+":"+id] = true
+	return nil
+}
@@ -0,0 +1,248 @@
+package messagebroker_test
+
+import (
+	"context"
+	"errors"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newMemory(t *testing.T) *messagebroker.Memory {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	memory := messagebroker.NewMemory(mockLogger, messagebroker.NewRetryPolicy(config.Queue{
+		RetryMaxAttempts:        3,
+		RetryInitialDelaySecond: 10 * time.Millisecond,
+		RetryMaxDelaySecond:     20 * time.Millisecond,
+	}))
+	t.Cleanup(memory.Close)
+
+	return memory
+}
+
+func wait(t *testing.T, memory *messagebroker.Memory, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	require.NoError(t, memory.Wait(ctx))
+}
+
+func TestMemory_Produce(t *testing.T) {
+	memory := newMemory(t)
+
+	var mu sync.Mutex
+	var received []string
+	require.NoError(t, memory.RegisterConsumer("send_email_otp", port.ConsumerOptions{}, func(_ context.Context, message []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, string(message))
+		return nil
+	}))
+
+	require.NoError(t, memory.Produce("send_email_otp", map[string]string{"to": "john.doe@example.com"}, 0))
+	require.NoError(t, memory.Produce("send_welcome", map[string]string{"to": "jane.doe@example.com"}, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	// send_welcome has no consumer, so its message keeps the driver busy
+	require.ErrorIs(t, memory.Wait(ctx), context.DeadlineExceeded)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{`{"to":"john.doe@example.com"}`}, received)
+}
+
+func TestMemory_ProduceDelayed(t *testing.T) {
+	memory := newMemory(t)
+
+	deliveredAt := make(chan time.Time, 1)
+	require.NoError(t, memory.RegisterConsumer("send_welcome", port.ConsumerOptions{}, func(_ context.Context, message []byte) error {
+		deliveredAt <- time.Now()
+		return nil
+	}))
+
+	producedAt := time.Now()
+	require.NoError(t, memory.Produce("send_welcome", "welcome", 1))
+	wait(t, memory, 2*time.Second)
+
+	require.GreaterOrEqual(t, (<-deliveredAt).Sub(producedAt), time.Second)
+}
+
+func TestMemory_CompetingConsumers(t *testing.T) {
+	memory := newMemory(t)
+
+	var first, second atomic.Int32
+	release := make(chan struct{})
+	require.NoError(t, memory.RegisterConsumer("send_invitation", port.ConsumerOptions{}, func(_ context.Context, message []byte) error {
+		first.Add(1)
+		<-release
+		return nil
+	}))
+	require.NoError(t, memory.RegisterConsumer("send_invitation", port.ConsumerOptions{}, func(_ context.Context, message []byte) error {
+		second.Add(1)
+		<-release
+		return nil
+	}))
+
+	require.NoError(t, memory.Produce("send_invitation", 1, 0))
+	require.NoError(t, memory.Produce("send_invitation", 2, 0))
+
+	// each consumer blocks on its message, so both of them have to take one
+	require.Eventually(t, func() bool {
+		return first.Load() == 1 && second.Load() == 1
+	}, time.Second, time.Millisecond)
+	close(release)
+	wait(t, memory, time.Second)
+}
+
+func TestMemory_Retry(t *testing.T) {
+	t.Run("Retry until success", func(t *testing.T) {
+		memory := newMemory(t)
+
+		var calls atomic.Int32
+		require.NoError(t, memory.RegisterConsumer("send_email_otp", port.ConsumerOptions{}, func(_ context.Context, message []byte) error {
+			if calls.Add(1) < 3 {
+				return errors.New("smtp unavailable")
+			}
+			return nil
+		}))
+
+		require.NoError(t, memory.Produce("send_email_otp", "otp", 0))
+		wait(t, memory, time.Second)
+
+		require.Equal(t, int32(3), calls.Load())
+		deadLetters, err := memory.DeadLetters("send_email_otp", 10)
+		require.NoError(t, err)
+		require.Empty(t, deadLetters)
+	})
+
+	t.Run("Dead letter and replay", func(t *testing.T) {
+		memory := newMemory(t)
+
+		var fail atomic.Bool
+		fail.Store(true)
+		var calls atomic.Int32
+		require.NoError(t, memory.RegisterConsumer("send_email_otp", port.ConsumerOptions{}, func(_ context.Context, message []byte) error {
+			calls.Add(1)
+			if fail.Load() {
+				return errors.New("smtp unavailable")
+			}
+			return nil
+		}))
+
+		require.NoError(t, memory.Produce("send_email_otp", "otp", 0))
+		wait(t, memory, time.Second)
+
+		require.Equal(t, int32(3), calls.Load())
+		deadLetters, err := memory.DeadLetters("send_email_otp", 10)
+		require.NoError(t, err)
+		require.Len(t, deadLetters, 1)
+		require.Equal(t, []byte(`"otp"`), deadLetters[0].Body)
+		require.Equal(t, 3, deadLetters[0].Attempts)
+		require.Equal(t, "smtp unavailable", deadLetters[0].Error)
+
+		fail.Store(false)
+		replayed, err := memory.Replay("send_email_otp", 0)
+		require.NoError(t, err)
+		require.Equal(t, 1, replayed)
+		wait(t, memory, time.Second)
+
+		require.Equal(t, int32(4), calls.Load())
+		deadLetters, err = memory.DeadLetters("send_email_otp", 10)
+		require.NoError(t, err)
+		require.Empty(t, deadLetters)
+	})
+}
+
+func TestMemory_Close(t *testing.T) {
+	memory := newMemory(t)
+	memory.Close()
+
+	require.ErrorIs(t, memory.Produce("send_email_otp", "otp", 0), messagebroker.ErrMemoryClosed)
+	require.ErrorIs(t, memory.RegisterConsumer("send_email_otp", port.ConsumerOptions{}, func(_ context.Context, message []byte) error {
+		return nil
+	}), messagebroker.ErrMemoryClosed)
+}
+
+func TestMemory_Workers(t *testing.T) {
+	memory := newMemory(t)
+
+	var inFlight atomic.Int32
+	release := make(chan struct{})
+	require.NoError(t, memory.RegisterConsumer("send_email_otp", port.ConsumerOptions{Workers: 3}, func(_ context.Context, message []byte) error {
+		inFlight.Add(1)
+		<-release
+		return nil
+	}))
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, memory.Produce("send_email_otp", i, 0))
+	}
+
+	require.Eventually(t, func() bool {
+		return inFlight.Load() == 3
+	}, time.Second, time.Millisecond)
+	close(release)
+	wait(t, memory, time.Second)
+}
+
+func TestMemory_Shutdown(t *testing.T) {
+	t.Run("Shutdown waits for the messages in hand", func(t *testing.T) {
+		memory := newMemory(t)
+
+		started := make(chan struct{})
+		var handled atomic.Int32
+		require.NoError(t, memory.RegisterConsumer("send_email_otp", port.ConsumerOptions{}, func(_ context.Context, message []byte) error {
+			close(started)
+			time.Sleep(50 * time.Millisecond)
+			handled.Add(1)
+			return nil
+		}))
+		require.NoError(t, memory.Produce("send_email_otp", 1, 0))
+		<-started
+		require.NoError(t, memory.Produce("send_email_otp", 2, 0))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.NoError(t, memory.Shutdown(ctx))
+
+		// the message taken before the shutdown is finished, the waiting one is left alone
+		require.Equal(t, int32(1), handled.Load())
+	})
+
+	t.Run("Shutdown cancels the handlers after the deadline", func(t *testing.T) {
+		memory := newMemory(t)
+
+		started := make(chan struct{})
+		canceled := make(chan struct{})
+		require.NoError(t, memory.RegisterConsumer("send_email_otp", port.ConsumerOptions{}, func(ctx context.Context, message []byte) error {
+			close(started)
+			<-ctx.Done()
+			close(canceled)
+			return ctx.Err()
+		}))
+		require.NoError(t, memory.Produce("send_email_otp", 1, 0))
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, memory.Shutdown(ctx), context.DeadlineExceeded)
+
+		select {
+		case <-canceled:
+		case <-time.After(time.Second):
+			t.Fatal("the handler was not canceled")
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package messagebroker
+
+import (
+	"context"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDriver struct {
+	mock.Mock
+}
+
+func (r *MockDriver) Close() {
+	r.Called()
+}
+
+func (r *MockDriver) Produce(name string, message interface{}, delaySeconds int64) error {
+	args := r.Called(name, message, delaySeconds)
+	return args.Error(0)
+}
+
+func (r *MockDriver) RegisterConsumer(
+	name string,
+	options port.ConsumerOptions,
+	callback func(ctx context.Context, message []byte) error,
+) error {
+	args := r.Called(name, options, callback)
+	return args.Error(0)
+}
+
+func (r *MockDriver) Shutdown(ctx context.Context) error {
+	args := r.Called(ctx)
+	return args.Error(0)
+}
@@ -1,15 +1,30 @@
 package messagebroker
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"sync"
+	"time"
 )
 
+// ErrMessageInHand is returned for a delivery of a message another consumer claimed, the message is delivered
+// again once the claim expires, without counting as a failed attempt, and is skipped then if the other consumer
+// handled it.
+var ErrMessageInHand = errors.New("message is in hand of another consumer")
+
+// releaseTimeout bounds the release of the claim of a failed message.
+const releaseTimeout = 5 * time.Second
+
 type Queue struct {
-	Log    logger.Logger
-	Config config.Config
-	Driver port.Driver
+	Log       logger.Logger
+	Config    config.Config
+	Driver    port.Driver
+	Processed port.ProcessedMessageCache
 }
 
 func NewQueue(log logger.Logger, config config.Config) *Queue {
@@ -19,8 +34,104 @@ func NewQueue(log logger.Logger, config config.Config) *Queue {
 	}
 }
 
+// Produce wraps the message of the event name in an envelope and hands it to the driver.
+func (r *Queue) Produce(name string, version int, message interface{}, delaySeconds int64) error {
+	envelope, err := domain.NewEnvelope(context.Background(), name, version, message)
+	if err != nil {
+		r.Log.Error(logger.Queue, logger.RabbitMQProduce, fmt.Sprintf("Error marshalling value: %v", err), nil)
+		return err
+	}
+
+	return r.Driver.Produce(name, envelope, delaySeconds)
+}
+
+// Register consumes the queue of name with the workers and the prefetch configured for it.
+func (r *Queue) Register(name string, consume func(ctx context.Context, message []byte) error) error {
+	return r.Driver.RegisterConsumer(name, port.ConsumerOptions{
+		Workers:  max(r.Config.Consumer.WorkersOf(name), 1),
+		Prefetch: r.Config.Consumer.PrefetchOf(name),
+	}, r.Consumer(name, consume))
+}
+
+// Consumer unwraps the envelope before handing the payload to consume with the id and the correlation id of
+// the message in ctx, a message already handled is acked without calling consume again. The message is claimed before it
+// is handled so two deliveries of it never run at once, and the claim is released when consume fails.
+// Without a processed message cache every delivery is handled.
+func (r *Queue) Consumer(
+	name string,
+	consume func(ctx context.Context, message []byte) error,
+) func(ctx context.Context, message []byte) error {
+	return func(ctx context.Context, message []byte) error {
+		envelope := domain.DecodeEnvelope(message)
+		if envelope.CorrelationID != "" {
+			ctx = domain.WithCorrelationID(ctx, envelope.CorrelationID)
+		}
+		if envelope.ID != "" {
+			ctx = domain.WithMessageID(ctx, envelope.ID)
+		}
+		if envelope.ID == "" || r.Processed == nil {
+			return consume(ctx, envelope.Payload)
+		}
+
+		extra := map[logger.ExtraKey]interface{}{
+			logger.QueueName: name,
+			logger.Body:      string(message),
+		}
+
+		claimed, err := r.Processed.Claim(ctx, name, envelope.ID)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			processed, processedErr := r.Processed.IsProcessed(ctx, name, envelope.ID)
+			if processedErr != nil {
+				return processedErr
+			}
+			if processed {
+				r.Log.Warn(logger.Queue, logger.QueueDuplicate, fmt.Sprintf("Message %s already processed, skipped", envelope.ID), extra)
+				return nil
+			}
+			return ErrMessageInHand
+		}
+
+		if err = consume(ctx, envelope.Payload); err != nil {
+			// the claim is released even when the handlers are canceled, it would block the retries otherwise
+			releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+			defer cancel()
+
+			if releaseErr := r.Processed.Release(releaseCtx, name, envelope.ID); releaseErr != nil {
+				r.Log.Error(logger.Queue, logger.QueueDuplicate, fmt.Sprintf("Error release claimed message: %v", releaseErr), extra)
+			}
+			return err
+		}
+
+		// the message is handled, failing to record it only risks handling a redelivery again
+		if err = r.Processed.MarkProcessed(ctx, name, envelope.ID); err != nil {
+			r.Log.Error(logger.Queue, logger.QueueDuplicate, fmt.Sprintf("Error record processed message: %v", err), extra)
+		}
+
+		return nil
+	}
+}
+
 func RegisterEvents(events ...port.Event) {
 	for _, event := range events {
 		event.Register()
 	}
 }
+
+// waitWithContext waits for the group until ctx is done, it returns the error of ctx when the group is still busy.
+func waitWithContext(ctx context.Context, group *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		group.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
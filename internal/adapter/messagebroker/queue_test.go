@@ -0,0 +1,103 @@
+package messagebroker_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newQueue(processed *messagebroker.MemoryProcessedMessages) *messagebroker.Queue {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	queue := messagebroker.NewQueue(mockLogger, config.Config{})
+	queue.Processed = processed
+
+	return queue
+}
+
+func envelope(t *testing.T) (*domain.Envelope, []byte) {
+	message, err := domain.NewEnvelope(context.Background(), "welcome", 1, map[string]string{"email": "john.doe@example.com"})
+	require.NoError(t, err)
+
+	body, err := json.Marshal(message)
+	require.NoError(t, err)
+
+	return message, body
+}
+
+func TestQueue_Consumer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Consumer passes the message ids in ctx", func(t *testing.T) {
+		message, body := envelope(t)
+
+		consumer := newQueue(messagebroker.NewMemoryProcessedMessages()).Consumer("welcome", func(ctx context.Context, payload []byte) error {
+			require.Equal(t, message.ID, domain.MessageID(ctx))
+			require.Equal(t, message.CorrelationID, domain.CorrelationID(ctx))
+			return nil
+		})
+
+		require.NoError(t, consumer(ctx, body))
+	})
+
+	t.Run("Consumer skips a processed message", func(t *testing.T) {
+		_, body := envelope(t)
+
+		var calls int
+		consumer := newQueue(messagebroker.NewMemoryProcessedMessages()).Consumer("welcome", func(ctx context.Context, message []byte) error {
+			calls++
+			return nil
+		})
+
+		require.NoError(t, consumer(ctx, body))
+		require.NoError(t, consumer(ctx, body))
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("Consumer releases the claim of a failed message", func(t *testing.T) {
+		message, body := envelope(t)
+		processed := messagebroker.NewMemoryProcessedMessages()
+
+		var calls int
+		consumer := newQueue(processed).Consumer("welcome", func(ctx context.Context, message []byte) error {
+			calls++
+			if calls == 1 {
+				return errors.New("smtp is down")
+			}
+			return nil
+		})
+
+		require.Error(t, consumer(ctx, body))
+		require.NoError(t, consumer(ctx, body))
+		require.Equal(t, 2, calls)
+
+		isProcessed, err := processed.IsProcessed(ctx, "welcome", message.ID)
+		require.NoError(t, err)
+		require.True(t, isProcessed)
+	})
+
+	t.Run("Consumer leaves a message in hand of another consumer", func(t *testing.T) {
+		message, body := envelope(t)
+		processed := messagebroker.NewMemoryProcessedMessages()
+
+		claimed, err := processed.Claim(ctx, "welcome", message.ID)
+		require.NoError(t, err)
+		require.True(t, claimed)
+
+		consumer := newQueue(processed).Consumer("welcome", func(ctx context.Context, message []byte) error {
+			t.Fatal("a claimed message must not be handled twice")
+			return nil
+		})
+
+		require.ErrorIs(t, consumer(ctx, body), messagebroker.ErrMessageInHand)
+	})
+}
@@ -1,33 +1,97 @@
 package messagebroker
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrRabbitMQNotConnected is returned while the connection to the broker is being restored.
+	ErrRabbitMQNotConnected = errors.New("rabbitmq is not connected")
+	// ErrRabbitMQNotConfirmed is returned when the broker refuses to take a published message.
+	ErrRabbitMQNotConfirmed = errors.New("rabbitmq did not confirm the message")
 )
 
 type RabbitMQ struct {
-	conn *amqp.Connection
-	log  logger.Logger
+	conf  config.RabbitMQ
+	log   logger.Logger
+	retry RetryPolicy
+
+	handlers       context.Context
+	cancelHandlers context.CancelFunc
+
+	done      chan struct{}
+	closeOnce sync.Once
+
+	mu         sync.Mutex
+	conn       *amqp.Connection
+	publishers chan *amqp.Channel
+	declared   map[string]bool
+	consumers  []*rabbitMQConsumer
+	stopping   bool
+	workers    sync.WaitGroup
 }
 
-func NewRabbitMQ(url string, log logger.Logger) (*RabbitMQ, error) {
-	conn, err := amqp.Dial(url)
+// rabbitMQConsumer keeps what a consumer was registered with, so it is registered again on a new connection,
+// and the channel and the tag it is canceled with on shutdown.
+type rabbitMQConsumer struct {
+	name     string
+	options  port.ConsumerOptions
+	callback func(ctx context.Context, message []byte) error
+
+	channel *amqp.Channel
+	tag     string
+}
+
+// NewRabbitMQ dials the broker and supervises the connection, once it is lost it is dialed again with
+// a backoff and the consumers are registered on the new one.
+func NewRabbitMQ(conf config.RabbitMQ, retry RetryPolicy, log logger.Logger) (*RabbitMQ, error) {
+	conn, err := amqp.Dial(conf.URL)
 	if err != nil {
 		return nil, err
 	}
 
-	return &RabbitMQ{
-		conn: conn,
-		log:  log,
-	}, nil
+	handlers, cancelHandlers := context.WithCancel(context.Background())
+
+	r := &RabbitMQ{
+		conf:           conf,
+		log:            log,
+		retry:          retry,
+		handlers:       handlers,
+		cancelHandlers: cancelHandlers,
+		done:           make(chan struct{}),
+		conn:           conn,
+		publishers:     make(chan *amqp.Channel, max(conf.PublisherPoolSize, 1)),
+		declared:       make(map[string]bool),
+	}
+	go r.supervise(conn)
+
+	return r, nil
 }
 
 func (r *RabbitMQ) Close() {
-	if err := r.conn.Close(); err != nil {
-		r.log.Error(logger.Queue, logger.RabbitMQ, err.Error(), nil)
-	}
+	r.closeOnce.Do(func() {
+		close(r.done)
+		r.cancelHandlers()
+
+		r.mu.Lock()
+		conn := r.conn
+		r.mu.Unlock()
+
+		if err := conn.Close(); err != nil && !errors.Is(err, amqp.ErrClosed) {
+			r.log.Error(logger.Queue, logger.RabbitMQ, err.Error(), nil)
+		}
+	})
 }
 
 func (r *RabbitMQ) Produce(name string, msg interface{}, delaySeconds int64) error {
@@ -42,147 +106,574 @@ func (r *RabbitMQ) Produce(name string, msg interface{}, delaySeconds int64) err
 		logger.Body:      message,
 	}
 
-	channel, err := r.conn.Channel()
+	if err = r.publish(context.Background(), name, DelayedExchange, name, amqp.Publishing{
+		ContentType:  "text/plain",
+		DeliveryMode: amqp.Persistent,
+		Body:         message,
+		Headers:      amqp.Table{"x-delay": delaySeconds * 1000},
+	}); err != nil {
+		r.log.Error(logger.Queue, logger.RabbitMQProduce, fmt.Sprintf("Error Publish message: %v", err), extra)
+		return err
+	}
+
+	return nil
+}
+
+// RegisterConsumer delivers the messages of the queue to the workers of the consumer, the broker hands out
+// at most Prefetch unacked messages to it. A failed message is published again through the delayed exchange
+// with an exponential backoff, once it runs out of attempts it is moved to the dead-letter queue.
+// The delivery is acked only after the retry or the dead letter is confirmed by the broker.
+func (r *RabbitMQ) RegisterConsumer(
+	name string,
+	options port.ConsumerOptions,
+	callback func(ctx context.Context, message []byte) error,
+) error {
+	consumer := &rabbitMQConsumer{
+		name:     name,
+		options:  options,
+		callback: callback,
+	}
+
+	if err := r.consume(consumer); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.consumers = append(r.consumers, consumer)
+	r.mu.Unlock()
+
+	return nil
+}
+
+// consume opens a channel for the consumer on the current connection and starts its workers. A channel
+// closed by the broker while the connection stays up is opened again, a lost connection is left to supervise.
+func (r *RabbitMQ) consume(consumer *rabbitMQConsumer) error {
+	extra := map[logger.ExtraKey]interface{}{
+		logger.QueueName: consumer.name,
+	}
+
+	channel, err := r.channel()
 	if err != nil {
-		r.log.Error(logger.Queue, logger.RabbitMQProduce, fmt.Sprintf("Error create channel: %v", err), extra)
+		r.log.Error(logger.Queue, logger.RabbitMQRegisterConsumer, fmt.Sprintf("Error create channel: %v", err), extra)
 		return err
 	}
+	closed := channel.NotifyClose(make(chan *amqp.Error, 1))
 
-	defer func(ch *amqp.Channel) {
-		if err = ch.Close(); err != nil {
-			r.log.Error(logger.Queue, logger.RabbitMQProduce, fmt.Sprintf("Error closing channel: %v", err), extra)
-		}
-	}(channel)
+	if err = r.declare(channel, consumer.name, logger.RabbitMQRegisterConsumer); err != nil {
+		return err
+	}
 
-	if err = channel.ExchangeDeclare(
-		"delayed_exchange",
-		"x-delayed-message",
-		true,
-		false,
-		false,
-		false,
-		amqp.Table{"x-delayed-type": "direct"},
-	); err != nil {
-		r.log.Error(logger.Queue, logger.RabbitMQProduce, fmt.Sprintf("Error ExchangeDeclare: %v", err), extra)
+	if err = channel.Qos(consumer.options.Prefetch, 0, false); err != nil {
+		r.log.Error(logger.Queue, logger.RabbitMQRegisterConsumer, fmt.Sprintf("Error Qos channel: %v", err), extra)
 		return err
 	}
 
-	queueDeclare, err := channel.QueueDeclare(
-		name,
-		true,
+	tag := fmt.Sprintf("%s-%s", consumer.name, uuid.NewString())
+	deliveries, err := channel.Consume(
+		consumer.name,
+		tag,
+		false,
 		false,
 		false,
 		false,
 		nil,
 	)
 	if err != nil {
-		r.log.Error(logger.Queue, logger.RabbitMQProduce, fmt.Sprintf("Error QueueDeclare: %v", err), extra)
+		r.log.Error(
+			logger.Queue,
+			logger.RabbitMQRegisterConsumer,
+			fmt.Sprintf("Error Consume channel: %v", err),
+			extra,
+		)
 		return err
 	}
 
-	if err = channel.QueueBind(
-		queueDeclare.Name,
-		queueDeclare.Name,
-		"delayed_exchange",
-		false,
-		nil,
-	); err != nil {
-		r.log.Error(logger.Queue, logger.RabbitMQProduce, fmt.Sprintf("Error QueueBind: %v", err), extra)
-		return err
+	r.mu.Lock()
+	consumer.channel = channel
+	consumer.tag = tag
+	r.mu.Unlock()
+
+	for i := 0; i < max(consumer.options.Workers, 1); i++ {
+		r.workers.Add(1)
+		go func() {
+			defer r.workers.Done()
+
+			for delivery := range deliveries {
+				r.handle(consumer.name, delivery, consumer.callback)
+			}
+		}()
 	}
 
-	if err = channel.Publish(
-		"delayed_exchange",
-		queueDeclare.Name,
-		false,
-		false,
-		amqp.Publishing{
-			ContentType: "text/plain",
-			Body:        message,
-			Headers:     amqp.Table{"x-delay": delaySeconds * 1000},
-		},
-	); err != nil {
-		r.log.Error(logger.Queue, logger.RabbitMQProduce, fmt.Sprintf("Error Publish message: %v", err), extra)
+	go func() {
+		closeErr, ok := <-closed
+		if !ok || closeErr == nil {
+			return
+		}
+
+		// the connection going down closes its channels too, wait so supervise can tell which one was lost
+		if !r.sleep(r.conf.ReconnectInitialDelaySecond) || r.isStopping() {
+			return
+		}
+
+		r.mu.Lock()
+		connectionLost := r.conn.IsClosed() || consumer.channel != channel
+		r.mu.Unlock()
+		if connectionLost {
+			return
+		}
+
+		r.log.Warn(logger.Queue, logger.RabbitMQReconnect, fmt.Sprintf("Consumer channel closed: %v", closeErr), extra)
+		if err := r.consume(consumer); err != nil {
+			r.log.Error(logger.Queue, logger.RabbitMQReconnect, fmt.Sprintf("Error register consumer again: %v", err), extra)
+		}
+	}()
+
+	return nil
+}
+
+// Shutdown cancels the consumers, so the broker stops delivering, and waits for the workers to finish
+// the deliveries in hand. The deliveries left unacked are requeued once the connection is closed.
+func (r *RabbitMQ) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	r.stopping = true
+	for _, consumer := range r.consumers {
+		if err := consumer.channel.Cancel(consumer.tag, false); err != nil {
+			r.log.Error(logger.Queue, logger.RabbitMQ, fmt.Sprintf("Error Cancel consumer: %v", err), nil)
+		}
+	}
+	r.consumers = nil
+	r.mu.Unlock()
+
+	if err := waitWithContext(ctx, &r.workers); err != nil {
+		r.cancelHandlers()
 		return err
 	}
 
 	return nil
 }
 
-func (r *RabbitMQ) RegisterConsumer(name string, callback func(message []byte) error) error {
+// supervise waits for the connection to be lost and replaces it, a connection closed by Close ends it.
+func (r *RabbitMQ) supervise(conn *amqp.Connection) {
+	for {
+		closeErr, ok := <-conn.NotifyClose(make(chan *amqp.Error, 1))
+		if !ok || closeErr == nil {
+			return
+		}
+		r.log.Error(logger.Queue, logger.RabbitMQReconnect, fmt.Sprintf("Connection lost: %v", closeErr), nil)
 
-	extra := map[logger.ExtraKey]interface{}{
-		logger.QueueName: name,
+		if conn = r.reconnect(); conn == nil {
+			return
+		}
+	}
+}
+
+// reconnect dials the broker until it succeeds or the driver is closed, then drops the publisher channels
+// and the declared queues of the lost connection and registers the consumers again.
+func (r *RabbitMQ) reconnect() *amqp.Connection {
+	delay := r.conf.ReconnectInitialDelaySecond
+	for attempt := 1; ; attempt++ {
+		if !r.sleep(delay) {
+			return nil
+		}
+
+		conn, err := amqp.Dial(r.conf.URL)
+		if err != nil {
+			r.log.Warn(
+				logger.Queue,
+				logger.RabbitMQReconnect,
+				fmt.Sprintf("Error reconnect, attempt %d: %v", attempt, err),
+				nil,
+			)
+			delay = min(delay*2, r.conf.ReconnectMaxDelaySecond)
+			continue
+		}
+
+		r.mu.Lock()
+		select {
+		case <-r.done:
+			r.mu.Unlock()
+			_ = conn.Close()
+			return nil
+		default:
+		}
+		r.conn = conn
+		r.publishers = make(chan *amqp.Channel, max(r.conf.PublisherPoolSize, 1))
+		r.declared = make(map[string]bool)
+		var consumers []*rabbitMQConsumer
+		if !r.stopping {
+			consumers = append(consumers, r.consumers...)
+		}
+		r.mu.Unlock()
+
+		for _, consumer := range consumers {
+			if err = r.consume(consumer); err != nil {
+				r.log.Error(
+					logger.Queue,
+					logger.RabbitMQReconnect,
+					fmt.Sprintf("Error register consumer again: %v", err),
+					map[logger.ExtraKey]interface{}{logger.QueueName: consumer.name},
+				)
+			}
+		}
+
+		r.log.Info(logger.Queue, logger.RabbitMQReconnect, fmt.Sprintf("Reconnected after %d attempts", attempt), nil)
+		return conn
 	}
+}
+
+// sleep waits for d and reports false when the driver is closed meanwhile.
+func (r *RabbitMQ) sleep(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-r.done:
+		return false
+	}
+}
+
+func (r *RabbitMQ) isStopping() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.stopping
+}
+
+// channel opens a channel on the current connection.
+func (r *RabbitMQ) channel() (*amqp.Channel, error) {
+	r.mu.Lock()
+	conn := r.conn
+	r.mu.Unlock()
+
+	if conn.IsClosed() {
+		return nil, ErrRabbitMQNotConnected
+	}
+
+	return conn.Channel()
+}
 
-	channel, err := r.conn.Channel()
+// publish sends the message through a pooled channel in confirm mode, declaring the queue of name first,
+// and waits for the broker to confirm it. A channel is put back into the pool only when the publish succeeded.
+func (r *RabbitMQ) publish(ctx context.Context, name string, exchange string, key string, msg amqp.Publishing) error {
+	channel, err := r.publisher()
 	if err != nil {
-		r.log.Error(logger.Queue, logger.RabbitMQProduce, fmt.Sprintf("Error create channel: %v", err), extra)
 		return err
 	}
 
-	queueDeclare, err := channel.QueueDeclare(
-		name,
-		true,
-		false,
-		false,
-		false,
-		nil,
-	)
+	r.mu.Lock()
+	declared := r.declared[name]
+	r.mu.Unlock()
+	if !declared {
+		if err = r.declare(channel, name, logger.RabbitMQPublish); err != nil {
+			_ = channel.Close()
+			return err
+		}
+
+		r.mu.Lock()
+		r.declared[name] = true
+		r.mu.Unlock()
+	}
+
+	confirmation, err := channel.PublishWithDeferredConfirmWithContext(ctx, exchange, key, false, false, msg)
 	if err != nil {
-		r.log.Error(
-			logger.Queue,
-			logger.RabbitMQRegisterConsumer,
-			fmt.Sprintf("Error QueueDeclare channel: %v", err),
-			extra,
-		)
+		_ = channel.Close()
 		return err
 	}
 
-	deliveries, err := channel.Consume(
-		queueDeclare.Name,
-		"",
-		false,
-		false,
-		false,
-		false,
-		nil,
-	)
+	confirmCtx, cancel := context.WithTimeout(ctx, r.conf.ConfirmTimeoutSecond)
+	defer cancel()
+
+	acked, err := confirmation.WaitContext(confirmCtx)
 	if err != nil {
+		// the confirmation may still arrive later, the channel cannot be trusted to pair the next one
+		_ = channel.Close()
+		return err
+	}
+
+	r.release(channel)
+	if !acked {
+		return ErrRabbitMQNotConfirmed
+	}
+
+	return nil
+}
+
+// publisher takes an open channel from the pool or opens a new one in confirm mode.
+func (r *RabbitMQ) publisher() (*amqp.Channel, error) {
+	r.mu.Lock()
+	publishers := r.publishers
+	r.mu.Unlock()
+
+	for {
+		select {
+		case channel := <-publishers:
+			if !channel.IsClosed() {
+				return channel, nil
+			}
+			continue
+		default:
+		}
+		break
+	}
+
+	channel, err := r.channel()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = channel.Confirm(false); err != nil {
+		_ = channel.Close()
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+// release puts the channel back into the pool, or closes it when the pool is full.
+func (r *RabbitMQ) release(channel *amqp.Channel) {
+	r.mu.Lock()
+	publishers := r.publishers
+	r.mu.Unlock()
+
+	select {
+	case publishers <- channel:
+	default:
+		_ = channel.Close()
+	}
+}
+
+func (r *RabbitMQ) handle(
+	name string,
+	delivery amqp.Delivery,
+	callback func(ctx context.Context, message []byte) error,
+) {
+	extra := map[logger.ExtraKey]interface{}{
+		logger.QueueName: name,
+		logger.Body:      string(delivery.Body),
+	}
+
+	consumeErr := callback(r.handlers, delivery.Body)
+	if consumeErr != nil {
 		r.log.Error(
 			logger.Queue,
 			logger.RabbitMQRegisterConsumer,
-			fmt.Sprintf("Error Consume channel: %v", err),
+			fmt.Sprintf("Error Consume message: %v", consumeErr),
 			extra,
 		)
-		return err
-	}
 
-	go func() {
-		for delivery := range deliveries {
-			extra = map[logger.ExtraKey]interface{}{
-				logger.QueueName: name,
-				logger.Body:      string(delivery.Body),
-			}
-			if err = callback(delivery.Body); err != nil {
+		if err := r.reschedule(name, delivery, consumeErr); err != nil {
+			// the message is kept in the queue, so a failed publish never loses it
+			if err = delivery.Nack(false, true); err != nil {
 				r.log.Error(
 					logger.Queue,
 					logger.RabbitMQRegisterConsumer,
-					fmt.Sprintf("Error Consume message: %v", err),
+					fmt.Sprintf("Error Nack Consume message: %v", err),
 					extra,
 				)
-			} else {
-				if err = delivery.Ack(false); err != nil {
-					r.log.Error(
-						logger.Queue,
-						logger.RabbitMQRegisterConsumer,
-						fmt.Sprintf("Error Ack Consume message: %v", err),
-						extra,
-					)
-				}
 			}
+			return
 		}
-	}()
+	}
+
+	if err := delivery.Ack(false); err != nil {
+		r.log.Error(
+			logger.Queue,
+			logger.RabbitMQRegisterConsumer,
+			fmt.Sprintf("Error Ack Consume message: %v", err),
+			extra,
+		)
+	}
+}
+
+// reschedule publishes the failed message again after the backoff delay, or to the dead-letter queue
+// when it has no attempts left.
+func (r *RabbitMQ) reschedule(name string, delivery amqp.Delivery, consumeErr error) error {
+	attempt, delay, exhausted := r.retry.Next(Attempt(delivery.Headers), consumeErr)
+	extra := map[logger.ExtraKey]interface{}{
+		logger.QueueName: name,
+		logger.Body:      string(delivery.Body),
+	}
+
+	headers := amqp.Table{
+		AttemptHeader: int64(attempt),
+		ErrorHeader:   consumeErr.Error(),
+	}
+	body := domain.EnvelopeWithAttempt(delivery.Body, attempt)
+
+	if exhausted {
+		headers[DeadLetteredAtHeader] = time.Now().Unix()
+		if err := r.publish(r.handlers, name, "", DeadLetterQueue(name), amqp.Publishing{
+			ContentType:  delivery.ContentType,
+			DeliveryMode: amqp.Persistent,
+			Body:         body,
+			Headers:      headers,
+		}); err != nil {
+			r.log.Error(logger.Queue, logger.RabbitMQDeadLetter, fmt.Sprintf("Error Publish dead letter: %v", err), extra)
+			return err
+		}
+
+		r.log.Warn(logger.Queue, logger.RabbitMQDeadLetter, fmt.Sprintf("Message moved to the dead-letter queue after %d attempts", attempt), extra)
+		return nil
+	}
+
+	headers["x-delay"] = delay.Milliseconds()
+	if err := r.publish(r.handlers, name, DelayedExchange, name, amqp.Publishing{
+		ContentType:  delivery.ContentType,
+		DeliveryMode: amqp.Persistent,
+		Body:         body,
+		Headers:      headers,
+	}); err != nil {
+		r.log.Error(logger.Queue, logger.RabbitMQRetry, fmt.Sprintf("Error Publish retry: %v", err), extra)
+		return err
+	}
+
+	r.log.Info(logger.Queue, logger.RabbitMQRetry, fmt.Sprintf("Message retried in %s, attempt %d", delay, attempt), extra)
+	return nil
+}
+
+// DeadLetter is a message that ran out of attempts.
+type DeadLetter struct {
+	Body           []byte
+	Attempts       int
+	Error          string
+	DeadLetteredAt time.Time
+}
+
+// DeadLetters returns at most limit messages of the dead-letter queue of name without removing them.
+func (r *RabbitMQ) DeadLetters(name string, limit int) ([]DeadLetter, error) {
+	channel, err := r.channel()
+	if err != nil {
+		r.log.Error(logger.Queue, logger.RabbitMQDeadLetter, fmt.Sprintf("Error create channel: %v", err), nil)
+		return nil, err
+	}
+	// closing the channel requeues every message that was read and not acked
+	defer func(ch *amqp.Channel) {
+		_ = ch.Close()
+	}(channel)
+
+	if err = r.declare(channel, name, logger.RabbitMQDeadLetter); err != nil {
+		return nil, err
+	}
+
+	var deadLetters []DeadLetter
+	for len(deadLetters) < limit {
+		delivery, ok, getErr := channel.Get(DeadLetterQueue(name), false)
+		if getErr != nil {
+			r.log.Error(logger.Queue, logger.RabbitMQDeadLetter, fmt.Sprintf("Error Get dead letter: %v", getErr), nil)
+			return nil, getErr
+		}
+		if !ok {
+			break
+		}
+
+		deadLetter := DeadLetter{
+			Body:     delivery.Body,
+			Attempts: Attempt(delivery.Headers),
+		}
+		if consumeErr, isString := delivery.Headers[ErrorHeader].(string); isString {
+			deadLetter.Error = consumeErr
+		}
+		if deadLetteredAt, isInt := delivery.Headers[DeadLetteredAtHeader].(int64); isInt {
+			deadLetter.DeadLetteredAt = time.Unix(deadLetteredAt, 0)
+		}
+		deadLetters = append(deadLetters, deadLetter)
+	}
+
+	return deadLetters, nil
+}
+
+// Replay moves at most limit messages of the dead-letter queue of name back to the queue with fresh attempts,
+// a limit below one replays every message. It returns the number of replayed messages.
+func (r *RabbitMQ) Replay(name string, limit int) (int, error) {
+	channel, err := r.channel()
+	if err != nil {
+		r.log.Error(logger.Queue, logger.RabbitMQDeadLetter, fmt.Sprintf("Error create channel: %v", err), nil)
+		return 0, err
+	}
+	defer func(ch *amqp.Channel) {
+		_ = ch.Close()
+	}(channel)
+
+	if err = r.declare(channel, name, logger.RabbitMQDeadLetter); err != nil {
+		return 0, err
+	}
+
+	replayed := 0
+	for limit < 1 || replayed < limit {
+		delivery, ok, getErr := channel.Get(DeadLetterQueue(name), false)
+		if getErr != nil {
+			r.log.Error(logger.Queue, logger.RabbitMQDeadLetter, fmt.Sprintf("Error Get dead letter: %v", getErr), nil)
+			return replayed, getErr
+		}
+		if !ok {
+			break
+		}
+
+		if err = r.publish(context.Background(), name, DelayedExchange, name, amqp.Publishing{
+			ContentType:  delivery.ContentType,
+			DeliveryMode: amqp.Persistent,
+			Body:         domain.EnvelopeWithAttempt(delivery.Body, 0),
+			Headers:      amqp.Table{"x-delay": int64(0)},
+		}); err != nil {
+			r.log.Error(logger.Queue, logger.RabbitMQDeadLetter, fmt.Sprintf("Error Publish replay: %v", err), nil)
+			return replayed, err
+		}
+
+		if err = delivery.Ack(false); err != nil {
+			r.log.Error(logger.Queue, logger.RabbitMQDeadLetter, fmt.Sprintf("Error Ack dead letter: %v", err), nil)
+			return replayed, err
+		}
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+// declare makes sure the delayed exchange, the queue bound to it and its dead-letter queue exist.
+func (r *RabbitMQ) declare(channel *amqp.Channel, name string, subCategory logger.SubCategory) error {
+	extra := map[logger.ExtraKey]interface{}{
+		logger.QueueName: name,
+	}
+
+	if err := channel.ExchangeDeclare(
+		DelayedExchange,
+		"x-delayed-message",
+		true,
+		false,
+		false,
+		false,
+		amqp.Table{"x-delayed-type": "direct"},
+	); err != nil {
+		r.log.Error(logger.Queue, subCategory, fmt.Sprintf("Error ExchangeDeclare: %v", err), extra)
+		return err
+	}
+
+	for _, queueName := range []string{name, DeadLetterQueue(name)} {
+		if _, err := channel.QueueDeclare(
+			queueName,
+			true,
+			false,
+			false,
+			false,
+			nil,
+		); err != nil {
+			r.log.Error(logger.Queue, subCategory, fmt.Sprintf("Error QueueDeclare: %v", err), extra)
+			return err
+		}
+	}
+
+	if err := channel.QueueBind(
+		name,
+		name,
+		DelayedExchange,
+		false,
+		nil,
+	); err != nil {
+		r.log.Error(logger.Queue, subCategory, fmt.Sprintf("Error QueueBind: %v", err), extra)
+		return err
+	}
 
 	return nil
 }
@@ -0,0 +1,449 @@
+package messagebroker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-redis/redis"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// RedisStreamGroup is the consumer group every consumer of a stream joins, so each message is handled once.
+	RedisStreamGroup = "consumers"
+
+	bodyField           = "body"
+	attemptField        = "attempt"
+	errorField          = "error"
+	deadLetteredAtField = "dead_lettered_at"
+)
+
+// promoteScript moves the due members of the delayed sorted set KEYS[1] to the stream KEYS[2] atomically,
+// so a message is never lost nor promoted twice by consumers running side by side.
+var promoteScript = redis.NewScript(`
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, member in ipairs(due) do
+	local message = cjson.decode(member)
+	redis.call('XADD', KEYS[2], '*', 'body', message.body, 'attempt', message.attempt)
+	redis.call('ZREM', KEYS[1], member)
+end
+return #due
+`)
+
+// RedisStream is a driver on Redis Streams. Every queue is a stream consumed by a consumer group, a delayed message
+// waits in a sorted set scored by its due time until a consumer of the queue moves it to the stream. A failed
+// message is retried with the retry policy and moved to the dead-letter stream of its queue once it runs out of
+// attempts, a message left pending by a crashed consumer is claimed by another consumer after ClaimIdleSecond.
+type RedisStream struct {
+	client *redis.Client
+	log    logger.Logger
+	conf   config.RedisStream
+	retry  RetryPolicy
+	prefix string
+
+	handlers       context.Context
+	cancelHandlers context.CancelFunc
+
+	consumerID string
+	consumers  int
+	mu         sync.Mutex
+	stop       chan struct{}
+	stopOnce   sync.Once
+	wg         sync.WaitGroup
+}
+
+func NewRedisStream(log logger.Logger, client *redis.Client, conf config.Config) *RedisStream {
+	hostname, _ := os.Hostname()
+	handlers, cancelHandlers := context.WithCancel(context.Background())
+
+	return &RedisStream{
+		client:         client,
+		log:            log,
+		conf:           conf.RedisStream,
+		retry:          NewRetryPolicy(conf.Queue),
+		prefix:         conf.Redis.Prefix,
+		handlers:       handlers,
+		cancelHandlers: cancelHandlers,
+		consumerID:     fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		stop:           make(chan struct{}),
+	}
+}
+
+// Close stops the consumers, waits for the messages they hold and closes the client.
+func (r *RedisStream) Close() {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+	})
+	r.wg.Wait()
+	r.cancelHandlers()
+
+	if err := r.client.Close(); err != nil {
+		r.log.Error(logger.Queue, logger.RedisStreamConsume, err.Error(), nil)
+	}
+}
+
+func (r *RedisStream) Produce(name string, msg interface{}, delaySeconds int64) error {
+	message, err := json.Marshal(msg)
+	if err != nil {
+		r.log.Error(logger.Queue, logger.RedisStreamProduce, fmt.Sprintf("Error marshalling value: %v", err), nil)
+		return err
+	}
+
+	if err = r.schedule(name, message, 0, time.Duration(delaySeconds)*time.Second); err != nil {
+		r.log.Error(logger.Queue, logger.RedisStreamProduce, fmt.Sprintf("Error Publish message: %v", err), map[logger.ExtraKey]interface{}{
+			logger.QueueName: name,
+			logger.Body:      message,
+		})
+		return err
+	}
+
+	return nil
+}
+
+// RegisterConsumer joins the workers of the consumer to the consumer group of the queue, every worker reads
+// at most Prefetch messages at once. The consumer also promotes the due delayed messages of the queue
+// and claims the messages its crashed peers left pending.
+func (r *RedisStream) RegisterConsumer(
+	name string,
+	options port.ConsumerOptions,
+	callback func(ctx context.Context, message []byte) error,
+) error {
+	if err := r.client.XGroupCreateMkStream(r.streamKey(name), RedisStreamGroup, "0").Err(); err != nil &&
+		!strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		r.log.Error(logger.Queue, logger.RedisStreamConsume, fmt.Sprintf("Error create consumer group: %v", err), map[logger.ExtraKey]interface{}{
+			logger.QueueName: name,
+		})
+		return err
+	}
+
+	var consumers []string
+	r.mu.Lock()
+	for i := 0; i < max(options.Workers, 1); i++ {
+		r.consumers++
+		consumers = append(consumers, fmt.Sprintf("%s-%d", r.consumerID, r.consumers))
+	}
+	r.mu.Unlock()
+
+	for _, consumer := range consumers {
+		r.wg.Add(1)
+		go r.consume(name, consumer, int64(options.Prefetch), callback)
+	}
+	r.wg.Add(1)
+	go r.maintain(name, consumers[0], callback)
+
+	return nil
+}
+
+// Shutdown stops reading the streams and waits for the workers to finish the messages in hand,
+// a message left unacknowledged is claimed by another consumer later.
+func (r *RedisStream) Shutdown(ctx context.Context) error {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+	})
+
+	if err := waitWithContext(ctx, &r.wg); err != nil {
+		r.cancelHandlers()
+		return err
+	}
+
+	return nil
+}
+
+// DeadLetters returns at most limit messages of the dead-letter stream of name without removing them.
+func (r *RedisStream) DeadLetters(name string, limit int) ([]DeadLetter, error) {
+	messages, err := r.client.XRangeN(r.streamKey(DeadLetterQueue(name)), "-", "+", int64(limit)).Result()
+	if err != nil {
+		r.log.Error(logger.Queue, logger.RedisStreamDeadLetter, fmt.Sprintf("Error read dead letters: %v", err), nil)
+		return nil, err
+	}
+
+	deadLetters := make([]DeadLetter, 0, len(messages))
+	for _, message := range messages {
+		deadLetter := DeadLetter{
+			Body:     []byte(stringField(message.Values, bodyField)),
+			Attempts: intField(message.Values, attemptField),
+			Error:    stringField(message.Values, errorField),
+		}
+		if deadLetteredAt := intField(message.Values, deadLetteredAtField); deadLetteredAt > 0 {
+			deadLetter.DeadLetteredAt = time.Unix(int64(deadLetteredAt), 0)
+		}
+		deadLetters = append(deadLetters, deadLetter)
+	}
+
+	return deadLetters, nil
+}
+
+// Replay moves at most limit messages of the dead-letter stream of name back to the queue with fresh attempts,
+// a limit below one replays every message. It returns the number of replayed messages.
+func (r *RedisStream) Replay(name string, limit int) (int, error) {
+	var messages []redis.XMessage
+	var err error
+	if limit < 1 {
+		messages, err = r.client.XRange(r.streamKey(DeadLetterQueue(name)), "-", "+").Result()
+	} else {
+		messages, err = r.client.XRangeN(r.streamKey(DeadLetterQueue(name)), "-", "+", int64(limit)).Result()
+	}
+	if err != nil {
+		r.log.Error(logger.Queue, logger.RedisStreamDeadLetter, fmt.Sprintf("Error read dead letters: %v", err), nil)
+		return 0, err
+	}
+
+	replayed := 0
+	for _, message := range messages {
+		pipe := r.client.TxPipeline()
+		pipe.XAdd(&redis.XAddArgs{
+			Stream: r.streamKey(name),
+			Values: map[string]interface{}{
+				bodyField:    string(domain.EnvelopeWithAttempt([]byte(stringField(message.Values, bodyField)), 0)),
+				attemptField: 0,
+			},
+		})
+		pipe.XDel(r.streamKey(DeadLetterQueue(name)), message.ID)
+		if _, err = pipe.Exec(); err != nil {
+			r.log.Error(logger.Queue, logger.RedisStreamDeadLetter, fmt.Sprintf("Error replay dead letter: %v", err), nil)
+			return replayed, err
+		}
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+func (r *RedisStream) consume(name string, consumer string, count int64, callback func(ctx context.Context, message []byte) error) {
+	defer r.wg.Done()
+
+	for !r.stopped() {
+		streams, err := r.client.XReadGroup(&redis.XReadGroupArgs{
+			Group:    RedisStreamGroup,
+			Consumer: consumer,
+			Streams:  []string{r.streamKey(name), ">"},
+			Count:    count,
+			Block:    r.conf.PollIntervalSecond,
+		}).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			r.log.Error(logger.Queue, logger.RedisStreamConsume, fmt.Sprintf("Error read stream: %v", err), map[logger.ExtraKey]interface{}{
+				logger.QueueName: name,
+			})
+			r.sleep(r.conf.PollIntervalSecond)
+			continue
+		}
+
+		for _, stream := range streams {
+			for _, message := range stream.Messages {
+				r.handle(name, message, callback)
+			}
+		}
+	}
+}
+
+// maintain promotes the due delayed messages and claims the stale pending ones every PollIntervalSecond.
+func (r *RedisStream) maintain(name string, consumer string, callback func(ctx context.Context, message []byte) error) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.conf.PollIntervalSecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			if err := promoteScript.Run(
+				r.client,
+				[]string{r.delayedKey(name), r.streamKey(name)},
+				time.Now().UnixMilli(),
+				r.conf.BatchSize,
+			).Err(); err != nil && err != redis.Nil {
+				r.log.Error(logger.Queue, logger.RedisStreamProduce, fmt.Sprintf("Error promote delayed messages: %v", err), map[logger.ExtraKey]interface{}{
+					logger.QueueName: name,
+				})
+			}
+
+			for _, message := range r.claim(name, consumer) {
+				r.handle(name, message, callback)
+			}
+		}
+	}
+}
+
+// claim takes over the messages that stayed pending longer than ClaimIdleSecond.
+func (r *RedisStream) claim(name string, consumer string) []redis.XMessage {
+	pending, err := r.client.XPendingExt(&redis.XPendingExtArgs{
+		Stream: r.streamKey(name),
+		Group:  RedisStreamGroup,
+		Start:  "-",
+		End:    "+",
+		Count:  int64(r.conf.BatchSize),
+	}).Result()
+	if err != nil {
+		r.log.Error(logger.Queue, logger.RedisStreamClaim, fmt.Sprintf("Error read pending messages: %v", err), map[logger.ExtraKey]interface{}{
+			logger.QueueName: name,
+		})
+		return nil
+	}
+
+	var ids []string
+	for _, entry := range pending {
+		if entry.Idle >= r.conf.ClaimIdleSecond {
+			ids = append(ids, entry.Id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	messages, err := r.client.XClaim(&redis.XClaimArgs{
+		Stream:   r.streamKey(name),
+		Group:    RedisStreamGroup,
+		Consumer: consumer,
+		MinIdle:  r.conf.ClaimIdleSecond,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		r.log.Error(logger.Queue, logger.RedisStreamClaim, fmt.Sprintf("Error claim pending messages: %v", err), map[logger.ExtraKey]interface{}{
+			logger.QueueName: name,
+		})
+		return nil
+	}
+
+	if len(messages) > 0 {
+		r.log.Warn(logger.Queue, logger.RedisStreamClaim, fmt.Sprintf("%d pending messages claimed", len(messages)), map[logger.ExtraKey]interface{}{
+			logger.QueueName: name,
+		})
+	}
+
+	return messages
+}
+
+// handle delivers the message to the callback, a failed message is scheduled again or dead-lettered before
+// it is acknowledged, so a failure to reschedule leaves it pending to be claimed later.
+func (r *RedisStream) handle(name string, message redis.XMessage, callback func(ctx context.Context, message []byte) error) {
+	body := stringField(message.Values, bodyField)
+	extra := map[logger.ExtraKey]interface{}{
+		logger.QueueName: name,
+		logger.Body:      body,
+	}
+
+	if consumeErr := callback(r.handlers, []byte(body)); consumeErr != nil {
+		r.log.Error(logger.Queue, logger.RedisStreamConsume, fmt.Sprintf("Error Consume message: %v", consumeErr), extra)
+
+		if err := r.reschedule(name, []byte(body), intField(message.Values, attemptField), consumeErr); err != nil {
+			return
+		}
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.XAck(r.streamKey(name), RedisStreamGroup, message.ID)
+	pipe.XDel(r.streamKey(name), message.ID)
+	if _, err := pipe.Exec(); err != nil {
+		r.log.Error(logger.Queue, logger.RedisStreamConsume, fmt.Sprintf("Error Ack Consume message: %v", err), extra)
+	}
+}
+
+// reschedule delays the message that failed after attempt failed deliveries by the backoff, or moves it to
+// the dead-letter stream when it has no attempts left.
+func (r *RedisStream) reschedule(name string, body []byte, attempt int, consumeErr error) error {
+	attempt, delay, exhausted := r.retry.Next(attempt, consumeErr)
+	body = domain.EnvelopeWithAttempt(body, attempt)
+	extra := map[logger.ExtraKey]interface{}{
+		logger.QueueName: name,
+		logger.Body:      string(body),
+	}
+
+	if exhausted {
+		if err := r.client.XAdd(&redis.XAddArgs{
+			Stream: r.streamKey(DeadLetterQueue(name)),
+			Values: map[string]interface{}{
+				bodyField:           string(body),
+				attemptField:        attempt,
+				errorField:          consumeErr.Error(),
+				deadLetteredAtField: time.Now().Unix(),
+			},
+		}).Err(); err != nil {
+			r.log.Error(logger.Queue, logger.RedisStreamDeadLetter, fmt.Sprintf("Error Publish dead letter: %v", err), extra)
+			return err
+		}
+
+		r.log.Warn(logger.Queue, logger.RedisStreamDeadLetter, fmt.Sprintf("Message moved to the dead-letter stream after %d attempts", attempt), extra)
+		return nil
+	}
+
+	if err := r.schedule(name, body, attempt, delay); err != nil {
+		r.log.Error(logger.Queue, logger.RedisStreamRetry, fmt.Sprintf("Error Publish retry: %v", err), extra)
+		return err
+	}
+
+	r.log.Info(logger.Queue, logger.RedisStreamRetry, fmt.Sprintf("Message retried in %s, attempt %d", delay, attempt), extra)
+	return nil
+}
+
+// schedule adds the message to the stream of name, or to its delayed set when it has to wait.
+func (r *RedisStream) schedule(name string, body []byte, attempt int, delay time.Duration) error {
+	if delay <= 0 {
+		return r.client.XAdd(&redis.XAddArgs{
+			Stream: r.streamKey(name),
+			Values: map[string]interface{}{bodyField: string(body), attemptField: attempt},
+		}).Err()
+	}
+
+	// the id keeps equal messages apart, the sorted set would keep only one of them otherwise
+	member, err := json.Marshal(map[string]interface{}{
+		"id":         uuid.NewString(),
+		bodyField:    string(body),
+		attemptField: attempt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.client.ZAdd(r.delayedKey(name), redis.Z{
+		Score:  float64(time.Now().Add(delay).UnixMilli()),
+		Member: string(member),
+	}).Err()
+}
+
+func (r *RedisStream) streamKey(name string) string {
+	return fmt.Sprintf("%s:stream:%s", r.prefix, name)
+}
+
+func (r *RedisStream) delayedKey(name string) string {
+	return fmt.Sprintf("%s:stream:%s:delayed", r.prefix, name)
+}
+
+func (r *RedisStream) stopped() bool {
+	select {
+	case <-r.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *RedisStream) sleep(duration time.Duration) {
+	select {
+	case <-r.stop:
+	case <-time.After(duration):
+	}
+}
+
+func stringField(values map[string]interface{}, field string) string {
+	value, _ := values[field].(string)
+	return value
+}
+
+func intField(values map[string]interface{}, field string) int {
+	value, _ := strconv.Atoi(stringField(values, field))
+	return value
+}
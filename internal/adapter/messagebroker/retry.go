@@ -0,0 +1,95 @@
+package messagebroker
+
+import (
+	"errors"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"time"
+)
+
+const (
+	// DelayedExchange routes the published messages to the queue named by the routing key after the x-delay header.
+	DelayedExchange = "delayed_exchange"
+
+	// AttemptHeader counts the failed deliveries of a message.
+	AttemptHeader = "x-attempt"
+	// ErrorHeader keeps the error of the last failed delivery.
+	ErrorHeader = "x-last-error"
+	// DeadLetteredAtHeader is the unix time the message was moved to the dead-letter queue.
+	DeadLetteredAtHeader = "x-dead-lettered-at"
+
+	deadLetterSuffix = ".dead"
+)
+
+// DeadLetterQueue is the name of the queue the messages of name are moved to once they run out of attempts.
+func DeadLetterQueue(name string) string {
+	return name + deadLetterSuffix
+}
+
+// RetryPolicy decides when a failed message is delivered again and when it is given up on.
+// A message in hand of another consumer waits for the claim of that consumer to expire.
+type RetryPolicy struct {
+	maxAttempts  int
+	initialDelay time.Duration
+	maxDelay     time.Duration
+	inHandDelay  time.Duration
+}
+
+func NewRetryPolicy(conf config.Queue) RetryPolicy {
+	return RetryPolicy{
+		maxAttempts:  conf.RetryMaxAttempts,
+		initialDelay: conf.RetryInitialDelaySecond,
+		maxDelay:     conf.RetryMaxDelaySecond,
+		inHandDelay:  conf.ClaimTTLSecond,
+	}
+}
+
+// Next returns the attempt count of a message that failed with err after attempt failed deliveries, the wait
+// before delivering it again and whether it is given up on. A message in hand of another consumer did not fail,
+// it keeps its attempts and is delivered again once the claim of the other consumer expired.
+func (r RetryPolicy) Next(attempt int, err error) (int, time.Duration, bool) {
+	if errors.Is(err, ErrMessageInHand) {
+		return attempt, r.inHandDelay, false
+	}
+
+	attempt++
+	if r.Exhausted(attempt) {
+		return attempt, 0, true
+	}
+
+	return attempt, r.Delay(attempt), false
+}
+
+// Exhausted reports whether the message failed as many times as it is allowed to.
+func (r RetryPolicy) Exhausted(attempt int) bool {
+	return attempt >= r.maxAttempts
+}
+
+// Delay is the wait before delivering the message again after its attempt-th failure,
+// it doubles on every failure and never exceeds the max delay.
+func (r RetryPolicy) Delay(attempt int) time.Duration {
+	delay := r.initialDelay
+	for i := 1; i < attempt && delay < r.maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, r.maxDelay)
+}
+
+// Attempt returns the number of failed deliveries recorded in the headers of the message.
+func Attempt(headers amqp.Table) int {
+	switch attempt := headers[AttemptHeader].(type) {
+	case int:
+		return attempt
+	case int8:
+		return int(attempt)
+	case int16:
+		return int(attempt)
+	case int32:
+		return int(attempt)
+	case int64:
+		return int(attempt)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,74 @@
+package messagebroker_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	policy := messagebroker.NewRetryPolicy(config.Queue{
+		RetryMaxAttempts:        5,
+		RetryInitialDelaySecond: 5 * time.Second,
+		RetryMaxDelaySecond:     30 * time.Second,
+	})
+
+	tests := []struct {
+		attempt   int
+		delay     time.Duration
+		exhausted bool
+	}{
+		{attempt: 1, delay: 5 * time.Second},
+		{attempt: 2, delay: 10 * time.Second},
+		{attempt: 3, delay: 20 * time.Second},
+		{attempt: 4, delay: 30 * time.Second},
+		{attempt: 5, delay: 30 * time.Second, exhausted: true},
+		{attempt: 64, delay: 30 * time.Second, exhausted: true},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.delay, policy.Delay(tt.attempt), "attempt %d", tt.attempt)
+		require.Equal(t, tt.exhausted, policy.Exhausted(tt.attempt), "attempt %d", tt.attempt)
+	}
+}
+
+func TestRetryPolicy_Next(t *testing.T) {
+	policy := messagebroker.NewRetryPolicy(config.Queue{
+		ClaimTTLSecond:          600 * time.Second,
+		RetryMaxAttempts:        2,
+		RetryInitialDelaySecond: 5 * time.Second,
+		RetryMaxDelaySecond:     30 * time.Second,
+	})
+
+	attempt, delay, exhausted := policy.Next(0, errors.New("smtp is down"))
+	require.Equal(t, 1, attempt)
+	require.Equal(t, 5*time.Second, delay)
+	require.False(t, exhausted)
+
+	attempt, _, exhausted = policy.Next(1, errors.New("smtp is down"))
+	require.Equal(t, 2, attempt)
+	require.True(t, exhausted)
+
+	// a message in hand of another consumer keeps its attempts and waits for the claim to expire
+	attempt, delay, exhausted = policy.Next(1, fmt.Errorf("redelivery: %w", messagebroker.ErrMessageInHand))
+	require.Equal(t, 1, attempt)
+	require.Equal(t, 600*time.Second, delay)
+	require.False(t, exhausted)
+}
+
+func TestAttempt(t *testing.T) {
+	require.Equal(t, 0, messagebroker.Attempt(nil))
+	require.Equal(t, 0, messagebroker.Attempt(amqp.Table{messagebroker.AttemptHeader: "3"}))
+	require.Equal(t, 3, messagebroker.Attempt(amqp.Table{messagebroker.AttemptHeader: 3}))
+	require.Equal(t, 3, messagebroker.Attempt(amqp.Table{messagebroker.AttemptHeader: int32(3)}))
+	require.Equal(t, 3, messagebroker.Attempt(amqp.Table{messagebroker.AttemptHeader: int64(3)}))
+}
+
+func TestDeadLetterQueue(t *testing.T) {
+	require.Equal(t, "export_user_data.dead", messagebroker.DeadLetterQueue("export_user_data"))
+}
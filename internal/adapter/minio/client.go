@@ -7,6 +7,12 @@ import (
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 )
 
 type Client struct {
@@ -46,16 +52,121 @@ func NewMinioClient(ctx context.Context, log logger.Logger, conf config.Minio) (
 	}, nil
 }
 
-func (r *Client) UploadFile(ctx context.Context, objectName, filePath, contentType string) (string, error) {
-	_, err := r.client.FPutObject(ctx, r.conf.BucketName, objectName, filePath, minio.PutObjectOptions{ContentType: contentType})
+// Put uploads the content of the reader as key, size is the exact number of bytes to read.
+func (r *Client) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	_, err := r.client.PutObject(ctx, r.conf.BucketName, key, reader, size, minio.PutObjectOptions{ContentType: contentType})
 	if err != nil {
 		r.log.Error(logger.Minio, logger.MinioUpload, err.Error(), map[logger.ExtraKey]interface{}{
-			"objectName":  objectName,
-			"filePath":    filePath,
+			"objectName":  key,
+			"contentType": contentType,
+		})
+		return err
+	}
+
+	return nil
+}
+
+// Get opens the object for reading, a missing object is reported as serviceerror.RecordNotFound.
+func (r *Client) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := r.client.GetObject(ctx, r.conf.BucketName, key, minio.GetObjectOptions{})
+	if err == nil {
+		// GetObject is lazy, the request is only sent by the first read or stat
+		_, err = object.Stat()
+	}
+	if err != nil {
+		if object != nil {
+			_ = object.Close()
+		}
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, serviceerror.New(serviceerror.RecordNotFound)
+		}
+
+		r.log.Error(logger.Minio, logger.MinioGet, err.Error(), map[logger.ExtraKey]interface{}{
+			"objectName": key,
+		})
+		return nil, err
+	}
+
+	return object, nil
+}
+
+func (r *Client) Delete(ctx context.Context, key string) error {
+	if err := r.client.RemoveObject(ctx, r.conf.BucketName, key, minio.RemoveObjectOptions{}); err != nil {
+		r.log.Error(logger.Minio, logger.MinioRemove, err.Error(), map[logger.ExtraKey]interface{}{
+			"objectName": key,
+		})
+		return err
+	}
+
+	return nil
+}
+
+// Copy copies the object to dstKey inside the bucket, a missing object is reported as serviceerror.RecordNotFound.
+func (r *Client) Copy(ctx context.Context, srcKey string, dstKey string) error {
+	_, err := r.client.CopyObject(
+		ctx,
+		minio.CopyDestOptions{Bucket: r.conf.BucketName, Object: dstKey},
+		minio.CopySrcOptions{Bucket: r.conf.BucketName, Object: srcKey},
+	)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return serviceerror.New(serviceerror.RecordNotFound)
+		}
+
+		r.log.Error(logger.Minio, logger.MinioCopy, err.Error(), map[logger.ExtraKey]interface{}{
+			"objectName":  srcKey,
+			"destination": dstKey,
+		})
+		return err
+	}
+
+	return nil
+}
+
+// Presign returns a download link of the object that stops working after expiry.
+func (r *Client) Presign(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	presignedURL, err := r.client.PresignedGetObject(ctx, r.conf.BucketName, key, expiry, url.Values{})
+	if err != nil {
+		r.log.Error(logger.Minio, logger.MinioPresign, err.Error(), map[logger.ExtraKey]interface{}{
+			"objectName": key,
+		})
+		return "", err
+	}
+
+	return presignedURL.String(), nil
+}
+
+// PresignPut returns an upload link that stops working after expiry, the Content-Type and Content-Length
+// headers are part of the signature so the upload has to be exactly size bytes of contentType.
+func (r *Client) PresignPut(ctx context.Context, key string, expiry time.Duration, contentType string, size int64) (string, error) {
+	headers := http.Header{}
+	headers.Set("Content-Type", contentType)
+	headers.Set("Content-Length", strconv.FormatInt(size, 10))
+
+	presignedURL, err := r.client.PresignHeader(ctx, http.MethodPut, r.conf.BucketName, key, expiry, url.Values{}, headers)
+	if err != nil {
+		r.log.Error(logger.Minio, logger.MinioPresign, err.Error(), map[logger.ExtraKey]interface{}{
+			"objectName":  key,
 			"contentType": contentType,
 		})
 		return "", err
 	}
 
-	return r.client.EndpointURL().String() + "/" + r.conf.BucketName + "/" + objectName, nil
+	return presignedURL.String(), nil
+}
+
+// List returns the keys of every object whose key starts with prefix.
+func (r *Client) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	for object := range r.client.ListObjects(ctx, r.conf.BucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			r.log.Error(logger.Minio, logger.MinioList, object.Err.Error(), map[logger.ExtraKey]interface{}{
+				"prefix": prefix,
+			})
+			return nil, object.Err
+		}
+		keys = append(keys, object.Key)
+	}
+
+	return keys, nil
 }
@@ -0,0 +1,192 @@
+package auditrepository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/metrics"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"strings"
+)
+
+// AuditLogRepository implements port.AuditLogRepository, the table is append-only so it never updates or deletes
+type AuditLogRepository struct {
+	log logger.Logger
+	tx  *sql.Tx
+}
+
+func NewAuditLogRepository(log logger.Logger, tx *sql.Tx) *AuditLogRepository {
+	return &AuditLogRepository{
+		log: log,
+		tx:  tx,
+	}
+}
+
+func (r *AuditLogRepository) Create(auditLog domain.AuditLog) error {
+	changes, err := json.Marshal(auditLog.Changes)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("audit_logs", "Create", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), nil)
+		return serviceerror.NewServerError()
+	}
+
+	var ip, userAgent *string
+	if auditLog.Actor.IP != "" {
+		ip = &auditLog.Actor.IP
+	}
+	if auditLog.Actor.UserAgent != "" {
+		userAgent = &auditLog.Actor.UserAgent
+	}
+
+	res, err := r.tx.Exec(
+		`INSERT INTO audit_logs (actor_id, action, target_type, target_uuid, changes, ip, user_agent)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		auditLog.Actor.UserID,
+		auditLog.Action,
+		auditLog.TargetType,
+		auditLog.TargetUUID,
+		changes,
+		ip,
+		userAgent,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("audit_logs", "Create", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), map[logger.ExtraKey]interface{}{
+			logger.InsertDBArg: auditLog,
+		})
+		return serviceerror.NewServerError()
+	}
+
+	if affected, affectedErr := res.RowsAffected(); affectedErr != nil || affected <= 0 {
+		metrics.DbCall.WithLabelValues("audit_logs", "Create", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, fmt.Sprintf("There is any effected row in DB: %v", affectedErr), nil)
+		return serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("audit_logs", "Create", "Success").Inc()
+
+	return nil
+}
+
+func (r *AuditLogRepository) List(filter domain.AuditLogFilter) ([]*domain.AuditLog, uint64, error) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.ActorUUID != nil {
+		addCondition("u.uuid = $%d", *filter.ActorUUID)
+	}
+	if filter.Action != nil {
+		addCondition("al.action = $%d", *filter.Action)
+	}
+	if filter.TargetType != nil {
+		addCondition("al.target_type = $%d", *filter.TargetType)
+	}
+	if filter.TargetUUID != nil {
+		addCondition("al.target_uuid = $%d", *filter.TargetUUID)
+	}
+	if filter.From != nil {
+		addCondition("al.created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("al.created_at <= $%d", *filter.To)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total uint64
+	if err := r.tx.QueryRow(
+		"SELECT count(*) FROM audit_logs AS al LEFT JOIN users AS u ON u.id = al.actor_id "+where,
+		args...,
+	).Scan(&total); err != nil {
+		metrics.DbCall.WithLabelValues("audit_logs", "List", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, 0, serviceerror.NewServerError()
+	}
+
+	args = append(args, filter.PerPage, filter.Offset())
+	rows, err := r.tx.Query(
+		fmt.Sprintf(
+			`SELECT al.uuid, al.actor_id, u.uuid, al.action, al.target_type, al.target_uuid, al.changes, al.ip, al.user_agent, al.created_at
+					FROM audit_logs AS al
+					LEFT JOIN users AS u ON u.id = al.actor_id
+					%s
+					ORDER BY al.id DESC
+					LIMIT $%d OFFSET $%d`,
+			where,
+			len(args)-1,
+			len(args),
+		),
+		args...,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("audit_logs", "List", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, 0, serviceerror.NewServerError()
+	}
+
+	defer func(rows *sql.Rows) {
+		if err = rows.Close(); err != nil {
+			r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		}
+	}(rows)
+
+	var auditLogs []*domain.AuditLog
+	for rows.Next() {
+		var auditLog domain.AuditLog
+		var changes []byte
+		var ip, userAgent sql.NullString
+		if err = rows.Scan(
+			&auditLog.Base.UUID,
+			&auditLog.Actor.UserID,
+			&auditLog.Actor.UserUUID,
+			&auditLog.Action,
+			&auditLog.TargetType,
+			&auditLog.TargetUUID,
+			&changes,
+			&ip,
+			&userAgent,
+			&auditLog.Base.CreatedAt,
+		); err != nil {
+			metrics.DbCall.WithLabelValues("audit_logs", "List", "Failed").Inc()
+
+			r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+			return nil, 0, serviceerror.NewServerError()
+		}
+
+		if err = json.Unmarshal(changes, &auditLog.Changes); err != nil {
+			metrics.DbCall.WithLabelValues("audit_logs", "List", "Failed").Inc()
+
+			r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+			return nil, 0, serviceerror.NewServerError()
+		}
+		auditLog.Actor.IP = ip.String
+		auditLog.Actor.UserAgent = userAgent.String
+
+		auditLogs = append(auditLogs, &auditLog)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DbCall.WithLabelValues("audit_logs", "List", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, 0, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("audit_logs", "List", "Success").Inc()
+
+	return auditLogs, total, nil
+}
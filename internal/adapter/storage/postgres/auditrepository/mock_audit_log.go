@@ -0,0 +1,20 @@
+package auditrepository
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditLogRepository struct {
+	mock.Mock
+}
+
+func (r *MockAuditLogRepository) Create(auditLog domain.AuditLog) error {
+	args := r.Called(auditLog)
+	return args.Error(0)
+}
+
+func (r *MockAuditLogRepository) List(filter domain.AuditLogFilter) ([]*domain.AuditLog, uint64, error) {
+	args := r.Called(filter)
+	return args.Get(0).([]*domain.AuditLog), args.Get(1).(uint64), args.Error(2)
+}
@@ -24,3 +24,13 @@ func (r *MockPermissionRepository) FilterValidPermissions(uuids []uuid.UUID) ([]
 	args := r.Called(uuids)
 	return args.Get(0).([]uint64), args.Error(1)
 }
+
+func (r *MockPermissionRepository) Upsert(permission domain.Permission) (uint64, bool, error) {
+	args := r.Called(permission)
+	return args.Get(0).(uint64), args.Bool(1), args.Error(2)
+}
+
+func (r *MockPermissionRepository) ListKeys() ([]domain.PermissionKeyType, error) {
+	args := r.Called()
+	return args.Get(0).([]domain.PermissionKeyType), args.Error(1)
+}
@@ -10,9 +10,9 @@ type MockRoleRepository struct {
 	mock.Mock
 }
 
-func (r *MockRoleRepository) Create(role domain.Role) error {
+func (r *MockRoleRepository) Create(role domain.Role) (*domain.Role, error) {
 	args := r.Called(role)
-	return args.Error(0)
+	return args.Get(0).(*domain.Role), args.Error(1)
 }
 
 func (r *MockRoleRepository) GetByUUID(uuid uuid.UUID) (*domain.Role, error) {
@@ -59,3 +59,24 @@ func (r *MockRoleRepository) GetUserRoleKeys(userID uint64) ([]domain.RoleKeyTyp
 	args := r.Called(userID)
 	return args.Get(0).([]domain.RoleKeyType), args.Error(1)
 }
+
+func (r *MockRoleRepository) GetAncestors(roleUUID uuid.UUID) ([]*domain.Role, error) {
+	args := r.Called(roleUUID)
+	return args.Get(0).([]*domain.Role), args.Error(1)
+}
+
+func (r *MockRoleRepository) GetSubtreeHeight(roleUUID uuid.UUID) (int, error) {
+	args := r.Called(roleUUID)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *MockRoleRepository) AttachPermission(
+	permissionKey domain.PermissionKeyType,
+	roleKeys ...domain.RoleKeyType,
+) ([]domain.RoleKeyType, error) {
+	args := r.Called(permissionKey, roleKeys)
+	if roles := args.Get(0); roles != nil {
+		return roles.([]domain.RoleKeyType), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
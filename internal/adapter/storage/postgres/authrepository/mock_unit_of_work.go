@@ -25,6 +25,10 @@ func (r *MockUnitOfWork) Rollback() error {
 	return args.Error(0)
 }
 
+func (r *MockUnitOfWork) AfterCommit(hook func() error) {
+	r.Called(hook)
+}
+
 func (r *MockUnitOfWork) RoleRepository() port.RoleRepository {
 	args := r.Called()
 	return args.Get(0).(port.RoleRepository)
@@ -39,3 +43,18 @@ func (r *MockUnitOfWork) ACLRepository() port.ACLRepository {
 	args := r.Called()
 	return args.Get(0).(port.ACLRepository)
 }
+
+func (r *MockUnitOfWork) AuditLogRepository() port.AuditLogRepository {
+	args := r.Called()
+	return args.Get(0).(port.AuditLogRepository)
+}
+
+func (r *MockUnitOfWork) PasswordHistoryRepository() port.PasswordHistoryRepository {
+	args := r.Called()
+	return args.Get(0).(port.PasswordHistoryRepository)
+}
+
+func (r *MockUnitOfWork) OutboxRepository() port.OutboxRepository {
+	args := r.Called()
+	return args.Get(0).(port.OutboxRepository)
+}
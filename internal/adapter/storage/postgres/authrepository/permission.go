@@ -2,6 +2,7 @@ package authrepository
 
 import (
 	"database/sql"
+	"errors"
 	"github.com/google/uuid"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
@@ -25,12 +26,22 @@ func NewPermissionRepository(log logger.Logger, tx *sql.Tx) *PermissionRepositor
 
 func (r *PermissionRepository) GetUserPermissionKeys(userID uint64) ([]domain.PermissionKeyType, error) {
 	rows, err := r.tx.Query(
-		`SELECT DISTINCT p.key FROM access_controls AS ac
-				LEFT JOIN roles r on r.id = ac.role_id AND r.deleted_at IS NULL
-				LEFT JOIN role_permissions rp on rp.role_id = r.id AND r.deleted_at IS NULL
-				LEFT JOIN permissions AS p on (p.id = rp.permission_id OR p.id = ac.permission_id) AND p.deleted_at IS NULL
-				WHERE ac.deleted_at IS NULL AND ac.user_id = $1`,
+		`WITH RECURSIVE user_roles AS (
+					SELECT r.id, r.parent_id, 0 AS depth FROM access_controls AS ac
+					INNER JOIN roles r on r.id = ac.role_id AND r.deleted_at IS NULL
+					WHERE ac.deleted_at IS NULL AND ac.user_id = $1
+					UNION
+					SELECT p.id, p.parent_id, ur.depth + 1 FROM roles AS p
+					INNER JOIN user_roles ur on ur.parent_id = p.id
+					WHERE p.deleted_at IS NULL AND ur.depth < $2
+				)
+				SELECT DISTINCT p.key FROM permissions AS p
+				WHERE p.deleted_at IS NULL AND (
+					p.id IN (SELECT rp.permission_id FROM role_permissions AS rp INNER JOIN user_roles ur on ur.id = rp.role_id)
+					OR p.id IN (SELECT ac.permission_id FROM access_controls AS ac WHERE ac.deleted_at IS NULL AND ac.user_id = $1)
+				)`,
 		userID,
+		domain.RoleMaxHierarchyDepth,
 	)
 	if err != nil {
 		metrics.DbCall.WithLabelValues("users", "GetUserPermissionKeys", "Failed").Inc()
@@ -155,3 +166,78 @@ func (r *PermissionRepository) FilterValidPermissions(uuids []uuid.UUID) ([]uint
 
 	return validPermissions, nil
 }
+
+func (r *PermissionRepository) Upsert(permission domain.Permission) (uint64, bool, error) {
+	var id uint64
+	var created bool
+	err := r.tx.QueryRow(
+		`INSERT INTO permissions (title, key, "group", description) VALUES ($1, $2, $3, $4)
+				ON CONFLICT (key) DO UPDATE SET title = EXCLUDED.title, "group" = EXCLUDED."group", description = EXCLUDED.description,
+					updated_at = now(), deleted_at = NULL, deleted_by = NULL
+				WHERE permissions.deleted_at IS NOT NULL
+					OR permissions.title IS DISTINCT FROM EXCLUDED.title
+					OR permissions."group" IS DISTINCT FROM EXCLUDED."group"
+					OR permissions.description IS DISTINCT FROM EXCLUDED.description
+				RETURNING id, (xmax = 0)`,
+		permission.Title,
+		permission.Key,
+		permission.Group,
+		permission.Description,
+	).Scan(&id, &created)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			metrics.DbCall.WithLabelValues("permissions", "Upsert", "Success").Inc()
+			return 0, false, nil
+		}
+
+		metrics.DbCall.WithLabelValues("permissions", "Upsert", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), map[logger.ExtraKey]interface{}{
+			logger.InsertDBArg: permission,
+		})
+		return 0, false, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("permissions", "Upsert", "Success").Inc()
+
+	return id, created, nil
+}
+
+func (r *PermissionRepository) ListKeys() ([]domain.PermissionKeyType, error) {
+	rows, err := r.tx.Query("SELECT key FROM permissions WHERE deleted_at IS NULL ORDER BY id")
+	if err != nil {
+		metrics.DbCall.WithLabelValues("permissions", "ListKeys", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, serviceerror.NewServerError()
+	}
+
+	defer func(rows *sql.Rows) {
+		if err = rows.Close(); err != nil {
+			r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		}
+	}(rows)
+
+	var keys []domain.PermissionKeyType
+	for rows.Next() {
+		var key domain.PermissionKeyType
+		if err = rows.Scan(&key); err != nil {
+			metrics.DbCall.WithLabelValues("permissions", "ListKeys", "Failed").Inc()
+
+			r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+			return nil, serviceerror.NewServerError()
+		}
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DbCall.WithLabelValues("permissions", "ListKeys", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("permissions", "ListKeys", "Success").Inc()
+
+	return keys, nil
+}
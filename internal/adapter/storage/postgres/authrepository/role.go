@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/metrics"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"strconv"
+	"strings"
 )
 
 type RoleRepository struct {
@@ -23,39 +26,51 @@ func NewRoleRepository(log logger.Logger, tx *sql.Tx) *RoleRepository {
 	}
 }
 
-func (r *RoleRepository) Create(role domain.Role) error {
-	res, err := r.tx.Exec(
-		"INSERT INTO roles (title, key, description, created_by) VALUES ($1, $2, $3, $4)",
+// Create inserts the role and returns it with the id and the UUID the database gave it.
+func (r *RoleRepository) Create(role domain.Role) (*domain.Role, error) {
+	err := r.tx.QueryRow(
+		"INSERT INTO roles (title, key, description, parent_id, created_by) VALUES ($1, $2, $3, $4, $5) RETURNING id, uuid",
 		role.Title,
 		role.Key,
 		role.Description,
+		role.ParentID,
 		role.Modifier.CreatedBy,
-	)
+	).Scan(&role.Base.ID, &role.Base.UUID)
 	if err != nil {
 		metrics.DbCall.WithLabelValues("roles", "Create", "Failed").Inc()
 
 		r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), map[logger.ExtraKey]interface{}{
 			logger.InsertDBArg: role,
 		})
-		return serviceerror.NewServerError()
-	}
-
-	if affected, affectedErr := res.RowsAffected(); affectedErr != nil || affected <= 0 {
-		metrics.DbCall.WithLabelValues("roles", "Create", "Failed").Inc()
-
-		r.log.Error(logger.Database, logger.DatabaseInsert, fmt.Sprintf("There is any effected row in DB: %v", affectedErr), nil)
-		return serviceerror.NewServerError()
+		return nil, serviceerror.NewServerError()
 	}
 
 	metrics.DbCall.WithLabelValues("roles", "Create", "Success").Inc()
 
-	return nil
+	return &role, nil
 }
 
 func (r *RoleRepository) GetByUUID(uuid uuid.UUID) (*domain.Role, error) {
 	var role domain.Role
-	err := r.tx.QueryRow("SELECT id, uuid, title, key, description, is_default FROM roles WHERE deleted_at IS NULL AND uuid = $1", uuid).
-		Scan(&role.Base.ID, &role.Base.UUID, &role.Title, &role.Key, &role.Description, &role.IsDefault)
+	var parent domain.Role
+	var parentTitle sql.NullString
+	err := r.tx.QueryRow(
+		`SELECT r.id, r.uuid, r.title, r.key, r.description, r.is_default, r.parent_id, p.uuid, p.title
+				FROM roles AS r
+				LEFT JOIN roles AS p ON p.id = r.parent_id AND p.deleted_at IS NULL
+				WHERE r.deleted_at IS NULL AND r.uuid = $1`,
+		uuid,
+	).Scan(
+		&role.Base.ID,
+		&role.Base.UUID,
+		&role.Title,
+		&role.Key,
+		&role.Description,
+		&role.IsDefault,
+		&role.ParentID,
+		&parent.Base.UUID,
+		&parentTitle,
+	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			metrics.DbCall.WithLabelValues("roles", "GetByUUID", "Success").Inc()
@@ -71,6 +86,11 @@ func (r *RoleRepository) GetByUUID(uuid uuid.UUID) (*domain.Role, error) {
 
 	metrics.DbCall.WithLabelValues("roles", "GetByUUID", "Success").Inc()
 
+	if parentTitle.Valid {
+		parent.Title = parentTitle.String
+		role.Parent = &parent
+	}
+
 	return &role, nil
 }
 
@@ -117,10 +137,11 @@ func (r *RoleRepository) List() ([]*domain.Role, error) {
 
 func (r *RoleRepository) Update(role domain.Role, uuid uuid.UUID) error {
 	res, err := r.tx.Exec(
-		"UPDATE roles SET title = $1, key = $2, description = $3, updated_at = now(), updated_by = $4 WHERE deleted_at IS NULL AND uuid = $5;",
+		"UPDATE roles SET title = $1, key = $2, description = $3, parent_id = $4, updated_at = now(), updated_by = $5 WHERE deleted_at IS NULL AND uuid = $6;",
 		role.Title,
 		role.Key,
 		role.Description,
+		role.ParentID,
 		role.Modifier.UpdatedBy,
 		uuid,
 	)
@@ -287,10 +308,18 @@ func (r *RoleRepository) GetPermissions(roleUUID uuid.UUID) (*domain.Role, error
 
 func (r *RoleRepository) GetUserRoleKeys(userID uint64) ([]domain.RoleKeyType, error) {
 	rows, err := r.tx.Query(
-		`SELECT DISTINCT r.key FROM access_controls AS ac
-         		INNER JOIN roles r on r.id = ac.role_id AND r.deleted_at IS NULL
-				WHERE ac.deleted_at IS NULL AND ac.user_id = $1`,
+		`WITH RECURSIVE user_roles AS (
+					SELECT r.id, r.key, r.parent_id, 0 AS depth FROM access_controls AS ac
+					INNER JOIN roles r on r.id = ac.role_id AND r.deleted_at IS NULL
+					WHERE ac.deleted_at IS NULL AND ac.user_id = $1
+					UNION
+					SELECT p.id, p.key, p.parent_id, ur.depth + 1 FROM roles AS p
+					INNER JOIN user_roles ur on ur.parent_id = p.id
+					WHERE p.deleted_at IS NULL AND ur.depth < $2
+				)
+				SELECT DISTINCT key FROM user_roles`,
 		userID,
+		domain.RoleMaxHierarchyDepth,
 	)
 	if err != nil {
 		metrics.DbCall.WithLabelValues("roles", "GetUserRoleKeys", "Failed").Inc()
@@ -373,3 +402,157 @@ func (r *RoleRepository) SyncPermissions(roleID uint64, permissionIDs []uint64)
 
 	return nil
 }
+
+func (r *RoleRepository) GetAncestors(roleUUID uuid.UUID) ([]*domain.Role, error) {
+	rows, err := r.tx.Query(
+		`WITH RECURSIVE ancestors AS (
+					SELECT r.id, r.uuid, r.title, r.key, r.description, r.parent_id, 0 AS depth FROM roles AS r
+					WHERE r.deleted_at IS NULL AND r.uuid = $1
+					UNION ALL
+					SELECT p.id, p.uuid, p.title, p.key, p.description, p.parent_id, a.depth + 1 FROM roles AS p
+					INNER JOIN ancestors a on a.parent_id = p.id
+					WHERE p.deleted_at IS NULL AND a.depth < $2
+				)
+				SELECT id, uuid, title, key, description, parent_id FROM ancestors WHERE depth > 0 ORDER BY depth`,
+		roleUUID,
+		domain.RoleMaxHierarchyDepth,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("roles", "GetAncestors", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, serviceerror.NewServerError()
+	}
+
+	defer func(rows *sql.Rows) {
+		if err = rows.Close(); err != nil {
+			r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		}
+	}(rows)
+
+	var roles []*domain.Role
+
+	for rows.Next() {
+		var role domain.Role
+		if err = rows.Scan(
+			&role.Base.ID,
+			&role.Base.UUID,
+			&role.Title,
+			&role.Key,
+			&role.Description,
+			&role.ParentID,
+		); err != nil {
+			metrics.DbCall.WithLabelValues("roles", "GetAncestors", "Failed").Inc()
+
+			r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+			return nil, serviceerror.NewServerError()
+		}
+
+		roles = append(roles, &role)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DbCall.WithLabelValues("roles", "GetAncestors", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("roles", "GetAncestors", "Success").Inc()
+
+	return roles, nil
+}
+
+// GetSubtreeHeight returns the number of levels below the role, zero when the role has no children.
+func (r *RoleRepository) GetSubtreeHeight(roleUUID uuid.UUID) (int, error) {
+	var height int
+	err := r.tx.QueryRow(
+		`WITH RECURSIVE descendants AS (
+					SELECT r.id, 0 AS depth FROM roles AS r
+					WHERE r.deleted_at IS NULL AND r.uuid = $1
+					UNION ALL
+					SELECT c.id, d.depth + 1 FROM roles AS c
+					INNER JOIN descendants d on c.parent_id = d.id
+					WHERE c.deleted_at IS NULL AND d.depth < $2
+				)
+				SELECT COALESCE(MAX(depth), 0) FROM descendants`,
+		roleUUID,
+		domain.RoleMaxHierarchyDepth,
+	).Scan(&height)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("roles", "GetSubtreeHeight", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return 0, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("roles", "GetSubtreeHeight", "Success").Inc()
+
+	return height, nil
+}
+
+// AttachPermission attaches the permission of permissionKey to the roles it is not attached to yet
+// and returns the keys of those roles.
+func (r *RoleRepository) AttachPermission(
+	permissionKey domain.PermissionKeyType,
+	roleKeys ...domain.RoleKeyType,
+) ([]domain.RoleKeyType, error) {
+	if len(roleKeys) == 0 {
+		return nil, nil
+	}
+
+	placeholders := strings.Join(helper.MakeSQLPlaceholders(uint(len(roleKeys))), ",")
+	args := make([]interface{}, 0, len(roleKeys)+1)
+	for _, key := range roleKeys {
+		args = append(args, key)
+	}
+	args = append(args, permissionKey)
+
+	rows, err := r.tx.Query(
+		`WITH attached AS (
+					INSERT INTO role_permissions (role_id, permission_id)
+					SELECT roles.id, permissions.id FROM roles
+					INNER JOIN permissions ON permissions.deleted_at IS NULL AND permissions.key = $`+strconv.Itoa(len(args))+`
+					WHERE roles.deleted_at IS NULL AND roles.key IN (`+placeholders+`)
+					ON CONFLICT DO NOTHING
+					RETURNING role_id
+				)
+				SELECT roles.key FROM roles INNER JOIN attached ON attached.role_id = roles.id ORDER BY roles.id`,
+		args...,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("roles", "AttachPermission", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), nil)
+		return nil, serviceerror.NewServerError()
+	}
+
+	defer func(rows *sql.Rows) {
+		if err = rows.Close(); err != nil {
+			r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), nil)
+		}
+	}(rows)
+
+	var attached []domain.RoleKeyType
+	for rows.Next() {
+		var key domain.RoleKeyType
+		if err = rows.Scan(&key); err != nil {
+			metrics.DbCall.WithLabelValues("roles", "AttachPermission", "Failed").Inc()
+
+			r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), nil)
+			return nil, serviceerror.NewServerError()
+		}
+		attached = append(attached, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DbCall.WithLabelValues("roles", "AttachPermission", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), nil)
+		return nil, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("roles", "AttachPermission", "Success").Inc()
+
+	return attached, nil
+}
@@ -3,6 +3,9 @@ package authrepository
 import (
 	"context"
 	"database/sql"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/auditrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/outboxrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/passwordrepository"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
@@ -13,9 +16,14 @@ type unitOfWork struct {
 	db  *sql.DB
 	tx  *sql.Tx
 
-	roleRepository       port.RoleRepository
-	permissionRepository port.PermissionRepository
-	aclRepository        port.ACLRepository
+	afterCommit []func() error
+
+	roleRepository            port.RoleRepository
+	permissionRepository      port.PermissionRepository
+	aclRepository             port.ACLRepository
+	auditLogRepository        port.AuditLogRepository
+	passwordHistoryRepository port.PasswordHistoryRepository
+	outboxRepository          port.OutboxRepository
 	// Add other repositories as needed
 }
 
@@ -35,26 +43,40 @@ func (r *unitOfWork) BeginTx(ctx context.Context) error {
 	}
 
 	r.tx = tx
+	r.afterCommit = nil
 
 	r.roleRepository = NewRoleRepository(r.log, tx)
 	r.permissionRepository = NewPermissionRepository(r.log, tx)
 	r.aclRepository = NewACLRepository(r.log, tx)
+	r.auditLogRepository = auditrepository.NewAuditLogRepository(r.log, tx)
+	r.passwordHistoryRepository = passwordrepository.NewPasswordHistoryRepository(r.log, tx)
+	r.outboxRepository = outboxrepository.NewOutboxRepository(r.log, tx)
 	// Initialize other repositories as needed
 
 	return nil
 }
 
 func (r *unitOfWork) Commit() error {
+	hooks := r.afterCommit
+	r.afterCommit = nil
 
 	if err := r.tx.Commit(); err != nil {
 		r.log.Error(logger.Database, logger.DatabaseCommit, err.Error(), nil)
 		return serviceerror.NewServerError()
 	}
 
+	// the changes are stored already, a failing hook is logged and does not fail the commit
+	for _, hook := range hooks {
+		if err := hook(); err != nil {
+			r.log.Error(logger.Database, logger.DatabaseAfterCommit, err.Error(), nil)
+		}
+	}
+
 	return nil
 }
 
 func (r *unitOfWork) Rollback() error {
+	r.afterCommit = nil
 
 	if err := r.tx.Rollback(); err != nil {
 		r.log.Error(logger.Database, logger.DatabaseRollback, err.Error(), nil)
@@ -64,6 +86,10 @@ func (r *unitOfWork) Rollback() error {
 	return nil
 }
 
+func (r *unitOfWork) AfterCommit(hook func() error) {
+	r.afterCommit = append(r.afterCommit, hook)
+}
+
 func (r *unitOfWork) RoleRepository() port.RoleRepository {
 	return r.roleRepository
 }
@@ -75,3 +101,15 @@ func (r *unitOfWork) PermissionRepository() port.PermissionRepository {
 func (r *unitOfWork) ACLRepository() port.ACLRepository {
 	return r.aclRepository
 }
+
+func (r *unitOfWork) AuditLogRepository() port.AuditLogRepository {
+	return r.auditLogRepository
+}
+
+func (r *unitOfWork) PasswordHistoryRepository() port.PasswordHistoryRepository {
+	return r.passwordHistoryRepository
+}
+
+func (r *unitOfWork) OutboxRepository() port.OutboxRepository {
+	return r.outboxRepository
+}
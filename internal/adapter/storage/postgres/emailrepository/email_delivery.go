@@ -0,0 +1,107 @@
+package emailrepository
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/metrics"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"strings"
+)
+
+// EmailDeliveryRepository implements port.EmailDeliveryRepository interface and provides access to the postgres database
+type EmailDeliveryRepository struct {
+	log logger.Logger
+	tx  *sql.Tx
+}
+
+// NewEmailDeliveryRepository creates a new email delivery repository instance
+func NewEmailDeliveryRepository(log logger.Logger, tx *sql.Tx) *EmailDeliveryRepository {
+	return &EmailDeliveryRepository{
+		log: log,
+		tx:  tx,
+	}
+}
+
+// Save stores the delivery, a delivery recorded before with the same UUID takes the new status and reason.
+func (r *EmailDeliveryRepository) Save(delivery domain.EmailDelivery) error {
+	_, err := r.tx.Exec(
+		`INSERT INTO email_deliveries (uuid, email, subject, provider, status, reason) VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+				ON CONFLICT (uuid) DO UPDATE SET status = EXCLUDED.status, reason = EXCLUDED.reason, updated_at = NOW()`,
+		delivery.Base.UUID,
+		strings.ToLower(delivery.Email),
+		delivery.Subject,
+		delivery.Provider,
+		delivery.Status,
+		delivery.Reason,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("email_deliveries", "Save", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), map[logger.ExtraKey]interface{}{
+			logger.InsertDBArg: delivery,
+		})
+		return serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("email_deliveries", "Save", "Success").Inc()
+
+	return nil
+}
+
+// GetByUUID returns nil when there is no delivery with the UUID.
+func (r *EmailDeliveryRepository) GetByUUID(deliveryUUID uuid.UUID) (*domain.EmailDelivery, error) {
+	var delivery domain.EmailDelivery
+	var reason sql.NullString
+	err := r.tx.QueryRow(
+		`SELECT id, uuid, email, subject, provider, status, reason, created_at FROM email_deliveries WHERE uuid = $1`,
+		deliveryUUID,
+	).Scan(
+		&delivery.Base.ID,
+		&delivery.Base.UUID,
+		&delivery.Email,
+		&delivery.Subject,
+		&delivery.Provider,
+		&delivery.Status,
+		&reason,
+		&delivery.Base.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			metrics.DbCall.WithLabelValues("email_deliveries", "GetByUUID", "Success").Inc()
+
+			return nil, nil
+		}
+		metrics.DbCall.WithLabelValues("email_deliveries", "GetByUUID", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("email_deliveries", "GetByUUID", "Success").Inc()
+
+	delivery.Reason = reason.String
+
+	return &delivery, nil
+}
+
+func (r *EmailDeliveryRepository) UpdateStatus(id uint64, status domain.EmailDeliveryStatusType, reason string) error {
+	_, err := r.tx.Exec(
+		`UPDATE email_deliveries SET status = $1, reason = NULLIF($2, ''), updated_at = NOW() WHERE id = $3`,
+		status,
+		reason,
+		id,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("email_deliveries", "UpdateStatus", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseUpdate, err.Error(), nil)
+		return serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("email_deliveries", "UpdateStatus", "Success").Inc()
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package emailrepository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/metrics"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"strings"
+)
+
+// EmailSuppressionRepository implements port.EmailSuppressionRepository interface and provides access to the postgres database
+type EmailSuppressionRepository struct {
+	log logger.Logger
+	tx  *sql.Tx
+}
+
+// NewEmailSuppressionRepository creates a new email suppression repository instance
+func NewEmailSuppressionRepository(log logger.Logger, tx *sql.Tx) *EmailSuppressionRepository {
+	return &EmailSuppressionRepository{
+		log: log,
+		tx:  tx,
+	}
+}
+
+func (r *EmailSuppressionRepository) IsSuppressed(email string) (bool, error) {
+	var suppressed bool
+	err := r.tx.QueryRow(
+		`SELECT EXISTS(SELECT 1 FROM email_suppressions WHERE email = $1)`,
+		strings.ToLower(email),
+	).Scan(&suppressed)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("email_suppressions", "IsSuppressed", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return false, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("email_suppressions", "IsSuppressed", "Success").Inc()
+
+	return suppressed, nil
+}
+
+// Create adds the address to the suppression list, an address already on it keeps its first reason.
+func (r *EmailSuppressionRepository) Create(suppression domain.EmailSuppression) error {
+	_, err := r.tx.Exec(
+		`INSERT INTO email_suppressions (email, reason, description) VALUES ($1, $2, NULLIF($3, ''))
+				ON CONFLICT (email) DO NOTHING`,
+		strings.ToLower(suppression.Email),
+		suppression.Reason,
+		suppression.Description,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("email_suppressions", "Create", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), map[logger.ExtraKey]interface{}{
+			logger.InsertDBArg: suppression,
+		})
+		return serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("email_suppressions", "Create", "Success").Inc()
+
+	return nil
+}
+
+func (r *EmailSuppressionRepository) List(filter domain.EmailSuppressionFilter) ([]*domain.EmailSuppression, uint64, error) {
+	where := ""
+	var args []interface{}
+	if filter.Email != nil {
+		args = append(args, strings.ToLower(*filter.Email))
+		where = "WHERE email = $1"
+	}
+
+	var total uint64
+	if err := r.tx.QueryRow("SELECT count(*) FROM email_suppressions "+where, args...).Scan(&total); err != nil {
+		metrics.DbCall.WithLabelValues("email_suppressions", "List", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, 0, serviceerror.NewServerError()
+	}
+
+	args = append(args, filter.PerPage, filter.Offset())
+	rows, err := r.tx.Query(
+		fmt.Sprintf(
+			`SELECT uuid, email, reason, description, created_at FROM email_suppressions %s
+					ORDER BY id DESC
+					LIMIT $%d OFFSET $%d`,
+			where,
+			len(args)-1,
+			len(args),
+		),
+		args...,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("email_suppressions", "List", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, 0, serviceerror.NewServerError()
+	}
+	defer func(rows *sql.Rows) {
+		if err = rows.Close(); err != nil {
+			r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		}
+	}(rows)
+
+	var suppressions []*domain.EmailSuppression
+	for rows.Next() {
+		var suppression domain.EmailSuppression
+		var description sql.NullString
+		if err = rows.Scan(
+			&suppression.Base.UUID,
+			&suppression.Email,
+			&suppression.Reason,
+			&description,
+			&suppression.Base.CreatedAt,
+		); err != nil {
+			metrics.DbCall.WithLabelValues("email_suppressions", "List", "Failed").Inc()
+
+			r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+			return nil, 0, serviceerror.NewServerError()
+		}
+		suppression.Description = description.String
+
+		suppressions = append(suppressions, &suppression)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DbCall.WithLabelValues("email_suppressions", "List", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, 0, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("email_suppressions", "List", "Success").Inc()
+
+	return suppressions, total, nil
+}
+
+func (r *EmailSuppressionRepository) Delete(suppressionUUID uuid.UUID) error {
+	result, err := r.tx.Exec(`DELETE FROM email_suppressions WHERE uuid = $1`, suppressionUUID)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("email_suppressions", "Delete", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseDelete, err.Error(), nil)
+		return serviceerror.NewServerError()
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		metrics.DbCall.WithLabelValues("email_suppressions", "Delete", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseDelete, err.Error(), nil)
+		return serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("email_suppressions", "Delete", "Success").Inc()
+
+	if affected == 0 {
+		return serviceerror.New(serviceerror.RecordNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package emailrepository
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEmailDeliveryRepository struct {
+	mock.Mock
+}
+
+func (r *MockEmailDeliveryRepository) Save(delivery domain.EmailDelivery) error {
+	args := r.Called(delivery)
+	return args.Error(0)
+}
+
+func (r *MockEmailDeliveryRepository) GetByUUID(deliveryUUID uuid.UUID) (*domain.EmailDelivery, error) {
+	args := r.Called(deliveryUUID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.EmailDelivery), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (r *MockEmailDeliveryRepository) UpdateStatus(id uint64, status domain.EmailDeliveryStatusType, reason string) error {
+	args := r.Called(id, status, reason)
+	return args.Error(0)
+}
@@ -0,0 +1,34 @@
+package emailrepository
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEmailSuppressionRepository struct {
+	mock.Mock
+}
+
+func (r *MockEmailSuppressionRepository) IsSuppressed(email string) (bool, error) {
+	args := r.Called(email)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *MockEmailSuppressionRepository) Create(suppression domain.EmailSuppression) error {
+	args := r.Called(suppression)
+	return args.Error(0)
+}
+
+func (r *MockEmailSuppressionRepository) List(filter domain.EmailSuppressionFilter) ([]*domain.EmailSuppression, uint64, error) {
+	args := r.Called(filter)
+	if args.Get(0) != nil {
+		return args.Get(0).([]*domain.EmailSuppression), args.Get(1).(uint64), args.Error(2)
+	}
+	return nil, args.Get(1).(uint64), args.Error(2)
+}
+
+func (r *MockEmailSuppressionRepository) Delete(suppressionUUID uuid.UUID) error {
+	args := r.Called(suppressionUUID)
+	return args.Error(0)
+}
@@ -0,0 +1,3 @@
+ALTER TABLE roles
+    DROP CONSTRAINT IF EXISTS chk_roles_parent_id,
+    DROP COLUMN parent_id;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- Add the parent_id column
+ALTER TABLE roles
+    ADD COLUMN parent_id INTEGER
+        CONSTRAINT fk_roles_parent_id REFERENCES roles,
+    ADD CONSTRAINT chk_roles_parent_id CHECK (parent_id <> id);
\ No newline at end of file
@@ -0,0 +1,3 @@
+UPDATE roles
+SET parent_id = NULL
+WHERE id IN (2, 3);
\ No newline at end of file
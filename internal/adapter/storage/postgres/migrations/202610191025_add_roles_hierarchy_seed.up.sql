@@ -0,0 +1,8 @@
+-- ADMIN inherits MANAGER, which inherits STAFF
+UPDATE roles
+SET parent_id = 3
+WHERE id = 2;
+
+UPDATE roles
+SET parent_id = 7
+WHERE id = 3;
\ No newline at end of file
@@ -0,0 +1,35 @@
+-- Table: audit_logs
+CREATE TABLE IF NOT EXISTS audit_logs
+(
+    id          BIGINT GENERATED BY DEFAULT AS IDENTITY
+        CONSTRAINT pk_audit_logs PRIMARY KEY,
+    uuid        uuid                     DEFAULT gen_random_uuid() UNIQUE,
+    actor_id    INTEGER
+        CONSTRAINT fk_audit_logs_actor_id REFERENCES users,
+    action      VARCHAR(64) NOT NULL,
+    target_type VARCHAR(64) NOT NULL,
+    target_uuid uuid,
+    changes     JSONB       NOT NULL     DEFAULT '{}'::jsonb,
+    ip          VARCHAR(45),
+    user_agent  TEXT,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_id ON audit_logs (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs (action);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_target ON audit_logs (target_type, target_uuid);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at);
+
+-- audit logs are append-only
+CREATE OR REPLACE FUNCTION prevent_audit_logs_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_audit_logs_append_only
+    BEFORE UPDATE OR DELETE
+    ON audit_logs
+    FOR EACH ROW
+EXECUTE FUNCTION prevent_audit_logs_change();
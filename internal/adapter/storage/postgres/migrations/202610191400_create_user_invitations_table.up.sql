@@ -0,0 +1,27 @@
+-- Table: user_invitations
+CREATE TABLE IF NOT EXISTS user_invitations
+(
+    id          BIGINT GENERATED BY DEFAULT AS IDENTITY
+        CONSTRAINT pk_user_invitations PRIMARY KEY,
+    uuid        uuid                     DEFAULT gen_random_uuid() UNIQUE,
+    user_id     INTEGER      NOT NULL
+        CONSTRAINT fk_user_invitations_user_id REFERENCES users,
+    token_hash  VARCHAR(64)  NOT NULL
+        CONSTRAINT uq_user_invitations_token_hash UNIQUE,
+    sent_count  INTEGER      NOT NULL    DEFAULT 1,
+    expires_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    revoked_at  TIMESTAMP WITH TIME ZONE,
+    created_by  INTEGER
+        CONSTRAINT fk_user_invitations_created_by REFERENCES users,
+    revoked_by  INTEGER
+        CONSTRAINT fk_user_invitations_revoked_by REFERENCES users,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at  TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_invitations_user_id ON user_invitations (user_id);
+
+-- a user has at most one pending invitation
+CREATE UNIQUE INDEX IF NOT EXISTS uq_user_invitations_pending_user_id
+    ON user_invitations (user_id) WHERE accepted_at IS NULL AND revoked_at IS NULL;
@@ -0,0 +1,12 @@
+-- Table: password_histories
+CREATE TABLE IF NOT EXISTS password_histories
+(
+    id         BIGINT GENERATED BY DEFAULT AS IDENTITY
+        CONSTRAINT pk_password_histories PRIMARY KEY,
+    user_id    INTEGER      NOT NULL
+        CONSTRAINT fk_password_histories_user_id REFERENCES users ON DELETE CASCADE,
+    password   VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_histories_user_id_created_at ON password_histories (user_id, created_at DESC);
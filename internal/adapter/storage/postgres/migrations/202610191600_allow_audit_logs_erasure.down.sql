@@ -0,0 +1,6 @@
+CREATE OR REPLACE FUNCTION prevent_audit_logs_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
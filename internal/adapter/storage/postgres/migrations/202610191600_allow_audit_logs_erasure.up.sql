@@ -0,0 +1,17 @@
+-- audit logs stay append-only, erasing a user may only clear the client details of an entry
+CREATE OR REPLACE FUNCTION prevent_audit_logs_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND NEW.ip IS NULL
+        AND NEW.user_agent IS NULL
+        AND (NEW.id, NEW.uuid, NEW.actor_id, NEW.action, NEW.target_type, NEW.target_uuid, NEW.changes, NEW.created_at)
+            IS NOT DISTINCT FROM
+            (OLD.id, OLD.uuid, OLD.actor_id, OLD.action, OLD.target_type, OLD.target_uuid, OLD.changes, OLD.created_at)
+    THEN
+        RETURN NEW;
+    END IF;
+
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,22 @@
+-- Table: upload_sessions
+CREATE TABLE IF NOT EXISTS upload_sessions
+(
+    id           BIGINT GENERATED BY DEFAULT AS IDENTITY
+        CONSTRAINT pk_upload_sessions PRIMARY KEY,
+    uuid         uuid                     DEFAULT gen_random_uuid() UNIQUE,
+    user_id      INTEGER      NOT NULL
+        CONSTRAINT fk_upload_sessions_user_id REFERENCES users,
+    purpose      VARCHAR(32)  NOT NULL,
+    key          VARCHAR(255) NOT NULL
+        CONSTRAINT uq_upload_sessions_key UNIQUE,
+    content_type VARCHAR(127) NOT NULL,
+    size         BIGINT       NOT NULL,
+    checksum     VARCHAR(64)  NOT NULL,
+    status       VARCHAR(16)  NOT NULL    DEFAULT 'PENDING',
+    expires_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at   TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_upload_sessions_user_id ON upload_sessions (user_id);
@@ -0,0 +1,17 @@
+-- Table: outbox_messages
+CREATE TABLE IF NOT EXISTS outbox_messages
+(
+    id            BIGINT GENERATED BY DEFAULT AS IDENTITY
+        CONSTRAINT pk_outbox_messages PRIMARY KEY,
+    queue         VARCHAR(255) NOT NULL,
+    payload       JSONB        NOT NULL,
+    delay_seconds BIGINT       NOT NULL    DEFAULT 0,
+    attempts      INTEGER      NOT NULL    DEFAULT 0,
+    last_error    TEXT,
+    available_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    published_at  TIMESTAMP WITH TIME ZONE,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_pending ON outbox_messages (available_at, id) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_published_at ON outbox_messages (published_at) WHERE published_at IS NOT NULL;
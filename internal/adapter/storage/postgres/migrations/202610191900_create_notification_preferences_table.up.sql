@@ -0,0 +1,13 @@
+-- Table: notification_preferences
+CREATE TABLE IF NOT EXISTS notification_preferences
+(
+    id         BIGINT GENERATED BY DEFAULT AS IDENTITY
+        CONSTRAINT pk_notification_preferences PRIMARY KEY,
+    user_id    INTEGER     NOT NULL
+        CONSTRAINT fk_notification_preferences_user_id REFERENCES users,
+    category   VARCHAR(50) NOT NULL,
+    enabled    BOOLEAN     NOT NULL    DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT uq_notification_preferences_user_id_category UNIQUE (user_id, category)
+);
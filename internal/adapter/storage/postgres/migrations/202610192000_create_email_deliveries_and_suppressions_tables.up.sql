@@ -0,0 +1,29 @@
+-- Table: email_deliveries
+CREATE TABLE IF NOT EXISTS email_deliveries
+(
+    id         BIGINT GENERATED BY DEFAULT AS IDENTITY
+        CONSTRAINT pk_email_deliveries PRIMARY KEY,
+    uuid       uuid                     DEFAULT gen_random_uuid() UNIQUE,
+    email      VARCHAR(255) NOT NULL,
+    subject    VARCHAR(255) NOT NULL,
+    provider   VARCHAR(50)  NOT NULL,
+    status     VARCHAR(50)  NOT NULL,
+    reason     TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_deliveries_email ON email_deliveries (email);
+
+-- Table: email_suppressions
+CREATE TABLE IF NOT EXISTS email_suppressions
+(
+    id          BIGINT GENERATED BY DEFAULT AS IDENTITY
+        CONSTRAINT pk_email_suppressions PRIMARY KEY,
+    uuid        uuid                     DEFAULT gen_random_uuid() UNIQUE,
+    email       VARCHAR(255) NOT NULL
+        CONSTRAINT uq_email_suppressions_email UNIQUE,
+    reason      VARCHAR(50)  NOT NULL,
+    description TEXT,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
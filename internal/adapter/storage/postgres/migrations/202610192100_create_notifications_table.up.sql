@@ -0,0 +1,18 @@
+-- Table: notifications
+CREATE TABLE IF NOT EXISTS notifications
+(
+    id         BIGINT GENERATED BY DEFAULT AS IDENTITY
+        CONSTRAINT pk_notifications PRIMARY KEY,
+    uuid       uuid                     DEFAULT gen_random_uuid() UNIQUE,
+    user_id    INTEGER      NOT NULL
+        CONSTRAINT fk_notifications_user_id REFERENCES users,
+    type       VARCHAR(50)  NOT NULL,
+    title      VARCHAR(255) NOT NULL,
+    body       TEXT         NOT NULL,
+    link       VARCHAR(2048),
+    read_at    TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id_created_at ON notifications (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id_unread ON notifications (user_id) WHERE read_at IS NULL;
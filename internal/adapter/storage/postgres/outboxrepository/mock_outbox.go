@@ -0,0 +1,36 @@
+package outboxrepository
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+func (r *MockOutboxRepository) Create(message domain.OutboxMessage) error {
+	args := r.Called(message)
+	return args.Error(0)
+}
+
+func (r *MockOutboxRepository) ListDue(limit int) ([]*domain.OutboxMessage, error) {
+	args := r.Called(limit)
+	return args.Get(0).([]*domain.OutboxMessage), args.Error(1)
+}
+
+func (r *MockOutboxRepository) MarkPublished(id uint64) error {
+	args := r.Called(id)
+	return args.Error(0)
+}
+
+func (r *MockOutboxRepository) MarkFailed(id uint64, lastError string, availableAt time.Time) error {
+	args := r.Called(id, lastError, availableAt)
+	return args.Error(0)
+}
+
+func (r *MockOutboxRepository) DeletePublished(before time.Time) (int64, error) {
+	args := r.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,172 @@
+package outboxrepository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/metrics"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"time"
+)
+
+// OutboxRepository implements port.OutboxRepository, it shares the transaction of the change the message announces
+type OutboxRepository struct {
+	log logger.Logger
+	tx  *sql.Tx
+}
+
+func NewOutboxRepository(log logger.Logger, tx *sql.Tx) *OutboxRepository {
+	return &OutboxRepository{
+		log: log,
+		tx:  tx,
+	}
+}
+
+func (r *OutboxRepository) Create(message domain.OutboxMessage) error {
+	res, err := r.tx.Exec(
+		"INSERT INTO outbox_messages (queue, payload, delay_seconds) VALUES ($1, $2, $3)",
+		message.Queue,
+		message.Payload,
+		message.DelaySeconds,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("outbox_messages", "Create", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), map[logger.ExtraKey]interface{}{
+			logger.InsertDBArg: message.Queue,
+		})
+		return serviceerror.NewServerError()
+	}
+
+	if affected, affectedErr := res.RowsAffected(); affectedErr != nil || affected <= 0 {
+		metrics.DbCall.WithLabelValues("outbox_messages", "Create", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, fmt.Sprintf("There is any effected row in DB: %v", affectedErr), nil)
+		return serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("outbox_messages", "Create", "Success").Inc()
+
+	return nil
+}
+
+// ListDue locks the oldest unpublished messages that are available, rows locked by another relay are skipped,
+// so several relays never publish the same message at the same time.
+func (r *OutboxRepository) ListDue(limit int) ([]*domain.OutboxMessage, error) {
+	rows, err := r.tx.Query(
+		`SELECT id, queue, payload, delay_seconds, attempts, available_at, created_at
+				FROM outbox_messages
+				WHERE published_at IS NULL AND available_at <= now()
+				ORDER BY available_at, id
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED`,
+		limit,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("outbox_messages", "ListDue", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, serviceerror.NewServerError()
+	}
+	defer func(rows *sql.Rows) {
+		if err = rows.Close(); err != nil {
+			r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		}
+	}(rows)
+
+	var messages []*domain.OutboxMessage
+	for rows.Next() {
+		var message domain.OutboxMessage
+		if err = rows.Scan(
+			&message.ID,
+			&message.Queue,
+			&message.Payload,
+			&message.DelaySeconds,
+			&message.Attempts,
+			&message.AvailableAt,
+			&message.CreatedAt,
+		); err != nil {
+			metrics.DbCall.WithLabelValues("outbox_messages", "ListDue", "Failed").Inc()
+
+			r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+			return nil, serviceerror.NewServerError()
+		}
+		messages = append(messages, &message)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DbCall.WithLabelValues("outbox_messages", "ListDue", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("outbox_messages", "ListDue", "Success").Inc()
+
+	return messages, nil
+}
+
+func (r *OutboxRepository) MarkPublished(id uint64) error {
+	return r.update(
+		"MarkPublished",
+		"UPDATE outbox_messages SET published_at = now(), last_error = NULL WHERE id = $1 AND published_at IS NULL",
+		id,
+	)
+}
+
+// MarkFailed records the failed publish, the message is picked up again at availableAt.
+func (r *OutboxRepository) MarkFailed(id uint64, lastError string, availableAt time.Time) error {
+	return r.update(
+		"MarkFailed",
+		`UPDATE outbox_messages SET attempts = attempts + 1, last_error = $2, available_at = $3
+				WHERE id = $1 AND published_at IS NULL`,
+		id,
+		lastError,
+		availableAt,
+	)
+}
+
+// DeletePublished removes the messages published before the given time and returns how many were removed.
+func (r *OutboxRepository) DeletePublished(before time.Time) (int64, error) {
+	res, err := r.tx.Exec("DELETE FROM outbox_messages WHERE published_at < $1", before)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("outbox_messages", "DeletePublished", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseDelete, err.Error(), nil)
+		return 0, serviceerror.NewServerError()
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		metrics.DbCall.WithLabelValues("outbox_messages", "DeletePublished", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseDelete, err.Error(), nil)
+		return 0, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("outbox_messages", "DeletePublished", "Success").Inc()
+
+	return deleted, nil
+}
+
+func (r *OutboxRepository) update(operation string, query string, args ...interface{}) error {
+	res, err := r.tx.Exec(query, args...)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("outbox_messages", operation, "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseUpdate, err.Error(), nil)
+		return serviceerror.NewServerError()
+	}
+
+	if affected, affectedErr := res.RowsAffected(); affectedErr != nil || affected <= 0 {
+		metrics.DbCall.WithLabelValues("outbox_messages", operation, "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseUpdate, fmt.Sprintf("There is any effected row in DB: %v", affectedErr), nil)
+		return serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("outbox_messages", operation, "Success").Inc()
+
+	return nil
+}
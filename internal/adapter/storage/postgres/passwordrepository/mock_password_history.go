@@ -0,0 +1,24 @@
+package passwordrepository
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPasswordHistoryRepository struct {
+	mock.Mock
+}
+
+func (r *MockPasswordHistoryRepository) Create(userID uint64, password string) error {
+	args := r.Called(userID, password)
+	return args.Error(0)
+}
+
+func (r *MockPasswordHistoryRepository) ListRecent(userID uint64, limit int) ([]string, error) {
+	args := r.Called(userID, limit)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (r *MockPasswordHistoryRepository) Prune(userID uint64, keep int) error {
+	args := r.Called(userID, keep)
+	return args.Error(0)
+}
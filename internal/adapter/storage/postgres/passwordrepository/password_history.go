@@ -0,0 +1,113 @@
+package passwordrepository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/metrics"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+)
+
+// PasswordHistoryRepository implements port.PasswordHistoryRepository, it only keeps password hashes
+type PasswordHistoryRepository struct {
+	log logger.Logger
+	tx  *sql.Tx
+}
+
+func NewPasswordHistoryRepository(log logger.Logger, tx *sql.Tx) *PasswordHistoryRepository {
+	return &PasswordHistoryRepository{
+		log: log,
+		tx:  tx,
+	}
+}
+
+func (r *PasswordHistoryRepository) Create(userID uint64, password string) error {
+	res, err := r.tx.Exec(
+		"INSERT INTO password_histories (user_id, password) VALUES ($1, $2)",
+		userID,
+		password,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("password_histories", "Create", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), map[logger.ExtraKey]interface{}{
+			logger.InsertDBArg: userID,
+		})
+		return serviceerror.NewServerError()
+	}
+
+	if affected, affectedErr := res.RowsAffected(); affectedErr != nil || affected <= 0 {
+		metrics.DbCall.WithLabelValues("password_histories", "Create", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, fmt.Sprintf("There is any effected row in DB: %v", affectedErr), nil)
+		return serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("password_histories", "Create", "Success").Inc()
+
+	return nil
+}
+
+func (r *PasswordHistoryRepository) ListRecent(userID uint64, limit int) ([]string, error) {
+	rows, err := r.tx.Query(
+		"SELECT password FROM password_histories WHERE user_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2",
+		userID,
+		limit,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("password_histories", "ListRecent", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, serviceerror.NewServerError()
+	}
+	defer func(rows *sql.Rows) {
+		if err = rows.Close(); err != nil {
+			r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		}
+	}(rows)
+
+	var passwords []string
+	for rows.Next() {
+		var password string
+		if err = rows.Scan(&password); err != nil {
+			metrics.DbCall.WithLabelValues("password_histories", "ListRecent", "Failed").Inc()
+
+			r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+			return nil, serviceerror.NewServerError()
+		}
+		passwords = append(passwords, password)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DbCall.WithLabelValues("password_histories", "ListRecent", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("password_histories", "ListRecent", "Success").Inc()
+
+	return passwords, nil
+}
+
+// Prune removes everything but the keep most recent entries of the user
+func (r *PasswordHistoryRepository) Prune(userID uint64, keep int) error {
+	_, err := r.tx.Exec(
+		`DELETE FROM password_histories
+				WHERE user_id = $1 AND id NOT IN (
+					SELECT id FROM password_histories WHERE user_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2
+				)`,
+		userID,
+		keep,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("password_histories", "Prune", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseDelete, err.Error(), nil)
+		return serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("password_histories", "Prune", "Success").Inc()
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package sentencerepository
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSentenceRepository struct {
+	mock.Mock
+}
+
+func (r *MockSentenceRepository) GetByUUID(sentenceUUID uuid.UUID) (*domain.Sentence, error) {
+	args := r.Called(sentenceUUID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.Sentence), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (r *MockSentenceRepository) Update(sentence domain.Sentence) error {
+	args := r.Called(sentence)
+	return args.Error(0)
+}
+
+func (r *MockSentenceRepository) Delete(id uint64, deletedBy uint64) error {
+	args := r.Called(id, deletedBy)
+	return args.Error(0)
+}
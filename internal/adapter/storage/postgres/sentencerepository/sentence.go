@@ -0,0 +1,106 @@
+package sentencerepository
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/metrics"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+)
+
+// SentenceRepository implements port.SentenceRepository interface and provides access to the postgres database
+type SentenceRepository struct {
+	log logger.Logger
+	tx  *sql.Tx
+}
+
+// NewSentenceRepository creates a new sentence repository instance
+func NewSentenceRepository(log logger.Logger, tx *sql.Tx) *SentenceRepository {
+	return &SentenceRepository{
+		log: log,
+		tx:  tx,
+	}
+}
+
+// GetByUUID locks the sentence until the transaction ends, so its creator is still the one checked on change.
+func (r *SentenceRepository) GetByUUID(sentenceUUID uuid.UUID) (*domain.Sentence, error) {
+	var sentence domain.Sentence
+	err := r.tx.QueryRow(
+		`SELECT id, uuid, text, status, level, grammar_id, created_by
+				FROM sentences WHERE deleted_at IS NULL AND uuid = $1 FOR UPDATE`,
+		sentenceUUID,
+	).Scan(
+		&sentence.Base.ID,
+		&sentence.Base.UUID,
+		&sentence.Text,
+		&sentence.Status,
+		&sentence.Level,
+		&sentence.Grammar.Base.ID,
+		&sentence.Modifier.CreatedBy,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("sentences", "GetByUUID", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, serviceerror.New(serviceerror.RecordNotFound)
+		}
+		return nil, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("sentences", "GetByUUID", "Success").Inc()
+
+	return &sentence, nil
+}
+
+func (r *SentenceRepository) Update(sentence domain.Sentence) error {
+	return r.change(
+		"Update",
+		logger.DatabaseUpdate,
+		`UPDATE sentences SET text = $1, level = $2, updated_by = $3, updated_at = now()
+				WHERE deleted_at IS NULL AND id = $4`,
+		sentence.Text,
+		sentence.Level,
+		sentence.Modifier.UpdatedBy,
+		sentence.Base.ID,
+	)
+}
+
+func (r *SentenceRepository) Delete(id uint64, deletedBy uint64) error {
+	return r.change(
+		"Delete",
+		logger.DatabaseDelete,
+		`UPDATE sentences SET deleted_at = now(), deleted_by = $1 WHERE deleted_at IS NULL AND id = $2`,
+		deletedBy,
+		id,
+	)
+}
+
+// change runs a statement on a single sentence, it returns serviceerror.RecordNotFound when the sentence is gone.
+func (r *SentenceRepository) change(operation string, category logger.SubCategory, query string, args ...interface{}) error {
+	result, err := r.tx.Exec(query, args...)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("sentences", operation, "Failed").Inc()
+
+		r.log.Error(logger.Database, category, err.Error(), nil)
+		return serviceerror.NewServerError()
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		metrics.DbCall.WithLabelValues("sentences", operation, "Failed").Inc()
+
+		r.log.Error(logger.Database, category, err.Error(), nil)
+		return serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("sentences", operation, "Success").Inc()
+
+	if affected == 0 {
+		return serviceerror.New(serviceerror.RecordNotFound)
+	}
+
+	return nil
+}
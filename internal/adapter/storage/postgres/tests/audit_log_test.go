@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/auditrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type AuditLogRepositoryTestSuite struct {
+	TestSuite
+}
+
+func (r *AuditLogRepositoryTestSuite) TestAuditLogRepository_Create_List() {
+	mockLogger := new(logger.MockLogger)
+
+	user := insertUser(r.T(), r.GetTx(), &domain.User{
+		FirstName: helper.StringPtr("John"),
+		LastName:  helper.StringPtr("Doe"),
+		Email:     "john.doe@example.com",
+		Password:  helper.StringPtr("hashedPassword"),
+		Status:    domain.UserStatusActive,
+	})
+
+	roleUUID := uuid.New()
+	repo := auditrepository.NewAuditLogRepository(mockLogger, r.GetTx())
+
+	err := repo.Create(domain.NewAuditLog(
+		domain.AuditActor{UserID: &user.Base.ID, IP: "127.0.0.1", UserAgent: "Go-http-client/1.1"},
+		domain.AuditActionRoleUpdated,
+		domain.AuditTargetRole,
+		&roleUUID,
+		map[string]interface{}{"title": "Staff"},
+		map[string]interface{}{"title": "Member"},
+	))
+	require.NoError(r.T(), err)
+
+	err = repo.Create(domain.NewAuditLog(
+		domain.AuditActor{},
+		domain.AuditActionPermissionRegistrySync,
+		domain.AuditTargetPermission,
+		nil,
+		nil,
+		map[string]interface{}{"created": []string{"READ_AUDIT_LOG"}},
+	))
+	require.NoError(r.T(), err)
+
+	auditLogs, total, err := repo.List(domain.AuditLogFilter{Page: 1, PerPage: 10})
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), uint64(2), total)
+	require.Len(r.T(), auditLogs, 2)
+	require.Equal(r.T(), domain.AuditActionPermissionRegistrySync, auditLogs[0].Action)
+	require.Nil(r.T(), auditLogs[0].Actor.UserID)
+
+	action := domain.AuditActionRoleUpdated
+	auditLogs, total, err = repo.List(domain.AuditLogFilter{
+		ActorUUID:  &user.Base.UUID,
+		Action:     &action,
+		TargetUUID: &roleUUID,
+		Page:       1,
+		PerPage:    10,
+	})
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), uint64(1), total)
+	require.Len(r.T(), auditLogs, 1)
+	require.Equal(r.T(), user.Base.UUID, auditLogs[0].Actor.UserUUID)
+	require.Equal(r.T(), "127.0.0.1", auditLogs[0].Actor.IP)
+	require.Equal(r.T(), &roleUUID, auditLogs[0].TargetUUID)
+	require.Equal(r.T(), map[string]domain.AuditChange{
+		"title": {Before: "Staff", After: "Member"},
+	}, auditLogs[0].Changes)
+}
+
+func (r *AuditLogRepositoryTestSuite) TestAuditLogRepository_Update_Rejected() {
+	mockLogger := new(logger.MockLogger)
+
+	repo := auditrepository.NewAuditLogRepository(mockLogger, r.GetTx())
+	err := repo.Create(domain.NewAuditLog(
+		domain.AuditActor{},
+		domain.AuditActionRoleDeleted,
+		domain.AuditTargetRole,
+		nil,
+		map[string]interface{}{"title": "Staff"},
+		nil,
+	))
+	require.NoError(r.T(), err)
+
+	_, err = r.GetTx().Exec("UPDATE audit_logs SET action = 'ROLE_CREATED'")
+	require.Error(r.T(), err)
+}
+
+func (r *AuditLogRepositoryTestSuite) TestAuditLogRepository_List_DBError() {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := r.GetTx().Exec("DROP TABLE IF EXISTS audit_logs CASCADE")
+	require.NoError(r.T(), err)
+
+	repo := auditrepository.NewAuditLogRepository(mockLogger, r.GetTx())
+	auditLogs, total, err := repo.List(domain.AuditLogFilter{Page: 1, PerPage: 10})
+
+	require.Error(r.T(), err)
+	require.Nil(r.T(), auditLogs)
+	require.Zero(r.T(), total)
+	require.Equal(r.T(), serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+	mockLogger.AssertExpectations(r.T())
+}
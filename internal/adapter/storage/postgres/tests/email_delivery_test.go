@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/emailrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/require"
+)
+
+type EmailDeliveryRepositoryTestSuite struct {
+	TestSuite
+}
+
+func (r *EmailDeliveryRepositoryTestSuite) TestEmailDeliveryRepository_Save_GetByUUID_UpdateStatus() {
+	mockLogger := new(logger.MockLogger)
+	repo := emailrepository.NewEmailDeliveryRepository(mockLogger, r.GetTx())
+
+	deliveryUUID := uuid.New()
+	delivery, err := repo.GetByUUID(deliveryUUID)
+	require.NoError(r.T(), err)
+	require.Nil(r.T(), delivery)
+
+	require.NoError(r.T(), repo.Save(domain.EmailDelivery{
+		Base:     domain.Base{UUID: deliveryUUID},
+		Email:    "John.Doe@Example.com",
+		Subject:  "Your code",
+		Provider: "sendgrid",
+		Status:   domain.EmailDeliveryStatusSent,
+	}))
+	require.NoError(r.T(), repo.Save(domain.EmailDelivery{
+		Base:     domain.Base{UUID: deliveryUUID},
+		Email:    "john.doe@example.com",
+		Subject:  "Your code",
+		Provider: "sendgrid",
+		Status:   domain.EmailDeliveryStatusFailed,
+		Reason:   "connection refused",
+	}))
+
+	delivery, err = repo.GetByUUID(deliveryUUID)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), "john.doe@example.com", delivery.Email)
+	require.Equal(r.T(), domain.EmailDeliveryStatusFailed, delivery.Status)
+	require.Equal(r.T(), "connection refused", delivery.Reason)
+
+	require.NoError(r.T(), repo.UpdateStatus(delivery.Base.ID, domain.EmailDeliveryStatusBounced, "550 unknown user"))
+
+	delivery, err = repo.GetByUUID(deliveryUUID)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), domain.EmailDeliveryStatusBounced, delivery.Status)
+	require.Equal(r.T(), "550 unknown user", delivery.Reason)
+}
+
+func (r *EmailDeliveryRepositoryTestSuite) TestEmailSuppressionRepository_Create_List_Delete() {
+	mockLogger := new(logger.MockLogger)
+	repo := emailrepository.NewEmailSuppressionRepository(mockLogger, r.GetTx())
+
+	suppressed, err := repo.IsSuppressed("john.doe@example.com")
+	require.NoError(r.T(), err)
+	require.False(r.T(), suppressed)
+
+	require.NoError(r.T(), repo.Create(domain.EmailSuppression{
+		Email:  "John.Doe@Example.com",
+		Reason: domain.EmailSuppressionReasonBounce,
+	}))
+	require.NoError(r.T(), repo.Create(domain.EmailSuppression{
+		Email:  "john.doe@example.com",
+		Reason: domain.EmailSuppressionReasonSpamReport,
+	}))
+	require.NoError(r.T(), repo.Create(domain.EmailSuppression{
+		Email:  "jane.doe@example.com",
+		Reason: domain.EmailSuppressionReasonSpamReport,
+	}))
+
+	suppressed, err = repo.IsSuppressed("JOHN.DOE@example.com")
+	require.NoError(r.T(), err)
+	require.True(r.T(), suppressed)
+
+	suppressions, total, err := repo.List(domain.EmailSuppressionFilter{Page: 1, PerPage: 10})
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), uint64(2), total)
+	require.Len(r.T(), suppressions, 2)
+
+	suppressions, total, err = repo.List(domain.EmailSuppressionFilter{
+		Email:   helper.StringPtr("john.doe@example.com"),
+		Page:    1,
+		PerPage: 10,
+	})
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), uint64(1), total)
+	require.Equal(r.T(), domain.EmailSuppressionReasonBounce, suppressions[0].Reason)
+
+	require.NoError(r.T(), repo.Delete(suppressions[0].Base.UUID))
+	require.Equal(r.T(), serviceerror.New(serviceerror.RecordNotFound), repo.Delete(suppressions[0].Base.UUID))
+
+	suppressed, err = repo.IsSuppressed("john.doe@example.com")
+	require.NoError(r.T(), err)
+	require.False(r.T(), suppressed)
+}
@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/userrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+type NotificationPreferenceRepositoryTestSuite struct {
+	TestSuite
+}
+
+func (r *NotificationPreferenceRepositoryTestSuite) TestNotificationPreferenceRepository_Upsert_ListByUserID() {
+	mockLogger := new(logger.MockLogger)
+	user := insertUser(r.T(), r.GetTx(), &domain.User{
+		FirstName: helper.StringPtr("John"),
+		LastName:  helper.StringPtr("Doe"),
+		Email:     "john.doe@example.com",
+		Status:    domain.UserStatusActive,
+	})
+
+	repo := userrepository.NewNotificationPreferenceRepository(mockLogger, r.GetTx())
+
+	preferences, err := repo.ListByUserID(user.Base.ID)
+	require.NoError(r.T(), err)
+	require.Empty(r.T(), preferences)
+
+	require.NoError(r.T(), repo.Upsert(domain.NotificationPreference{
+		UserID:   user.Base.ID,
+		Category: domain.NotificationCategoryProduct,
+		Enabled:  false,
+	}))
+	require.NoError(r.T(), repo.Upsert(domain.NotificationPreference{
+		UserID:   user.Base.ID,
+		Category: domain.NotificationCategoryLearningReminder,
+		Enabled:  false,
+	}))
+	require.NoError(r.T(), repo.Upsert(domain.NotificationPreference{
+		UserID:   user.Base.ID,
+		Category: domain.NotificationCategoryProduct,
+		Enabled:  true,
+	}))
+
+	preferences, err = repo.ListByUserID(user.Base.ID)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), []domain.NotificationPreference{
+		{UserID: user.Base.ID, Category: domain.NotificationCategoryProduct, Enabled: true},
+		{UserID: user.Base.ID, Category: domain.NotificationCategoryLearningReminder, Enabled: false},
+	}, preferences)
+}
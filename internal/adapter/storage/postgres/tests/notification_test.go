@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/userrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+type NotificationRepositoryTestSuite struct {
+	TestSuite
+}
+
+func (r *NotificationRepositoryTestSuite) TestNotificationRepository_Create_List() {
+	mockLogger := new(logger.MockLogger)
+	user := insertUser(r.T(), r.GetTx(), &domain.User{
+		FirstName: helper.StringPtr("John"),
+		LastName:  helper.StringPtr("Doe"),
+		Email:     "john.doe@example.com",
+		Status:    domain.UserStatusActive,
+	})
+
+	repo := userrepository.NewNotificationRepository(mockLogger, r.GetTx())
+
+	first := domain.Notification{
+		UserID: user.Base.ID,
+		Type:   domain.NotificationTypeAssignment,
+		Title:  "New assignment",
+		Body:   "Translate ten sentences by Friday.",
+		Link:   helper.StringPtr("/assignments/1"),
+	}
+	require.NoError(r.T(), repo.Create(&first))
+	require.NotZero(r.T(), first.Base.ID)
+	require.NotEqual(r.T(), uuid.Nil, first.Base.UUID)
+	require.False(r.T(), first.Base.CreatedAt.IsZero())
+
+	second := domain.Notification{
+		UserID: user.Base.ID,
+		Type:   domain.NotificationTypeStreakAtRisk,
+		Title:  "Your streak is at risk",
+		Body:   "Practice one sentence today to keep your streak.",
+	}
+	require.NoError(r.T(), repo.Create(&second))
+
+	notifications, total, err := repo.List(user.Base.ID, domain.NotificationFilter{Page: 1, PerPage: 1})
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), uint64(2), total)
+	require.Len(r.T(), notifications, 1)
+	require.Equal(r.T(), second.Base.UUID, notifications[0].Base.UUID)
+	require.Nil(r.T(), notifications[0].Link)
+	require.Nil(r.T(), notifications[0].ReadAt)
+
+	notifications, _, err = repo.List(user.Base.ID, domain.NotificationFilter{Page: 2, PerPage: 1})
+	require.NoError(r.T(), err)
+	require.Len(r.T(), notifications, 1)
+	require.Equal(r.T(), first.Base.UUID, notifications[0].Base.UUID)
+	require.Equal(r.T(), "/assignments/1", *notifications[0].Link)
+}
+
+func (r *NotificationRepositoryTestSuite) TestNotificationRepository_MarkRead_CountUnread() {
+	mockLogger := new(logger.MockLogger)
+	user := insertUser(r.T(), r.GetTx(), &domain.User{
+		FirstName: helper.StringPtr("John"),
+		LastName:  helper.StringPtr("Doe"),
+		Email:     "john.doe@example.com",
+		Status:    domain.UserStatusActive,
+	})
+	other := insertUser(r.T(), r.GetTx(), &domain.User{
+		FirstName: helper.StringPtr("Jane"),
+		LastName:  helper.StringPtr("Doe"),
+		Email:     "jane.doe@example.com",
+		Status:    domain.UserStatusActive,
+	})
+
+	repo := userrepository.NewNotificationRepository(mockLogger, r.GetTx())
+
+	notifications := make([]domain.Notification, 3)
+	for i := range notifications {
+		notifications[i] = domain.Notification{
+			UserID: user.Base.ID,
+			Type:   domain.NotificationTypeAssignment,
+			Title:  "New assignment",
+			Body:   "Translate ten sentences by Friday.",
+		}
+		require.NoError(r.T(), repo.Create(&notifications[i]))
+	}
+	foreign := domain.Notification{
+		UserID: other.Base.ID,
+		Type:   domain.NotificationTypeRoleChanged,
+		Title:  "Your role changed",
+		Body:   "Jane, you are a teacher now.",
+	}
+	require.NoError(r.T(), repo.Create(&foreign))
+
+	count, err := repo.CountUnread(user.Base.ID)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), uint64(3), count)
+
+	require.NoError(r.T(), repo.MarkRead(user.Base.ID, []uuid.UUID{notifications[0].Base.UUID, foreign.Base.UUID}))
+
+	count, err = repo.CountUnread(user.Base.ID)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), uint64(2), count)
+
+	count, err = repo.CountUnread(other.Base.ID)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), uint64(1), count)
+
+	unread, total, err := repo.List(user.Base.ID, domain.NotificationFilter{UnreadOnly: true, Page: 1, PerPage: 10})
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), uint64(2), total)
+	require.Len(r.T(), unread, 2)
+
+	require.NoError(r.T(), repo.MarkAllRead(user.Base.ID))
+
+	count, err = repo.CountUnread(user.Base.ID)
+	require.NoError(r.T(), err)
+	require.Zero(r.T(), count)
+}
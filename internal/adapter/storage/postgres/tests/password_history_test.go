@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/passwordrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type PasswordHistoryRepositoryTestSuite struct {
+	TestSuite
+}
+
+func (r *PasswordHistoryRepositoryTestSuite) TestPasswordHistoryRepository_Create_ListRecent_Prune() {
+	mockLogger := new(logger.MockLogger)
+
+	user := insertUser(r.T(), r.GetTx(), &domain.User{
+		FirstName: helper.StringPtr("John"),
+		LastName:  helper.StringPtr("Doe"),
+		Email:     "john.doe@example.com",
+		Status:    domain.UserStatusActive,
+	})
+
+	repo := passwordrepository.NewPasswordHistoryRepository(mockLogger, r.GetTx())
+	for _, password := range []string{"first", "second", "third"} {
+		require.NoError(r.T(), repo.Create(user.Base.ID, password))
+	}
+
+	passwords, err := repo.ListRecent(user.Base.ID, 2)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), []string{"third", "second"}, passwords)
+
+	require.NoError(r.T(), repo.Prune(user.Base.ID, 1))
+
+	passwords, err = repo.ListRecent(user.Base.ID, 10)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), []string{"third"}, passwords)
+}
+
+func (r *PasswordHistoryRepositoryTestSuite) TestPasswordHistoryRepository_Create_DBError() {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	repo := passwordrepository.NewPasswordHistoryRepository(mockLogger, r.GetTx())
+	err := repo.Create(0, "hashed")
+
+	require.Error(r.T(), err)
+	require.Equal(r.T(), serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+	mockLogger.AssertExpectations(r.T())
+}
@@ -231,6 +231,77 @@ func (r *PermissionRepositoryTestSuite) TestPermissionRepository_FilterValidPerm
 	mockLogger.AssertExpectations(r.T())
 }
 
+func (r *PermissionRepositoryTestSuite) TestPermissionRepository_Upsert_Success() {
+	mockLogger := new(logger.MockLogger)
+
+	permission := domain.Permission{
+		Title:       helper.StringPtr("Publish sentence"),
+		Key:         (*domain.PermissionKeyType)(helper.StringPtr("PUBLISH_SENTENCE")),
+		Description: helper.StringPtr("Publish a sentence"),
+		Group:       helper.StringPtr("sentence"),
+	}
+
+	repo := authrepository.NewPermissionRepository(mockLogger, r.GetTx())
+
+	id, created, err := repo.Upsert(permission)
+	require.NoError(r.T(), err)
+	require.True(r.T(), created)
+	require.NotZero(r.T(), id)
+
+	unchangedID, created, err := repo.Upsert(permission)
+	require.NoError(r.T(), err)
+	require.False(r.T(), created)
+	require.Zero(r.T(), unchangedID)
+
+	permission.Description = helper.StringPtr("Publish any sentence")
+	updatedID, created, err := repo.Upsert(permission)
+	require.NoError(r.T(), err)
+	require.False(r.T(), created)
+	require.Equal(r.T(), id, updatedID)
+
+	keys, err := repo.ListKeys()
+	require.NoError(r.T(), err)
+	require.Contains(r.T(), keys, *permission.Key)
+}
+
+func (r *PermissionRepositoryTestSuite) TestPermissionRepository_Upsert_DBError() {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := r.GetTx().Exec("DROP TABLE IF EXISTS permissions CASCADE;")
+	require.NoError(r.T(), err)
+
+	repo := authrepository.NewPermissionRepository(mockLogger, r.GetTx())
+	id, created, err := repo.Upsert(domain.Permission{
+		Title: helper.StringPtr("Publish sentence"),
+		Key:   (*domain.PermissionKeyType)(helper.StringPtr("PUBLISH_SENTENCE")),
+	})
+
+	require.Error(r.T(), err)
+	require.Equal(r.T(), serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+	require.False(r.T(), created)
+	require.Zero(r.T(), id)
+
+	mockLogger.AssertExpectations(r.T())
+}
+
+func (r *PermissionRepositoryTestSuite) TestPermissionRepository_ListKeys_DBError() {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := r.GetTx().Exec("DROP TABLE IF EXISTS permissions CASCADE;")
+	require.NoError(r.T(), err)
+
+	repo := authrepository.NewPermissionRepository(mockLogger, r.GetTx())
+	keys, err := repo.ListKeys()
+
+	require.Error(r.T(), err)
+	require.Equal(r.T(), serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+	require.Nil(r.T(), keys)
+
+	mockLogger.AssertExpectations(r.T())
+}
+
 func insertInvalidPermission(t *testing.T, tx *sql.Tx, permission *domain.Permission) *domain.Permission {
 	require.NoError(t, tx.QueryRow(
 		"INSERT INTO permissions (title) VALUES ($1) RETURNING uuid",
@@ -91,6 +91,15 @@ func TestRepositoriesTestSuite(t *testing.T) {
 	suite.Run(t, new(UserRepositoryTestSuite))
 	suite.Run(t, new(PermissionRepositoryTestSuite))
 	suite.Run(t, new(ACLRepositoryTestSuite))
+	suite.Run(t, new(AuditLogRepositoryTestSuite))
+	suite.Run(t, new(UserInvitationRepositoryTestSuite))
+	suite.Run(t, new(PasswordHistoryRepositoryTestSuite))
+	suite.Run(t, new(UserDataRepositoryTestSuite))
+	suite.Run(t, new(UploadSessionRepositoryTestSuite))
+	suite.Run(t, new(NotificationPreferenceRepositoryTestSuite))
+	suite.Run(t, new(EmailDeliveryRepositoryTestSuite))
+	suite.Run(t, new(NotificationRepositoryTestSuite))
+	suite.Run(t, new(SentenceRepositoryTestSuite))
 }
 
 func insertUser(t *testing.T, tx *sql.Tx, user *domain.User) *domain.User {
@@ -113,10 +122,11 @@ func insertUser(t *testing.T, tx *sql.Tx, user *domain.User) *domain.User {
 
 func insertRole(t *testing.T, tx *sql.Tx, role *domain.Role) *domain.Role {
 	require.NoError(t, tx.QueryRow(
-		"INSERT INTO roles (title, key, description, created_by) VALUES ($1, $2, $3, $4) RETURNING id, uuid",
+		"INSERT INTO roles (title, key, description, parent_id, created_by) VALUES ($1, $2, $3, $4, $5) RETURNING id, uuid",
 		role.Title,
 		role.Key,
 		role.Description,
+		role.ParentID,
 		role.Modifier.CreatedBy,
 	).Scan(&role.Base.ID, &role.Base.UUID))
 
@@ -27,7 +27,7 @@ func (r *RoleRepositoryTestSuite) TestRoleRepository_Create_Success() {
 	})
 
 	repo := authrepository.NewRoleRepository(mockLogger, r.GetTx())
-	err := repo.Create(domain.Role{
+	created, err := repo.Create(domain.Role{
 		Title:       "Admin",
 		Key:         "admin",
 		Description: "Administrator Role",
@@ -36,6 +36,9 @@ func (r *RoleRepositoryTestSuite) TestRoleRepository_Create_Success() {
 		},
 	})
 	require.NoError(r.T(), err)
+	require.NotZero(r.T(), created.Base.ID)
+	require.NotEqual(r.T(), uuid.Nil, created.Base.UUID)
+	require.Equal(r.T(), "admin", string(created.Key))
 }
 
 func (r *RoleRepositoryTestSuite) TestRoleRepository_Create_DBError() {
@@ -46,7 +49,7 @@ func (r *RoleRepositoryTestSuite) TestRoleRepository_Create_DBError() {
 	require.NoError(r.T(), err)
 
 	repo := authrepository.NewRoleRepository(mockLogger, r.GetTx())
-	err = repo.Create(domain.Role{
+	_, err = repo.Create(domain.Role{
 		Title:       "Admin",
 		Key:         "admin",
 		Description: "Administrator Role",
@@ -81,7 +84,7 @@ func (r *RoleRepositoryTestSuite) TestRoleRepository_Create_NoRowsAffected() {
 	require.NoError(r.T(), err)
 
 	repo := authrepository.NewRoleRepository(mockLogger, r.GetTx())
-	err = repo.Create(domain.Role{
+	_, err = repo.Create(domain.Role{
 		Title:       "Admin",
 		Key:         "admin",
 		Description: "Administrator Role",
@@ -547,6 +550,170 @@ func (r *RoleRepositoryTestSuite) TestRoleRepository_GetUserRoleKeys_DBError() {
 	mockLogger.AssertExpectations(r.T())
 }
 
+func (r *RoleRepositoryTestSuite) TestRoleRepository_GetUserRoleKeys_InheritedRoles() {
+	mockLogger := new(logger.MockLogger)
+
+	user := insertUser(r.T(), r.GetTx(), &domain.User{
+		FirstName: helper.StringPtr("John"),
+		LastName:  helper.StringPtr("Doe"),
+		Email:     "john.doe@example.com",
+		Password:  helper.StringPtr("hashedPassword"),
+		Status:    domain.UserStatusActive,
+	})
+
+	parentRole := insertRole(r.T(), r.GetTx(), &domain.Role{
+		Title:       "Team Lead",
+		Key:         "TEAM_LEAD",
+		Description: "Team Lead Role",
+	})
+	childRole := insertRole(r.T(), r.GetTx(), &domain.Role{
+		Title:       "Head of Team",
+		Key:         "HEAD_OF_TEAM",
+		Description: "Head of Team Role",
+		ParentID:    &parentRole.Base.ID,
+	})
+
+	addRoleToUser(r.T(), r.GetTx(), user.Base.ID, childRole.Base.ID)
+
+	repo := authrepository.NewRoleRepository(mockLogger, r.GetTx())
+	keys, err := repo.GetUserRoleKeys(user.Base.ID)
+
+	require.NoError(r.T(), err)
+	require.ElementsMatch(r.T(), []domain.RoleKeyType{childRole.Key, parentRole.Key}, keys)
+}
+
+func (r *RoleRepositoryTestSuite) TestRoleRepository_GetAncestors_Success() {
+	mockLogger := new(logger.MockLogger)
+
+	grandParentRole := insertRole(r.T(), r.GetTx(), &domain.Role{
+		Title:       "Team Member",
+		Key:         "TEAM_MEMBER",
+		Description: "Team Member Role",
+	})
+	parentRole := insertRole(r.T(), r.GetTx(), &domain.Role{
+		Title:       "Team Lead",
+		Key:         "TEAM_LEAD",
+		Description: "Team Lead Role",
+		ParentID:    &grandParentRole.Base.ID,
+	})
+	childRole := insertRole(r.T(), r.GetTx(), &domain.Role{
+		Title:       "Head of Team",
+		Key:         "HEAD_OF_TEAM",
+		Description: "Head of Team Role",
+		ParentID:    &parentRole.Base.ID,
+	})
+
+	repo := authrepository.NewRoleRepository(mockLogger, r.GetTx())
+	ancestors, err := repo.GetAncestors(childRole.Base.UUID)
+
+	require.NoError(r.T(), err)
+	require.Len(r.T(), ancestors, 2)
+	require.Equal(r.T(), parentRole.Base.UUID, ancestors[0].Base.UUID)
+	require.Equal(r.T(), grandParentRole.Base.UUID, ancestors[1].Base.UUID)
+}
+
+func (r *RoleRepositoryTestSuite) TestRoleRepository_GetAncestors_DBError() {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := r.GetTx().Exec("DROP TABLE IF EXISTS roles CASCADE")
+	require.NoError(r.T(), err)
+
+	repo := authrepository.NewRoleRepository(mockLogger, r.GetTx())
+	ancestors, err := repo.GetAncestors(uuid.New())
+
+	require.Error(r.T(), err)
+	require.Equal(r.T(), serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+	require.Nil(r.T(), ancestors)
+
+	mockLogger.AssertExpectations(r.T())
+}
+
+func (r *RoleRepositoryTestSuite) TestRoleRepository_GetSubtreeHeight_Success() {
+	mockLogger := new(logger.MockLogger)
+
+	rootRole := insertRole(r.T(), r.GetTx(), &domain.Role{
+		Title:       "Team Member",
+		Key:         "TEAM_MEMBER",
+		Description: "Team Member Role",
+	})
+	parentRole := insertRole(r.T(), r.GetTx(), &domain.Role{
+		Title:       "Team Lead",
+		Key:         "TEAM_LEAD",
+		Description: "Team Lead Role",
+		ParentID:    &rootRole.Base.ID,
+	})
+	insertRole(r.T(), r.GetTx(), &domain.Role{
+		Title:       "Reviewer",
+		Key:         "REVIEWER",
+		Description: "Reviewer Role",
+		ParentID:    &rootRole.Base.ID,
+	})
+	childRole := insertRole(r.T(), r.GetTx(), &domain.Role{
+		Title:       "Head of Team",
+		Key:         "HEAD_OF_TEAM",
+		Description: "Head of Team Role",
+		ParentID:    &parentRole.Base.ID,
+	})
+
+	repo := authrepository.NewRoleRepository(mockLogger, r.GetTx())
+
+	height, err := repo.GetSubtreeHeight(rootRole.Base.UUID)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), 2, height)
+
+	height, err = repo.GetSubtreeHeight(childRole.Base.UUID)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), 0, height)
+}
+
+func (r *RoleRepositoryTestSuite) TestRoleRepository_GetSubtreeHeight_DBError() {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := r.GetTx().Exec("DROP TABLE IF EXISTS roles CASCADE")
+	require.NoError(r.T(), err)
+
+	repo := authrepository.NewRoleRepository(mockLogger, r.GetTx())
+	height, err := repo.GetSubtreeHeight(uuid.New())
+
+	require.Error(r.T(), err)
+	require.Equal(r.T(), serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+	require.Zero(r.T(), height)
+
+	mockLogger.AssertExpectations(r.T())
+}
+
+func (r *RoleRepositoryTestSuite) TestRoleRepository_AttachPermission_Success() {
+	mockLogger := new(logger.MockLogger)
+
+	role := insertRole(r.T(), r.GetTx(), &domain.Role{
+		Title:       "Editor",
+		Key:         "EDITOR",
+		Description: "Editor Role",
+	})
+	permission := insertPermission(r.T(), r.GetTx(), &domain.Permission{
+		Title:       helper.StringPtr("Publish sentence"),
+		Key:         (*domain.PermissionKeyType)(helper.StringPtr("PUBLISH_SENTENCE")),
+		Description: helper.StringPtr("Publish a sentence"),
+	})
+
+	repo := authrepository.NewRoleRepository(mockLogger, r.GetTx())
+	attached, err := repo.AttachPermission(*permission.Key, role.Key)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), []domain.RoleKeyType{role.Key}, attached)
+
+	// attaching twice is a no-op
+	attached, err = repo.AttachPermission(*permission.Key, role.Key)
+	require.NoError(r.T(), err)
+	require.Empty(r.T(), attached)
+
+	rolePermissions, err := repo.GetPermissions(role.Base.UUID)
+	require.NoError(r.T(), err)
+	require.Len(r.T(), rolePermissions.Permissions, 1)
+	require.Equal(r.T(), permission.Base.UUID, rolePermissions.Permissions[0].Base.UUID)
+}
+
 func (r *RoleRepositoryTestSuite) TestRoleRepository_SyncPermissions_Success() {
 	mockLogger := new(logger.MockLogger)
 
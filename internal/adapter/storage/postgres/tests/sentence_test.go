@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/sentencerepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type SentenceRepositoryTestSuite struct {
+	TestSuite
+}
+
+func (r *SentenceRepositoryTestSuite) insertSentence(createdBy *uint64) domain.Sentence {
+	var grammarID uint64
+	require.NoError(r.T(), r.GetTx().QueryRow(
+		"INSERT INTO grammars (title, created_by) VALUES ($1, $2) RETURNING id",
+		"Present perfect continuous",
+		createdBy,
+	).Scan(&grammarID))
+
+	sentence := domain.Sentence{
+		Modifier: domain.Modifier{CreatedBy: createdBy},
+		Text:     "I have been living here for ten years.",
+		Grammar:  domain.Grammar{Base: domain.Base{ID: grammarID}},
+		Level:    domain.SentenceLevelEasy,
+		Status:   domain.StatusActive,
+	}
+	require.NoError(r.T(), r.GetTx().QueryRow(
+		"INSERT INTO sentences (text, level, grammar_id, created_by) VALUES ($1, $2, $3, $4) RETURNING id, uuid",
+		sentence.Text,
+		sentence.Level,
+		grammarID,
+		createdBy,
+	).Scan(&sentence.Base.ID, &sentence.Base.UUID))
+
+	return sentence
+}
+
+func (r *SentenceRepositoryTestSuite) TestSentenceRepository_GetByUUID_Update() {
+	mockLogger := new(logger.MockLogger)
+	user := insertUser(r.T(), r.GetTx(), &domain.User{
+		FirstName: helper.StringPtr("John"),
+		LastName:  helper.StringPtr("Doe"),
+		Email:     "john.doe@example.com",
+		Status:    domain.UserStatusActive,
+	})
+	sentence := r.insertSentence(&user.Base.ID)
+
+	repo := sentencerepository.NewSentenceRepository(mockLogger, r.GetTx())
+	stored, err := repo.GetByUUID(sentence.Base.UUID)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), sentence, *stored)
+
+	stored.Text = "I have lived here for ten years."
+	stored.Level = domain.SentenceLevelNormal
+	stored.Modifier.UpdatedBy = user.Base.ID
+	require.NoError(r.T(), repo.Update(*stored))
+
+	updated, err := repo.GetByUUID(sentence.Base.UUID)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), "I have lived here for ten years.", updated.Text)
+	require.Equal(r.T(), domain.SentenceLevelNormal, updated.Level)
+}
+
+func (r *SentenceRepositoryTestSuite) TestSentenceRepository_GetByUUID_WithoutCreator() {
+	mockLogger := new(logger.MockLogger)
+	sentence := r.insertSentence(nil)
+
+	stored, err := sentencerepository.NewSentenceRepository(mockLogger, r.GetTx()).GetByUUID(sentence.Base.UUID)
+	require.NoError(r.T(), err)
+	require.Nil(r.T(), stored.Modifier.CreatedBy)
+}
+
+func (r *SentenceRepositoryTestSuite) TestSentenceRepository_Delete() {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	user := insertUser(r.T(), r.GetTx(), &domain.User{
+		FirstName: helper.StringPtr("John"),
+		LastName:  helper.StringPtr("Doe"),
+		Email:     "john.doe@example.com",
+		Status:    domain.UserStatusActive,
+	})
+	sentence := r.insertSentence(&user.Base.ID)
+
+	repo := sentencerepository.NewSentenceRepository(mockLogger, r.GetTx())
+	require.NoError(r.T(), repo.Delete(sentence.Base.ID, user.Base.ID))
+
+	_, err := repo.GetByUUID(sentence.Base.UUID)
+	require.Equal(r.T(), serviceerror.New(serviceerror.RecordNotFound), err)
+
+	require.Equal(r.T(), serviceerror.New(serviceerror.RecordNotFound), repo.Delete(sentence.Base.ID, user.Base.ID))
+	_, err = repo.GetByUUID(uuid.New())
+	require.Equal(r.T(), serviceerror.New(serviceerror.RecordNotFound), err)
+}
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/userrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"time"
+)
+
+type UploadSessionRepositoryTestSuite struct {
+	TestSuite
+}
+
+func (r *UploadSessionRepositoryTestSuite) newSession() domain.UploadSession {
+	user := insertUser(r.T(), r.GetTx(), &domain.User{
+		FirstName: helper.StringPtr("John"),
+		LastName:  helper.StringPtr("Doe"),
+		Email:     "john.doe@example.com",
+		Status:    domain.UserStatusActive,
+	})
+
+	sessionUUID := uuid.New()
+	return domain.UploadSession{
+		Base:        domain.Base{UUID: sessionUUID},
+		UserID:      user.Base.ID,
+		Purpose:     domain.UploadPurposeSentenceAudio,
+		Key:         domain.UploadStagingKey(domain.UploadPurposeSentenceAudio, sessionUUID),
+		ContentType: "audio/mpeg",
+		Size:        482133,
+		Checksum:    "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		Status:      domain.UploadSessionStatusPending,
+		ExpiresAt:   time.Now().Add(15 * time.Minute).UTC().Truncate(time.Second),
+	}
+}
+
+func (r *UploadSessionRepositoryTestSuite) TestUploadSessionRepository_Create_GetByUUID_MarkCompleted() {
+	mockLogger := new(logger.MockLogger)
+	session := r.newSession()
+
+	repo := userrepository.NewUploadSessionRepository(mockLogger, r.GetTx())
+	require.NoError(r.T(), repo.Create(session))
+
+	stored, err := repo.GetByUUID(session.Base.UUID)
+	require.NoError(r.T(), err)
+	require.NotZero(r.T(), stored.Base.ID)
+	require.Equal(r.T(), session.Key, stored.Key)
+	require.Equal(r.T(), session.Checksum, stored.Checksum)
+	require.Equal(r.T(), domain.UploadSessionStatusPending, stored.Status)
+	require.True(r.T(), session.ExpiresAt.Equal(stored.ExpiresAt))
+	require.Nil(r.T(), stored.CompletedAt)
+
+	key := domain.UploadKey(session.Purpose, session.Base.UUID)
+	require.NoError(r.T(), repo.MarkCompleted(stored.Base.ID, key))
+
+	stored, err = repo.GetByUUID(session.Base.UUID)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), domain.UploadSessionStatusCompleted, stored.Status)
+	require.Equal(r.T(), key, stored.Key)
+	require.NotNil(r.T(), stored.CompletedAt)
+}
+
+func (r *UploadSessionRepositoryTestSuite) TestUploadSessionRepository_MarkAbandoned_NotPending() {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	session := r.newSession()
+
+	repo := userrepository.NewUploadSessionRepository(mockLogger, r.GetTx())
+	require.NoError(r.T(), repo.Create(session))
+
+	stored, err := repo.GetByUUID(session.Base.UUID)
+	require.NoError(r.T(), err)
+	require.NoError(r.T(), repo.MarkAbandoned(stored.Base.ID))
+
+	err = repo.MarkCompleted(stored.Base.ID, domain.UploadKey(session.Purpose, session.Base.UUID))
+	require.Error(r.T(), err)
+	require.Equal(r.T(), serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+}
+
+func (r *UploadSessionRepositoryTestSuite) TestUploadSessionRepository_GetByUUID_NotFound() {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	repo := userrepository.NewUploadSessionRepository(mockLogger, r.GetTx())
+	session, err := repo.GetByUUID(uuid.New())
+
+	require.Nil(r.T(), session)
+	require.Error(r.T(), err)
+	require.Equal(r.T(), serviceerror.RecordNotFound, err.(*serviceerror.ServiceError).GetErrorMessage())
+}
@@ -0,0 +1,225 @@
+package tests
+
+import (
+	"database/sql"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/auditrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/emailrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/outboxrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/passwordrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/userrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type UserDataRepositoryTestSuite struct {
+	TestSuite
+}
+
+func (r *UserDataRepositoryTestSuite) TestUserDataRepository_GetExport() {
+	mockLogger := new(logger.MockLogger)
+
+	user := insertUser(r.T(), r.GetTx(), &domain.User{
+		FirstName: helper.StringPtr("John"),
+		LastName:  helper.StringPtr("Doe"),
+		Email:     "john.doe@example.com",
+		Status:    domain.UserStatusActive,
+	})
+	role := insertRole(r.T(), r.GetTx(), &domain.Role{Title: "Learner", Key: "LEARNER", Description: "Learner role"})
+	addRoleToUser(r.T(), r.GetTx(), user.Base.ID, role.Base.ID)
+
+	require.NoError(r.T(), auditrepository.NewAuditLogRepository(mockLogger, r.GetTx()).Create(domain.NewAuditLog(
+		domain.AuditActor{UserID: &user.Base.ID, IP: "127.0.0.1", UserAgent: "Go-http-client/1.1"},
+		domain.AuditActionUserLoggedIn,
+		domain.AuditTargetUser,
+		&user.Base.UUID,
+		nil,
+		nil,
+	)))
+
+	var grammarID uint64
+	require.NoError(r.T(), r.GetTx().QueryRow(
+		"INSERT INTO grammars (title, created_by) VALUES ($1, $2) RETURNING id",
+		"Present Simple",
+		user.Base.ID,
+	).Scan(&grammarID))
+	_, err := r.GetTx().Exec(
+		"INSERT INTO sentences (text, grammar_id, created_by) VALUES ($1, $2, $3)",
+		"I learn every day.",
+		grammarID,
+		user.Base.ID,
+	)
+	require.NoError(r.T(), err)
+
+	require.NoError(r.T(), userrepository.NewNotificationRepository(mockLogger, r.GetTx()).Create(&domain.Notification{
+		UserID: user.Base.ID,
+		Type:   domain.NotificationTypeAssignment,
+		Title:  "New assignment",
+		Body:   "Translate ten sentences by Friday.",
+		Link:   helper.StringPtr("/assignments/1"),
+	}))
+	require.NoError(r.T(), userrepository.NewNotificationPreferenceRepository(mockLogger, r.GetTx()).Upsert(
+		domain.NotificationPreference{
+			UserID:   user.Base.ID,
+			Category: domain.NotificationCategoryProduct,
+			Enabled:  false,
+		},
+	))
+
+	repo := userrepository.NewUserDataRepository(mockLogger, r.GetTx())
+	export, err := repo.GetExport(user.Base.ID)
+	require.NoError(r.T(), err)
+
+	require.Equal(r.T(), user.Base.UUID, export.Profile.UUID)
+	require.Equal(r.T(), "john.doe@example.com", export.Profile.Email)
+	require.Equal(r.T(), "John", *export.Profile.FirstName)
+
+	require.Len(r.T(), export.Roles, 1)
+	require.Equal(r.T(), "LEARNER", export.Roles[0].Key)
+
+	require.Len(r.T(), export.Sessions, 1)
+	require.Equal(r.T(), domain.AuditActionUserLoggedIn, export.Sessions[0].Action)
+	require.Equal(r.T(), "127.0.0.1", *export.Sessions[0].IP)
+
+	require.Len(r.T(), export.Sentences, 1)
+	require.Equal(r.T(), "I learn every day.", export.Sentences[0].Text)
+	require.Equal(r.T(), "Present Simple", export.Sentences[0].Grammar)
+
+	require.Len(r.T(), export.Notifications, 1)
+	require.Equal(r.T(), domain.NotificationTypeAssignment, export.Notifications[0].Type)
+	require.Equal(r.T(), "New assignment", export.Notifications[0].Title)
+	require.Equal(r.T(), "/assignments/1", *export.Notifications[0].Link)
+	require.Nil(r.T(), export.Notifications[0].ReadAt)
+
+	require.Len(r.T(), export.NotificationPreferences, 1)
+	require.Equal(r.T(), domain.NotificationCategoryProduct, export.NotificationPreferences[0].Category)
+	require.False(r.T(), export.NotificationPreferences[0].Enabled)
+}
+
+func (r *UserDataRepositoryTestSuite) TestUserDataRepository_GetExport_NotFound() {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	repo := userrepository.NewUserDataRepository(mockLogger, r.GetTx())
+	export, err := repo.GetExport(0)
+
+	require.Error(r.T(), err)
+	require.Nil(r.T(), export)
+	require.Equal(r.T(), serviceerror.RecordNotFound, err.(*serviceerror.ServiceError).GetErrorMessage())
+}
+
+func (r *UserDataRepositoryTestSuite) TestUserDataRepository_Erase() {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	admin := insertUser(r.T(), r.GetTx(), &domain.User{
+		Email:  "admin@example.com",
+		Status: domain.UserStatusActive,
+	})
+	user := insertUser(r.T(), r.GetTx(), &domain.User{
+		FirstName: helper.StringPtr("John"),
+		LastName:  helper.StringPtr("Doe"),
+		Email:     "john.doe@example.com",
+		Password:  helper.StringPtr("hashedPassword"),
+		Avatar:    helper.StringPtr("http://minio:9000/bucket/avatar.png"),
+		Status:    domain.UserStatusActive,
+	})
+	createdBy := user.Base.ID
+	role := insertRole(r.T(), r.GetTx(), &domain.Role{
+		Title:       "Learner",
+		Key:         "LEARNER",
+		Description: "Learner role",
+		Modifier:    domain.Modifier{CreatedBy: &createdBy},
+	})
+	addRoleToUser(r.T(), r.GetTx(), user.Base.ID, role.Base.ID)
+	require.NoError(r.T(), passwordrepository.NewPasswordHistoryRepository(mockLogger, r.GetTx()).Create(user.Base.ID, "old"))
+	require.NoError(r.T(), userrepository.NewNotificationRepository(mockLogger, r.GetTx()).Create(&domain.Notification{
+		UserID: user.Base.ID,
+		Type:   domain.NotificationTypeRoleChanged,
+		Title:  "Your role changed",
+		Body:   "John, you are a learner now.",
+	}))
+	require.NoError(r.T(), auditrepository.NewAuditLogRepository(mockLogger, r.GetTx()).Create(domain.NewAuditLog(
+		domain.AuditActor{UserID: &user.Base.ID, IP: "127.0.0.1", UserAgent: "Go-http-client/1.1"},
+		domain.AuditActionUserLoggedIn,
+		domain.AuditTargetUser,
+		&user.Base.UUID,
+		nil,
+		nil,
+	)))
+
+	require.NoError(r.T(), emailrepository.NewEmailSuppressionRepository(mockLogger, r.GetTx()).Create(domain.EmailSuppression{
+		Email:  "John.Doe@example.com",
+		Reason: domain.EmailSuppressionReasonBounce,
+	}))
+	outboxRepo := outboxrepository.NewOutboxRepository(mockLogger, r.GetTx())
+	require.NoError(r.T(), outboxRepo.Create(domain.OutboxMessage{
+		Queue:   "send_email",
+		Payload: []byte(`{"payload":{"to":"John.Doe@example.com","name":"John Doe"}}`),
+	}))
+	require.NoError(r.T(), outboxRepo.Create(domain.OutboxMessage{
+		Queue:   "send_email",
+		Payload: []byte(`{"payload":{"to":"admin@example.com","name":"Admin"}}`),
+	}))
+	require.NoError(r.T(), outboxRepo.Create(domain.OutboxMessage{
+		Queue:   "send_email",
+		Payload: []byte(`{"payload":{"to":"jimbob.john.doe@example.com.au","name":"Jim Bob"}}`),
+	}))
+
+	repo := userrepository.NewUserDataRepository(mockLogger, r.GetTx())
+	erased, err := repo.Erase(user.Base.UUID, &admin.Base.ID)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), user.Base.ID, erased.Base.ID)
+	require.Equal(r.T(), "http://minio:9000/bucket/avatar.png", *erased.Avatar)
+
+	var firstName, password, avatar sql.NullString
+	var email string
+	var status domain.UserStatusType
+	var deletedBy sql.NullInt64
+	require.NoError(r.T(), r.GetTx().QueryRow(
+		"SELECT first_name, password, avatar, email, status, deleted_by FROM users WHERE id = $1",
+		user.Base.ID,
+	).Scan(&firstName, &password, &avatar, &email, &status, &deletedBy))
+	require.False(r.T(), firstName.Valid)
+	require.False(r.T(), password.Valid)
+	require.False(r.T(), avatar.Valid)
+	require.Equal(r.T(), domain.ErasedEmail(user.Base.UUID), email)
+	require.Equal(r.T(), domain.UserStatusInactive, status)
+	require.Equal(r.T(), int64(admin.Base.ID), deletedBy.Int64)
+
+	var roleCreatedBy uint64
+	require.NoError(r.T(), r.GetTx().QueryRow("SELECT created_by FROM roles WHERE id = $1", role.Base.ID).Scan(&roleCreatedBy))
+	require.Equal(r.T(), user.Base.ID, roleCreatedBy)
+
+	var count int
+	require.NoError(r.T(), r.GetTx().QueryRow("SELECT COUNT(*) FROM access_controls WHERE user_id = $1", user.Base.ID).Scan(&count))
+	require.Zero(r.T(), count)
+	require.NoError(r.T(), r.GetTx().QueryRow("SELECT COUNT(*) FROM password_histories WHERE user_id = $1", user.Base.ID).Scan(&count))
+	require.Zero(r.T(), count)
+	require.NoError(r.T(), r.GetTx().QueryRow("SELECT COUNT(*) FROM notifications WHERE user_id = $1", user.Base.ID).Scan(&count))
+	require.Zero(r.T(), count)
+	require.NoError(r.T(), r.GetTx().QueryRow(
+		"SELECT COUNT(*) FROM email_suppressions WHERE email = 'john.doe@example.com'",
+	).Scan(&count))
+	require.Equal(r.T(), 1, count)
+	require.NoError(r.T(), r.GetTx().QueryRow(
+		"SELECT COUNT(*) FROM outbox_messages WHERE LOWER(payload->'payload'->>'to') = 'john.doe@example.com'",
+	).Scan(&count))
+	require.Zero(r.T(), count)
+	require.NoError(r.T(), r.GetTx().QueryRow(
+		"SELECT COUNT(*) FROM outbox_messages WHERE payload->'payload'->>'to' IN ('admin@example.com', 'jimbob.john.doe@example.com.au')",
+	).Scan(&count))
+	require.Equal(r.T(), 2, count)
+	require.NoError(r.T(), r.GetTx().QueryRow(
+		"SELECT COUNT(*) FROM audit_logs WHERE actor_id = $1 AND (ip IS NOT NULL OR user_agent IS NOT NULL)",
+		user.Base.ID,
+	).Scan(&count))
+	require.Zero(r.T(), count)
+
+	_, err = repo.Erase(user.Base.UUID, &admin.Base.ID)
+	require.Error(r.T(), err)
+	require.Equal(r.T(), serviceerror.RecordNotFound, err.(*serviceerror.ServiceError).GetErrorMessage())
+}
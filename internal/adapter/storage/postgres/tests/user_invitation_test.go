@@ -0,0 +1,149 @@
+package tests
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/userrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"time"
+)
+
+type UserInvitationRepositoryTestSuite struct {
+	TestSuite
+}
+
+func (r *UserInvitationRepositoryTestSuite) insertInvitedUser() *domain.User {
+	return insertUser(r.T(), r.GetTx(), &domain.User{
+		FirstName: helper.StringPtr("John"),
+		LastName:  helper.StringPtr("Doe"),
+		Email:     "john.doe@example.com",
+		Status:    domain.UserStatusUnverified,
+	})
+}
+
+func (r *UserInvitationRepositoryTestSuite) TestUserInvitationRepository_Lifecycle() {
+	mockLogger := new(logger.MockLogger)
+
+	user := r.insertInvitedUser()
+	repo := userrepository.NewUserInvitationRepository(mockLogger, r.GetTx())
+
+	tokenHash := helper.HashToken("first-token")
+	require.NoError(r.T(), repo.Create(domain.UserInvitation{
+		UserID:    user.Base.ID,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}))
+
+	invitation, err := repo.GetOpenByUserUUID(user.Base.UUID)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), user.Base.ID, invitation.UserID)
+	require.Equal(r.T(), user.Email, invitation.User.Email)
+	require.Equal(r.T(), uint(1), invitation.SentCount)
+	require.Equal(r.T(), domain.UserInvitationStatusPending, invitation.Status(time.Now()))
+
+	refreshedHash := helper.HashToken("second-token")
+	require.NoError(r.T(), repo.Refresh(invitation.Base.ID, refreshedHash, time.Now().Add(2*time.Hour)))
+
+	invitation, err = repo.GetByTokenHash(refreshedHash)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), uint(2), invitation.SentCount)
+
+	require.NoError(r.T(), repo.Accept(invitation.Base.ID))
+
+	invitation, err = repo.GetByTokenHash(refreshedHash)
+	require.NoError(r.T(), err)
+	require.NotNil(r.T(), invitation.AcceptedAt)
+	require.Equal(r.T(), domain.UserInvitationStatusAccepted, invitation.Status(time.Now()))
+}
+
+func (r *UserInvitationRepositoryTestSuite) TestUserInvitationRepository_Revoke() {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	admin := insertUser(r.T(), r.GetTx(), &domain.User{
+		Email:  "admin@example.com",
+		Status: domain.UserStatusActive,
+	})
+	user := r.insertInvitedUser()
+	repo := userrepository.NewUserInvitationRepository(mockLogger, r.GetTx())
+
+	require.NoError(r.T(), repo.Create(domain.UserInvitation{
+		UserID:    user.Base.ID,
+		TokenHash: helper.HashToken("token"),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}))
+
+	invitation, err := repo.GetOpenByUserUUID(user.Base.UUID)
+	require.NoError(r.T(), err)
+
+	require.NoError(r.T(), repo.Revoke(invitation.Base.ID, admin.Base.ID))
+
+	err = repo.Revoke(invitation.Base.ID, admin.Base.ID)
+	require.Error(r.T(), err)
+	require.Equal(r.T(), serviceerror.InvitationInvalid, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+	_, err = repo.GetOpenByUserUUID(user.Base.UUID)
+	require.Error(r.T(), err)
+	require.Equal(r.T(), serviceerror.RecordNotFound, err.(*serviceerror.ServiceError).GetErrorMessage())
+}
+
+func (r *UserInvitationRepositoryTestSuite) TestUserInvitationRepository_GetByTokenHash_RecordNotFound() {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	repo := userrepository.NewUserInvitationRepository(mockLogger, r.GetTx())
+	invitation, err := repo.GetByTokenHash(helper.HashToken("unknown"))
+
+	require.Error(r.T(), err)
+	require.Nil(r.T(), invitation)
+	require.Equal(r.T(), serviceerror.RecordNotFound, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+	mockLogger.AssertExpectations(r.T())
+}
+
+func (r *UserInvitationRepositoryTestSuite) TestUserInvitationRepository_AssignRoles() {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	user := r.insertInvitedUser()
+	role := insertRole(r.T(), r.GetTx(), &domain.Role{
+		Title:       "Editor",
+		Key:         "editor",
+		Description: "Editor Role",
+	})
+	repo := userrepository.NewUserInvitationRepository(mockLogger, r.GetTx())
+
+	require.NoError(r.T(), repo.AssignRoles(user.Base.ID, []uuid.UUID{role.Base.UUID}))
+
+	var count int
+	require.NoError(r.T(), r.GetTx().QueryRow(
+		"SELECT COUNT(*) FROM access_controls WHERE user_id = $1 AND role_id = $2",
+		user.Base.ID,
+		role.Base.ID,
+	).Scan(&count))
+	require.Equal(r.T(), 1, count)
+
+	err := repo.AssignRoles(user.Base.ID, []uuid.UUID{uuid.New()})
+	require.Error(r.T(), err)
+	require.Equal(r.T(), serviceerror.RecordNotFound, err.(*serviceerror.ServiceError).GetErrorMessage())
+}
+
+func (r *UserInvitationRepositoryTestSuite) TestUserInvitationRepository_AssignDefaultRole() {
+	mockLogger := new(logger.MockLogger)
+
+	user := r.insertInvitedUser()
+	repo := userrepository.NewUserInvitationRepository(mockLogger, r.GetTx())
+
+	require.NoError(r.T(), repo.AssignDefaultRole(user.Base.ID))
+
+	var roleKey domain.RoleKeyType
+	require.NoError(r.T(), r.GetTx().QueryRow(
+		"SELECT r.key FROM access_controls AS ac JOIN roles AS r ON r.id = ac.role_id WHERE ac.user_id = $1",
+		user.Base.ID,
+	).Scan(&roleKey))
+	require.Equal(r.T(), domain.RoleKeyUser, roleKey)
+}
@@ -628,3 +628,44 @@ func (r *UserRepositoryTestSuite) TestUserRepository_UpdatePassword_NoRowsAffect
 
 	mockLogger.AssertExpectations(r.T())
 }
+
+func (r *UserRepositoryTestSuite) TestUserRepository_UpdateAvatar_Success() {
+	mockLogger := new(logger.MockLogger)
+
+	oldAvatar := "avatars/0a1b2c.png"
+	user := insertUser(r.T(), r.GetTx(), &domain.User{
+		Email:  "john.doe@example.com",
+		Avatar: helper.StringPtr(oldAvatar),
+		Status: domain.UserStatusActive,
+	})
+
+	repo := userrepository.NewUserRepository(mockLogger, r.GetTx())
+
+	inUse, err := repo.IsAvatarInUse(oldAvatar)
+	require.NoError(r.T(), err)
+	require.True(r.T(), inUse)
+
+	newAvatar := "avatars/3d4e5f.jpg"
+	require.NoError(r.T(), repo.UpdateAvatar(user.Base.ID, newAvatar))
+
+	fetchedUser, err := repo.GetByID(user.Base.ID)
+	require.NoError(r.T(), err)
+	require.Equal(r.T(), newAvatar, *fetchedUser.Avatar)
+
+	inUse, err = repo.IsAvatarInUse(oldAvatar)
+	require.NoError(r.T(), err)
+	require.False(r.T(), inUse)
+}
+
+func (r *UserRepositoryTestSuite) TestUserRepository_UpdateAvatar_UserNotFound() {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", logger.Database, logger.DatabaseUpdate, mock.Anything, mock.Anything).Return()
+
+	repo := userrepository.NewUserRepository(mockLogger, r.GetTx())
+	err := repo.UpdateAvatar(100_000, "avatars/0a1b2c.png")
+
+	require.Error(r.T(), err)
+	require.Equal(r.T(), serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+	mockLogger.AssertExpectations(r.T())
+}
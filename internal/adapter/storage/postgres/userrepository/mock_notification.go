@@ -0,0 +1,42 @@
+package userrepository
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (r *MockNotificationRepository) Create(notification *domain.Notification) error {
+	args := r.Called(notification)
+	return args.Error(0)
+}
+
+func (r *MockNotificationRepository) List(
+	userID uint64,
+	filter domain.NotificationFilter,
+) ([]*domain.Notification, uint64, error) {
+	args := r.Called(userID, filter)
+	if args.Get(0) != nil {
+		return args.Get(0).([]*domain.Notification), args.Get(1).(uint64), args.Error(2)
+	}
+	return nil, args.Get(1).(uint64), args.Error(2)
+}
+
+func (r *MockNotificationRepository) MarkRead(userID uint64, notificationUUIDs []uuid.UUID) error {
+	args := r.Called(userID, notificationUUIDs)
+	return args.Error(0)
+}
+
+func (r *MockNotificationRepository) MarkAllRead(userID uint64) error {
+	args := r.Called(userID)
+	return args.Error(0)
+}
+
+func (r *MockNotificationRepository) CountUnread(userID uint64) (uint64, error) {
+	args := r.Called(userID)
+	return args.Get(0).(uint64), args.Error(1)
+}
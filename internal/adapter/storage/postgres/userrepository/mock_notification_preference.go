@@ -0,0 +1,23 @@
+package userrepository
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationPreferenceRepository struct {
+	mock.Mock
+}
+
+func (r *MockNotificationPreferenceRepository) ListByUserID(userID uint64) ([]domain.NotificationPreference, error) {
+	args := r.Called(userID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]domain.NotificationPreference), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (r *MockNotificationPreferenceRepository) Upsert(preference domain.NotificationPreference) error {
+	args := r.Called(preference)
+	return args.Error(0)
+}
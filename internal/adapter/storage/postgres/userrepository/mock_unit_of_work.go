@@ -25,7 +25,66 @@ func (r *MockUnitOfWork) Rollback() error {
 	return args.Error(0)
 }
 
+func (r *MockUnitOfWork) AfterCommit(hook func() error) {
+	r.Called(hook)
+}
+
 func (r *MockUnitOfWork) UserRepository() port.UserRepository {
 	args := r.Called()
 	return args.Get(0).(port.UserRepository)
 }
+
+func (r *MockUnitOfWork) UserInvitationRepository() port.UserInvitationRepository {
+	args := r.Called()
+	return args.Get(0).(port.UserInvitationRepository)
+}
+
+func (r *MockUnitOfWork) UserDataRepository() port.UserDataRepository {
+	args := r.Called()
+	return args.Get(0).(port.UserDataRepository)
+}
+
+func (r *MockUnitOfWork) UploadSessionRepository() port.UploadSessionRepository {
+	args := r.Called()
+	return args.Get(0).(port.UploadSessionRepository)
+}
+
+func (r *MockUnitOfWork) NotificationPreferenceRepository() port.NotificationPreferenceRepository {
+	args := r.Called()
+	return args.Get(0).(port.NotificationPreferenceRepository)
+}
+
+func (r *MockUnitOfWork) NotificationRepository() port.NotificationRepository {
+	args := r.Called()
+	return args.Get(0).(port.NotificationRepository)
+}
+
+func (r *MockUnitOfWork) EmailDeliveryRepository() port.EmailDeliveryRepository {
+	args := r.Called()
+	return args.Get(0).(port.EmailDeliveryRepository)
+}
+
+func (r *MockUnitOfWork) EmailSuppressionRepository() port.EmailSuppressionRepository {
+	args := r.Called()
+	return args.Get(0).(port.EmailSuppressionRepository)
+}
+
+func (r *MockUnitOfWork) SentenceRepository() port.SentenceRepository {
+	args := r.Called()
+	return args.Get(0).(port.SentenceRepository)
+}
+
+func (r *MockUnitOfWork) AuditLogRepository() port.AuditLogRepository {
+	args := r.Called()
+	return args.Get(0).(port.AuditLogRepository)
+}
+
+func (r *MockUnitOfWork) PasswordHistoryRepository() port.PasswordHistoryRepository {
+	args := r.Called()
+	return args.Get(0).(port.PasswordHistoryRepository)
+}
+
+func (r *MockUnitOfWork) OutboxRepository() port.OutboxRepository {
+	args := r.Called()
+	return args.Get(0).(port.OutboxRepository)
+}
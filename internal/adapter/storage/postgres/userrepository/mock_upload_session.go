@@ -0,0 +1,31 @@
+package userrepository
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUploadSessionRepository struct {
+	mock.Mock
+}
+
+func (r *MockUploadSessionRepository) Create(session domain.UploadSession) error {
+	args := r.Called(session)
+	return args.Error(0)
+}
+
+func (r *MockUploadSessionRepository) GetByUUID(sessionUUID uuid.UUID) (*domain.UploadSession, error) {
+	args := r.Called(sessionUUID)
+	return args.Get(0).(*domain.UploadSession), args.Error(1)
+}
+
+func (r *MockUploadSessionRepository) MarkCompleted(id uint64, key string) error {
+	args := r.Called(id, key)
+	return args.Error(0)
+}
+
+func (r *MockUploadSessionRepository) MarkAbandoned(id uint64) error {
+	args := r.Called(id)
+	return args.Error(0)
+}
@@ -64,3 +64,18 @@ func (r *MockUserRepository) UpdatePassword(id uint64, password string) error {
 	args := r.Called(id, password)
 	return args.Error(0)
 }
+
+func (r *MockUserRepository) Activate(id uint64, password string) error {
+	args := r.Called(id, password)
+	return args.Error(0)
+}
+
+func (r *MockUserRepository) UpdateAvatar(id uint64, avatar string) error {
+	args := r.Called(id, avatar)
+	return args.Error(0)
+}
+
+func (r *MockUserRepository) IsAvatarInUse(avatar string) (bool, error) {
+	args := r.Called(avatar)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,21 @@
+package userrepository
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserDataRepository struct {
+	mock.Mock
+}
+
+func (r *MockUserDataRepository) GetExport(userID uint64) (*domain.UserDataExport, error) {
+	args := r.Called(userID)
+	return args.Get(0).(*domain.UserDataExport), args.Error(1)
+}
+
+func (r *MockUserDataRepository) Erase(userUUID uuid.UUID, erasedBy *uint64) (*domain.User, error) {
+	args := r.Called(userUUID, erasedBy)
+	return args.Get(0).(*domain.User), args.Error(1)
+}
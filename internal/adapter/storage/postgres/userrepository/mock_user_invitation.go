@@ -0,0 +1,52 @@
+package userrepository
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type MockUserInvitationRepository struct {
+	mock.Mock
+}
+
+func (r *MockUserInvitationRepository) Create(invitation domain.UserInvitation) error {
+	args := r.Called(invitation)
+	return args.Error(0)
+}
+
+func (r *MockUserInvitationRepository) GetByTokenHash(tokenHash string) (*domain.UserInvitation, error) {
+	args := r.Called(tokenHash)
+	return args.Get(0).(*domain.UserInvitation), args.Error(1)
+}
+
+func (r *MockUserInvitationRepository) GetOpenByUserUUID(userUUID uuid.UUID) (*domain.UserInvitation, error) {
+	args := r.Called(userUUID)
+	return args.Get(0).(*domain.UserInvitation), args.Error(1)
+}
+
+func (r *MockUserInvitationRepository) Refresh(id uint64, tokenHash string, expiresAt time.Time) error {
+	args := r.Called(id, tokenHash, expiresAt)
+	return args.Error(0)
+}
+
+func (r *MockUserInvitationRepository) Accept(id uint64) error {
+	args := r.Called(id)
+	return args.Error(0)
+}
+
+func (r *MockUserInvitationRepository) Revoke(id uint64, revokedBy uint64) error {
+	args := r.Called(id, revokedBy)
+	return args.Error(0)
+}
+
+func (r *MockUserInvitationRepository) AssignRoles(userID uint64, roleUUIDs []uuid.UUID) error {
+	args := r.Called(userID, roleUUIDs)
+	return args.Error(0)
+}
+
+func (r *MockUserInvitationRepository) AssignDefaultRole(userID uint64) error {
+	args := r.Called(userID)
+	return args.Error(0)
+}
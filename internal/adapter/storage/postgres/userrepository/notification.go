@@ -0,0 +1,196 @@
+package userrepository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/metrics"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"strconv"
+	"strings"
+)
+
+// NotificationRepository implements port.NotificationRepository interface and provides access to the postgres database
+type NotificationRepository struct {
+	log logger.Logger
+	tx  *sql.Tx
+}
+
+// NewNotificationRepository creates a new notification repository instance
+func NewNotificationRepository(log logger.Logger, tx *sql.Tx) *NotificationRepository {
+	return &NotificationRepository{
+		log: log,
+		tx:  tx,
+	}
+}
+
+// Create stores the notification and fills in its ID, UUID and CreatedAt.
+func (r *NotificationRepository) Create(notification *domain.Notification) error {
+	err := r.tx.QueryRow(
+		`INSERT INTO notifications (user_id, type, title, body, link) VALUES ($1, $2, $3, $4, $5)
+				RETURNING id, uuid, created_at`,
+		notification.UserID,
+		notification.Type,
+		notification.Title,
+		notification.Body,
+		notification.Link,
+	).Scan(&notification.Base.ID, &notification.Base.UUID, &notification.Base.CreatedAt)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("notifications", "Create", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), map[logger.ExtraKey]interface{}{
+			logger.InsertDBArg: notification,
+		})
+		return serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("notifications", "Create", "Success").Inc()
+
+	return nil
+}
+
+// List returns a page of the notifications of the user, newest first, together with their total.
+func (r *NotificationRepository) List(
+	userID uint64,
+	filter domain.NotificationFilter,
+) ([]*domain.Notification, uint64, error) {
+	where := "WHERE user_id = $1"
+	if filter.UnreadOnly {
+		where += " AND read_at IS NULL"
+	}
+
+	var total uint64
+	if err := r.tx.QueryRow("SELECT count(*) FROM notifications "+where, userID).Scan(&total); err != nil {
+		metrics.DbCall.WithLabelValues("notifications", "List", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, 0, serviceerror.NewServerError()
+	}
+
+	rows, err := r.tx.Query(
+		fmt.Sprintf(
+			`SELECT id, uuid, user_id, type, title, body, link, read_at, created_at FROM notifications %s
+					ORDER BY created_at DESC, id DESC
+					LIMIT $2 OFFSET $3`,
+			where,
+		),
+		userID,
+		filter.PerPage,
+		filter.Offset(),
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("notifications", "List", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, 0, serviceerror.NewServerError()
+	}
+	defer func(rows *sql.Rows) {
+		if err = rows.Close(); err != nil {
+			r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		}
+	}(rows)
+
+	var notifications []*domain.Notification
+	for rows.Next() {
+		var notification domain.Notification
+		var link sql.NullString
+		var readAt sql.NullTime
+		if err = rows.Scan(
+			&notification.Base.ID,
+			&notification.Base.UUID,
+			&notification.UserID,
+			&notification.Type,
+			&notification.Title,
+			&notification.Body,
+			&link,
+			&readAt,
+			&notification.Base.CreatedAt,
+		); err != nil {
+			metrics.DbCall.WithLabelValues("notifications", "List", "Failed").Inc()
+
+			r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+			return nil, 0, serviceerror.NewServerError()
+		}
+		if link.Valid {
+			notification.Link = &link.String
+		}
+		if readAt.Valid {
+			notification.ReadAt = &readAt.Time
+		}
+
+		notifications = append(notifications, &notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DbCall.WithLabelValues("notifications", "List", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, 0, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("notifications", "List", "Success").Inc()
+
+	return notifications, total, nil
+}
+
+// MarkRead marks the given notifications of the user as read, a UUID of another user's notification is skipped.
+func (r *NotificationRepository) MarkRead(userID uint64, notificationUUIDs []uuid.UUID) error {
+	if len(notificationUUIDs) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, len(notificationUUIDs)+1)
+	for _, notificationUUID := range notificationUUIDs {
+		args = append(args, notificationUUID)
+	}
+	args = append(args, userID)
+
+	placeholders := strings.Join(helper.MakeSQLPlaceholders(uint(len(notificationUUIDs))), ",")
+	query := `UPDATE notifications SET read_at = now()
+				WHERE user_id = $` + strconv.Itoa(len(args)) + ` AND read_at IS NULL AND uuid IN (` + placeholders + `)`
+	if _, err := r.tx.Exec(query, args...); err != nil {
+		metrics.DbCall.WithLabelValues("notifications", "MarkRead", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseUpdate, err.Error(), nil)
+		return serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("notifications", "MarkRead", "Success").Inc()
+
+	return nil
+}
+
+func (r *NotificationRepository) MarkAllRead(userID uint64) error {
+	_, err := r.tx.Exec(`UPDATE notifications SET read_at = now() WHERE user_id = $1 AND read_at IS NULL`, userID)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("notifications", "MarkAllRead", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseUpdate, err.Error(), nil)
+		return serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("notifications", "MarkAllRead", "Success").Inc()
+
+	return nil
+}
+
+func (r *NotificationRepository) CountUnread(userID uint64) (uint64, error) {
+	var count uint64
+	err := r.tx.QueryRow(
+		`SELECT count(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`,
+		userID,
+	).Scan(&count)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("notifications", "CountUnread", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return 0, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("notifications", "CountUnread", "Success").Inc()
+
+	return count, nil
+}
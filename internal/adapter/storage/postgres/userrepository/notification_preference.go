@@ -0,0 +1,87 @@
+package userrepository
+
+import (
+	"database/sql"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/metrics"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+)
+
+// NotificationPreferenceRepository implements port.NotificationPreferenceRepository interface and provides access to the postgres database
+type NotificationPreferenceRepository struct {
+	log logger.Logger
+	tx  *sql.Tx
+}
+
+// NewNotificationPreferenceRepository creates a new notification preference repository instance
+func NewNotificationPreferenceRepository(log logger.Logger, tx *sql.Tx) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{
+		log: log,
+		tx:  tx,
+	}
+}
+
+// ListByUserID returns the stored preferences of the user, a category the user never changed has no row.
+func (r *NotificationPreferenceRepository) ListByUserID(userID uint64) ([]domain.NotificationPreference, error) {
+	rows, err := r.tx.Query(
+		`SELECT user_id, category, enabled FROM notification_preferences WHERE user_id = $1 ORDER BY id`,
+		userID,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("notification_preferences", "ListByUserID", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, serviceerror.NewServerError()
+	}
+	defer func(rows *sql.Rows) {
+		if err = rows.Close(); err != nil {
+			r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		}
+	}(rows)
+
+	var preferences []domain.NotificationPreference
+	for rows.Next() {
+		var preference domain.NotificationPreference
+		if err = rows.Scan(&preference.UserID, &preference.Category, &preference.Enabled); err != nil {
+			metrics.DbCall.WithLabelValues("notification_preferences", "ListByUserID", "Failed").Inc()
+
+			r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+			return nil, serviceerror.NewServerError()
+		}
+		preferences = append(preferences, preference)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DbCall.WithLabelValues("notification_preferences", "ListByUserID", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return nil, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("notification_preferences", "ListByUserID", "Success").Inc()
+
+	return preferences, nil
+}
+
+func (r *NotificationPreferenceRepository) Upsert(preference domain.NotificationPreference) error {
+	_, err := r.tx.Exec(
+		`INSERT INTO notification_preferences (user_id, category, enabled) VALUES ($1, $2, $3)
+				ON CONFLICT (user_id, category) DO UPDATE SET enabled = EXCLUDED.enabled, updated_at = NOW()`,
+		preference.UserID,
+		preference.Category,
+		preference.Enabled,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("notification_preferences", "Upsert", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), map[logger.ExtraKey]interface{}{
+			logger.InsertDBArg: preference,
+		})
+		return serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("notification_preferences", "Upsert", "Success").Inc()
+
+	return nil
+}
@@ -3,6 +3,11 @@ package userrepository
 import (
 	"context"
 	"database/sql"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/auditrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/emailrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/outboxrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/passwordrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/sentencerepository"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
@@ -13,7 +18,20 @@ type unitOfWork struct {
 	db  *sql.DB
 	tx  *sql.Tx
 
-	userRepository port.UserRepository
+	afterCommit []func() error
+
+	userRepository                   port.UserRepository
+	userInvitationRepository         port.UserInvitationRepository
+	userDataRepository               port.UserDataRepository
+	uploadSessionRepository          port.UploadSessionRepository
+	notificationPreferenceRepository port.NotificationPreferenceRepository
+	notificationRepository           port.NotificationRepository
+	emailDeliveryRepository          port.EmailDeliveryRepository
+	emailSuppressionRepository       port.EmailSuppressionRepository
+	sentenceRepository               port.SentenceRepository
+	auditLogRepository               port.AuditLogRepository
+	passwordHistoryRepository        port.PasswordHistoryRepository
+	outboxRepository                 port.OutboxRepository
 	// Add other repositories as needed
 }
 
@@ -33,23 +51,45 @@ func (r *unitOfWork) BeginTx(ctx context.Context) error {
 	}
 
 	r.tx = tx
+	r.afterCommit = nil
 	r.userRepository = NewUserRepository(r.log, tx)
+	r.userInvitationRepository = NewUserInvitationRepository(r.log, tx)
+	r.userDataRepository = NewUserDataRepository(r.log, tx)
+	r.uploadSessionRepository = NewUploadSessionRepository(r.log, tx)
+	r.notificationPreferenceRepository = NewNotificationPreferenceRepository(r.log, tx)
+	r.notificationRepository = NewNotificationRepository(r.log, tx)
+	r.emailDeliveryRepository = emailrepository.NewEmailDeliveryRepository(r.log, tx)
+	r.emailSuppressionRepository = emailrepository.NewEmailSuppressionRepository(r.log, tx)
+	r.sentenceRepository = sentencerepository.NewSentenceRepository(r.log, tx)
+	r.auditLogRepository = auditrepository.NewAuditLogRepository(r.log, tx)
+	r.passwordHistoryRepository = passwordrepository.NewPasswordHistoryRepository(r.log, tx)
+	r.outboxRepository = outboxrepository.NewOutboxRepository(r.log, tx)
 	// Initialize other repositories as needed
 
 	return nil
 }
 
 func (r *unitOfWork) Commit() error {
+	hooks := r.afterCommit
+	r.afterCommit = nil
 
 	if err := r.tx.Commit(); err != nil {
 		r.log.Error(logger.Database, logger.DatabaseCommit, err.Error(), nil)
 		return serviceerror.NewServerError()
 	}
 
+	// the changes are stored already, a failing hook is logged and does not fail the commit
+	for _, hook := range hooks {
+		if err := hook(); err != nil {
+			r.log.Error(logger.Database, logger.DatabaseAfterCommit, err.Error(), nil)
+		}
+	}
+
 	return nil
 }
 
 func (r *unitOfWork) Rollback() error {
+	r.afterCommit = nil
 
 	if err := r.tx.Rollback(); err != nil {
 		r.log.Error(logger.Database, logger.DatabaseRollback, err.Error(), nil)
@@ -59,6 +99,54 @@ func (r *unitOfWork) Rollback() error {
 	return nil
 }
 
+func (r *unitOfWork) AfterCommit(hook func() error) {
+	r.afterCommit = append(r.afterCommit, hook)
+}
+
 func (r *unitOfWork) UserRepository() port.UserRepository {
 	return r.userRepository
 }
+
+func (r *unitOfWork) UserInvitationRepository() port.UserInvitationRepository {
+	return r.userInvitationRepository
+}
+
+func (r *unitOfWork) UserDataRepository() port.UserDataRepository {
+	return r.userDataRepository
+}
+
+func (r *unitOfWork) UploadSessionRepository() port.UploadSessionRepository {
+	return r.uploadSessionRepository
+}
+
+func (r *unitOfWork) NotificationPreferenceRepository() port.NotificationPreferenceRepository {
+	return r.notificationPreferenceRepository
+}
+
+func (r *unitOfWork) NotificationRepository() port.NotificationRepository {
+	return r.notificationRepository
+}
+
+func (r *unitOfWork) EmailDeliveryRepository() port.EmailDeliveryRepository {
+	return r.emailDeliveryRepository
+}
+
+func (r *unitOfWork) EmailSuppressionRepository() port.EmailSuppressionRepository {
+	return r.emailSuppressionRepository
+}
+
+func (r *unitOfWork) SentenceRepository() port.SentenceRepository {
+	return r.sentenceRepository
+}
+
+func (r *unitOfWork) AuditLogRepository() port.AuditLogRepository {
+	return r.auditLogRepository
+}
+
+func (r *unitOfWork) PasswordHistoryRepository() port.PasswordHistoryRepository {
+	return r.passwordHistoryRepository
+}
+
+func (r *unitOfWork) OutboxRepository() port.OutboxRepository {
+	return r.outboxRepository
+}
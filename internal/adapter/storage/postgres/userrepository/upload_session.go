@@ -0,0 +1,138 @@
+package userrepository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/metrics"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+)
+
+// UploadSessionRepository implements port.UploadSessionRepository interface and provides access to the postgres database
+type UploadSessionRepository struct {
+	log logger.Logger
+	tx  *sql.Tx
+}
+
+// NewUploadSessionRepository creates a new upload session repository instance
+func NewUploadSessionRepository(log logger.Logger, tx *sql.Tx) *UploadSessionRepository {
+	return &UploadSessionRepository{
+		log: log,
+		tx:  tx,
+	}
+}
+
+func (r *UploadSessionRepository) Create(session domain.UploadSession) error {
+	_, err := r.tx.Exec(
+		`INSERT INTO upload_sessions (uuid, user_id, purpose, key, content_type, size, checksum, status, expires_at) 
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		session.Base.UUID,
+		session.UserID,
+		session.Purpose,
+		session.Key,
+		session.ContentType,
+		session.Size,
+		session.Checksum,
+		session.Status,
+		session.ExpiresAt,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("upload_sessions", "Create", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), map[logger.ExtraKey]interface{}{
+			logger.InsertDBArg: session,
+		})
+		return serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("upload_sessions", "Create", "Success").Inc()
+
+	return nil
+}
+
+// GetByUUID locks the session until the end of the transaction, so it is completed or abandoned only once.
+func (r *UploadSessionRepository) GetByUUID(sessionUUID uuid.UUID) (*domain.UploadSession, error) {
+	var session domain.UploadSession
+	err := r.tx.QueryRow(
+		`SELECT id, uuid, user_id, purpose, key, content_type, size, checksum, status, expires_at, completed_at
+				FROM upload_sessions WHERE uuid = $1 FOR UPDATE`,
+		sessionUUID,
+	).Scan(
+		&session.Base.ID,
+		&session.Base.UUID,
+		&session.UserID,
+		&session.Purpose,
+		&session.Key,
+		&session.ContentType,
+		&session.Size,
+		&session.Checksum,
+		&session.Status,
+		&session.ExpiresAt,
+		&session.CompletedAt,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("upload_sessions", "GetByUUID", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, serviceerror.New(serviceerror.RecordNotFound)
+		}
+		return nil, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("upload_sessions", "GetByUUID", "Success").Inc()
+
+	return &session, nil
+}
+
+// MarkCompleted completes a pending session and points it at the key of the verified object.
+func (r *UploadSessionRepository) MarkCompleted(id uint64, key string) error {
+	return r.close(
+		"MarkCompleted",
+		"UPDATE upload_sessions SET status = $1, key = $4, completed_at = NOW(), updated_at = NOW() WHERE id = $2 AND status = $3",
+		domain.UploadSessionStatusCompleted,
+		id,
+		key,
+	)
+}
+
+func (r *UploadSessionRepository) MarkAbandoned(id uint64) error {
+	return r.close(
+		"MarkAbandoned",
+		"UPDATE upload_sessions SET status = $1, updated_at = NOW() WHERE id = $2 AND status = $3",
+		domain.UploadSessionStatusAbandoned,
+		id,
+	)
+}
+
+// close moves a pending session to its final status, the query gets the status, the id, the pending status
+// and then the extra args.
+func (r *UploadSessionRepository) close(
+	operation string,
+	query string,
+	status domain.UploadSessionStatusType,
+	id uint64,
+	args ...interface{},
+) error {
+	res, err := r.tx.Exec(query, append([]interface{}{status, id, domain.UploadSessionStatusPending}, args...)...)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("upload_sessions", operation, "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseUpdate, err.Error(), nil)
+		return serviceerror.NewServerError()
+	}
+
+	if affected, affectedErr := res.RowsAffected(); affectedErr != nil || affected <= 0 {
+		metrics.DbCall.WithLabelValues("upload_sessions", operation, "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseUpdate, fmt.Sprintf("There is any effected row in DB: %v", affectedErr), nil)
+		return serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("upload_sessions", operation, "Success").Inc()
+
+	return nil
+}
@@ -75,7 +75,7 @@ func (r *UserRepository) Save(user *domain.User) (*domain.User, error) {
 
 func (r *UserRepository) GetByUUID(uuid uuid.UUID) (*domain.User, error) {
 	row := r.tx.QueryRow(
-		"SELECT id, uuid, first_name, last_name, email, status FROM users WHERE deleted_at IS NULL AND uuid = $1",
+		"SELECT id, uuid, first_name, last_name, email, avatar, status FROM users WHERE deleted_at IS NULL AND uuid = $1",
 		uuid,
 	)
 	user, err := scanUser(row)
@@ -103,7 +103,7 @@ func (r *UserRepository) GetByUUID(uuid uuid.UUID) (*domain.User, error) {
 
 func (r *UserRepository) GetByID(id uint64) (*domain.User, error) {
 	row := r.tx.QueryRow(
-		"SELECT id, uuid, first_name, last_name, email, status FROM users WHERE deleted_at IS NULL AND id = $1",
+		"SELECT id, uuid, first_name, last_name, email, avatar, status FROM users WHERE deleted_at IS NULL AND id = $1",
 		id,
 	)
 	user, err := scanUser(row)
@@ -161,7 +161,7 @@ func (r *UserRepository) GetByEmail(email string) (*domain.User, error) {
 }
 
 func (r *UserRepository) List() ([]*domain.User, error) {
-	rows, err := r.tx.Query("SELECT id, uuid, first_name, last_name, email, status FROM users WHERE deleted_at IS NULL")
+	rows, err := r.tx.Query("SELECT id, uuid, first_name, last_name, email, avatar, status FROM users WHERE deleted_at IS NULL")
 	if err != nil {
 		metrics.DbCall.WithLabelValues("users", "List", "Failed").Inc()
 
@@ -309,16 +309,86 @@ func (r *UserRepository) UpdatePassword(id uint64, password string) error {
 	return nil
 }
 
+// Activate sets the password of an invited user and marks the account as active with a verified email.
+func (r *UserRepository) Activate(id uint64, password string) error {
+	result, err := r.tx.Exec(
+		`UPDATE users SET password = $1, status = $2, email_verified_at = NOW(), updated_at = NOW()
+				WHERE deleted_at IS NULL AND id = $3;`,
+		password,
+		domain.UserStatusActive,
+		id,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("users", "Activate", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseUpdate, err.Error(), nil)
+		return serviceerror.NewServerError()
+	}
+
+	if affected, affectedErr := result.RowsAffected(); affectedErr != nil || affected <= 0 {
+		metrics.DbCall.WithLabelValues("users", "Activate", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseUpdate, fmt.Sprintf("There is any effected row in DB: %v", affectedErr), nil)
+		return serviceerror.NewServerError()
+	}
+	metrics.DbCall.WithLabelValues("users", "Activate", "Success").Inc()
+
+	return nil
+}
+
+// UpdateAvatar replaces the avatar of the user with the key of a newly stored one.
+func (r *UserRepository) UpdateAvatar(id uint64, avatar string) error {
+	result, err := r.tx.Exec(
+		"UPDATE users SET avatar = $1, updated_at = NOW() WHERE deleted_at IS NULL AND id = $2;",
+		avatar,
+		id,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("users", "UpdateAvatar", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseUpdate, err.Error(), nil)
+		return serviceerror.NewServerError()
+	}
+
+	if affected, affectedErr := result.RowsAffected(); affectedErr != nil || affected <= 0 {
+		metrics.DbCall.WithLabelValues("users", "UpdateAvatar", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseUpdate, fmt.Sprintf("There is any effected row in DB: %v", affectedErr), nil)
+		return serviceerror.NewServerError()
+	}
+	metrics.DbCall.WithLabelValues("users", "UpdateAvatar", "Success").Inc()
+
+	return nil
+}
+
+// IsAvatarInUse reports whether any user still has the avatar, avatars are content-addressed so users uploading
+// the same picture share the stored objects.
+func (r *UserRepository) IsAvatarInUse(avatar string) (bool, error) {
+	var inUse bool
+	err := r.tx.QueryRow("SELECT EXISTS (SELECT 1 FROM users WHERE avatar = $1)", avatar).Scan(&inUse)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("users", "IsAvatarInUse", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		return false, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("users", "IsAvatarInUse", "Success").Inc()
+
+	return inUse, nil
+}
+
 func scanUser(scanner postgres.Scanner) (domain.User, error) {
 	var user domain.User
 	var firstName sql.NullString
 	var lastName sql.NullString
+	var avatar sql.NullString
 
-	if err := scanner.Scan(&user.Base.ID, &user.Base.UUID, &firstName, &lastName, &user.Email, &user.Status); err != nil {
+	if err := scanner.Scan(&user.Base.ID, &user.Base.UUID, &firstName, &lastName, &user.Email, &avatar, &user.Status); err != nil {
 		return domain.User{}, err
 	}
 
-	user.SetFirstName(firstName).SetLastName(lastName)
+	user.SetFirstName(firstName).SetLastName(lastName).SetAvatar(avatar)
 
 	return user, nil
 }
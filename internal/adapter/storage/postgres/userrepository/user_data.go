@@ -0,0 +1,435 @@
+package userrepository
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/metrics"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UserDataRepository implements port.UserDataRepository, it gathers and erases the personal data of a user across tables
+type UserDataRepository struct {
+	log logger.Logger
+	tx  *sql.Tx
+}
+
+func NewUserDataRepository(log logger.Logger, tx *sql.Tx) *UserDataRepository {
+	return &UserDataRepository{
+		log: log,
+		tx:  tx,
+	}
+}
+
+func (r *UserDataRepository) GetExport(userID uint64) (*domain.UserDataExport, error) {
+	profile, err := r.getProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := r.getRoles(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := r.getSessions(profile.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	sentences, err := r.getSentences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := r.getNotifications(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences, err := r.getNotificationPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UserDataExport{
+		Profile:                 *profile,
+		Roles:                   roles,
+		Sessions:                sessions,
+		Sentences:               sentences,
+		Notifications:           notifications,
+		NotificationPreferences: preferences,
+		GeneratedAt:             time.Now().UTC(),
+	}, nil
+}
+
+// Erase anonymizes the user in place, the row is kept so every created_by, updated_by and deleted_by
+// reference stays valid. Roles, password history, notifications and their preferences, the email deliveries,
+// the outbox messages carrying the email and open invitations are removed and the client details of the audit
+// entries about the user are cleared, the returned user holds the avatar it had. The email suppressions are kept,
+// a bounced or complaining address must not be mailed again when a later account uses it.
+func (r *UserDataRepository) Erase(userUUID uuid.UUID, erasedBy *uint64) (*domain.User, error) {
+	var user domain.User
+	var avatar, oldEmail sql.NullString
+	err := r.tx.QueryRow(
+		`UPDATE users AS u
+				SET first_name = NULL, last_name = NULL, email = $1, password = NULL,
+				    google_id = NULL, facebook_id = NULL, apple_id = NULL, avatar = NULL, last_login = NULL,
+				    email_verified_at = NULL, status = $2, deleted_by = COALESCE(u.deleted_by, $3),
+				    deleted_at = COALESCE(u.deleted_at, NOW()), updated_by = $3, updated_at = NOW()
+				FROM (SELECT id, avatar, email FROM users WHERE uuid = $4 AND email IS DISTINCT FROM $1 FOR UPDATE) AS old
+				WHERE u.id = old.id
+				RETURNING u.id, u.uuid, u.email, u.status, old.avatar, old.email`,
+		domain.ErasedEmail(userUUID),
+		domain.UserStatusInactive,
+		erasedBy,
+		userUUID,
+	).Scan(&user.Base.ID, &user.Base.UUID, &user.Email, &user.Status, &avatar, &oldEmail)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("users", "Erase", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseUpdate, err.Error(), nil)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, serviceerror.New(serviceerror.RecordNotFound)
+		}
+		return nil, serviceerror.NewServerError()
+	}
+	metrics.DbCall.WithLabelValues("users", "Erase", "Success").Inc()
+
+	if avatar.Valid {
+		user.Avatar = &avatar.String
+	}
+
+	if err = r.exec("access_controls", "Erase", `DELETE FROM access_controls WHERE user_id = $1`, user.Base.ID); err != nil {
+		return nil, err
+	}
+
+	if err = r.exec("password_histories", "Erase", `DELETE FROM password_histories WHERE user_id = $1`, user.Base.ID); err != nil {
+		return nil, err
+	}
+
+	if err = r.exec(
+		"notification_preferences",
+		"Erase",
+		`DELETE FROM notification_preferences WHERE user_id = $1`,
+		user.Base.ID,
+	); err != nil {
+		return nil, err
+	}
+
+	if err = r.exec("notifications", "Erase", `DELETE FROM notifications WHERE user_id = $1`, user.Base.ID); err != nil {
+		return nil, err
+	}
+
+	if err = r.exec(
+		"email_deliveries",
+		"Erase",
+		`DELETE FROM email_deliveries WHERE email = LOWER($1)`,
+		oldEmail.String,
+	); err != nil {
+		return nil, err
+	}
+
+	// the emails to the user are addressed in the "to" field of the message in the envelope
+	if err = r.exec(
+		"outbox_messages",
+		"Erase",
+		`DELETE FROM outbox_messages WHERE LOWER(payload->'payload'->>'to') = LOWER($1)`,
+		oldEmail.String,
+	); err != nil {
+		return nil, err
+	}
+
+	if err = r.exec(
+		"user_invitations",
+		"Erase",
+		`UPDATE user_invitations SET revoked_at = NOW(), revoked_by = $1, updated_at = NOW()
+				WHERE user_id = $2 AND accepted_at IS NULL AND revoked_at IS NULL`,
+		erasedBy,
+		user.Base.ID,
+	); err != nil {
+		return nil, err
+	}
+
+	if err = r.exec(
+		"audit_logs",
+		"Erase",
+		`UPDATE audit_logs SET ip = NULL, user_agent = NULL
+				WHERE (actor_id = $1 OR (target_type = $2 AND target_uuid = $3))
+				  AND (ip IS NOT NULL OR user_agent IS NOT NULL)`,
+		user.Base.ID,
+		domain.AuditTargetUser,
+		user.Base.UUID,
+	); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *UserDataRepository) getProfile(userID uint64) (*domain.UserDataProfile, error) {
+	var profile domain.UserDataProfile
+	var firstName, lastName, avatar sql.NullString
+	var emailVerifiedAt, lastLogin sql.NullTime
+	err := r.tx.QueryRow(
+		`SELECT id, uuid, first_name, last_name, email, avatar, status, google_id IS NOT NULL,
+       				email_verified_at, last_login, created_at
+				FROM users WHERE deleted_at IS NULL AND id = $1`,
+		userID,
+	).Scan(
+		&profile.ID,
+		&profile.UUID,
+		&firstName,
+		&lastName,
+		&profile.Email,
+		&avatar,
+		&profile.Status,
+		&profile.GoogleLinked,
+		&emailVerifiedAt,
+		&lastLogin,
+		&profile.CreatedAt,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("users", "GetExport", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, serviceerror.New(serviceerror.RecordNotFound)
+		}
+		return nil, serviceerror.NewServerError()
+	}
+	metrics.DbCall.WithLabelValues("users", "GetExport", "Success").Inc()
+
+	profile.FirstName = nullStringPtr(firstName)
+	profile.LastName = nullStringPtr(lastName)
+	profile.Avatar = nullStringPtr(avatar)
+	if emailVerifiedAt.Valid {
+		profile.EmailVerifiedAt = &emailVerifiedAt.Time
+	}
+	if lastLogin.Valid {
+		profile.LastLogin = &lastLogin.Time
+	}
+
+	return &profile, nil
+}
+
+func (r *UserDataRepository) getRoles(userID uint64) ([]domain.UserDataRole, error) {
+	rows, err := r.tx.Query(
+		`SELECT r.key, r.title, ac.created_at
+				FROM access_controls AS ac
+				INNER JOIN roles AS r ON r.id = ac.role_id
+				WHERE ac.deleted_at IS NULL AND ac.user_id = $1
+				ORDER BY ac.id`,
+		userID,
+	)
+	if err != nil {
+		return nil, r.selectFailed("access_controls", err)
+	}
+	defer r.closeRows(rows)
+
+	roles := make([]domain.UserDataRole, 0)
+	for rows.Next() {
+		var role domain.UserDataRole
+		if err = rows.Scan(&role.Key, &role.Title, &role.AssignedAt); err != nil {
+			return nil, r.selectFailed("access_controls", err)
+		}
+		roles = append(roles, role)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, r.selectFailed("access_controls", err)
+	}
+	metrics.DbCall.WithLabelValues("access_controls", "GetExport", "Success").Inc()
+
+	return roles, nil
+}
+
+func (r *UserDataRepository) getSessions(userUUID uuid.UUID) ([]domain.UserDataSession, error) {
+	actions := domain.SessionAuditActions
+	args := make([]interface{}, 0, len(actions)+2)
+	for _, action := range actions {
+		args = append(args, action)
+	}
+	args = append(args, domain.AuditTargetUser, userUUID)
+
+	placeholders := strings.Join(helper.MakeSQLPlaceholders(uint(len(actions))), ",")
+	rows, err := r.tx.Query(
+		`SELECT action, ip, user_agent, created_at FROM audit_logs
+				WHERE action IN (`+placeholders+`)
+				  AND target_type = $`+strconv.Itoa(len(args)-1)+` AND target_uuid = $`+strconv.Itoa(len(args))+`
+				ORDER BY id`,
+		args...,
+	)
+	if err != nil {
+		return nil, r.selectFailed("audit_logs", err)
+	}
+	defer r.closeRows(rows)
+
+	sessions := make([]domain.UserDataSession, 0)
+	for rows.Next() {
+		var session domain.UserDataSession
+		var ip, userAgent sql.NullString
+		if err = rows.Scan(&session.Action, &ip, &userAgent, &session.CreatedAt); err != nil {
+			return nil, r.selectFailed("audit_logs", err)
+		}
+		session.IP = nullStringPtr(ip)
+		session.UserAgent = nullStringPtr(userAgent)
+		sessions = append(sessions, session)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, r.selectFailed("audit_logs", err)
+	}
+	metrics.DbCall.WithLabelValues("audit_logs", "GetExport", "Success").Inc()
+
+	return sessions, nil
+}
+
+func (r *UserDataRepository) getSentences(userID uint64) ([]domain.UserDataSentence, error) {
+	rows, err := r.tx.Query(
+		`SELECT s.uuid, s.text, g.title, s.level, s.status, s.created_at
+				FROM sentences AS s
+				INNER JOIN grammars AS g ON g.id = s.grammar_id
+				WHERE s.deleted_at IS NULL AND s.created_by = $1
+				ORDER BY s.id`,
+		userID,
+	)
+	if err != nil {
+		return nil, r.selectFailed("sentences", err)
+	}
+	defer r.closeRows(rows)
+
+	sentences := make([]domain.UserDataSentence, 0)
+	for rows.Next() {
+		var sentence domain.UserDataSentence
+		if err = rows.Scan(
+			&sentence.UUID,
+			&sentence.Text,
+			&sentence.Grammar,
+			&sentence.Level,
+			&sentence.Status,
+			&sentence.CreatedAt,
+		); err != nil {
+			return nil, r.selectFailed("sentences", err)
+		}
+		sentences = append(sentences, sentence)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, r.selectFailed("sentences", err)
+	}
+	metrics.DbCall.WithLabelValues("sentences", "GetExport", "Success").Inc()
+
+	return sentences, nil
+}
+
+func (r *UserDataRepository) getNotifications(userID uint64) ([]domain.UserDataNotification, error) {
+	rows, err := r.tx.Query(
+		`SELECT uuid, type, title, body, link, read_at, created_at
+				FROM notifications
+				WHERE user_id = $1
+				ORDER BY id`,
+		userID,
+	)
+	if err != nil {
+		return nil, r.selectFailed("notifications", err)
+	}
+	defer r.closeRows(rows)
+
+	notifications := make([]domain.UserDataNotification, 0)
+	for rows.Next() {
+		var notification domain.UserDataNotification
+		var link sql.NullString
+		var readAt sql.NullTime
+		if err = rows.Scan(
+			&notification.UUID,
+			&notification.Type,
+			&notification.Title,
+			&notification.Body,
+			&link,
+			&readAt,
+			&notification.CreatedAt,
+		); err != nil {
+			return nil, r.selectFailed("notifications", err)
+		}
+		notification.Link = nullStringPtr(link)
+		if readAt.Valid {
+			notification.ReadAt = &readAt.Time
+		}
+		notifications = append(notifications, notification)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, r.selectFailed("notifications", err)
+	}
+	metrics.DbCall.WithLabelValues("notifications", "GetExport", "Success").Inc()
+
+	return notifications, nil
+}
+
+func (r *UserDataRepository) getNotificationPreferences(userID uint64) ([]domain.UserDataNotificationPreference, error) {
+	rows, err := r.tx.Query(
+		`SELECT category, enabled, created_at, updated_at
+				FROM notification_preferences
+				WHERE user_id = $1
+				ORDER BY id`,
+		userID,
+	)
+	if err != nil {
+		return nil, r.selectFailed("notification_preferences", err)
+	}
+	defer r.closeRows(rows)
+
+	preferences := make([]domain.UserDataNotificationPreference, 0)
+	for rows.Next() {
+		var preference domain.UserDataNotificationPreference
+		var updatedAt sql.NullTime
+		if err = rows.Scan(&preference.Category, &preference.Enabled, &preference.CreatedAt, &updatedAt); err != nil {
+			return nil, r.selectFailed("notification_preferences", err)
+		}
+		if updatedAt.Valid {
+			preference.UpdatedAt = &updatedAt.Time
+		}
+		preferences = append(preferences, preference)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, r.selectFailed("notification_preferences", err)
+	}
+	metrics.DbCall.WithLabelValues("notification_preferences", "GetExport", "Success").Inc()
+
+	return preferences, nil
+}
+
+func (r *UserDataRepository) exec(table string, operation string, query string, args ...interface{}) error {
+	if _, err := r.tx.Exec(query, args...); err != nil {
+		metrics.DbCall.WithLabelValues(table, operation, "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseUpdate, err.Error(), nil)
+		return serviceerror.NewServerError()
+	}
+	metrics.DbCall.WithLabelValues(table, operation, "Success").Inc()
+
+	return nil
+}
+
+func (r *UserDataRepository) selectFailed(table string, err error) error {
+	metrics.DbCall.WithLabelValues(table, "GetExport", "Failed").Inc()
+
+	r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+	return serviceerror.NewServerError()
+}
+
+func (r *UserDataRepository) closeRows(rows *sql.Rows) {
+	if err := rows.Close(); err != nil {
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+	}
+}
+
+func nullStringPtr(value sql.NullString) *string {
+	if !value.Valid {
+		return nil
+	}
+	return &value.String
+}
@@ -0,0 +1,248 @@
+package userrepository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/metrics"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UserInvitationRepository implements port.UserInvitationRepository interface and provides access to the postgres database
+type UserInvitationRepository struct {
+	log logger.Logger
+	tx  *sql.Tx
+}
+
+// NewUserInvitationRepository creates a new user invitation repository instance
+func NewUserInvitationRepository(log logger.Logger, tx *sql.Tx) *UserInvitationRepository {
+	return &UserInvitationRepository{
+		log: log,
+		tx:  tx,
+	}
+}
+
+func (r *UserInvitationRepository) Create(invitation domain.UserInvitation) error {
+	res, err := r.tx.Exec(
+		`INSERT INTO user_invitations (user_id, token_hash, expires_at, created_by) VALUES ($1, $2, $3, $4)`,
+		invitation.UserID,
+		invitation.TokenHash,
+		invitation.ExpiresAt,
+		invitation.Modifier.CreatedBy,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("user_invitations", "Create", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), map[logger.ExtraKey]interface{}{
+			logger.InsertDBArg: invitation.UserID,
+		})
+		return serviceerror.NewServerError()
+	}
+
+	if affected, affectedErr := res.RowsAffected(); affectedErr != nil || affected <= 0 {
+		metrics.DbCall.WithLabelValues("user_invitations", "Create", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, fmt.Sprintf("There is any effected row in DB: %v", affectedErr), nil)
+		return serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("user_invitations", "Create", "Success").Inc()
+
+	return nil
+}
+
+func (r *UserInvitationRepository) GetByTokenHash(tokenHash string) (*domain.UserInvitation, error) {
+	invitation, err := r.get("ui.token_hash = $1", tokenHash)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("user_invitations", "GetByTokenHash", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, serviceerror.New(serviceerror.RecordNotFound)
+		}
+		return nil, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("user_invitations", "GetByTokenHash", "Success").Inc()
+
+	return invitation, nil
+}
+
+func (r *UserInvitationRepository) GetOpenByUserUUID(userUUID uuid.UUID) (*domain.UserInvitation, error) {
+	invitation, err := r.get("u.uuid = $1 AND ui.accepted_at IS NULL AND ui.revoked_at IS NULL", userUUID)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("user_invitations", "GetOpenByUserUUID", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseSelect, err.Error(), nil)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, serviceerror.New(serviceerror.RecordNotFound)
+		}
+		return nil, serviceerror.NewServerError()
+	}
+
+	metrics.DbCall.WithLabelValues("user_invitations", "GetOpenByUserUUID", "Success").Inc()
+
+	return invitation, nil
+}
+
+func (r *UserInvitationRepository) Refresh(id uint64, tokenHash string, expiresAt time.Time) error {
+	return r.update(
+		"Refresh",
+		`UPDATE user_invitations SET token_hash = $1, expires_at = $2, sent_count = sent_count + 1, updated_at = NOW()
+				WHERE id = $3 AND accepted_at IS NULL AND revoked_at IS NULL`,
+		tokenHash,
+		expiresAt,
+		id,
+	)
+}
+
+func (r *UserInvitationRepository) Accept(id uint64) error {
+	return r.update(
+		"Accept",
+		`UPDATE user_invitations SET accepted_at = NOW(), updated_at = NOW()
+				WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL`,
+		id,
+	)
+}
+
+func (r *UserInvitationRepository) Revoke(id uint64, revokedBy uint64) error {
+	return r.update(
+		"Revoke",
+		`UPDATE user_invitations SET revoked_at = NOW(), revoked_by = $1, updated_at = NOW()
+				WHERE id = $2 AND accepted_at IS NULL AND revoked_at IS NULL`,
+		revokedBy,
+		id,
+	)
+}
+
+// AssignRoles grants the roles to the invited user, it fails with RecordNotFound when any of the roles does not exist.
+func (r *UserInvitationRepository) AssignRoles(userID uint64, roleUUIDs []uuid.UUID) error {
+	if len(roleUUIDs) == 0 {
+		return nil
+	}
+
+	placeholders := strings.Join(helper.MakeSQLPlaceholders(uint(len(roleUUIDs))), ",")
+	args := make([]interface{}, 0, len(roleUUIDs)+1)
+	for _, roleUUID := range roleUUIDs {
+		args = append(args, roleUUID)
+	}
+	args = append(args, userID)
+
+	query := `INSERT INTO access_controls (user_id, role_id)
+				SELECT $` + strconv.Itoa(len(args)) + `, id FROM roles WHERE deleted_at IS NULL AND uuid IN (` + placeholders + `)
+				ON CONFLICT DO NOTHING;`
+	res, err := r.tx.Exec(query, args...)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("access_controls", "AssignRoles", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), nil)
+		return serviceerror.NewServerError()
+	}
+
+	if affected, affectedErr := res.RowsAffected(); affectedErr != nil || affected != int64(len(roleUUIDs)) {
+		metrics.DbCall.WithLabelValues("access_controls", "AssignRoles", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, fmt.Sprintf("Some roles were not found: %v", affectedErr), nil)
+		return serviceerror.New(serviceerror.RecordNotFound)
+	}
+
+	metrics.DbCall.WithLabelValues("access_controls", "AssignRoles", "Success").Inc()
+
+	return nil
+}
+
+// AssignDefaultRole grants the USER role to the invited user, the role every registered user starts with.
+func (r *UserInvitationRepository) AssignDefaultRole(userID uint64) error {
+	res, err := r.tx.Exec(
+		`INSERT INTO access_controls (user_id, role_id)
+				SELECT $1, id FROM roles WHERE deleted_at IS NULL AND key = $2
+				ON CONFLICT DO NOTHING;`,
+		userID,
+		domain.RoleKeyUser,
+	)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("access_controls", "AssignDefaultRole", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, err.Error(), nil)
+		return serviceerror.NewServerError()
+	}
+
+	if affected, affectedErr := res.RowsAffected(); affectedErr != nil || affected != 1 {
+		metrics.DbCall.WithLabelValues("access_controls", "AssignDefaultRole", "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseInsert, fmt.Sprintf("The default role was not found: %v", affectedErr), nil)
+		return serviceerror.New(serviceerror.RecordNotFound)
+	}
+
+	metrics.DbCall.WithLabelValues("access_controls", "AssignDefaultRole", "Success").Inc()
+
+	return nil
+}
+
+func (r *UserInvitationRepository) get(condition string, arg interface{}) (*domain.UserInvitation, error) {
+	var invitation domain.UserInvitation
+	var user domain.User
+	var firstName, lastName sql.NullString
+
+	err := r.tx.QueryRow(
+		`SELECT ui.id, ui.uuid, ui.user_id, ui.token_hash, ui.sent_count, ui.expires_at, ui.accepted_at, ui.revoked_at,
+       				u.uuid, u.first_name, u.last_name, u.email, u.status
+				FROM user_invitations AS ui
+				INNER JOIN users AS u ON u.id = ui.user_id AND u.deleted_at IS NULL
+				WHERE `+condition+`
+				ORDER BY ui.id DESC
+				LIMIT 1`,
+		arg,
+	).Scan(
+		&invitation.Base.ID,
+		&invitation.Base.UUID,
+		&invitation.UserID,
+		&invitation.TokenHash,
+		&invitation.SentCount,
+		&invitation.ExpiresAt,
+		&invitation.AcceptedAt,
+		&invitation.RevokedAt,
+		&user.Base.UUID,
+		&firstName,
+		&lastName,
+		&user.Email,
+		&user.Status,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Base.ID = invitation.UserID
+	user.SetFirstName(firstName).SetLastName(lastName)
+	invitation.User = &user
+
+	return &invitation, nil
+}
+
+func (r *UserInvitationRepository) update(operation string, query string, args ...interface{}) error {
+	res, err := r.tx.Exec(query, args...)
+	if err != nil {
+		metrics.DbCall.WithLabelValues("user_invitations", operation, "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseUpdate, err.Error(), nil)
+		return serviceerror.NewServerError()
+	}
+
+	if affected, affectedErr := res.RowsAffected(); affectedErr != nil || affected <= 0 {
+		metrics.DbCall.WithLabelValues("user_invitations", operation, "Failed").Inc()
+
+		r.log.Error(logger.Database, logger.DatabaseUpdate, fmt.Sprintf("There is any effected row in DB: %v", affectedErr), nil)
+		return serviceerror.New(serviceerror.InvitationInvalid)
+	}
+
+	metrics.DbCall.WithLabelValues("user_invitations", operation, "Success").Inc()
+
+	return nil
+}
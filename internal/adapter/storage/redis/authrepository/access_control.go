@@ -0,0 +1,133 @@
+package authrepository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"time"
+)
+
+const scanCount int64 = 100
+
+type ACLCache struct {
+	log    logger.Logger
+	conf   config.Redis
+	client *redis.Client
+}
+
+func NewACLCache(log logger.Logger, conf config.Redis, driver *redis.Client) *ACLCache {
+	return &ACLCache{
+		log:    log,
+		conf:   conf,
+		client: driver,
+	}
+}
+
+func (r ACLCache) Set(ctx context.Context, key string, value *domain.UserAccess, expiration time.Duration) error {
+	key = fmt.Sprintf("%s:%s", r.conf.Prefix, key)
+
+	extra := map[logger.ExtraKey]interface{}{
+		logger.CacheKey:    key,
+		logger.CacheSetArg: value,
+	}
+
+	data, marshalErr := json.Marshal(value)
+	if marshalErr != nil {
+		r.log.Error(logger.Cache, logger.RedisSet, fmt.Sprintf("Error marshalling value: %v", marshalErr), extra)
+		return serviceerror.NewServerError()
+	}
+
+	if err := r.client.WithContext(ctx).Set(key, data, expiration).Err(); err != nil {
+		r.log.Error(logger.Cache, logger.RedisSet, fmt.Sprintf("Error Set value: %v", err), extra)
+		return serviceerror.NewServerError()
+	}
+
+	return nil
+}
+
+func (r ACLCache) Get(ctx context.Context, key string) (*domain.UserAccess, error) {
+	key = fmt.Sprintf("%s:%s", r.conf.Prefix, key)
+
+	extra := map[logger.ExtraKey]interface{}{
+		logger.CacheKey: key,
+	}
+
+	result, err := r.client.WithContext(ctx).Get(key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		r.log.Error(logger.Cache, logger.RedisGet, fmt.Sprintf("Error Get value: %v", err), extra)
+		return nil, serviceerror.NewServerError()
+	}
+
+	var access *domain.UserAccess
+	if err = json.Unmarshal([]byte(result), &access); err != nil {
+		r.log.Error(logger.Cache, logger.RedisGet, fmt.Sprintf("Error Get value: %v", err), extra)
+		return nil, serviceerror.NewServerError()
+	}
+
+	return access, nil
+}
+
+func (r ACLCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	prefixedKeys := make([]string, len(keys))
+	for index, key := range keys {
+		prefixedKeys[index] = fmt.Sprintf("%s:%s", r.conf.Prefix, key)
+	}
+
+	if err := r.client.WithContext(ctx).Del(prefixedKeys...).Err(); err != nil {
+		r.log.Error(logger.Cache, logger.RedisDel, fmt.Sprintf("Error Del value: %v", err), map[logger.ExtraKey]interface{}{
+			logger.CacheKey: prefixedKeys,
+		})
+		return serviceerror.NewServerError()
+	}
+
+	return nil
+}
+
+func (r ACLCache) DeleteByPattern(ctx context.Context, pattern string) error {
+	pattern = fmt.Sprintf("%s:%s", r.conf.Prefix, pattern)
+
+	extra := map[logger.ExtraKey]interface{}{
+		logger.CacheKey: pattern,
+	}
+
+	client := r.client.WithContext(ctx)
+
+	var cursor uint64
+	for {
+		keys, nextCursor, err := client.Scan(cursor, pattern, scanCount).Result()
+		if err != nil {
+			r.log.Error(logger.Cache, logger.RedisDel, fmt.Sprintf("Error Scan keys: %v", err), extra)
+			return serviceerror.NewServerError()
+		}
+
+		if len(keys) > 0 {
+			if err = client.Del(keys...).Err(); err != nil {
+				r.log.Error(logger.Cache, logger.RedisDel, fmt.Sprintf("Error Del value: %v", err), extra)
+				return serviceerror.NewServerError()
+			}
+		}
+
+		cursor = nextCursor
+		if cursor == 0 {
+			break
+		}
+	}
+
+	r.log.Info(logger.Cache, logger.RedisDel, "The ACL cache flushed successfully.", extra)
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package authrepository
+
+import (
+	"context"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type MockACLCache struct {
+	mock.Mock
+}
+
+func (r *MockACLCache) Set(ctx context.Context, key string, value *domain.UserAccess, expiration time.Duration) error {
+	args := r.Called(ctx, key, value, expiration)
+	return args.Error(0)
+}
+
+func (r *MockACLCache) Get(ctx context.Context, key string) (*domain.UserAccess, error) {
+	args := r.Called(ctx, key)
+	return args.Get(0).(*domain.UserAccess), args.Error(1)
+}
+
+func (r *MockACLCache) Delete(ctx context.Context, keys ...string) error {
+	args := r.Called(ctx, keys)
+	return args.Error(0)
+}
+
+func (r *MockACLCache) DeleteByPattern(ctx context.Context, pattern string) error {
+	args := r.Called(ctx, pattern)
+	return args.Error(0)
+}
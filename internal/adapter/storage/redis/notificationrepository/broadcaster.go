@@ -0,0 +1,105 @@
+package notificationrepository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-redis/redis"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/constant"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+)
+
+// Broadcaster publishes the notifications on a Redis channel per user, every instance holding a stream of
+// the user subscribes to it. A notification published while no stream of its user is open is dropped,
+// the client lists it on the next connect.
+type Broadcaster struct {
+	log    logger.Logger
+	conf   config.Redis
+	client *redis.Client
+}
+
+func NewBroadcaster(log logger.Logger, conf config.Redis, client *redis.Client) *Broadcaster {
+	return &Broadcaster{
+		log:    log,
+		conf:   conf,
+		client: client,
+	}
+}
+
+func (r Broadcaster) Publish(ctx context.Context, notification domain.Notification) error {
+	channel := r.channel(notification.UserID)
+
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		r.log.Error(logger.Cache, logger.RedisPublish, fmt.Sprintf("Error Marshal notification: %v", err), nil)
+		return serviceerror.NewServerError()
+	}
+
+	if err = r.client.WithContext(ctx).Publish(channel, payload).Err(); err != nil {
+		r.log.Error(logger.Cache, logger.RedisPublish, fmt.Sprintf("Error Publish value: %v", err), map[logger.ExtraKey]interface{}{
+			logger.CacheKey: channel,
+		})
+		return serviceerror.NewServerError()
+	}
+
+	return nil
+}
+
+func (r Broadcaster) Subscribe(ctx context.Context, userID uint64) (<-chan domain.Notification, error) {
+	channel := r.channel(userID)
+
+	pubSub := r.client.Subscribe(channel)
+	// waits for the subscription, a notification published after Subscribe returns is not missed
+	if _, err := pubSub.Receive(); err != nil {
+		r.log.Error(logger.Cache, logger.RedisSubscribe, fmt.Sprintf("Error Subscribe channel: %v", err), map[logger.ExtraKey]interface{}{
+			logger.CacheKey: channel,
+		})
+		_ = pubSub.Close()
+		return nil, serviceerror.NewServerError()
+	}
+
+	notifications := make(chan domain.Notification)
+	go func() {
+		defer close(notifications)
+		defer func() {
+			if err := pubSub.Close(); err != nil {
+				r.log.Warn(logger.Cache, logger.RedisSubscribe, fmt.Sprintf("Error Close subscription: %v", err), nil)
+			}
+		}()
+
+		messages := pubSub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var notification domain.Notification
+				if err := json.Unmarshal([]byte(message.Payload), &notification); err != nil {
+					r.log.Error(logger.Cache, logger.RedisSubscribe, fmt.Sprintf("Error Unmarshal notification: %v", err), map[logger.ExtraKey]interface{}{
+						logger.CacheKey: channel,
+					})
+					continue
+				}
+
+				select {
+				case notifications <- notification:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return notifications, nil
+}
+
+func (r Broadcaster) channel(userID uint64) string {
+	return fmt.Sprintf("%s:%s:%d", r.conf.Prefix, constant.NotificationChannelPrefix, userID)
+}
@@ -0,0 +1,24 @@
+package notificationrepository
+
+import (
+	"context"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBroadcaster struct {
+	mock.Mock
+}
+
+func (r *MockBroadcaster) Publish(ctx context.Context, notification domain.Notification) error {
+	args := r.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (r *MockBroadcaster) Subscribe(ctx context.Context, userID uint64) (<-chan domain.Notification, error) {
+	args := r.Called(ctx, userID)
+	if args.Get(0) != nil {
+		return args.Get(0).(<-chan domain.Notification), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
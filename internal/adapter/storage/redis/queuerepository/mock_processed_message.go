@@ -0,0 +1,30 @@
+package queuerepository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProcessedMessageCache struct {
+	mock.Mock
+}
+
+func (r *MockProcessedMessageCache) Claim(ctx context.Context, queue string, id string) (bool, error) {
+	args := r.Called(ctx, queue, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *MockProcessedMessageCache) Release(ctx context.Context, queue string, id string) error {
+	args := r.Called(ctx, queue, id)
+	return args.Error(0)
+}
+
+func (r *MockProcessedMessageCache) IsProcessed(ctx context.Context, queue string, id string) (bool, error) {
+	args := r.Called(ctx, queue, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *MockProcessedMessageCache) MarkProcessed(ctx context.Context, queue string, id string) error {
+	args := r.Called(ctx, queue, id)
+	return args.Error(0)
+}
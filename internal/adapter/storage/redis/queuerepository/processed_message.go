@@ -0,0 +1,97 @@
+package queuerepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/constant"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"time"
+)
+
+// claimedValue marks a message a consumer has in hand, a processed message keeps the unix time it was handled at.
+const claimedValue = "claimed"
+
+// ProcessedMessageCache keeps the ids of the handled messages for the processed message ttl, a redelivery later
+// than that is handled again. A claim lives for the claim ttl, so a consumer that died with the message in hand
+// only holds its redeliveries back until then.
+type ProcessedMessageCache struct {
+	log    logger.Logger
+	conf   config.Redis
+	queue  config.Queue
+	client *redis.Client
+}
+
+func NewProcessedMessageCache(log logger.Logger, conf config.Redis, queue config.Queue, client *redis.Client) *ProcessedMessageCache {
+	return &ProcessedMessageCache{
+		log:    log,
+		conf:   conf,
+		queue:  queue,
+		client: client,
+	}
+}
+
+func (r ProcessedMessageCache) Claim(ctx context.Context, queue string, id string) (bool, error) {
+	key := r.key(queue, id)
+
+	claimed, err := r.client.WithContext(ctx).SetNX(key, claimedValue, r.queue.ClaimTTLSecond).Result()
+	if err != nil {
+		r.log.Error(logger.Cache, logger.RedisSet, fmt.Sprintf("Error SetNX value: %v", err), map[logger.ExtraKey]interface{}{
+			logger.CacheKey: key,
+		})
+		return false, serviceerror.NewServerError()
+	}
+
+	return claimed, nil
+}
+
+func (r ProcessedMessageCache) Release(ctx context.Context, queue string, id string) error {
+	key := r.key(queue, id)
+
+	if err := r.client.WithContext(ctx).Del(key).Err(); err != nil {
+		r.log.Error(logger.Cache, logger.RedisDel, fmt.Sprintf("Error Del value: %v", err), map[logger.ExtraKey]interface{}{
+			logger.CacheKey: key,
+		})
+		return serviceerror.NewServerError()
+	}
+
+	return nil
+}
+
+func (r ProcessedMessageCache) IsProcessed(ctx context.Context, queue string, id string) (bool, error) {
+	key := r.key(queue, id)
+
+	value, err := r.client.WithContext(ctx).Get(key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return false, nil
+		}
+
+		r.log.Error(logger.Cache, logger.RedisGet, fmt.Sprintf("Error Get value: %v", err), map[logger.ExtraKey]interface{}{
+			logger.CacheKey: key,
+		})
+		return false, serviceerror.NewServerError()
+	}
+
+	return value != claimedValue, nil
+}
+
+func (r ProcessedMessageCache) MarkProcessed(ctx context.Context, queue string, id string) error {
+	key := r.key(queue, id)
+
+	if err := r.client.WithContext(ctx).Set(key, time.Now().Unix(), r.queue.ProcessedMessageTTLSecond).Err(); err != nil {
+		r.log.Error(logger.Cache, logger.RedisSet, fmt.Sprintf("Error Set value: %v", err), map[logger.ExtraKey]interface{}{
+			logger.CacheKey: key,
+		})
+		return serviceerror.NewServerError()
+	}
+
+	return nil
+}
+
+func (r ProcessedMessageCache) key(queue string, id string) string {
+	return fmt.Sprintf("%s:%s:%s:%s", r.conf.Prefix, constant.ProcessedMessageKeyPrefix, queue, id)
+}
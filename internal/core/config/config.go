@@ -5,6 +5,7 @@ import (
 	"github.com/joho/godotenv"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -13,6 +14,7 @@ const (
 	AppDeviceHeaderKey     string = "x-AppDevice"
 	AppVersionHeaderKey    string = "x-AppVersion"
 	AuthorizationHeaderKey string = "Authorization"
+	RequestIDHeaderKey     string = "X-Request-ID"
 )
 
 // please never change this keys
@@ -44,6 +46,8 @@ type App struct {
 	GracefullyShutdown time.Duration
 	ResetPasswordURL   string
 	VerificationURL    string
+	InvitationURL      string
+	UnsubscribeURL     string
 	SupportEmail       string
 }
 
@@ -140,17 +144,154 @@ type Jwt struct {
 }
 
 type Password struct {
-	BcryptCost int
+	Argon2Memory      uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+	Argon2SaltLength  uint32
+	Argon2KeyLength   uint32
+
+	MinLength        int
+	RequireUpper     bool
+	RequireLower     bool
+	RequireDigit     bool
+	RequireSpecial   bool
+	MaxRepeat        int
+	HistorySize      int
+	BreachedCheck    bool
+	BreachedListPath string
 }
 
+// OTPLinkSecretMinLength is the length in bytes LinkSecret needs at least, the size of the HMAC-SHA256 key it is.
+const OTPLinkSecretMinLength = 32
+
 type OTP struct {
 	ExpireSecond               time.Duration
 	ForgetPasswordExpireSecond time.Duration
 	Digits                     int8
+	LinkSecret                 string
+}
+
+// Validate reports a LinkSecret too short to sign the OTP links, the service signing them must not start with it.
+func (r OTP) Validate() error {
+	if len(r.LinkSecret) < OTPLinkSecretMinLength {
+		return fmt.Errorf("OTP_LINK_SECRET must be at least %d characters long", OTPLinkSecretMinLength)
+	}
+	return nil
+}
+
+type ACL struct {
+	CacheExpireSecond time.Duration
+}
+
+type Invitation struct {
+	ExpireSecond time.Duration
+}
+
+// Unsubscribe signs the one-click unsubscribe links of the notification emails with Secret, the links do not
+// expire so the one in an old email keeps working.
+type Unsubscribe struct {
+	Secret string
+}
+
+// UnsubscribeSecretMinLength is the length in bytes Secret needs at least, the size of the HMAC-SHA256 key it is.
+const UnsubscribeSecretMinLength = 32
+
+// Validate reports a Secret too short to sign the unsubscribe links, the service signing them must not start with it.
+func (r Unsubscribe) Validate() error {
+	if len(r.Secret) < UnsubscribeSecretMinLength {
+		return fmt.Errorf("NOTIFICATION_UNSUBSCRIBE_SECRET must be at least %d characters long", UnsubscribeSecretMinLength)
+	}
+	return nil
 }
 
+// NotificationStream keeps the in-app notification streams open, a comment is written to an idle stream every
+// HeartbeatSecond so the proxies on the way do not close it.
+type NotificationStream struct {
+	HeartbeatSecond time.Duration
+}
+
+type DataExport struct {
+	LinkExpireSecond time.Duration
+}
+
+const (
+	QueueDriverRabbitMQ = "rabbitmq"
+	QueueDriverMemory   = "memory"
+	QueueDriverRedis    = "redis"
+)
+
+// Queue selects the message broker, the memory driver delivers the messages only inside the process
+// that produced them, so it is meant for tests and running the services without RabbitMQ, the redis driver
+// runs the queues on Redis Streams. Every driver retries a failed message with an exponential backoff starting
+// at RetryInitialDelaySecond up to RetryMaxDelaySecond, after RetryMaxAttempts failed deliveries the message is
+// moved to the dead letters of its queue. The consumers remember the handled messages for ProcessedMessageTTLSecond
+// to skip their redeliveries, a message in hand is claimed for ClaimTTLSecond so the claim of a crashed consumer
+// does not outlive it for long, a redelivery finding the message claimed waits as long without using an attempt.
+type Queue struct {
+	Driver                    string
+	ProcessedMessageTTLSecond time.Duration
+	ClaimTTLSecond            time.Duration
+	RetryMaxAttempts          int
+	RetryInitialDelaySecond   time.Duration
+	RetryMaxDelaySecond       time.Duration
+}
+
+// Consumer sizes the queue consumers, every event is handled by Workers goroutines that take at most Prefetch
+// messages ahead, unless EventWorkers or EventPrefetch set other values for the event by its queue name.
+// On shutdown the consumers stop taking messages and the ones in hand get ShutdownTimeoutSecond to finish.
+type Consumer struct {
+	Workers               int
+	Prefetch              int
+	EventWorkers          map[string]int
+	EventPrefetch         map[string]int
+	ShutdownTimeoutSecond time.Duration
+}
+
+// WorkersOf returns the number of workers handling the messages of the event name.
+func (r Consumer) WorkersOf(name string) int {
+	if workers, ok := r.EventWorkers[name]; ok {
+		return workers
+	}
+	return r.Workers
+}
+
+// PrefetchOf returns the number of messages the consumers of the event name take ahead.
+func (r Consumer) PrefetchOf(name string) int {
+	if prefetch, ok := r.EventPrefetch[name]; ok {
+		return prefetch
+	}
+	return r.Prefetch
+}
+
+// RedisStream tunes the Redis Streams driver, consumers wait PollIntervalSecond for new messages, due delayed
+// messages are moved to their stream BatchSize at a time every PollIntervalSecond, and a message left
+// unacknowledged for ClaimIdleSecond by a crashed consumer is claimed by another one.
+type RedisStream struct {
+	BatchSize          int
+	PollIntervalSecond time.Duration
+	ClaimIdleSecond    time.Duration
+}
+
+// RabbitMQ dials a lost connection again after ReconnectInitialDelaySecond doubled on every failure up to
+// ReconnectMaxDelaySecond. Messages are published through at most PublisherPoolSize idle channels and
+// a publish fails when the broker does not confirm it within ConfirmTimeoutSecond.
 type RabbitMQ struct {
-	URL string
+	URL                         string
+	ReconnectInitialDelaySecond time.Duration
+	ReconnectMaxDelaySecond     time.Duration
+	PublisherPoolSize           int
+	ConfirmTimeoutSecond        time.Duration
+}
+
+// Outbox controls the relay publishing the outbox messages, a failed publish is retried after RetryDelaySecond
+// doubled on every attempt up to RetryMaxDelaySecond, published messages are removed after RetentionSecond.
+type Outbox struct {
+	RelayIntervalSecond   time.Duration
+	BatchSize             int
+	RetryDelaySecond      time.Duration
+	RetryMaxDelaySecond   time.Duration
+	RetentionSecond       time.Duration
+	CleanupIntervalSecond time.Duration
 }
 
 type SendGrid struct {
@@ -159,6 +300,42 @@ type SendGrid struct {
 	Address string
 }
 
+const (
+	EmailDriverSendGrid = "sendgrid"
+	EmailDriverSMTP     = "smtp"
+	EmailDriverFile     = "file"
+	EmailDriverConsole  = "console"
+)
+
+// Email selects how the emails are delivered, the file driver writes every email as an .eml file under
+// FilePath and the console driver prints it, so development can read them without a mail provider.
+// Every driver but SendGrid sends from FromName and FromAddress.
+type Email struct {
+	Driver      string
+	FromName    string
+	FromAddress string
+	FilePath    string
+	SMTP        SMTP
+	Webhook     EmailWebhook
+}
+
+// EmailWebhook verifies the signed event webhook of SendGrid with the base64 PublicKey, an event batch signed
+// more than ToleranceSecond ago is rejected so a captured request cannot be replayed.
+type EmailWebhook struct {
+	PublicKey       string
+	ToleranceSecond time.Duration
+}
+
+// SMTP is the mail server the smtp driver talks to, the connection is upgraded with STARTTLS when the server
+// offers it and the username is used to authenticate only when it is set.
+type SMTP struct {
+	Host          string
+	Port          int
+	Username      string
+	Password      string
+	TimeoutSecond time.Duration
+}
+
 type Oauth struct {
 	Google
 }
@@ -177,6 +354,45 @@ type Minio struct {
 	BucketName string
 }
 
+const (
+	StorageDriverMinio = "minio"
+	StorageDriverLocal = "local"
+)
+
+// Storage selects where files are kept, the local driver writes them under LocalPath and serves them
+// from LocalURL with links signed by LocalSecret, so development does not need a running MinIO.
+type Storage struct {
+	Driver      string
+	LocalPath   string
+	LocalURL    string
+	LocalSecret string
+}
+
+// StorageLocalSecretMinLength is the length in bytes LocalSecret needs at least, the size of the HMAC-SHA256 key it is.
+const StorageLocalSecretMinLength = 32
+
+// Validate reports a LocalSecret too short to sign the links of the local driver, MinIO signs its own links.
+func (r Storage) Validate() error {
+	if r.Driver == StorageDriverLocal && len(r.LocalSecret) < StorageLocalSecretMinLength {
+		return fmt.Errorf("STORAGE_LOCAL_SECRET must be at least %d characters long", StorageLocalSecretMinLength)
+	}
+	return nil
+}
+
+// Upload limits the files uploaded straight to the object storage through upload sessions.
+type Upload struct {
+	URLExpireSecond time.Duration
+	AudioMaxSize    int64
+	ImportMaxSize   int64
+}
+
+type Avatar struct {
+	MaxSize         int64
+	MaxDimension    int
+	ThumbnailSizes  []int
+	URLExpireSecond time.Duration
+}
+
 // Config represents the application configuration.
 type Config struct {
 	Kong           Kong
@@ -191,10 +407,23 @@ type Config struct {
 	Redis          Redis
 	Jwt            Jwt
 	OTP            OTP
+	ACL            ACL
+	Invitation     Invitation
+	Unsubscribe    Unsubscribe
+	Stream         NotificationStream
+	DataExport     DataExport
+	Queue          Queue
+	Consumer       Consumer
+	RedisStream    RedisStream
 	RabbitMQ       RabbitMQ
+	Outbox         Outbox
 	SendGrid       SendGrid
+	Email          Email
 	Oauth          Oauth
 	Minio          Minio
+	Storage        Storage
+	Avatar         Avatar
+	Upload         Upload
 	Password       Password
 }
 
@@ -225,6 +454,8 @@ func (r *Config) LoadConfig(envPath ...string) (Config, error) {
 	app.GracefullyShutdown = time.Duration(getIntEnv("APP_GRACEFULLY_SHUTDOWN", 5))
 	app.ResetPasswordURL = os.Getenv("APP_RESET_PASSWORD_URL")
 	app.VerificationURL = os.Getenv("APP_VERIFICATION_URL")
+	app.InvitationURL = os.Getenv("APP_INVITATION_URL")
+	app.UnsubscribeURL = os.Getenv("APP_UNSUBSCRIBE_URL")
 	app.SupportEmail = os.Getenv("APP_SUPPORT_EMAIL")
 
 	var auth Auth
@@ -303,21 +534,95 @@ func (r *Config) LoadConfig(envPath ...string) (Config, error) {
 	jwt.AccessTokenExpireDay = time.Duration(getIntEnv("JWT_ACCESS_TOKEN_EXPIRE_DAY", 7))
 
 	var password Password
-	password.BcryptCost = getIntEnv("PASSWORD_BCRYPT_COST", 10)
+	password.Argon2Memory = uint32(getIntEnv("PASSWORD_ARGON2_MEMORY", 19456))
+	password.Argon2Iterations = uint32(getIntEnv("PASSWORD_ARGON2_ITERATIONS", 2))
+	password.Argon2Parallelism = uint8(getIntEnv("PASSWORD_ARGON2_PARALLELISM", 1))
+	password.Argon2SaltLength = uint32(getIntEnv("PASSWORD_ARGON2_SALT_LENGTH", 16))
+	password.Argon2KeyLength = uint32(getIntEnv("PASSWORD_ARGON2_KEY_LENGTH", 32))
+	password.MinLength = getIntEnv("PASSWORD_MIN_LENGTH", 8)
+	password.RequireUpper = getBoolEnv("PASSWORD_REQUIRE_UPPER", true)
+	password.RequireLower = getBoolEnv("PASSWORD_REQUIRE_LOWER", true)
+	password.RequireDigit = getBoolEnv("PASSWORD_REQUIRE_DIGIT", true)
+	password.RequireSpecial = getBoolEnv("PASSWORD_REQUIRE_SPECIAL", true)
+	password.MaxRepeat = getIntEnv("PASSWORD_MAX_REPEAT", 3)
+	password.HistorySize = getIntEnv("PASSWORD_HISTORY_SIZE", 5)
+	password.BreachedCheck = getBoolEnv("PASSWORD_BREACHED_CHECK", true)
+	password.BreachedListPath = os.Getenv("PASSWORD_BREACHED_LIST_PATH")
 
 	var otp OTP
 	otp.ExpireSecond = time.Duration(getIntEnv("OTP_EXPIRE_SECOND", 7)) * time.Second
 	otp.ForgetPasswordExpireSecond = time.Duration(getIntEnv("FORGET_PASSWORD_EXPIRE_SECOND", 86400)) * time.Second
 	otp.Digits = int8(getIntEnv("OTP_DIGITS", 6))
+	otp.LinkSecret = os.Getenv("OTP_LINK_SECRET")
+
+	var acl ACL
+	acl.CacheExpireSecond = time.Duration(getIntEnv("ACL_CACHE_EXPIRE_SECOND", 300)) * time.Second
+
+	var invitation Invitation
+	invitation.ExpireSecond = time.Duration(getIntEnv("INVITATION_EXPIRE_SECOND", 604800)) * time.Second
+
+	var unsubscribe Unsubscribe
+	unsubscribe.Secret = os.Getenv("NOTIFICATION_UNSUBSCRIBE_SECRET")
+
+	var stream NotificationStream
+	stream.HeartbeatSecond = time.Duration(getIntEnv("NOTIFICATION_STREAM_HEARTBEAT_SECOND", 25)) * time.Second
+
+	var dataExport DataExport
+	dataExport.LinkExpireSecond = time.Duration(getIntEnv("DATA_EXPORT_LINK_EXPIRE_SECOND", 86400)) * time.Second
+
+	var queue Queue
+	queue.Driver = getStringEnv("QUEUE_DRIVER", QueueDriverRabbitMQ)
+	queue.ProcessedMessageTTLSecond = time.Duration(getIntEnv("QUEUE_PROCESSED_MESSAGE_TTL_SECOND", 604800)) * time.Second
+	queue.ClaimTTLSecond = time.Duration(getIntEnv("QUEUE_CLAIM_TTL_SECOND", 600)) * time.Second
+	queue.RetryMaxAttempts = getIntEnv("QUEUE_RETRY_MAX_ATTEMPTS", 5)
+	queue.RetryInitialDelaySecond = time.Duration(getIntEnv("QUEUE_RETRY_INITIAL_DELAY_SECOND", 5)) * time.Second
+	queue.RetryMaxDelaySecond = time.Duration(getIntEnv("QUEUE_RETRY_MAX_DELAY_SECOND", 600)) * time.Second
+
+	var consumer Consumer
+	consumer.Workers = getIntEnv("CONSUMER_WORKERS", 1)
+	consumer.Prefetch = getIntEnv("CONSUMER_PREFETCH", 10)
+	consumer.EventWorkers = getIntMapEnv("CONSUMER_EVENT_WORKERS")
+	consumer.EventPrefetch = getIntMapEnv("CONSUMER_EVENT_PREFETCH")
+	consumer.ShutdownTimeoutSecond = time.Duration(getIntEnv("CONSUMER_SHUTDOWN_TIMEOUT_SECOND", 30)) * time.Second
+
+	var redisStream RedisStream
+	redisStream.BatchSize = getIntEnv("REDIS_STREAM_BATCH_SIZE", 10)
+	redisStream.PollIntervalSecond = time.Duration(getIntEnv("REDIS_STREAM_POLL_INTERVAL_SECOND", 1)) * time.Second
+	redisStream.ClaimIdleSecond = time.Duration(getIntEnv("REDIS_STREAM_CLAIM_IDLE_SECOND", 60)) * time.Second
 
 	var rabbitMQ RabbitMQ
 	rabbitMQ.URL = os.Getenv("RABBITMQ_URL")
+	rabbitMQ.ReconnectInitialDelaySecond = time.Duration(getIntEnv("RABBITMQ_RECONNECT_INITIAL_DELAY_SECOND", 1)) * time.Second
+	rabbitMQ.ReconnectMaxDelaySecond = time.Duration(getIntEnv("RABBITMQ_RECONNECT_MAX_DELAY_SECOND", 30)) * time.Second
+	rabbitMQ.PublisherPoolSize = getIntEnv("RABBITMQ_PUBLISHER_POOL_SIZE", 4)
+	rabbitMQ.ConfirmTimeoutSecond = time.Duration(getIntEnv("RABBITMQ_CONFIRM_TIMEOUT_SECOND", 5)) * time.Second
+
+	var outbox Outbox
+	outbox.RelayIntervalSecond = time.Duration(getIntEnv("OUTBOX_RELAY_INTERVAL_SECOND", 1)) * time.Second
+	outbox.BatchSize = getIntEnv("OUTBOX_BATCH_SIZE", 100)
+	outbox.RetryDelaySecond = time.Duration(getIntEnv("OUTBOX_RETRY_DELAY_SECOND", 5)) * time.Second
+	outbox.RetryMaxDelaySecond = time.Duration(getIntEnv("OUTBOX_RETRY_MAX_DELAY_SECOND", 300)) * time.Second
+	outbox.RetentionSecond = time.Duration(getIntEnv("OUTBOX_RETENTION_SECOND", 604800)) * time.Second
+	outbox.CleanupIntervalSecond = time.Duration(getIntEnv("OUTBOX_CLEANUP_INTERVAL_SECOND", 3600)) * time.Second
 
 	var sendGrid SendGrid
 	sendGrid.Key = os.Getenv("SEND_GRID_KEY")
 	sendGrid.Name = os.Getenv("SEND_GRID_NAME")
 	sendGrid.Address = os.Getenv("SEND_GRID_ADDRESS")
 
+	var email Email
+	email.Driver = getStringEnv("EMAIL_DRIVER", EmailDriverSendGrid)
+	email.FromName = os.Getenv("EMAIL_FROM_NAME")
+	email.FromAddress = os.Getenv("EMAIL_FROM_ADDRESS")
+	email.FilePath = getStringEnv("EMAIL_FILE_PATH", "./storage/emails")
+	email.SMTP.Host = getStringEnv("EMAIL_SMTP_HOST", "localhost")
+	email.SMTP.Port = getIntEnv("EMAIL_SMTP_PORT", 1025)
+	email.SMTP.Username = os.Getenv("EMAIL_SMTP_USERNAME")
+	email.SMTP.Password = os.Getenv("EMAIL_SMTP_PASSWORD")
+	email.SMTP.TimeoutSecond = time.Duration(getIntEnv("EMAIL_SMTP_TIMEOUT_SECOND", 10)) * time.Second
+	email.Webhook.PublicKey = os.Getenv("EMAIL_WEBHOOK_PUBLIC_KEY")
+	email.Webhook.ToleranceSecond = time.Duration(getIntEnv("EMAIL_WEBHOOK_TOLERANCE_SECOND", 300)) * time.Second
+
 	var oauth Oauth
 	oauth.Google.ClientId = os.Getenv("OAUTH_GOOGLE_CLIENT_ID")
 	oauth.Google.ClientSecret = os.Getenv("OAUTH_GOOGLE_CLIENT_SECRET")
@@ -330,6 +635,23 @@ func (r *Config) LoadConfig(envPath ...string) (Config, error) {
 	minio.Secret = os.Getenv("MINIO_SECRET")
 	minio.BucketName = os.Getenv("MINIO_BUCKET_NAME")
 
+	var storage Storage
+	storage.Driver = getStringEnv("STORAGE_DRIVER", StorageDriverMinio)
+	storage.LocalPath = getStringEnv("STORAGE_LOCAL_PATH", "./storage")
+	storage.LocalURL = os.Getenv("STORAGE_LOCAL_URL")
+	storage.LocalSecret = os.Getenv("STORAGE_LOCAL_SECRET")
+
+	var avatar Avatar
+	avatar.MaxSize = int64(getIntEnv("AVATAR_MAX_SIZE", 5242880))
+	avatar.MaxDimension = getIntEnv("AVATAR_MAX_DIMENSION", 4096)
+	avatar.ThumbnailSizes = getIntListEnv("AVATAR_THUMBNAIL_SIZES", []int{64, 128, 256})
+	avatar.URLExpireSecond = time.Duration(getIntEnv("AVATAR_URL_EXPIRE_SECOND", 3600)) * time.Second
+
+	var upload Upload
+	upload.URLExpireSecond = time.Duration(getIntEnv("UPLOAD_URL_EXPIRE_SECOND", 900)) * time.Second
+	upload.AudioMaxSize = int64(getIntEnv("UPLOAD_AUDIO_MAX_SIZE", 20971520))
+	upload.ImportMaxSize = int64(getIntEnv("UPLOAD_IMPORT_MAX_SIZE", 52428800))
+
 	return Config{
 		Kong:           kong,
 		App:            app,
@@ -343,10 +665,23 @@ func (r *Config) LoadConfig(envPath ...string) (Config, error) {
 		Redis:          redis,
 		Jwt:            jwt,
 		OTP:            otp,
+		ACL:            acl,
+		Invitation:     invitation,
+		Unsubscribe:    unsubscribe,
+		Stream:         stream,
+		DataExport:     dataExport,
+		Queue:          queue,
+		Consumer:       consumer,
+		RedisStream:    redisStream,
 		RabbitMQ:       rabbitMQ,
+		Outbox:         outbox,
 		SendGrid:       sendGrid,
+		Email:          email,
 		Oauth:          oauth,
 		Minio:          minio,
+		Storage:        storage,
+		Avatar:         avatar,
+		Upload:         upload,
 		Password:       password,
 	}, nil
 }
@@ -360,6 +695,14 @@ func getBoolEnv(key string, defaultValue bool) bool {
 	return val
 }
 
+// Helper function to read a string environment variable with a fallback when it is empty
+func getStringEnv(key string, defaultValue string) string {
+	if val := os.Getenv(key); val != "" {
+		return val
+	}
+	return defaultValue
+}
+
 // Helper function to convert string environment variable to int
 func getIntEnv(key string, defaultValue int) int {
 	val, err := strconv.Atoi(os.Getenv(key))
@@ -369,6 +712,41 @@ func getIntEnv(key string, defaultValue int) int {
 	return val
 }
 
+// Helper function to convert a comma separated environment variable to a list of int
+func getIntListEnv(key string, defaultValue []int) []int {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return defaultValue
+	}
+
+	var values []int
+	for _, item := range strings.Split(value, ",") {
+		val, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil {
+			return defaultValue
+		}
+		values = append(values, val)
+	}
+	return values
+}
+
+// Helper function to convert a comma separated list of key:int pairs to a map, the malformed pairs are skipped
+func getIntMapEnv(key string) map[string]int {
+	values := make(map[string]int)
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(item), ":")
+		if !found {
+			continue
+		}
+		val, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+		values[strings.TrimSpace(name)] = val
+	}
+	return values
+}
+
 // GetConfig loads the configuration once and returns it.
 func (r *Config) GetConfig(envPath ...string) Config {
 	once.Do(func() {
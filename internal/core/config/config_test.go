@@ -65,3 +65,72 @@ func TestGetConfigPanic(t *testing.T) {
 		_ = conf.GetConfig("testdata/config/.invalid")
 	}, "Expected GetConfig to panic due to no such file or directory")
 }
+
+func TestConsumer(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	t.Setenv("CONSUMER_WORKERS", "2")
+	t.Setenv("CONSUMER_EVENT_WORKERS", "send_email_otp:4, send_welcome:x,send_invitation")
+	t.Setenv("CONSUMER_EVENT_PREFETCH", "send_email_otp:20")
+
+	conf := config.Config{}
+	cfg, err := conf.LoadConfig("testdata/config/.env")
+	require.NoError(t, err)
+
+	require.Equal(t, 4, cfg.Consumer.WorkersOf("send_email_otp"))
+	require.Equal(t, 2, cfg.Consumer.WorkersOf("send_welcome"))
+	require.Equal(t, 2, cfg.Consumer.WorkersOf("send_invitation"))
+	require.Equal(t, 20, cfg.Consumer.PrefetchOf("send_email_otp"))
+	require.Equal(t, 10, cfg.Consumer.PrefetchOf("send_welcome"))
+	require.Equal(t, 30*time.Second, cfg.Consumer.ShutdownTimeoutSecond)
+}
+
+func TestOTP_Validate(t *testing.T) {
+	t.Run("Validate empty secret error", func(t *testing.T) {
+		require.Error(t, config.OTP{}.Validate())
+	})
+
+	t.Run("Validate short secret error", func(t *testing.T) {
+		require.Error(t, config.OTP{LinkSecret: "secret"}.Validate())
+	})
+
+	t.Run("Validate success", func(t *testing.T) {
+		require.NoError(t, config.OTP{LinkSecret: "Jx3Vq8LrW2sTn6YbK0pHd5MfZc9GuA1e"}.Validate())
+	})
+}
+
+func TestUnsubscribe_Validate(t *testing.T) {
+	t.Run("Validate empty secret error", func(t *testing.T) {
+		require.Error(t, config.Unsubscribe{}.Validate())
+	})
+
+	t.Run("Validate short secret error", func(t *testing.T) {
+		require.Error(t, config.Unsubscribe{Secret: "secret"}.Validate())
+	})
+
+	t.Run("Validate success", func(t *testing.T) {
+		require.NoError(t, config.Unsubscribe{Secret: "Qm7Tz2KcR9wXe4HnV1bLs8YdJ5pFa3Ug"}.Validate())
+	})
+}
+
+func TestStorage_Validate(t *testing.T) {
+	t.Run("Validate local driver empty secret error", func(t *testing.T) {
+		require.Error(t, config.Storage{Driver: config.StorageDriverLocal}.Validate())
+	})
+
+	t.Run("Validate local driver short secret error", func(t *testing.T) {
+		require.Error(t, config.Storage{Driver: config.StorageDriverLocal, LocalSecret: "secret"}.Validate())
+	})
+
+	t.Run("Validate local driver success", func(t *testing.T) {
+		require.NoError(t, config.Storage{
+			Driver:      config.StorageDriverLocal,
+			LocalSecret: "Qm7Tz2Vw9KcR4nXb8LsHd1YpF6GeJ3Ua",
+		}.Validate())
+	})
+
+	t.Run("Validate minio driver without secret success", func(t *testing.T) {
+		require.NoError(t, config.Storage{Driver: config.StorageDriverMinio}.Validate())
+	})
+}
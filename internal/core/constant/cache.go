@@ -12,4 +12,13 @@ const (
 
 const (
 	RoleKeyPrefix string = "role"
+	ACLKeyPrefix  string = "acl"
+)
+
+const (
+	ProcessedMessageKeyPrefix string = "processed_message"
+)
+
+const (
+	NotificationChannelPrefix string = "notification"
 )
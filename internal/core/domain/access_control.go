@@ -8,3 +8,54 @@ type AccessControl struct {
 	RoleID       *uint
 	PermissionID *uint
 }
+
+type UserAccess struct {
+	UserID         uint64
+	RoleKeys       []RoleKeyType
+	PermissionKeys []PermissionKeyType
+}
+
+func (r *UserAccess) IsSuperAdmin() bool {
+	for _, key := range r.RoleKeys {
+		if key == RoleKeySuperAdmin {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *UserAccess) HasAnyPermission(requiredPermissions ...PermissionKeyType) bool {
+	for _, requiredPermission := range requiredPermissions {
+		if requiredPermission == PermissionKeyNone {
+			return true
+		}
+		for _, key := range r.PermissionKeys {
+			if requiredPermission == key {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// GrantedPermissions returns the required permissions the user holds, a super admin holds all of them.
+func (r *UserAccess) GrantedPermissions(requiredPermissions ...PermissionKeyType) []PermissionKeyType {
+	var granted []PermissionKeyType
+	for _, requiredPermission := range requiredPermissions {
+		if requiredPermission == PermissionKeyNone {
+			continue
+		}
+		if r.IsSuperAdmin() || r.HasAnyPermission(requiredPermission) {
+			granted = append(granted, requiredPermission)
+		}
+	}
+
+	return granted
+}
+
+// IsOwner reports whether the user created the resource described by modifier.
+func (r *UserAccess) IsOwner(modifier Modifier) bool {
+	return modifier.CreatedBy != nil && *modifier.CreatedBy == r.UserID
+}
@@ -0,0 +1,55 @@
+package domain_test
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestUserAccess_GrantedPermissions(t *testing.T) {
+	tests := []struct {
+		name           string
+		access         domain.UserAccess
+		required       []domain.PermissionKeyType
+		expectedResult []domain.PermissionKeyType
+	}{
+		{
+			name: "only held permissions are granted",
+			access: domain.UserAccess{
+				PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyUpdateOwnSentence},
+			},
+			required:       []domain.PermissionKeyType{domain.PermissionKeyUpdateSentence, domain.PermissionKeyUpdateOwnSentence},
+			expectedResult: []domain.PermissionKeyType{domain.PermissionKeyUpdateOwnSentence},
+		},
+		{
+			name: "super admin is granted everything required",
+			access: domain.UserAccess{
+				RoleKeys: []domain.RoleKeyType{domain.RoleKeySuperAdmin},
+			},
+			required:       []domain.PermissionKeyType{domain.PermissionKeyUpdateSentence, domain.PermissionKeyUpdateOwnSentence},
+			expectedResult: []domain.PermissionKeyType{domain.PermissionKeyUpdateSentence, domain.PermissionKeyUpdateOwnSentence},
+		},
+		{
+			name:           "NONE is never reported",
+			access:         domain.UserAccess{},
+			required:       []domain.PermissionKeyType{domain.PermissionKeyNone},
+			expectedResult: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedResult, test.access.GrantedPermissions(test.required...))
+		})
+	}
+}
+
+func TestUserAccess_IsOwner(t *testing.T) {
+	var creatorID uint64 = 5
+
+	access := domain.UserAccess{UserID: creatorID}
+
+	require.True(t, access.IsOwner(domain.Modifier{CreatedBy: &creatorID}))
+	require.False(t, access.IsOwner(domain.Modifier{}))
+	require.False(t, (&domain.UserAccess{UserID: 6}).IsOwner(domain.Modifier{CreatedBy: &creatorID}))
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"reflect"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditActionRoleCreated            AuditAction = "ROLE_CREATED"
+	AuditActionRoleUpdated            AuditAction = "ROLE_UPDATED"
+	AuditActionRoleDeleted            AuditAction = "ROLE_DELETED"
+	AuditActionRolePermissionsSynced  AuditAction = "ROLE_PERMISSIONS_SYNCED"
+	AuditActionPermissionRegistrySync AuditAction = "PERMISSION_REGISTRY_SYNCED"
+	AuditActionUserLoggedIn           AuditAction = "USER_LOGGED_IN"
+	AuditActionUserLoginFailed        AuditAction = "USER_LOGIN_FAILED"
+	AuditActionUserPasswordReset      AuditAction = "USER_PASSWORD_RESET"
+	AuditActionUserDataExported       AuditAction = "USER_DATA_EXPORTED"
+	AuditActionUserErased             AuditAction = "USER_ERASED"
+)
+
+type AuditTargetType string
+
+const (
+	AuditTargetRole       AuditTargetType = "role"
+	AuditTargetPermission AuditTargetType = "permission"
+	AuditTargetUser       AuditTargetType = "user"
+)
+
+// AuditActor describes who performed an audited action and from where, UserID is nil for system actions.
+type AuditActor struct {
+	UserID    *uint64
+	UserUUID  uuid.UUID
+	IP        string
+	UserAgent string
+}
+
+// AuditChange holds the value of a field before and after an audited action.
+type AuditChange struct {
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+type AuditLog struct {
+	Base
+
+	Actor      AuditActor
+	Action     AuditAction
+	TargetType AuditTargetType
+	TargetUUID *uuid.UUID
+	Changes    map[string]AuditChange
+}
+
+type AuditLogFilter struct {
+	ActorUUID  *uuid.UUID
+	Action     *AuditAction
+	TargetType *AuditTargetType
+	TargetUUID *uuid.UUID
+	From       *time.Time
+	To         *time.Time
+
+	Page    uint64
+	PerPage uint64
+}
+
+func (r AuditLogFilter) Offset() uint64 {
+	if r.Page <= 1 {
+		return 0
+	}
+
+	return (r.Page - 1) * r.PerPage
+}
+
+// NewAuditLog builds an audit entry holding only the fields whose value differs between before and after.
+func NewAuditLog(
+	actor AuditActor,
+	action AuditAction,
+	targetType AuditTargetType,
+	targetUUID *uuid.UUID,
+	before map[string]interface{},
+	after map[string]interface{},
+) AuditLog {
+	changes := make(map[string]AuditChange)
+	for field, value := range before {
+		if afterValue, ok := after[field]; !ok || !reflect.DeepEqual(value, afterValue) {
+			changes[field] = AuditChange{Before: value, After: afterValue}
+		}
+	}
+	for field, value := range after {
+		if _, ok := before[field]; !ok {
+			changes[field] = AuditChange{After: value}
+		}
+	}
+
+	return AuditLog{
+		Actor:      actor,
+		Action:     action,
+		TargetType: targetType,
+		TargetUUID: targetUUID,
+		Changes:    changes,
+	}
+}
@@ -0,0 +1,46 @@
+package domain_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewAuditLog(t *testing.T) {
+	targetUUID := uuid.New()
+	actor := domain.AuditActor{IP: "127.0.0.1", UserAgent: "Go-http-client/1.1"}
+
+	t.Run("keeps changed fields only", func(t *testing.T) {
+		auditLog := domain.NewAuditLog(
+			actor,
+			domain.AuditActionRoleUpdated,
+			domain.AuditTargetRole,
+			&targetUUID,
+			map[string]interface{}{"title": "Staff", "key": "STAFF", "parent": "a"},
+			map[string]interface{}{"title": "Staff", "key": "STAFF_MEMBER", "description": "new"},
+		)
+
+		require.Equal(t, actor, auditLog.Actor)
+		require.Equal(t, domain.AuditActionRoleUpdated, auditLog.Action)
+		require.Equal(t, domain.AuditTargetRole, auditLog.TargetType)
+		require.Equal(t, &targetUUID, auditLog.TargetUUID)
+		require.Equal(t, map[string]domain.AuditChange{
+			"key":         {Before: "STAFF", After: "STAFF_MEMBER"},
+			"parent":      {Before: "a"},
+			"description": {After: "new"},
+		}, auditLog.Changes)
+	})
+
+	t.Run("without snapshots", func(t *testing.T) {
+		auditLog := domain.NewAuditLog(actor, domain.AuditActionUserLoggedIn, domain.AuditTargetUser, &targetUUID, nil, nil)
+
+		require.Empty(t, auditLog.Changes)
+	})
+}
+
+func TestAuditLogFilter_Offset(t *testing.T) {
+	require.Equal(t, uint64(0), domain.AuditLogFilter{Page: 0, PerPage: 20}.Offset())
+	require.Equal(t, uint64(0), domain.AuditLogFilter{Page: 1, PerPage: 20}.Offset())
+	require.Equal(t, uint64(40), domain.AuditLogFilter{Page: 3, PerPage: 20}.Offset())
+}
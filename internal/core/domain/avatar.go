@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"path"
+	"strconv"
+	"strings"
+)
+
+// AvatarPrefix is the folder of the avatars uploaded through the pipeline,
+// any other avatar value is an external URL such as the Google profile picture.
+const AvatarPrefix = "avatars/"
+
+// AvatarURLs are the download links of an avatar, the thumbnails are keyed by their size in pixels.
+type AvatarURLs struct {
+	Original   string
+	Thumbnails map[int]string
+}
+
+func IsStoredAvatar(avatar string) bool {
+	return strings.HasPrefix(avatar, AvatarPrefix)
+}
+
+// AvatarThumbnailKey is the object name of a thumbnail, "avatars/<hash>.png" has "avatars/<hash>_64.png" as its 64 pixels thumbnail.
+func AvatarThumbnailKey(key string, size int) string {
+	ext := path.Ext(key)
+	return strings.TrimSuffix(key, ext) + "_" + strconv.Itoa(size) + ext
+}
@@ -0,0 +1,18 @@
+package domain_test
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIsStoredAvatar(t *testing.T) {
+	require.True(t, domain.IsStoredAvatar("avatars/abc.png"))
+	require.False(t, domain.IsStoredAvatar("https://lh3.googleusercontent.com/a/abc"))
+	require.False(t, domain.IsStoredAvatar(""))
+}
+
+func TestAvatarThumbnailKey(t *testing.T) {
+	require.Equal(t, "avatars/abc_64.png", domain.AvatarThumbnailKey("avatars/abc.png", 64))
+	require.Equal(t, "avatars/abc_128.jpg", domain.AvatarThumbnailKey("avatars/abc.jpg", 128))
+}
@@ -0,0 +1,24 @@
+package domain
+
+// Email is a rendered email, Text is the plaintext alternative of HTML for clients that do not show HTML.
+// Headers are added to the message as they are and ID, when set, is handed to the provider so its events
+// about the email carry it back.
+type Email struct {
+	ID      string
+	To      string
+	Name    string
+	Subject string
+	HTML    string
+	Text    string
+	Headers map[string]string
+}
+
+// SetUnsubscribe adds the List-Unsubscribe headers, so mail clients offer to unsubscribe with a single POST
+// to url as described by RFC 8058.
+func (r *Email) SetUnsubscribe(url string) {
+	if r.Headers == nil {
+		r.Headers = make(map[string]string)
+	}
+	r.Headers["List-Unsubscribe"] = "<" + url + ">"
+	r.Headers["List-Unsubscribe-Post"] = "List-Unsubscribe=One-Click"
+}
@@ -0,0 +1,98 @@
+package domain
+
+type EmailDeliveryStatusType string
+
+const (
+	EmailDeliveryStatusSent         EmailDeliveryStatusType = "SENT"
+	EmailDeliveryStatusFailed       EmailDeliveryStatusType = "FAILED"
+	EmailDeliveryStatusSuppressed   EmailDeliveryStatusType = "SUPPRESSED"
+	EmailDeliveryStatusDelivered    EmailDeliveryStatusType = "DELIVERED"
+	EmailDeliveryStatusBounced      EmailDeliveryStatusType = "BOUNCED"
+	EmailDeliveryStatusSpamReported EmailDeliveryStatusType = "SPAM_REPORTED"
+)
+
+// emailDeliveryStatusOrder ranks the statuses reported by the provider, the events of an email may arrive
+// in any order and a status never goes back to a lower one, a spam report after the delivery is kept.
+var emailDeliveryStatusOrder = map[EmailDeliveryStatusType]int{
+	EmailDeliveryStatusSent:         1,
+	EmailDeliveryStatusDelivered:    2,
+	EmailDeliveryStatusBounced:      3,
+	EmailDeliveryStatusSpamReported: 4,
+}
+
+func (r EmailDeliveryStatusType) IsValid() bool {
+	switch r {
+	case EmailDeliveryStatusSent,
+		EmailDeliveryStatusFailed,
+		EmailDeliveryStatusSuppressed,
+		EmailDeliveryStatusDelivered,
+		EmailDeliveryStatusBounced,
+		EmailDeliveryStatusSpamReported:
+		return true
+	}
+	return false
+}
+
+// CanBecome reports whether an email in this status moves to next, only a sent email gets the statuses of
+// the provider events.
+func (r EmailDeliveryStatusType) CanBecome(next EmailDeliveryStatusType) bool {
+	current, ok := emailDeliveryStatusOrder[r]
+	if !ok {
+		return false
+	}
+
+	return emailDeliveryStatusOrder[next] > current
+}
+
+// EmailDelivery is an email handed to the provider, its UUID is sent along with the email so the events of
+// the provider find it.
+type EmailDelivery struct {
+	Base
+
+	Email    string
+	Subject  string
+	Provider string
+	Status   EmailDeliveryStatusType
+	Reason   string
+}
+
+type EmailSuppressionReasonType string
+
+const (
+	EmailSuppressionReasonBounce     EmailSuppressionReasonType = "BOUNCE"
+	EmailSuppressionReasonSpamReport EmailSuppressionReasonType = "SPAM_REPORT"
+)
+
+// EmailSuppression is an address no email is sent to anymore.
+type EmailSuppression struct {
+	Base
+
+	Email       string
+	Reason      EmailSuppressionReasonType
+	Description string
+}
+
+// EmailEvent is an event of the provider about a sent email, Suppression is set when the address must not get
+// any other email.
+type EmailEvent struct {
+	DeliveryUUID string
+	Email        string
+	Status       EmailDeliveryStatusType
+	Reason       string
+	Suppression  EmailSuppressionReasonType
+}
+
+type EmailSuppressionFilter struct {
+	Email *string
+
+	Page    uint64
+	PerPage uint64
+}
+
+func (r EmailSuppressionFilter) Offset() uint64 {
+	if r.Page <= 1 {
+		return 0
+	}
+
+	return (r.Page - 1) * r.PerPage
+}
@@ -0,0 +1,30 @@
+package domain_test
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestEmailDeliveryStatusType_CanBecome(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  domain.EmailDeliveryStatusType
+		next     domain.EmailDeliveryStatusType
+		expected bool
+	}{
+		{name: "Sent to delivered", current: domain.EmailDeliveryStatusSent, next: domain.EmailDeliveryStatusDelivered, expected: true},
+		{name: "Sent to bounced", current: domain.EmailDeliveryStatusSent, next: domain.EmailDeliveryStatusBounced, expected: true},
+		{name: "Delivered to spam reported", current: domain.EmailDeliveryStatusDelivered, next: domain.EmailDeliveryStatusSpamReported, expected: true},
+		{name: "Bounced to delivered", current: domain.EmailDeliveryStatusBounced, next: domain.EmailDeliveryStatusDelivered, expected: false},
+		{name: "Delivered twice", current: domain.EmailDeliveryStatusDelivered, next: domain.EmailDeliveryStatusDelivered, expected: false},
+		{name: "Suppressed to delivered", current: domain.EmailDeliveryStatusSuppressed, next: domain.EmailDeliveryStatusDelivered, expected: false},
+		{name: "Failed to bounced", current: domain.EmailDeliveryStatusFailed, next: domain.EmailDeliveryStatusBounced, expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, test.current.CanBecome(test.next))
+		})
+	}
+}
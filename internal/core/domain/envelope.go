@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+// CorrelationIDKey is the context key of the id shared by a request and every message it causes.
+const CorrelationIDKey = "correlationID"
+
+// MessageIDKey is the context key of the envelope id of the message being consumed.
+const MessageIDKey = "messageID"
+
+// Envelope wraps every queue message, its ID stays the same across redeliveries and retries,
+// so consumers can tell a message they already handled from a new one.
+type Envelope struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	Version       int             `json:"version"`
+	ProducedAt    time.Time       `json:"producedAt"`
+	CorrelationID string          `json:"correlationId"`
+	Attempt       int             `json:"attempt"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// NewEnvelope wraps the message of the event type with the given schema version, the correlation id
+// is taken from ctx and a message produced outside a request starts a correlation of its own.
+func NewEnvelope(ctx context.Context, eventType string, version int, message interface{}) (*Envelope, error) {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.NewString()
+	correlationID := CorrelationID(ctx)
+	if correlationID == "" {
+		correlationID = id
+	}
+
+	return &Envelope{
+		ID:            id,
+		Type:          eventType,
+		Version:       version,
+		ProducedAt:    time.Now(),
+		CorrelationID: correlationID,
+		Payload:       payload,
+	}, nil
+}
+
+// DecodeEnvelope reads an enveloped message, a bare message published before the envelope existed
+// comes back as the payload of an envelope without an ID.
+func DecodeEnvelope(message []byte) Envelope {
+	var envelope Envelope
+	if err := json.Unmarshal(message, &envelope); err != nil || envelope.ID == "" || envelope.Payload == nil {
+		return Envelope{Payload: message}
+	}
+
+	return envelope
+}
+
+// EnvelopeWithAttempt returns the message with the attempt count of its envelope set, a bare message is returned as is.
+func EnvelopeWithAttempt(message []byte, attempt int) []byte {
+	envelope := DecodeEnvelope(message)
+	if envelope.ID == "" {
+		return message
+	}
+
+	envelope.Attempt = attempt
+	encoded, err := json.Marshal(envelope)
+	if err != nil {
+		return message
+	}
+
+	return encoded
+}
+
+// WithCorrelationID returns a copy of ctx carrying the correlation id.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	// the key is a plain string, gin.Context resolves only string keys to the values set on it
+	return context.WithValue(ctx, CorrelationIDKey, correlationID)
+}
+
+// CorrelationID returns the correlation id carried by ctx, empty when there is none.
+func CorrelationID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	correlationID, _ := ctx.Value(CorrelationIDKey).(string)
+	return correlationID
+}
+
+// WithMessageID returns a copy of ctx carrying the envelope id of the consumed message.
+func WithMessageID(ctx context.Context, messageID string) context.Context {
+	return context.WithValue(ctx, MessageIDKey, messageID)
+}
+
+// MessageID returns the envelope id carried by ctx, empty outside a consumer or for a bare message.
+func MessageID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	messageID, _ := ctx.Value(MessageIDKey).(string)
+	return messageID
+}
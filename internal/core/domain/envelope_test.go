@@ -0,0 +1,73 @@
+package domain_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewEnvelope(t *testing.T) {
+	t.Run("NewEnvelope starts a correlation", func(t *testing.T) {
+		envelope, err := domain.NewEnvelope(context.Background(), "send_welcome", 1, map[string]string{"to": "john.doe@example.com"})
+
+		require.NoError(t, err)
+		require.NotEmpty(t, envelope.ID)
+		require.Equal(t, envelope.ID, envelope.CorrelationID)
+		require.Equal(t, "send_welcome", envelope.Type)
+		require.Equal(t, 1, envelope.Version)
+		require.Zero(t, envelope.Attempt)
+		require.JSONEq(t, `{"to":"john.doe@example.com"}`, string(envelope.Payload))
+	})
+
+	t.Run("NewEnvelope keeps the correlation of ctx", func(t *testing.T) {
+		ctx := domain.WithCorrelationID(context.Background(), "request-id")
+
+		envelope, err := domain.NewEnvelope(ctx, "send_welcome", 1, nil)
+
+		require.NoError(t, err)
+		require.Equal(t, "request-id", envelope.CorrelationID)
+		require.NotEqual(t, "request-id", envelope.ID)
+	})
+}
+
+func TestDecodeEnvelope(t *testing.T) {
+	envelope, err := domain.NewEnvelope(context.Background(), "send_welcome", 1, map[string]string{"to": "john.doe@example.com"})
+	require.NoError(t, err)
+	message, err := json.Marshal(envelope)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		message []byte
+		id      string
+		payload string
+	}{
+		{name: "enveloped message", message: message, id: envelope.ID, payload: `{"to":"john.doe@example.com"}`},
+		{name: "bare message", message: []byte(`{"to":"john.doe@example.com"}`), payload: `{"to":"john.doe@example.com"}`},
+		{name: "not a json object", message: []byte(`"otp"`), payload: `"otp"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decoded := domain.DecodeEnvelope(test.message)
+
+			require.Equal(t, test.id, decoded.ID)
+			require.JSONEq(t, test.payload, string(decoded.Payload))
+		})
+	}
+}
+
+func TestEnvelopeWithAttempt(t *testing.T) {
+	envelope, err := domain.NewEnvelope(context.Background(), "send_welcome", 1, "welcome")
+	require.NoError(t, err)
+	message, err := json.Marshal(envelope)
+	require.NoError(t, err)
+
+	retried := domain.DecodeEnvelope(domain.EnvelopeWithAttempt(message, 3))
+	require.Equal(t, envelope.ID, retried.ID)
+	require.Equal(t, 3, retried.Attempt)
+
+	require.Equal(t, []byte(`"welcome"`), domain.EnvelopeWithAttempt([]byte(`"welcome"`), 3))
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type NotificationType string
+
+const (
+	NotificationTypeAssignment   NotificationType = "ASSIGNMENT"
+	NotificationTypeStreakAtRisk NotificationType = "STREAK_AT_RISK"
+	NotificationTypeRoleChanged  NotificationType = "ROLE_CHANGED"
+)
+
+var NotificationTypes = []NotificationType{
+	NotificationTypeAssignment,
+	NotificationTypeStreakAtRisk,
+	NotificationTypeRoleChanged,
+}
+
+func (r NotificationType) IsValid() bool {
+	return slices.Contains(NotificationTypes, r)
+}
+
+// Notification is an in-app notification of a user, the title and the body are stored in the language
+// they were sent in. Link is where the client goes when the notification is opened.
+type Notification struct {
+	Base
+
+	UserID uint64
+	Type   NotificationType
+	Title  string
+	Body   string
+	Link   *string
+	ReadAt *time.Time
+}
+
+type NotificationFilter struct {
+	UnreadOnly bool
+
+	Page    uint64
+	PerPage uint64
+}
+
+func (r NotificationFilter) Offset() uint64 {
+	if r.Page <= 1 {
+		return 0
+	}
+
+	return (r.Page - 1) * r.PerPage
+}
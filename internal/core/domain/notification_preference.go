@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"slices"
+)
+
+type NotificationCategoryType string
+
+const (
+	NotificationCategorySecurity         NotificationCategoryType = "SECURITY"
+	NotificationCategoryProduct          NotificationCategoryType = "PRODUCT"
+	NotificationCategoryLearningReminder NotificationCategoryType = "LEARNING_REMINDER"
+)
+
+// NotificationCategories lists every category a notification is sent under, in the order they are shown.
+var NotificationCategories = []NotificationCategoryType{
+	NotificationCategorySecurity,
+	NotificationCategoryProduct,
+	NotificationCategoryLearningReminder,
+}
+
+func (r NotificationCategoryType) IsValid() bool {
+	return slices.Contains(NotificationCategories, r)
+}
+
+// IsEssential reports whether the notifications of the category are always sent, security notifications
+// such as verification codes and password resets cannot be turned off.
+func (r NotificationCategoryType) IsEssential() bool {
+	return r == NotificationCategorySecurity
+}
+
+// NotificationPreference is whether a user receives the notifications of a category, a user without
+// a stored preference receives them.
+type NotificationPreference struct {
+	UserID   uint64
+	Category NotificationCategoryType
+	Enabled  bool
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+)
+
+// OutboxMessage is a queue message stored in the same transaction as the change it announces,
+// the outbox relay publishes it once the transaction is committed.
+type OutboxMessage struct {
+	ID           uint64
+	Queue        string
+	Payload      []byte
+	DelaySeconds int64
+	Attempts     int
+	AvailableAt  time.Time
+	PublishedAt  *time.Time
+	CreatedAt    time.Time
+}
+
+// RemainingDelaySeconds is the part of the delay that has not passed since the message was stored.
+func (r *OutboxMessage) RemainingDelaySeconds(now time.Time) int64 {
+	remaining := r.DelaySeconds - int64(now.Sub(r.CreatedAt).Seconds())
+	return max(remaining, 0)
+}
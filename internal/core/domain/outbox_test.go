@@ -0,0 +1,17 @@
+package domain_test
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestOutboxMessage_RemainingDelaySeconds(t *testing.T) {
+	now := time.Now()
+	message := domain.OutboxMessage{DelaySeconds: 60, CreatedAt: now.Add(-20 * time.Second)}
+
+	require.Equal(t, int64(40), message.RemainingDelaySeconds(now))
+	require.Equal(t, int64(0), message.RemainingDelaySeconds(now.Add(time.Minute)))
+	require.Equal(t, int64(0), (&domain.OutboxMessage{CreatedAt: now}).RemainingDelaySeconds(now))
+}
@@ -17,6 +17,14 @@ const (
 	PermissionKeyReadUserRoles           PermissionKeyType = "READ_USER_ROLES"
 	PermissionKeySyncPermissionsWithRole PermissionKeyType = "SYNC_PERMISSIONS_WITH_ROLE"
 	PermissionKeyReadRolePermissions     PermissionKeyType = "READ_ROLE_PERMISSIONS"
+	PermissionKeyUpdateSentence          PermissionKeyType = "UPDATE_SENTENCE"
+	PermissionKeyUpdateOwnSentence       PermissionKeyType = "UPDATE_OWN_SENTENCE"
+	PermissionKeyDeleteSentence          PermissionKeyType = "DELETE_SENTENCE"
+	PermissionKeyDeleteOwnSentence       PermissionKeyType = "DELETE_OWN_SENTENCE"
+	PermissionKeyReadAuditLog            PermissionKeyType = "READ_AUDIT_LOG"
+	PermissionKeyEraseUser               PermissionKeyType = "ERASE_USER"
+	PermissionKeyPreviewEmailTemplate    PermissionKeyType = "PREVIEW_EMAIL_TEMPLATE"
+	PermissionKeyManageEmailSuppression  PermissionKeyType = "MANAGE_EMAIL_SUPPRESSION"
 )
 
 type Permission struct {
@@ -0,0 +1,62 @@
+package domain
+
+// PermissionDefinition declares a permission the way it has to exist in the permissions table.
+// Roles are the roles `migrate permissions --grant` attaches the permission to whenever it is missing from them,
+// a SUPER_ADMIN holds every permission without being attached to it.
+type PermissionDefinition struct {
+	Key         PermissionKeyType
+	Group       string
+	Title       string
+	Description string
+	Roles       []RoleKeyType
+}
+
+// PermissionRegistry is the single source of truth for permissions, every PermissionKeyType
+// except PermissionKeyNone must be declared here and `migrate permissions` syncs it into the database.
+var PermissionRegistry = []PermissionDefinition{
+	{Key: PermissionKeyCreateUser, Group: "user", Title: "Create user", Description: "Create a new user"},
+	{Key: PermissionKeyReadUser, Group: "user", Title: "Read user", Description: "Read user information"},
+	{Key: PermissionKeyUpdateUser, Group: "user", Title: "Update user", Description: "Update user information"},
+	{Key: PermissionKeyDeleteUser, Group: "user", Title: "Delete user", Description: "Delete user"},
+	{Key: PermissionKeyEraseUser, Group: "user", Title: "Erase user", Description: "Erase the personal data of a user", Roles: []RoleKeyType{RoleKeyAdmin}},
+	{Key: PermissionKeyCreateRole, Group: "role", Title: "Create role", Description: "Create a new role"},
+	{Key: PermissionKeyReadRole, Group: "role", Title: "Read role", Description: "Read role information"},
+	{Key: PermissionKeyUpdateRole, Group: "role", Title: "Update role", Description: "Update role information"},
+	{Key: PermissionKeyDeleteRole, Group: "role", Title: "Delete role", Description: "Delete role"},
+	{Key: PermissionKeyReadPermission, Group: "permission", Title: "Read permission", Description: "Read permission information"},
+	{Key: PermissionKeySyncRolesWithUser, Group: "access_control", Title: "Sync Roles With User", Description: "Sync roles With user"},
+	{Key: PermissionKeyReadUserRoles, Group: "access_control", Title: "Read User Roles", Description: "Read user roles information"},
+	{Key: PermissionKeySyncPermissionsWithRole, Group: "access_control", Title: "Sync Permissions With Role", Description: "Sync permissions With role"},
+	{Key: PermissionKeyReadRolePermissions, Group: "access_control", Title: "Read Role Permissions", Description: "Read role permissions information"},
+	{Key: PermissionKeyUpdateSentence, Group: "sentence", Title: "Update sentence", Description: "Update any sentence", Roles: []RoleKeyType{RoleKeyAdmin}},
+	{Key: PermissionKeyUpdateOwnSentence, Group: "sentence", Title: "Update own sentence", Description: "Update sentences created by the user", Roles: []RoleKeyType{RoleKeyStaff}},
+	{Key: PermissionKeyDeleteSentence, Group: "sentence", Title: "Delete sentence", Description: "Delete any sentence", Roles: []RoleKeyType{RoleKeyAdmin}},
+	{Key: PermissionKeyDeleteOwnSentence, Group: "sentence", Title: "Delete own sentence", Description: "Delete sentences created by the user", Roles: []RoleKeyType{RoleKeyStaff}},
+	{Key: PermissionKeyReadAuditLog, Group: "audit_log", Title: "Read audit log", Description: "Read the audit log", Roles: []RoleKeyType{RoleKeyAdmin}},
+	{Key: PermissionKeyPreviewEmailTemplate, Group: "email_template", Title: "Preview email template", Description: "Preview the email templates with sample data", Roles: []RoleKeyType{RoleKeyAdmin}},
+	{Key: PermissionKeyManageEmailSuppression, Group: "email", Title: "Manage email suppression", Description: "List and remove the addresses no email is sent to", Roles: []RoleKeyType{RoleKeyAdmin}},
+}
+
+// PermissionSyncReport summarizes a permission registry sync.
+type PermissionSyncReport struct {
+	Created []PermissionKeyType
+	Updated []PermissionKeyType
+	// Orphans exist in the database but are not declared in the registry, they are reported and left untouched.
+	Orphans []PermissionKeyType
+	// Granted are the roles a permission was newly attached to.
+	Granted []RoleKeyType
+}
+
+func (r PermissionDefinition) ToPermission() Permission {
+	key := r.Key
+	group := r.Group
+	title := r.Title
+	description := r.Description
+
+	return Permission{
+		Key:         &key,
+		Group:       &group,
+		Title:       &title,
+		Description: &description,
+	}
+}
@@ -0,0 +1,100 @@
+package domain_test
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/require"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+)
+
+func TestPermissionRegistry(t *testing.T) {
+	keys := make(map[domain.PermissionKeyType]struct{}, len(domain.PermissionRegistry))
+	titles := make(map[string]struct{}, len(domain.PermissionRegistry))
+	// a SUPER_ADMIN holds every permission already, it is never granted one
+	grantable := []domain.RoleKeyType{
+		domain.RoleKeyAdmin,
+		domain.RoleKeyManager,
+		domain.RoleKeyAccountant,
+		domain.RoleKeySupplier,
+		domain.RoleKeySales,
+		domain.RoleKeyStaff,
+		domain.RoleKeyUser,
+	}
+
+	for _, definition := range domain.PermissionRegistry {
+		require.NotEqual(t, domain.PermissionKeyNone, definition.Key)
+		require.NotEmpty(t, definition.Group, definition.Key)
+		require.NotEmpty(t, definition.Title, definition.Key)
+
+		require.NotContains(t, keys, definition.Key, "duplicated key")
+		require.NotContains(t, titles, definition.Title, "duplicated title")
+
+		for _, role := range definition.Roles {
+			require.Contains(t, grantable, role, definition.Key)
+		}
+
+		keys[definition.Key] = struct{}{}
+		titles[definition.Title] = struct{}{}
+	}
+}
+
+// TestPermissionRegistry_DeclaresEveryKey reads the PermissionKeyType constants from permission.go,
+// so a new key can not be added without declaring it in the registry.
+func TestPermissionRegistry_DeclaresEveryKey(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "permission.go", nil, 0)
+	require.NoError(t, err)
+
+	registered := make(map[domain.PermissionKeyType]struct{}, len(domain.PermissionRegistry))
+	for _, definition := range domain.PermissionRegistry {
+		registered[definition.Key] = struct{}{}
+	}
+
+	var declared int
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			if ident, ok := valueSpec.Type.(*ast.Ident); !ok || ident.Name != "PermissionKeyType" {
+				continue
+			}
+
+			for i, name := range valueSpec.Names {
+				value, err := strconv.Unquote(valueSpec.Values[i].(*ast.BasicLit).Value)
+				require.NoError(t, err)
+
+				key := domain.PermissionKeyType(value)
+				if key == domain.PermissionKeyNone {
+					continue
+				}
+
+				declared++
+				require.Contains(t, registered, key, "%s is not declared in the registry", name.Name)
+			}
+		}
+	}
+
+	require.Equal(t, declared, len(domain.PermissionRegistry))
+}
+
+func TestPermissionDefinition_ToPermission(t *testing.T) {
+	definition := domain.PermissionDefinition{
+		Key:         domain.PermissionKeyReadUser,
+		Group:       "user",
+		Title:       "Read user",
+		Description: "Read user information",
+	}
+
+	permission := definition.ToPermission()
+
+	require.Equal(t, definition.Key, *permission.Key)
+	require.Equal(t, definition.Group, *permission.Group)
+	require.Equal(t, definition.Title, *permission.Title)
+	require.Equal(t, definition.Description, *permission.Description)
+}
@@ -15,6 +15,9 @@ const (
 	RoleKeyUser       RoleKeyType = "USER"
 )
 
+// RoleMaxHierarchyDepth bounds how many ancestors are followed when resolving inherited roles.
+const RoleMaxHierarchyDepth = 10
+
 type Role struct {
 	Base
 	Modifier
@@ -25,9 +28,38 @@ type Role struct {
 
 	IsDefault bool
 
+	ParentID *uint64
+	Parent   *Role
+
 	Permissions []*Permission
 }
 
+// PermissionSource describes a role permission together with the role that grants it.
+// Depth is zero for permissions assigned to the role itself and grows by one for each parent.
+type PermissionSource struct {
+	Permission *Permission
+	Role       *Role
+	Depth      int
+}
+
+func (r PermissionSource) IsInherited() bool {
+	return r.Depth > 0
+}
+
+// AuditFields returns the role fields tracked by the audit log.
+func (r *Role) AuditFields() map[string]interface{} {
+	fields := map[string]interface{}{
+		"title":       r.Title,
+		"key":         r.Key,
+		"description": r.Description,
+	}
+	if r.Parent != nil {
+		fields["parent"] = r.Parent.Base.UUID.String()
+	}
+
+	return fields
+}
+
 func (r *Role) SetKey(key string) {
 	key = helper.ConvertToUpperCase(key)
 	r.Key = RoleKeyType(key)
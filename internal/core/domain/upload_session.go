@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+	"time"
+)
+
+// UploadPrefix is the folder of the files uploaded straight to the object storage.
+const UploadPrefix = "uploads/"
+
+// UploadStagingPrefix is the folder the presigned links write to, an upload is copied out of it once it is verified.
+const UploadStagingPrefix = UploadPrefix + "staging/"
+
+type UploadPurposeType string
+
+const (
+	UploadPurposeSentenceAudio UploadPurposeType = "SENTENCE_AUDIO"
+	UploadPurposeImportFile    UploadPurposeType = "IMPORT_FILE"
+)
+
+// uploadContentTypes lists the content types a client can declare for each purpose.
+var uploadContentTypes = map[UploadPurposeType][]string{
+	UploadPurposeSentenceAudio: {"audio/mpeg", "audio/ogg", "audio/wav", "audio/webm"},
+	UploadPurposeImportFile:    {"application/json", "application/zip", "text/csv"},
+}
+
+func (r UploadPurposeType) ContentTypes() []string {
+	return uploadContentTypes[r]
+}
+
+func (r UploadPurposeType) Allows(contentType string) bool {
+	return slices.Contains(uploadContentTypes[r], contentType)
+}
+
+type UploadSessionStatusType string
+
+const (
+	UploadSessionStatusPending   UploadSessionStatusType = "PENDING"
+	UploadSessionStatusCompleted UploadSessionStatusType = "COMPLETED"
+	UploadSessionStatusAbandoned UploadSessionStatusType = "ABANDONED"
+)
+
+// UploadSession is a file a client uploads straight to the object storage with a presigned link,
+// the upload only counts once it is completed and the stored object matches Size and Checksum.
+type UploadSession struct {
+	Base
+
+	UserID      uint64
+	Purpose     UploadPurposeType
+	Key         string
+	ContentType string
+	Size        int64
+	// Checksum is the hex encoded SHA-256 of the file declared by the client.
+	Checksum    string
+	Status      UploadSessionStatusType
+	ExpiresAt   time.Time
+	CompletedAt *time.Time
+}
+
+// UploadKey is the object name of an upload, "uploads/sentence_audio/<uuid>" for the audio of a sentence.
+func UploadKey(purpose UploadPurposeType, sessionUUID uuid.UUID) string {
+	return UploadPrefix + strings.ToLower(string(purpose)) + "/" + sessionUUID.String()
+}
+
+// UploadStagingKey is the object name the presigned link of an upload writes to,
+// "uploads/staging/sentence_audio/<uuid>" for the audio of a sentence.
+func UploadStagingKey(purpose UploadPurposeType, sessionUUID uuid.UUID) string {
+	return UploadStagingPrefix + strings.ToLower(string(purpose)) + "/" + sessionUUID.String()
+}
+
+func (r *UploadSession) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
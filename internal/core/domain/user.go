@@ -113,3 +113,10 @@ func (r *User) SetLastName(lastName sql.NullString) *User {
 	}
 	return r
 }
+
+func (r *User) SetAvatar(avatar sql.NullString) *User {
+	if avatar.Valid {
+		r.Avatar = &avatar.String
+	}
+	return r
+}
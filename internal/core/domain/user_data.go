@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+// ErasedEmailDomain is the reserved domain of the placeholder email an erased user keeps, it can never receive mail.
+const ErasedEmailDomain = "erased.invalid"
+
+// UserDataExport holds everything stored about a user, it is the content of data.json in the export archive.
+type UserDataExport struct {
+	Profile                 UserDataProfile                  `json:"profile"`
+	Roles                   []UserDataRole                   `json:"roles"`
+	Sessions                []UserDataSession                `json:"sessions"`
+	Sentences               []UserDataSentence               `json:"sentences"`
+	Notifications           []UserDataNotification           `json:"notifications"`
+	NotificationPreferences []UserDataNotificationPreference `json:"notificationPreferences"`
+	GeneratedAt             time.Time                        `json:"generatedAt"`
+}
+
+type UserDataProfile struct {
+	ID              uint64     `json:"-"`
+	UUID            uuid.UUID  `json:"uuid"`
+	FirstName       *string    `json:"firstName"`
+	LastName        *string    `json:"lastName"`
+	Email           string     `json:"email"`
+	Avatar          *string    `json:"avatar"`
+	Status          string     `json:"status"`
+	GoogleLinked    bool       `json:"googleLinked"`
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt"`
+	LastLogin       *time.Time `json:"lastLogin"`
+	CreatedAt       time.Time  `json:"createdAt"`
+}
+
+type UserDataRole struct {
+	Key        string    `json:"key"`
+	Title      string    `json:"title"`
+	AssignedAt time.Time `json:"assignedAt"`
+}
+
+// UserDataSession is a sign-in related event of the audit log.
+type UserDataSession struct {
+	Action    AuditAction `json:"action"`
+	IP        *string     `json:"ip"`
+	UserAgent *string     `json:"userAgent"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+type UserDataSentence struct {
+	UUID      uuid.UUID         `json:"uuid"`
+	Text      string            `json:"text"`
+	Grammar   string            `json:"grammar"`
+	Level     SentenceLevelType `json:"level"`
+	Status    StatusType        `json:"status"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+type UserDataNotification struct {
+	UUID      uuid.UUID        `json:"uuid"`
+	Type      NotificationType `json:"type"`
+	Title     string           `json:"title"`
+	Body      string           `json:"body"`
+	Link      *string          `json:"link"`
+	ReadAt    *time.Time       `json:"readAt"`
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+// UserDataNotificationPreference is a preference the user stored, a category without one is enabled.
+type UserDataNotificationPreference struct {
+	Category  NotificationCategoryType `json:"category"`
+	Enabled   bool                     `json:"enabled"`
+	CreatedAt time.Time                `json:"createdAt"`
+	UpdatedAt *time.Time               `json:"updatedAt"`
+}
+
+// SessionAuditActions are the audit actions exported as the sessions of a user.
+var SessionAuditActions = []AuditAction{
+	AuditActionUserLoggedIn,
+	AuditActionUserLoginFailed,
+	AuditActionUserPasswordReset,
+}
+
+// ErasedEmail is the unique placeholder replacing the email of an erased user.
+func ErasedEmail(userUUID uuid.UUID) string {
+	return fmt.Sprintf("%s@%s", userUUID.String(), ErasedEmailDomain)
+}
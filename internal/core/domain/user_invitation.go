@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+)
+
+type UserInvitationStatusType string
+
+const (
+	UserInvitationStatusPending  UserInvitationStatusType = "PENDING"
+	UserInvitationStatusAccepted UserInvitationStatusType = "ACCEPTED"
+	UserInvitationStatusRevoked  UserInvitationStatusType = "REVOKED"
+	UserInvitationStatusExpired  UserInvitationStatusType = "EXPIRED"
+)
+
+// UserInvitation is the single-use set-password link sent to a user created by an admin,
+// only the SHA-256 of the token is stored.
+type UserInvitation struct {
+	Base
+	Modifier
+
+	UserID     uint64
+	User       *User
+	TokenHash  string
+	SentCount  uint
+	ExpiresAt  time.Time
+	AcceptedAt *time.Time
+	RevokedAt  *time.Time
+	RevokedBy  *uint64
+}
+
+func (r *UserInvitation) Status(now time.Time) UserInvitationStatusType {
+	switch {
+	case r.AcceptedAt != nil:
+		return UserInvitationStatusAccepted
+	case r.RevokedAt != nil:
+		return UserInvitationStatusRevoked
+	case !now.Before(r.ExpiresAt):
+		return UserInvitationStatusExpired
+	default:
+		return UserInvitationStatusPending
+	}
+}
+
+// IsOpen reports whether the invitation can still be resent or revoked, an expired invitation is still open.
+func (r *UserInvitation) IsOpen() bool {
+	return r.AcceptedAt == nil && r.RevokedAt == nil
+}
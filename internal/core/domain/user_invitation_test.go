@@ -0,0 +1,53 @@
+package domain_test
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestUserInvitation_Status(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name           string
+		invitation     domain.UserInvitation
+		expectedStatus domain.UserInvitationStatusType
+		expectedOpen   bool
+	}{
+		{
+			name:           "Pending",
+			invitation:     domain.UserInvitation{ExpiresAt: future},
+			expectedStatus: domain.UserInvitationStatusPending,
+			expectedOpen:   true,
+		},
+		{
+			name:           "Expired",
+			invitation:     domain.UserInvitation{ExpiresAt: past},
+			expectedStatus: domain.UserInvitationStatusExpired,
+			expectedOpen:   true,
+		},
+		{
+			name:           "Accepted",
+			invitation:     domain.UserInvitation{ExpiresAt: future, AcceptedAt: &past},
+			expectedStatus: domain.UserInvitationStatusAccepted,
+			expectedOpen:   false,
+		},
+		{
+			name:           "Revoked after expiry",
+			invitation:     domain.UserInvitation{ExpiresAt: past, RevokedAt: &past},
+			expectedStatus: domain.UserInvitationStatusRevoked,
+			expectedOpen:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedStatus, test.invitation.Status(now))
+			require.Equal(t, test.expectedOpen, test.invitation.IsOpen())
+		})
+	}
+}
@@ -1,57 +1,76 @@
 package authevent
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/email"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/emailtemplateservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/outboxservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/views"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
-	"html/template"
+	"net/url"
 )
 
 type SendEmailOTP struct {
 	queue       *messagebroker.Queue
 	emailSender port.EmailSender
+	templates   port.EmailTemplateService
 }
 
 var sendEmailOTPInstance *SendEmailOTP
 
 const DelaySendEmailOTPSeconds int64 = 0
 const SendEmailOtpName = "send_email_otp"
+const SendEmailOtpVersion = 1
 
 type SendEmailOTPDto struct {
-	To       string `json:"to"`
-	Name     string `json:"name"`
-	OTP      string `json:"otp"`
-	Language string `json:"language"`
+	To        string `json:"to"`
+	Name      string `json:"name"`
+	OTP       string `json:"otp"`
+	Signature string `json:"signature"`
+	Language  string `json:"language"`
 }
 
 func NewSendEmailOTP(queue *messagebroker.Queue) *SendEmailOTP {
 	if sendEmailOTPInstance == nil {
 		sendEmailOTPInstance = &SendEmailOTP{
 			queue:       queue,
-			emailSender: email.NewSender(queue.Log, queue.Config.SendGrid),
+			emailSender: email.New(queue.Log, queue.Config),
+			templates: emailtemplateservice.New(
+				views.EmailTemplates(),
+				queue.Config.App,
+				translation.NewTranslation(queue.Config.App),
+			),
 		}
 	}
 
 	return sendEmailOTPInstance
 }
 
+func (r *SendEmailOTP) SetEmailSender(emailSender port.EmailSender) {
+	r.emailSender = emailSender
+}
+
 func (r *SendEmailOTP) Name() string {
 	return SendEmailOtpName
 }
 
 func (r *SendEmailOTP) Publish(message interface{}) {
-	if err := r.queue.Driver.Produce(r.Name(), message, DelaySendEmailOTPSeconds); err != nil {
+	if err := r.queue.Produce(r.Name(), SendEmailOtpVersion, message, DelaySendEmailOTPSeconds); err != nil {
 		return
 	}
 	r.queue.Log.Info(logger.Queue, logger.RabbitMQPublish, fmt.Sprintf("published successfully to queue: %s", message), nil)
 }
 
-func (r *SendEmailOTP) Consume(message []byte) error {
+func (r *SendEmailOTP) Enqueue(ctx context.Context, outbox port.OutboxRepository, message interface{}) error {
+	return outboxservice.Enqueue(ctx, outbox, r.Name(), SendEmailOtpVersion, message, DelaySendEmailOTPSeconds)
+}
+
+func (r *SendEmailOTP) Consume(ctx context.Context, message []byte) error {
 	extra := map[logger.ExtraKey]interface{}{
 		logger.Body: string(message),
 	}
@@ -61,45 +80,35 @@ func (r *SendEmailOTP) Consume(message []byte) error {
 		return err
 	}
 
-	trans := translation.NewTranslation(r.queue.Config.App)
-	appName := trans.Lang("appName", nil, &msg.Language)
-
-	emailBuffer := new(bytes.Buffer)
-	parseFiles, err := template.ParseFiles("internal/core/views/email/base.html", "internal/core/views/email/auth/verify_email.html")
-	if err != nil {
-		r.queue.Log.Error(logger.Email, logger.SendEmail, err.Error(), nil)
-		return err
-	}
-
-	body := template.HTML(trans.Lang("email.verifyEmail.body", map[string]interface{}{
+	content, err := r.templates.Render("verify_email", msg.Language, map[string]interface{}{
 		"username":        msg.Name,
-		"app":             appName,
 		"otp":             msg.OTP,
-		"verificationUrl": r.queue.Config.App.VerificationURL + msg.OTP,
-	}, &msg.Language))
-
-	data := map[string]interface{}{
-		"language": msg.Language,
-		"body":     body,
-	}
-
-	if err = parseFiles.ExecuteTemplate(emailBuffer, "base.html", data); err != nil {
+		"verificationUrl": verificationLink(r.queue.Config.App.VerificationURL, msg.To, msg.Signature),
+	})
+	if err != nil {
 		r.queue.Log.Error(logger.Email, logger.SendEmail, err.Error(), nil)
 		return err
 	}
+	content.To = msg.To
+	content.Name = msg.Name
 
-	subject := trans.Lang("email.verifyEmail.subject", map[string]interface{}{
-		"app": appName,
-	}, &msg.Language)
-
-	err = r.emailSender.Send(msg.To, msg.Name, subject, emailBuffer.String())
+	err = r.emailSender.Send(content)
 
 	return err
 }
 
+// verificationLink builds the signed link checked by the email-link/verify endpoint.
+func verificationLink(baseURL string, email string, signature string) string {
+	query := url.Values{}
+	query.Set("email", email)
+	query.Set("signature", signature)
+
+	return baseURL + "?" + query.Encode()
+}
+
 func (r *SendEmailOTP) Register() {
 	go func() {
-		if err := r.queue.Driver.RegisterConsumer(r.Name(), r.Consume); err != nil {
+		if err := r.queue.Register(r.Name(), r.Consume); err != nil {
 			r.queue.Log.Error(
 				logger.Queue,
 				logger.RabbitMQRegisterConsumer,
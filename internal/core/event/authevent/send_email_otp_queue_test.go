@@ -0,0 +1,103 @@
+package authevent_test
+
+import (
+	"context"
+	"errors"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/email"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/event/authevent"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// chdirRoot runs the test from the repository root, where the email templates and the translations are looked up.
+func chdirRoot(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir("../../../.."))
+	t.Cleanup(func() {
+		require.NoError(t, os.Chdir(wd))
+	})
+}
+
+func TestSendEmailOTP(t *testing.T) {
+	chdirRoot(t)
+
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	conf := config.Config{
+		App: config.App{
+			Locale:          "en",
+			PathLocale:      "pkg/translation",
+			VerificationURL: "https://example.com/verify",
+		},
+	}
+	memory := messagebroker.NewMemory(mockLogger, messagebroker.NewRetryPolicy(config.Queue{
+		RetryMaxAttempts:        2,
+		RetryInitialDelaySecond: 10 * time.Millisecond,
+		RetryMaxDelaySecond:     10 * time.Millisecond,
+	}))
+	defer memory.Close()
+
+	queue := messagebroker.NewQueue(mockLogger, conf)
+	queue.Driver = memory
+
+	event := authevent.NewSendEmailOTP(queue)
+	event.Register()
+
+	wait := func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		require.NoError(t, memory.Wait(ctx))
+	}
+
+	message := authevent.SendEmailOTPDto{
+		To:        "john.doe@example.com",
+		Name:      "John Doe",
+		OTP:       "123456",
+		Signature: "signature",
+		Language:  "en",
+	}
+
+	t.Run("SendEmailOTP sends the email", func(t *testing.T) {
+		sender := new(email.MockSendGrid)
+		sender.On("Send", mock.MatchedBy(func(content domain.Email) bool {
+			return content.To == "john.doe@example.com" &&
+				content.Name == "John Doe" &&
+				strings.Contains(content.HTML, "123456") &&
+				strings.Contains(content.Text, "123456")
+		})).Return(nil).Once()
+		event.SetEmailSender(sender)
+
+		event.Publish(message)
+		wait(t)
+
+		sender.AssertExpectations(t)
+	})
+
+	t.Run("SendEmailOTP dead-letters after the attempts", func(t *testing.T) {
+		sender := new(email.MockSendGrid)
+		sender.On("Send", mock.Anything).
+			Return(errors.New("sendgrid unavailable")).Twice()
+		event.SetEmailSender(sender)
+
+		event.Publish(message)
+		wait(t)
+
+		sender.AssertExpectations(t)
+		deadLetters, err := memory.DeadLetters(authevent.SendEmailOtpName, 10)
+		require.NoError(t, err)
+		require.Len(t, deadLetters, 1)
+		require.Equal(t, "sendgrid unavailable", deadLetters[0].Error)
+	})
+}
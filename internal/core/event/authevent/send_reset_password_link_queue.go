@@ -1,27 +1,30 @@
 package authevent
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/email"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/emailtemplateservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/outboxservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/views"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
-	"html/template"
-	"strings"
 )
 
 type SendResetPasswordLink struct {
 	queue       *messagebroker.Queue
 	emailSender port.EmailSender
+	templates   port.EmailTemplateService
 }
 
 var resetPasswordLinkInstance *SendResetPasswordLink
 
 const DelaySendResetPasswordLinkSeconds int64 = 0
 const SendResetPasswordLinkName = "send_reset_password_link"
+const SendResetPasswordLinkVersion = 1
 
 type SendResetPasswordLinkDto struct {
 	To       string `json:"to"`
@@ -34,26 +37,39 @@ func NewSendResetPasswordLink(queue *messagebroker.Queue) *SendResetPasswordLink
 	if resetPasswordLinkInstance == nil {
 		resetPasswordLinkInstance = &SendResetPasswordLink{
 			queue:       queue,
-			emailSender: email.NewSender(queue.Log, queue.Config.SendGrid),
+			emailSender: email.New(queue.Log, queue.Config),
+			templates: emailtemplateservice.New(
+				views.EmailTemplates(),
+				queue.Config.App,
+				translation.NewTranslation(queue.Config.App),
+			),
 		}
 	}
 
 	return resetPasswordLinkInstance
 }
 
+func (r *SendResetPasswordLink) SetEmailSender(emailSender port.EmailSender) {
+	r.emailSender = emailSender
+}
+
 func (r *SendResetPasswordLink) Name() string {
 	return SendResetPasswordLinkName
 }
 
 func (r *SendResetPasswordLink) Publish(message interface{}) {
 
-	if err := r.queue.Driver.Produce(r.Name(), message, DelaySendResetPasswordLinkSeconds); err != nil {
+	if err := r.queue.Produce(r.Name(), SendResetPasswordLinkVersion, message, DelaySendResetPasswordLinkSeconds); err != nil {
 		return
 	}
 	r.queue.Log.Info(logger.Queue, logger.RabbitMQPublish, fmt.Sprintf("published successfully to queue: %s", message), nil)
 }
 
-func (r *SendResetPasswordLink) Consume(message []byte) error {
+func (r *SendResetPasswordLink) Enqueue(ctx context.Context, outbox port.OutboxRepository, message interface{}) error {
+	return outboxservice.Enqueue(ctx, outbox, r.Name(), SendResetPasswordLinkVersion, message, DelaySendResetPasswordLinkSeconds)
+}
+
+func (r *SendResetPasswordLink) Consume(ctx context.Context, message []byte) error {
 	extra := map[logger.ExtraKey]interface{}{
 		logger.Body: string(message),
 	}
@@ -63,49 +79,25 @@ func (r *SendResetPasswordLink) Consume(message []byte) error {
 		return err
 	}
 
-	trans := translation.NewTranslation(r.queue.Config.App)
-	appName := trans.Lang("appName", nil, &msg.Language)
-
-	if strings.TrimSpace(msg.Name) == "" {
-		msg.Name = trans.Lang("user", nil, &msg.Language)
-	}
-
-	emailBuffer := new(bytes.Buffer)
-	parseFiles, err := template.ParseFiles("internal/core/views/email/base.html", "internal/core/views/email/auth/reset_password.html")
-	if err != nil {
-		r.queue.Log.Error(logger.Email, logger.SendEmail, err.Error(), nil)
-		return err
-	}
-
-	body := template.HTML(trans.Lang("email.resetPassword.body", map[string]interface{}{
+	content, err := r.templates.Render("reset_password", msg.Language, map[string]interface{}{
 		"username":         msg.Name,
-		"app":              appName,
 		"resetPasswordUrl": r.queue.Config.App.ResetPasswordURL + msg.OTP,
-		"supportEmail":     r.queue.Config.App.SupportEmail,
-	}, &msg.Language))
-
-	data := map[string]interface{}{
-		"language": msg.Language,
-		"body":     body,
-	}
-
-	if err = parseFiles.ExecuteTemplate(emailBuffer, "base.html", data); err != nil {
+	})
+	if err != nil {
 		r.queue.Log.Error(logger.Email, logger.SendEmail, err.Error(), nil)
 		return err
 	}
+	content.To = msg.To
+	content.Name = msg.Name
 
-	subject := trans.Lang("email.resetPassword.subject", map[string]interface{}{
-		"app": appName,
-	}, &msg.Language)
-
-	err = r.emailSender.Send(msg.To, msg.Name, subject, emailBuffer.String())
+	err = r.emailSender.Send(content)
 
 	return err
 }
 
 func (r *SendResetPasswordLink) Register() {
 	go func() {
-		if err := r.queue.Driver.RegisterConsumer(r.Name(), r.Consume); err != nil {
+		if err := r.queue.Register(r.Name(), r.Consume); err != nil {
 			r.queue.Log.Error(
 				logger.Queue,
 				logger.RabbitMQRegisterConsumer,
@@ -1,22 +1,25 @@
 package authevent
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/email"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/emailtemplateservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/outboxservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/views"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
-	"html/template"
-	"strings"
+	"net/url"
 )
 
 type SendWelcome struct {
 	queue       *messagebroker.Queue
 	emailSender port.EmailSender
+	templates   port.EmailTemplateService
 	userClient  port.UserClient
 }
 
@@ -24,6 +27,7 @@ var sendWelcomeInstance *SendWelcome
 
 const DelaySendWelcomeSeconds int64 = 60
 const SendWelcomeName = "send_welcome"
+const SendWelcomeVersion = 1
 
 type SendWelcomeDto struct {
 	UserID   uint64 `json:"userID"`
@@ -36,27 +40,44 @@ func NewSendWelcome(queue *messagebroker.Queue, userClient port.UserClient) *Sen
 	if sendWelcomeInstance == nil {
 		sendWelcomeInstance = &SendWelcome{
 			queue:       queue,
-			emailSender: email.NewSender(queue.Log, queue.Config.SendGrid),
-			userClient:  userClient,
+			emailSender: email.New(queue.Log, queue.Config),
+			templates: emailtemplateservice.New(
+				views.EmailTemplates(),
+				queue.Config.App,
+				translation.NewTranslation(queue.Config.App),
+			),
+			userClient: userClient,
 		}
 	}
 
 	return sendWelcomeInstance
 }
 
+func (r *SendWelcome) SetEmailSender(emailSender port.EmailSender) {
+	r.emailSender = emailSender
+}
+
+func (r *SendWelcome) SetUserClient(userClient port.UserClient) {
+	r.userClient = userClient
+}
+
 func (r *SendWelcome) Name() string {
 	return SendWelcomeName
 }
 
 func (r *SendWelcome) Publish(message interface{}) {
 
-	if err := r.queue.Driver.Produce(r.Name(), message, DelaySendWelcomeSeconds); err != nil {
+	if err := r.queue.Produce(r.Name(), SendWelcomeVersion, message, DelaySendWelcomeSeconds); err != nil {
 		return
 	}
 	r.queue.Log.Info(logger.Queue, logger.RabbitMQPublish, fmt.Sprintf("published successfully to queue: %s", message), nil)
 }
 
-func (r *SendWelcome) Consume(message []byte) error {
+func (r *SendWelcome) Enqueue(ctx context.Context, outbox port.OutboxRepository, message interface{}) error {
+	return outboxservice.Enqueue(ctx, outbox, r.Name(), SendWelcomeVersion, message, DelaySendWelcomeSeconds)
+}
+
+func (r *SendWelcome) Consume(ctx context.Context, message []byte) error {
 	extra := map[logger.ExtraKey]interface{}{
 		logger.Body: string(message),
 	}
@@ -66,43 +87,43 @@ func (r *SendWelcome) Consume(message []byte) error {
 		return err
 	}
 
-	trans := translation.NewTranslation(r.queue.Config.App)
-	appName := trans.Lang("appName", nil, &msg.Language)
-
-	if strings.TrimSpace(msg.Name) == "" {
-		msg.Name = trans.Lang("user", nil, &msg.Language)
-	}
-
-	emailBuffer := new(bytes.Buffer)
-	parseFiles, err := template.ParseFiles("internal/core/views/email/base.html", "internal/core/views/email/auth/welcome.html")
+	// the welcome email is a product notification, a user who turned them off is marked as welcomed without it
+	preference, token, err := r.userClient.GetNotificationPreference(ctx, msg.To, domain.NotificationCategoryProduct)
 	if err != nil {
-		r.queue.Log.Error(logger.Email, logger.SendEmail, err.Error(), nil)
+		r.queue.Log.Error(logger.Email, logger.SendEmail, err.Error(), extra)
 		return err
 	}
-
-	body := template.HTML(trans.Lang("email.welcome.body", map[string]interface{}{
-		"username":     msg.Name,
-		"supportEmail": r.queue.Config.App.SupportEmail,
-		"app":          appName,
-	}, &msg.Language))
+	if !preference.Enabled {
+		r.queue.Log.Info(logger.Email, logger.SendEmail, "Skipped, the user turned off the product notifications", extra)
+		if updateErr := r.userClient.MarkWelcomeMessageSent(ctx, msg.UserID); updateErr != nil {
+			r.queue.Log.Error(logger.Email, logger.SendEmail, updateErr.Error(), nil)
+		}
+		return nil
+	}
 
 	data := map[string]interface{}{
-		"language": msg.Language,
-		"body":     body,
+		"username": msg.Name,
+	}
+	var unsubscribeURL string
+	if token != "" && r.queue.Config.App.UnsubscribeURL != "" {
+		unsubscribeURL = r.queue.Config.App.UnsubscribeURL + url.QueryEscape(token)
+		data["unsubscribeUrl"] = unsubscribeURL
 	}
 
-	if err = parseFiles.ExecuteTemplate(emailBuffer, "base.html", data); err != nil {
+	content, err := r.templates.Render("welcome", msg.Language, data)
+	if err != nil {
 		r.queue.Log.Error(logger.Email, logger.SendEmail, err.Error(), nil)
 		return err
 	}
+	content.To = msg.To
+	content.Name = msg.Name
+	if unsubscribeURL != "" {
+		content.SetUnsubscribe(unsubscribeURL)
+	}
 
-	subject := trans.Lang("email.welcome.subject", map[string]interface{}{
-		"app": appName,
-	}, &msg.Language)
-
-	err = r.emailSender.Send(msg.To, msg.Name, subject, string(body))
+	err = r.emailSender.Send(content)
 	if err == nil {
-		if updateErr := r.userClient.MarkWelcomeMessageSent(context.Background(), msg.UserID); updateErr != nil {
+		if updateErr := r.userClient.MarkWelcomeMessageSent(ctx, msg.UserID); updateErr != nil {
 			r.queue.Log.Error(logger.Email, logger.SendEmail, updateErr.Error(), nil)
 		}
 	}
@@ -112,7 +133,7 @@ func (r *SendWelcome) Consume(message []byte) error {
 
 func (r *SendWelcome) Register() {
 	go func() {
-		if err := r.queue.Driver.RegisterConsumer(r.Name(), r.Consume); err != nil {
+		if err := r.queue.Register(r.Name(), r.Consume); err != nil {
 			r.queue.Log.Error(
 				logger.Queue,
 				logger.RabbitMQRegisterConsumer,
@@ -0,0 +1,110 @@
+package authevent_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/email"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/grpc/client"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/event/authevent"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+var welcomeConfig = config.Config{
+	App: config.App{
+		Locale:         "en",
+		PathLocale:     "pkg/translation",
+		UnsubscribeURL: "https://example.com/unsubscribe?token=",
+	},
+}
+
+func TestSendWelcome_Redelivery(t *testing.T) {
+	chdirRoot(t)
+
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warn", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	memory := messagebroker.NewMemory(mockLogger, messagebroker.NewRetryPolicy(config.Queue{RetryMaxAttempts: 1}))
+	defer memory.Close()
+
+	queue := messagebroker.NewQueue(mockLogger, welcomeConfig)
+	queue.Driver = memory
+	queue.Processed = messagebroker.NewMemoryProcessedMessages()
+
+	userClient := new(client.MockUserClient)
+	userClient.On("GetNotificationPreference", mock.Anything, "john.doe@example.com", domain.NotificationCategoryProduct).
+		Return(&domain.NotificationPreference{Category: domain.NotificationCategoryProduct, Enabled: true}, "token", nil).
+		Once()
+	userClient.On("MarkWelcomeMessageSent", mock.Anything, uint64(7)).Return(nil).Once()
+
+	sender := new(email.MockSendGrid)
+	sender.On("Send", mock.MatchedBy(func(content domain.Email) bool {
+		return content.To == "john.doe@example.com" && content.Name == "John Doe" &&
+			content.Headers["List-Unsubscribe"] == "<https://example.com/unsubscribe?token=token>" &&
+			strings.Contains(content.HTML, "https://example.com/unsubscribe?token=token")
+	})).Return(nil).Once()
+
+	event := authevent.NewSendWelcome(queue, userClient)
+	event.SetEmailSender(sender)
+	event.Register()
+
+	envelope, err := domain.NewEnvelope(context.Background(), authevent.SendWelcomeName, authevent.SendWelcomeVersion, authevent.SendWelcomeDto{
+		UserID:   7,
+		To:       "john.doe@example.com",
+		Name:     "John Doe",
+		Language: "en",
+	})
+	require.NoError(t, err)
+
+	// the same envelope delivered twice, like a broker redelivering an unacked message
+	require.NoError(t, memory.Produce(authevent.SendWelcomeName, envelope, 0))
+	require.NoError(t, memory.Produce(authevent.SendWelcomeName, envelope, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, memory.Wait(ctx))
+
+	sender.AssertExpectations(t)
+	userClient.AssertExpectations(t)
+}
+
+func TestSendWelcome_ProductNotificationsOff(t *testing.T) {
+	chdirRoot(t)
+
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	userClient := new(client.MockUserClient)
+	userClient.On("GetNotificationPreference", mock.Anything, "jane.doe@example.com", domain.NotificationCategoryProduct).
+		Return(&domain.NotificationPreference{Category: domain.NotificationCategoryProduct, Enabled: false}, "token", nil)
+	userClient.On("MarkWelcomeMessageSent", mock.Anything, uint64(8)).Return(nil)
+
+	sender := new(email.MockSendGrid)
+
+	event := authevent.NewSendWelcome(messagebroker.NewQueue(mockLogger, welcomeConfig), userClient)
+	event.SetUserClient(userClient)
+	event.SetEmailSender(sender)
+
+	message, err := json.Marshal(authevent.SendWelcomeDto{
+		UserID:   8,
+		To:       "jane.doe@example.com",
+		Name:     "Jane Doe",
+		Language: "en",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, event.Consume(context.Background(), message))
+
+	sender.AssertNotCalled(t, "Send", mock.Anything)
+	userClient.AssertExpectations(t)
+}
@@ -0,0 +1,112 @@
+package userevent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/outboxservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+)
+
+type AbandonUploadSession struct {
+	queue                *messagebroker.Queue
+	uowFactory           func() port.UserUnitOfWork
+	uploadSessionService port.UploadSessionService
+}
+
+var abandonUploadSessionInstance *AbandonUploadSession
+
+// DelayAbandonUploadSessionSeconds is the grace period after the upload link expires,
+// so an upload started just before the expiry can still finish.
+const DelayAbandonUploadSessionSeconds int64 = 60
+const AbandonUploadSessionName = "abandon_upload_session"
+const AbandonUploadSessionVersion = 1
+
+type AbandonUploadSessionDto struct {
+	SessionUUID string `json:"sessionUuid"`
+}
+
+func NewAbandonUploadSession(
+	queue *messagebroker.Queue,
+	uowFactory func() port.UserUnitOfWork,
+	uploadSessionService port.UploadSessionService,
+) *AbandonUploadSession {
+	if abandonUploadSessionInstance == nil {
+		abandonUploadSessionInstance = &AbandonUploadSession{
+			queue:                queue,
+			uowFactory:           uowFactory,
+			uploadSessionService: uploadSessionService,
+		}
+	}
+
+	return abandonUploadSessionInstance
+}
+
+func (r *AbandonUploadSession) Name() string {
+	return AbandonUploadSessionName
+}
+
+// Publish schedules the cleanup of the session for after its upload link expires.
+func (r *AbandonUploadSession) Publish(message interface{}) {
+	if err := r.queue.Produce(r.Name(), AbandonUploadSessionVersion, message, r.delaySeconds()); err != nil {
+		return
+	}
+	r.queue.Log.Info(logger.Queue, logger.RabbitMQPublish, fmt.Sprintf("published successfully to queue: %s", r.Name()), nil)
+}
+
+func (r *AbandonUploadSession) Enqueue(ctx context.Context, outbox port.OutboxRepository, message interface{}) error {
+	return outboxservice.Enqueue(ctx, outbox, r.Name(), AbandonUploadSessionVersion, message, r.delaySeconds())
+}
+
+// delaySeconds is the lifetime of the upload link plus the grace period.
+func (r *AbandonUploadSession) delaySeconds() int64 {
+	return int64(r.queue.Config.Upload.URLExpireSecond.Seconds()) + DelayAbandonUploadSessionSeconds
+}
+
+// Consume removes the objects the upload link of a session left behind once it expired.
+func (r *AbandonUploadSession) Consume(ctx context.Context, message []byte) error {
+	var msg AbandonUploadSessionDto
+	if err := json.Unmarshal(message, &msg); err != nil {
+		r.queue.Log.Error(logger.Queue, logger.RabbitMQConsume, fmt.Sprintf("Error unmarshalling message, error: %v", err), nil)
+		return err
+	}
+
+	uow := r.uowFactory()
+	if err := uow.BeginTx(ctx); err != nil {
+		return err
+	}
+
+	if err := r.uploadSessionService.Abandon(ctx, uow, msg.SessionUUID); err != nil {
+		if rErr := uow.Rollback(); rErr != nil {
+			return rErr
+		}
+
+		if serviceErr, ok := err.(*serviceerror.ServiceError); ok && serviceErr.GetErrorMessage() == serviceerror.RecordNotFound {
+			r.queue.Log.Warn(logger.Queue, logger.RabbitMQConsume, "The upload session to abandon does not exist", map[logger.ExtraKey]interface{}{
+				logger.QueueName: r.Name(),
+			})
+			return nil
+		}
+		return err
+	}
+
+	return uow.Commit()
+}
+
+func (r *AbandonUploadSession) Register() {
+	go func() {
+		if err := r.queue.Register(r.Name(), r.Consume); err != nil {
+			r.queue.Log.Error(
+				logger.Queue,
+				logger.RabbitMQRegisterConsumer,
+				fmt.Sprintf("Error on registering consumer, error: %v", err),
+				map[logger.ExtraKey]interface{}{
+					logger.QueueName: r.Name(),
+				},
+			)
+		}
+	}()
+}
@@ -0,0 +1,94 @@
+package userevent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/outboxservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"strings"
+)
+
+type DeleteDataExport struct {
+	queue         *messagebroker.Queue
+	objectStorage port.ObjectStorage
+}
+
+var deleteDataExportInstance *DeleteDataExport
+
+// DelayDeleteDataExportSeconds is the grace period after the download link expires,
+// so a download started just before the expiry can still finish.
+const DelayDeleteDataExportSeconds int64 = 60
+const DeleteDataExportName = "delete_data_export"
+const DeleteDataExportVersion = 1
+
+type DeleteDataExportDto struct {
+	ObjectName string `json:"objectName"`
+}
+
+func NewDeleteDataExport(queue *messagebroker.Queue, objectStorage port.ObjectStorage) *DeleteDataExport {
+	if deleteDataExportInstance == nil {
+		deleteDataExportInstance = &DeleteDataExport{
+			queue:         queue,
+			objectStorage: objectStorage,
+		}
+	}
+
+	return deleteDataExportInstance
+}
+
+func (r *DeleteDataExport) Name() string {
+	return DeleteDataExportName
+}
+
+// Publish schedules the removal of the archive for after its download link expires.
+func (r *DeleteDataExport) Publish(message interface{}) {
+	if err := r.queue.Produce(r.Name(), DeleteDataExportVersion, message, r.delaySeconds()); err != nil {
+		return
+	}
+	r.queue.Log.Info(logger.Queue, logger.RabbitMQPublish, fmt.Sprintf("published successfully to queue: %s", r.Name()), nil)
+}
+
+func (r *DeleteDataExport) Enqueue(ctx context.Context, outbox port.OutboxRepository, message interface{}) error {
+	return outboxservice.Enqueue(ctx, outbox, r.Name(), DeleteDataExportVersion, message, r.delaySeconds())
+}
+
+// delaySeconds is the lifetime of the download link plus the grace period.
+func (r *DeleteDataExport) delaySeconds() int64 {
+	return int64(r.queue.Config.DataExport.LinkExpireSecond.Seconds()) + DelayDeleteDataExportSeconds
+}
+
+// Consume removes the archive, an object outside the export folder is never touched.
+func (r *DeleteDataExport) Consume(ctx context.Context, message []byte) error {
+	var msg DeleteDataExportDto
+	if err := json.Unmarshal(message, &msg); err != nil {
+		r.queue.Log.Error(logger.Queue, logger.RabbitMQConsume, fmt.Sprintf("Error unmarshalling message, error: %v", err), nil)
+		return err
+	}
+
+	if !strings.HasPrefix(msg.ObjectName, DataExportPrefix+"/") {
+		r.queue.Log.Warn(logger.Queue, logger.RabbitMQConsume, "The object to delete is not a data export", map[logger.ExtraKey]interface{}{
+			logger.QueueName: r.Name(),
+		})
+		return nil
+	}
+
+	return r.objectStorage.Delete(ctx, msg.ObjectName)
+}
+
+func (r *DeleteDataExport) Register() {
+	go func() {
+		if err := r.queue.Register(r.Name(), r.Consume); err != nil {
+			r.queue.Log.Error(
+				logger.Queue,
+				logger.RabbitMQRegisterConsumer,
+				fmt.Sprintf("Error on registering consumer, error: %v", err),
+				map[logger.ExtraKey]interface{}{
+					logger.QueueName: r.Name(),
+				},
+			)
+		}
+	}()
+}
@@ -0,0 +1,137 @@
+package userevent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/avatar"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/outboxservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+)
+
+type EraseUserData struct {
+	queue           *messagebroker.Queue
+	uowFactory      func() port.UserUnitOfWork
+	userService     port.UserService
+	userDataService port.UserDataService
+	objectStorage   port.ObjectStorage
+	avatarStore     *avatar.Store
+}
+
+var eraseUserDataInstance *EraseUserData
+
+const DelayEraseUserDataSeconds int64 = 0
+const EraseUserDataName = "erase_user_data"
+const EraseUserDataVersion = 1
+
+type EraseUserDataDto struct {
+	UserUUID  string `json:"userUuid"`
+	ErasedBy  uint64 `json:"erasedBy"`
+	IP        string `json:"ip"`
+	UserAgent string `json:"userAgent"`
+}
+
+func NewEraseUserData(
+	queue *messagebroker.Queue,
+	uowFactory func() port.UserUnitOfWork,
+	userService port.UserService,
+	userDataService port.UserDataService,
+	objectStorage port.ObjectStorage,
+	avatarStore *avatar.Store,
+) *EraseUserData {
+	if eraseUserDataInstance == nil {
+		eraseUserDataInstance = &EraseUserData{
+			queue:           queue,
+			uowFactory:      uowFactory,
+			userService:     userService,
+			userDataService: userDataService,
+			objectStorage:   objectStorage,
+			avatarStore:     avatarStore,
+		}
+	}
+
+	return eraseUserDataInstance
+}
+
+func (r *EraseUserData) Name() string {
+	return EraseUserDataName
+}
+
+func (r *EraseUserData) Publish(message interface{}) {
+
+	if err := r.queue.Produce(r.Name(), EraseUserDataVersion, message, DelayEraseUserDataSeconds); err != nil {
+		return
+	}
+	r.queue.Log.Info(logger.Queue, logger.RabbitMQPublish, fmt.Sprintf("published successfully to queue: %s", r.Name()), nil)
+}
+
+func (r *EraseUserData) Enqueue(ctx context.Context, outbox port.OutboxRepository, message interface{}) error {
+	return outboxservice.Enqueue(ctx, outbox, r.Name(), EraseUserDataVersion, message, DelayEraseUserDataSeconds)
+}
+
+// Consume anonymizes the user in the database and, in the same transaction, schedules the removal of
+// its avatar and export archives from storage, so the files are removed even when this job is not redelivered.
+func (r *EraseUserData) Consume(ctx context.Context, message []byte) error {
+	var msg EraseUserDataDto
+	if err := json.Unmarshal(message, &msg); err != nil {
+		r.queue.Log.Error(logger.Queue, logger.RabbitMQConsume, fmt.Sprintf("Error unmarshalling message, error: %v", err), nil)
+		return err
+	}
+
+	uow := r.uowFactory()
+	if err := uow.BeginTx(ctx); err != nil {
+		return err
+	}
+
+	user, err := r.userDataService.Erase(ctx, uow, msg.UserUUID, domain.AuditActor{
+		UserID:    &msg.ErasedBy,
+		IP:        msg.IP,
+		UserAgent: msg.UserAgent,
+	})
+	if err != nil {
+		if rErr := uow.Rollback(); rErr != nil {
+			return rErr
+		}
+
+		// an unknown or already erased user has nothing left to erase, its files were scheduled with the erasure
+		if serviceErr, ok := err.(*serviceerror.ServiceError); ok && serviceErr.GetErrorMessage() == serviceerror.RecordNotFound {
+			r.queue.Log.Warn(logger.Queue, logger.RabbitMQConsume, "The user to erase does not exist", map[logger.ExtraKey]interface{}{
+				logger.QueueName: r.Name(),
+			})
+			return nil
+		}
+		return err
+	}
+
+	removeUserFiles := NewRemoveUserFiles(r.queue, r.uowFactory, r.userService, r.objectStorage, r.avatarStore)
+	if err = removeUserFiles.Enqueue(ctx, uow.OutboxRepository(), RemoveUserFilesDto{
+		UserUUID: user.Base.UUID.String(),
+		Avatar:   user.Avatar,
+	}); err != nil {
+		if rErr := uow.Rollback(); rErr != nil {
+			return rErr
+		}
+		return err
+	}
+
+	return uow.Commit()
+}
+
+func (r *EraseUserData) Register() {
+	go func() {
+		if err := r.queue.Register(r.Name(), r.Consume); err != nil {
+			r.queue.Log.Error(
+				logger.Queue,
+				logger.RabbitMQRegisterConsumer,
+				fmt.Sprintf("Error on registering consumer, error: %v", err),
+				map[logger.ExtraKey]interface{}{
+					logger.QueueName: r.Name(),
+				},
+			)
+		}
+	}()
+}
@@ -0,0 +1,182 @@
+package userevent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/outboxservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+)
+
+type ExportUserData struct {
+	queue           *messagebroker.Queue
+	uowFactory      func() port.UserUnitOfWork
+	userDataService port.UserDataService
+	objectStorage   port.ObjectStorage
+}
+
+var exportUserDataInstance *ExportUserData
+
+const DelayExportUserDataSeconds int64 = 0
+const ExportUserDataName = "export_user_data"
+const ExportUserDataVersion = 1
+
+// DataExportPrefix is the folder of the export archives, every user has its own sub folder named by its UUID.
+const DataExportPrefix = "exports"
+
+type ExportUserDataDto struct {
+	UserID   uint64 `json:"userId"`
+	Language string `json:"language"`
+}
+
+func NewExportUserData(
+	queue *messagebroker.Queue,
+	uowFactory func() port.UserUnitOfWork,
+	userDataService port.UserDataService,
+	objectStorage port.ObjectStorage,
+) *ExportUserData {
+	if exportUserDataInstance == nil {
+		exportUserDataInstance = &ExportUserData{
+			queue:           queue,
+			uowFactory:      uowFactory,
+			userDataService: userDataService,
+			objectStorage:   objectStorage,
+		}
+	}
+
+	return exportUserDataInstance
+}
+
+func (r *ExportUserData) Name() string {
+	return ExportUserDataName
+}
+
+func (r *ExportUserData) Publish(message interface{}) {
+
+	if err := r.queue.Produce(r.Name(), ExportUserDataVersion, message, DelayExportUserDataSeconds); err != nil {
+		return
+	}
+	r.queue.Log.Info(logger.Queue, logger.RabbitMQPublish, fmt.Sprintf("published successfully to queue: %s", r.Name()), nil)
+}
+
+func (r *ExportUserData) Enqueue(ctx context.Context, outbox port.OutboxRepository, message interface{}) error {
+	return outboxservice.Enqueue(ctx, outbox, r.Name(), ExportUserDataVersion, message, DelayExportUserDataSeconds)
+}
+
+// Consume builds the archive, stores it next to the previous exports of the user and emails a time-limited link,
+// the archive is removed by DeleteDataExport once the link expires. The archive is named by the message, so a
+// redelivery overwrites it, and the export is recorded with the removal and the email only once it is stored.
+func (r *ExportUserData) Consume(ctx context.Context, message []byte) error {
+	var msg ExportUserDataDto
+	if err := json.Unmarshal(message, &msg); err != nil {
+		r.queue.Log.Error(logger.Queue, logger.RabbitMQConsume, fmt.Sprintf("Error unmarshalling message, error: %v", err), nil)
+		return err
+	}
+
+	uow := r.uowFactory()
+	if err := uow.BeginTx(ctx); err != nil {
+		return err
+	}
+
+	export, err := r.userDataService.Export(uow, msg.UserID)
+	if err != nil {
+		if rErr := uow.Rollback(); rErr != nil {
+			return rErr
+		}
+		return err
+	}
+
+	if err = uow.Commit(); err != nil {
+		return err
+	}
+
+	archive, err := r.userDataService.Archive(export)
+	if err != nil {
+		return err
+	}
+
+	objectName := fmt.Sprintf("%s/%s/%s.zip", DataExportPrefix, export.Profile.UUID, exportName(ctx))
+	link, err := r.objectStorage.Presign(ctx, objectName, r.queue.Config.DataExport.LinkExpireSecond)
+	if err != nil {
+		return err
+	}
+
+	if err = r.objectStorage.Put(ctx, objectName, bytes.NewReader(archive), int64(len(archive)), "application/zip"); err != nil {
+		return err
+	}
+
+	uow = r.uowFactory()
+	if err = uow.BeginTx(ctx); err != nil {
+		return err
+	}
+
+	if err = r.record(ctx, uow, export, objectName, link, msg.Language); err != nil {
+		if rErr := uow.Rollback(); rErr != nil {
+			return rErr
+		}
+		return err
+	}
+
+	return uow.Commit()
+}
+
+// record saves the audit entry of the export with the removal of its archive and the email of its link.
+func (r *ExportUserData) record(
+	ctx context.Context,
+	uow port.UserUnitOfWork,
+	export *domain.UserDataExport,
+	objectName string,
+	link string,
+	language string,
+) error {
+	if err := r.userDataService.RecordExport(uow, export); err != nil {
+		return err
+	}
+
+	if err := NewDeleteDataExport(r.queue, r.objectStorage).Enqueue(ctx, uow.OutboxRepository(), DeleteDataExportDto{
+		ObjectName: objectName,
+	}); err != nil {
+		return err
+	}
+
+	return NewSendDataExport(r.queue).Enqueue(ctx, uow.OutboxRepository(), SendDataExportDto{
+		To:       export.Profile.Email,
+		Name:     fullName(export.Profile),
+		Link:     link,
+		Language: language,
+	})
+}
+
+func (r *ExportUserData) Register() {
+	go func() {
+		if err := r.queue.Register(r.Name(), r.Consume); err != nil {
+			r.queue.Log.Error(
+				logger.Queue,
+				logger.RabbitMQRegisterConsumer,
+				fmt.Sprintf("Error on registering consumer, error: %v", err),
+				map[logger.ExtraKey]interface{}{
+					logger.QueueName: r.Name(),
+				},
+			)
+		}
+	}()
+}
+
+func fullName(profile domain.UserDataProfile) string {
+	user := domain.User{FirstName: profile.FirstName, LastName: profile.LastName}
+	return user.GetFullName()
+}
+
+// exportName is the id of the consumed message, a bare message without one gets a random name.
+func exportName(ctx context.Context) string {
+	if messageID := domain.MessageID(ctx); messageID != "" {
+		return messageID
+	}
+
+	return uuid.NewString()
+}
@@ -0,0 +1,124 @@
+package userevent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/avatar"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/outboxservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+)
+
+type RemoveAvatar struct {
+	queue       *messagebroker.Queue
+	uowFactory  func() port.UserUnitOfWork
+	userService port.UserService
+	avatarStore *avatar.Store
+}
+
+var removeAvatarInstance *RemoveAvatar
+
+// DelayRemoveAvatarSeconds gives an upload of the same picture that is still in flight the time to be saved,
+// so the usage check of Consume sees it.
+const DelayRemoveAvatarSeconds int64 = 60
+const RemoveAvatarName = "remove_avatar"
+const RemoveAvatarVersion = 1
+
+// RemoveAvatarDto holds the avatar a user replaced.
+type RemoveAvatarDto struct {
+	Avatar string `json:"avatar"`
+}
+
+func NewRemoveAvatar(
+	queue *messagebroker.Queue,
+	uowFactory func() port.UserUnitOfWork,
+	userService port.UserService,
+	avatarStore *avatar.Store,
+) *RemoveAvatar {
+	if removeAvatarInstance == nil {
+		removeAvatarInstance = &RemoveAvatar{
+			queue:       queue,
+			uowFactory:  uowFactory,
+			userService: userService,
+			avatarStore: avatarStore,
+		}
+	}
+
+	return removeAvatarInstance
+}
+
+func (r *RemoveAvatar) Name() string {
+	return RemoveAvatarName
+}
+
+func (r *RemoveAvatar) Publish(message interface{}) {
+	if err := r.queue.Produce(r.Name(), RemoveAvatarVersion, message, DelayRemoveAvatarSeconds); err != nil {
+		return
+	}
+	r.queue.Log.Info(logger.Queue, logger.RabbitMQPublish, fmt.Sprintf("published successfully to queue: %s", r.Name()), nil)
+}
+
+func (r *RemoveAvatar) Enqueue(ctx context.Context, outbox port.OutboxRepository, message interface{}) error {
+	return outboxservice.Enqueue(ctx, outbox, r.Name(), RemoveAvatarVersion, message, DelayRemoveAvatarSeconds)
+}
+
+// Consume removes a replaced avatar from storage unless a user has it by the time the message is handled.
+func (r *RemoveAvatar) Consume(ctx context.Context, message []byte) error {
+	var msg RemoveAvatarDto
+	if err := json.Unmarshal(message, &msg); err != nil {
+		r.queue.Log.Error(logger.Queue, logger.RabbitMQConsume, fmt.Sprintf("Error unmarshalling message, error: %v", err), nil)
+		return err
+	}
+
+	return removeUnusedAvatar(ctx, r.uowFactory, r.userService, r.avatarStore, msg.Avatar)
+}
+
+func (r *RemoveAvatar) Register() {
+	go func() {
+		if err := r.queue.Register(r.Name(), r.Consume); err != nil {
+			r.queue.Log.Error(
+				logger.Queue,
+				logger.RabbitMQRegisterConsumer,
+				fmt.Sprintf("Error on registering consumer, error: %v", err),
+				map[logger.ExtraKey]interface{}{
+					logger.QueueName: r.Name(),
+				},
+			)
+		}
+	}()
+}
+
+// removeUnusedAvatar keeps an avatar another user still has, avatars are content-addressed
+// and another user may have uploaded the same picture.
+func removeUnusedAvatar(
+	ctx context.Context,
+	uowFactory func() port.UserUnitOfWork,
+	userService port.UserService,
+	avatarStore *avatar.Store,
+	avatar string,
+) error {
+	uow := uowFactory()
+	if err := uow.BeginTx(ctx); err != nil {
+		return err
+	}
+
+	inUse, err := userService.IsAvatarInUse(uow, avatar)
+	if err != nil {
+		if rErr := uow.Rollback(); rErr != nil {
+			return rErr
+		}
+		return err
+	}
+
+	if err = uow.Commit(); err != nil {
+		return err
+	}
+
+	if inUse {
+		return nil
+	}
+
+	return avatarStore.Remove(ctx, avatar)
+}
@@ -0,0 +1,119 @@
+package userevent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/avatar"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/outboxservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+)
+
+type RemoveUserFiles struct {
+	queue         *messagebroker.Queue
+	uowFactory    func() port.UserUnitOfWork
+	userService   port.UserService
+	objectStorage port.ObjectStorage
+	avatarStore   *avatar.Store
+}
+
+var removeUserFilesInstance *RemoveUserFiles
+
+const DelayRemoveUserFilesSeconds int64 = 0
+const RemoveUserFilesName = "remove_user_files"
+const RemoveUserFilesVersion = 1
+
+// RemoveUserFilesDto is keyed by the user, the avatar is the one the user had before its data was erased.
+type RemoveUserFilesDto struct {
+	UserUUID string  `json:"userUuid"`
+	Avatar   *string `json:"avatar"`
+}
+
+func NewRemoveUserFiles(
+	queue *messagebroker.Queue,
+	uowFactory func() port.UserUnitOfWork,
+	userService port.UserService,
+	objectStorage port.ObjectStorage,
+	avatarStore *avatar.Store,
+) *RemoveUserFiles {
+	if removeUserFilesInstance == nil {
+		removeUserFilesInstance = &RemoveUserFiles{
+			queue:         queue,
+			uowFactory:    uowFactory,
+			userService:   userService,
+			objectStorage: objectStorage,
+			avatarStore:   avatarStore,
+		}
+	}
+
+	return removeUserFilesInstance
+}
+
+func (r *RemoveUserFiles) Name() string {
+	return RemoveUserFilesName
+}
+
+func (r *RemoveUserFiles) Publish(message interface{}) {
+	if err := r.queue.Produce(r.Name(), RemoveUserFilesVersion, message, DelayRemoveUserFilesSeconds); err != nil {
+		return
+	}
+	r.queue.Log.Info(logger.Queue, logger.RabbitMQPublish, fmt.Sprintf("published successfully to queue: %s", r.Name()), nil)
+}
+
+func (r *RemoveUserFiles) Enqueue(ctx context.Context, outbox port.OutboxRepository, message interface{}) error {
+	return outboxservice.Enqueue(ctx, outbox, r.Name(), RemoveUserFilesVersion, message, DelayRemoveUserFilesSeconds)
+}
+
+// Consume removes the avatar and the export archives of an erased user from storage. Removing a missing
+// object succeeds, so a redelivery after a partial cleanup removes what is left.
+func (r *RemoveUserFiles) Consume(ctx context.Context, message []byte) error {
+	var msg RemoveUserFilesDto
+	if err := json.Unmarshal(message, &msg); err != nil {
+		r.queue.Log.Error(logger.Queue, logger.RabbitMQConsume, fmt.Sprintf("Error unmarshalling message, error: %v", err), nil)
+		return err
+	}
+
+	userUUID, err := uuid.Parse(msg.UserUUID)
+	if err != nil {
+		r.queue.Log.Warn(logger.Queue, logger.RabbitMQConsume, "The files to remove do not belong to a user", map[logger.ExtraKey]interface{}{
+			logger.QueueName: r.Name(),
+		})
+		return nil
+	}
+
+	if msg.Avatar != nil {
+		if err = removeUnusedAvatar(ctx, r.uowFactory, r.userService, r.avatarStore, *msg.Avatar); err != nil {
+			return err
+		}
+	}
+
+	exports, err := r.objectStorage.List(ctx, fmt.Sprintf("%s/%s/", DataExportPrefix, userUUID))
+	if err != nil {
+		return err
+	}
+	for _, key := range exports {
+		if err = r.objectStorage.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *RemoveUserFiles) Register() {
+	go func() {
+		if err := r.queue.Register(r.Name(), r.Consume); err != nil {
+			r.queue.Log.Error(
+				logger.Queue,
+				logger.RabbitMQRegisterConsumer,
+				fmt.Sprintf("Error on registering consumer, error: %v", err),
+				map[logger.ExtraKey]interface{}{
+					logger.QueueName: r.Name(),
+				},
+			)
+		}
+	}()
+}
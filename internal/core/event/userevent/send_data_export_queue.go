@@ -0,0 +1,107 @@
+package userevent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/email"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/emailtemplateservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/outboxservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/views"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
+)
+
+type SendDataExport struct {
+	queue       *messagebroker.Queue
+	emailSender port.EmailSender
+	templates   port.EmailTemplateService
+}
+
+var dataExportInstance *SendDataExport
+
+const DelaySendDataExportSeconds int64 = 0
+const SendDataExportName = "send_data_export"
+const SendDataExportVersion = 1
+
+type SendDataExportDto struct {
+	To       string `json:"to"`
+	Name     string `json:"name"`
+	Link     string `json:"link"`
+	Language string `json:"language"`
+}
+
+func NewSendDataExport(queue *messagebroker.Queue) *SendDataExport {
+	if dataExportInstance == nil {
+		dataExportInstance = &SendDataExport{
+			queue:       queue,
+			emailSender: email.New(queue.Log, queue.Config),
+			templates: emailtemplateservice.New(
+				views.EmailTemplates(),
+				queue.Config.App,
+				translation.NewTranslation(queue.Config.App),
+			),
+		}
+	}
+
+	return dataExportInstance
+}
+
+func (r *SendDataExport) SetEmailSender(emailSender port.EmailSender) {
+	r.emailSender = emailSender
+}
+
+func (r *SendDataExport) Name() string {
+	return SendDataExportName
+}
+
+func (r *SendDataExport) Publish(message interface{}) {
+
+	if err := r.queue.Produce(r.Name(), SendDataExportVersion, message, DelaySendDataExportSeconds); err != nil {
+		return
+	}
+	r.queue.Log.Info(logger.Queue, logger.RabbitMQPublish, fmt.Sprintf("published successfully to queue: %s", r.Name()), nil)
+}
+
+func (r *SendDataExport) Enqueue(ctx context.Context, outbox port.OutboxRepository, message interface{}) error {
+	return outboxservice.Enqueue(ctx, outbox, r.Name(), SendDataExportVersion, message, DelaySendDataExportSeconds)
+}
+
+func (r *SendDataExport) Consume(ctx context.Context, message []byte) error {
+	var msg SendDataExportDto
+	if err := json.Unmarshal(message, &msg); err != nil {
+		r.queue.Log.Error(logger.Queue, logger.RabbitMQConsume, fmt.Sprintf("Error unmarshalling message, error: %v", err), nil)
+		return err
+	}
+
+	content, err := r.templates.Render("data_export", msg.Language, map[string]interface{}{
+		"username":    msg.Name,
+		"downloadUrl": msg.Link,
+		"expireHours": int(r.queue.Config.DataExport.LinkExpireSecond.Hours()),
+	})
+	if err != nil {
+		r.queue.Log.Error(logger.Email, logger.SendEmail, err.Error(), nil)
+		return err
+	}
+	content.To = msg.To
+	content.Name = msg.Name
+
+	return r.emailSender.Send(content)
+}
+
+func (r *SendDataExport) Register() {
+	go func() {
+		if err := r.queue.Register(r.Name(), r.Consume); err != nil {
+			r.queue.Log.Error(
+				logger.Queue,
+				logger.RabbitMQRegisterConsumer,
+				fmt.Sprintf("Error on registering consumer, error: %v", err),
+				map[logger.ExtraKey]interface{}{
+					logger.QueueName: r.Name(),
+				},
+			)
+		}
+	}()
+}
@@ -0,0 +1,107 @@
+package userevent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/email"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/emailtemplateservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/outboxservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/views"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
+)
+
+type SendInvitation struct {
+	queue       *messagebroker.Queue
+	emailSender port.EmailSender
+	templates   port.EmailTemplateService
+}
+
+var invitationInstance *SendInvitation
+
+const DelaySendInvitationSeconds int64 = 0
+const SendInvitationName = "send_invitation"
+const SendInvitationVersion = 1
+
+type SendInvitationDto struct {
+	To       string `json:"to"`
+	Name     string `json:"name"`
+	Token    string `json:"token"`
+	Language string `json:"language"`
+}
+
+func NewSendInvitation(queue *messagebroker.Queue) *SendInvitation {
+	if invitationInstance == nil {
+		invitationInstance = &SendInvitation{
+			queue:       queue,
+			emailSender: email.New(queue.Log, queue.Config),
+			templates: emailtemplateservice.New(
+				views.EmailTemplates(),
+				queue.Config.App,
+				translation.NewTranslation(queue.Config.App),
+			),
+		}
+	}
+
+	return invitationInstance
+}
+
+func (r *SendInvitation) SetEmailSender(emailSender port.EmailSender) {
+	r.emailSender = emailSender
+}
+
+func (r *SendInvitation) Name() string {
+	return SendInvitationName
+}
+
+func (r *SendInvitation) Publish(message interface{}) {
+
+	if err := r.queue.Produce(r.Name(), SendInvitationVersion, message, DelaySendInvitationSeconds); err != nil {
+		return
+	}
+	r.queue.Log.Info(logger.Queue, logger.RabbitMQPublish, fmt.Sprintf("published successfully to queue: %s", r.Name()), nil)
+}
+
+func (r *SendInvitation) Enqueue(ctx context.Context, outbox port.OutboxRepository, message interface{}) error {
+	return outboxservice.Enqueue(ctx, outbox, r.Name(), SendInvitationVersion, message, DelaySendInvitationSeconds)
+}
+
+func (r *SendInvitation) Consume(ctx context.Context, message []byte) error {
+	var msg SendInvitationDto
+	if err := json.Unmarshal(message, &msg); err != nil {
+		r.queue.Log.Error(logger.Queue, logger.RabbitMQConsume, fmt.Sprintf("Error unmarshalling message, error: %v", err), nil)
+		return err
+	}
+
+	content, err := r.templates.Render("invitation", msg.Language, map[string]interface{}{
+		"username":      msg.Name,
+		"invitationUrl": r.queue.Config.App.InvitationURL + msg.Token,
+		"expireDays":    int(r.queue.Config.Invitation.ExpireSecond.Hours() / 24),
+	})
+	if err != nil {
+		r.queue.Log.Error(logger.Email, logger.SendEmail, err.Error(), nil)
+		return err
+	}
+	content.To = msg.To
+	content.Name = msg.Name
+
+	return r.emailSender.Send(content)
+}
+
+func (r *SendInvitation) Register() {
+	go func() {
+		if err := r.queue.Register(r.Name(), r.Consume); err != nil {
+			r.queue.Log.Error(
+				logger.Queue,
+				logger.RabbitMQRegisterConsumer,
+				fmt.Sprintf("Error on registering consumer, error: %v", err),
+				map[logger.ExtraKey]interface{}{
+					logger.QueueName: r.Name(),
+				},
+			)
+		}
+	}()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"time"
 )
 
 type ACLRepository interface {
@@ -16,6 +17,20 @@ type ACLService interface {
 		uow AuthUnitOfWork,
 		userUUID uuid.UUID,
 		requiredPermissions ...domain.PermissionKeyType,
-	) (bool, uint64, error)
+	) (bool, uint64, []domain.PermissionKeyType, error)
 	AssignUserRoleToUser(uow AuthUnitOfWork, userID uint64) error
 }
+
+type ACLCacheService interface {
+	Get(ctx context.Context, userUUID string) (*domain.UserAccess, error)
+	Set(ctx context.Context, userUUID string, access domain.UserAccess) error
+	Forget(ctx context.Context, userUUIDs ...string) error
+	Flush(ctx context.Context) error
+}
+
+type ACLCache interface {
+	Set(ctx context.Context, key string, value *domain.UserAccess, expiration time.Duration) error
+	Get(ctx context.Context, key string) (*domain.UserAccess, error)
+	Delete(ctx context.Context, keys ...string) error
+	DeleteByPattern(ctx context.Context, pattern string) error
+}
@@ -0,0 +1,23 @@
+package port
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+)
+
+type AuditLogRepository interface {
+	Create(auditLog domain.AuditLog) error
+	List(filter domain.AuditLogFilter) ([]*domain.AuditLog, uint64, error)
+}
+
+// AuditUnitOfWork is satisfied by every unit of work able to write the audit log
+// in the same transaction as the audited change.
+type AuditUnitOfWork interface {
+	UnitOfWork
+
+	AuditLogRepository() AuditLogRepository
+}
+
+type AuditLogService interface {
+	Record(uow AuditUnitOfWork, auditLog domain.AuditLog) error
+	List(uow AuditUnitOfWork, filter domain.AuditLogFilter) ([]*domain.AuditLog, uint64, error)
+}
@@ -25,6 +25,12 @@ type UserClient interface {
 	UpdateGoogleID(ctx context.Context, ID uint64, googleID string) error
 	UpdateLastLoginTime(ctx context.Context, ID uint64) error
 	UpdatePassword(ctx context.Context, ID uint64, password string) error
+
+	GetNotificationPreference(
+		ctx context.Context,
+		email string,
+		category domain.NotificationCategoryType,
+	) (*domain.NotificationPreference, string, error)
 }
 
 type AuthCache interface {
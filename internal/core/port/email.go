@@ -1,5 +1,13 @@
 package port
 
+import "github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+
 type EmailSender interface {
-	Send(to string, name string, subject string, body string) error
+	Send(email domain.Email) error
+}
+
+type EmailTemplateService interface {
+	Templates() []string
+	Render(name string, language string, data map[string]interface{}) (domain.Email, error)
+	Preview(name string, language string) (domain.Email, error)
 }
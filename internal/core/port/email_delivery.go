@@ -0,0 +1,39 @@
+package port
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+)
+
+type EmailDeliveryRepository interface {
+	Save(delivery domain.EmailDelivery) error
+	GetByUUID(deliveryUUID uuid.UUID) (*domain.EmailDelivery, error)
+	UpdateStatus(id uint64, status domain.EmailDeliveryStatusType, reason string) error
+}
+
+type EmailSuppressionRepository interface {
+	IsSuppressed(email string) (bool, error)
+	Create(suppression domain.EmailSuppression) error
+	List(filter domain.EmailSuppressionFilter) ([]*domain.EmailSuppression, uint64, error)
+	Delete(suppressionUUID uuid.UUID) error
+}
+
+type EmailDeliveryService interface {
+	IsSuppressed(uow UserUnitOfWork, email string) (bool, error)
+	Record(uow UserUnitOfWork, delivery domain.EmailDelivery) error
+	HandleEvents(uow UserUnitOfWork, events []domain.EmailEvent) error
+	ListSuppressions(uow UserUnitOfWork, filter domain.EmailSuppressionFilter) ([]*domain.EmailSuppression, uint64, error)
+	DeleteSuppression(uow UserUnitOfWork, suppressionUUID uuid.UUID) error
+}
+
+// EmailWebhook verifies and parses the event webhooks of the email provider.
+type EmailWebhook interface {
+	Parse(payload []byte, signature string, timestamp string) ([]domain.EmailEvent, error)
+}
+
+// EmailDeliveryClient reaches the deliveries and the suppression list from the services sending the emails.
+type EmailDeliveryClient interface {
+	IsEmailSuppressed(ctx context.Context, email string) (bool, error)
+	RecordEmailDelivery(ctx context.Context, delivery domain.EmailDelivery) error
+}
@@ -1,8 +1,14 @@
 package port
 
+import (
+	"context"
+)
+
 type Event interface {
 	Name() string
 	Publish(message interface{})
-	Consume(message []byte) error
+	// Enqueue stores the message in the outbox, it is published once the transaction of the outbox is committed.
+	Enqueue(ctx context.Context, outbox OutboxRepository, message interface{}) error
+	Consume(ctx context.Context, message []byte) error
 	Register()
 }
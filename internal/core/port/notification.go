@@ -0,0 +1,37 @@
+package port
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+)
+
+type NotificationRepository interface {
+	Create(notification *domain.Notification) error
+	List(userID uint64, filter domain.NotificationFilter) ([]*domain.Notification, uint64, error)
+	MarkRead(userID uint64, notificationUUIDs []uuid.UUID) error
+	MarkAllRead(userID uint64) error
+	CountUnread(userID uint64) (uint64, error)
+}
+
+// NotificationBroadcaster carries the stored notifications to the streams of their users, whichever
+// instance of the service holds the stream.
+type NotificationBroadcaster interface {
+	Publish(ctx context.Context, notification domain.Notification) error
+	// Subscribe returns the notifications published for the user until ctx is done, the channel is closed then.
+	Subscribe(ctx context.Context, userID uint64) (<-chan domain.Notification, error)
+}
+
+type NotificationService interface {
+	Send(uow UserUnitOfWork, notification domain.Notification) (*domain.Notification, error)
+	Publish(ctx context.Context, notification domain.Notification)
+	List(uow UserUnitOfWork, userID uint64, filter domain.NotificationFilter) ([]*domain.Notification, uint64, error)
+	MarkRead(uow UserUnitOfWork, userID uint64, notificationUUIDs []uuid.UUID) (uint64, error)
+	CountUnread(uow UserUnitOfWork, userID uint64) (uint64, error)
+	Subscribe(ctx context.Context, userID uint64) (<-chan domain.Notification, error)
+}
+
+// NotificationClient sends in-app notifications from the services other than user management.
+type NotificationClient interface {
+	SendNotification(ctx context.Context, notification domain.Notification) error
+}
@@ -0,0 +1,18 @@
+package port
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+)
+
+type NotificationPreferenceRepository interface {
+	ListByUserID(userID uint64) ([]domain.NotificationPreference, error)
+	Upsert(preference domain.NotificationPreference) error
+}
+
+type NotificationPreferenceService interface {
+	List(uow UserUnitOfWork, userID uint64) ([]domain.NotificationPreference, error)
+	Update(uow UserUnitOfWork, userID uint64, preferences []domain.NotificationPreference) ([]domain.NotificationPreference, error)
+	GetByEmail(uow UserUnitOfWork, email string, category domain.NotificationCategoryType) (*domain.NotificationPreference, string, error)
+	VerifyUnsubscribe(token string) (domain.NotificationCategoryType, error)
+	Unsubscribe(uow UserUnitOfWork, token string) (*domain.NotificationPreference, error)
+}
@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// ObjectStorage is an interface for keeping files such as avatars and data exports, objects are addressed by
+// slash separated keys like "avatars/<hash>.png".
+type ObjectStorage interface {
+	Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	Copy(ctx context.Context, srcKey string, dstKey string) error
+	Presign(ctx context.Context, key string, expiry time.Duration) (string, error)
+	PresignPut(ctx context.Context, key string, expiry time.Duration, contentType string, size int64) (string, error)
+	List(ctx context.Context, prefix string) ([]string, error)
+}
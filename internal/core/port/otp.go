@@ -10,6 +10,8 @@ type OTPCacheService interface {
 	Set(ctx context.Context, key string, otp string) error
 	Validate(ctx context.Context, key string, otp string) error
 	Used(ctx context.Context, key string) error
+	SignLink(key string, otp string) string
+	ValidateLink(ctx context.Context, key string, signature string) error
 
 	SetForgetPassword(ctx context.Context, key string, otp string) error
 	ValidateForgetPassword(ctx context.Context, key string, otp string) error
@@ -0,0 +1,21 @@
+package port
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"time"
+)
+
+type OutboxRepository interface {
+	Create(message domain.OutboxMessage) error
+	ListDue(limit int) ([]*domain.OutboxMessage, error)
+	MarkPublished(id uint64) error
+	MarkFailed(id uint64, lastError string, availableAt time.Time) error
+	DeletePublished(before time.Time) (int64, error)
+}
+
+// OutboxUnitOfWork is satisfied by every unit of work that can write to the outbox.
+type OutboxUnitOfWork interface {
+	UnitOfWork
+
+	OutboxRepository() OutboxRepository
+}
@@ -0,0 +1,26 @@
+package port
+
+type PasswordHistoryRepository interface {
+	Create(userID uint64, password string) error
+	ListRecent(userID uint64, limit int) ([]string, error)
+	Prune(userID uint64, keep int) error
+}
+
+// PasswordHistoryUnitOfWork is satisfied by every unit of work able to read and write
+// the password history in the same transaction as the password change.
+type PasswordHistoryUnitOfWork interface {
+	UnitOfWork
+
+	PasswordHistoryRepository() PasswordHistoryRepository
+}
+
+type PasswordHistoryService interface {
+	CheckReuse(uow PasswordHistoryUnitOfWork, userID uint64, currentHash *string, password string) error
+	Remember(uow PasswordHistoryUnitOfWork, userID uint64, hashedPassword string) error
+}
+
+// BreachedPasswordChecker looks passwords up in a k-anonymity hash list,
+// only the first five characters of the SHA-1 hash are used to select the range to search.
+type BreachedPasswordChecker interface {
+	IsBreached(password string) (bool, error)
+}
@@ -9,8 +9,13 @@ type PermissionRepository interface {
 	GetUserPermissionKeys(userID uint64) ([]domain.PermissionKeyType, error)
 	List() ([]*domain.Permission, error)
 	FilterValidPermissions(uuids []uuid.UUID) ([]uint64, error)
+	// Upsert inserts or refreshes a permission by key, it returns the id and whether the row was created,
+	// the id is zero when the stored permission already matches.
+	Upsert(permission domain.Permission) (uint64, bool, error)
+	ListKeys() ([]domain.PermissionKeyType, error)
 }
 
 type PermissionService interface {
 	List(uow AuthUnitOfWork) ([]*domain.Permission, error)
+	Sync(uow AuthUnitOfWork, grant bool) (*domain.PermissionSyncReport, error)
 }
@@ -1,7 +1,32 @@
 package port
 
+import (
+	"context"
+)
+
+// ConsumerOptions sizes a consumer, Workers messages are handled at the same time
+// and at most Prefetch messages are taken from the broker ahead of the handlers.
+type ConsumerOptions struct {
+	Workers  int
+	Prefetch int
+}
+
 type Driver interface {
 	Close()
 	Produce(name string, message interface{}, delaySeconds int64) error
-	RegisterConsumer(name string, callback func(message []byte) error) error
+	RegisterConsumer(name string, options ConsumerOptions, callback func(ctx context.Context, message []byte) error) error
+	// Shutdown stops taking messages and waits for the handlers to finish the ones in hand,
+	// the context of the handlers is canceled once ctx is done.
+	Shutdown(ctx context.Context) error
+}
+
+// ProcessedMessageCache remembers the messages the consumers of a queue handled, so a redelivered message is skipped.
+// A consumer claims a message before handling it, only one delivery of the message can hold the claim.
+type ProcessedMessageCache interface {
+	// Claim reserves the message for the caller, it returns false when the message is claimed or processed already.
+	Claim(ctx context.Context, queue string, id string) (bool, error)
+	// Release drops the claim of a message that failed, so its redelivery is handled again.
+	Release(ctx context.Context, queue string, id string) error
+	IsProcessed(ctx context.Context, queue string, id string) (bool, error)
+	MarkProcessed(ctx context.Context, queue string, id string) error
 }
@@ -8,7 +8,7 @@ import (
 )
 
 type RoleRepository interface {
-	Create(role domain.Role) error
+	Create(role domain.Role) (*domain.Role, error)
 	GetByUUID(uuid uuid.UUID) (*domain.Role, error)
 	List() ([]*domain.Role, error)
 	Update(role domain.Role, uuid uuid.UUID) error
@@ -19,19 +19,29 @@ type RoleRepository interface {
 
 	GetPermissions(uuid uuid.UUID) (*domain.Role, error)
 	SyncPermissions(roleID uint64, permissionIDs []uint64) error
+	AttachPermission(permissionKey domain.PermissionKeyType, roleKeys ...domain.RoleKeyType) ([]domain.RoleKeyType, error)
 
 	GetUserRoleKeys(userID uint64) ([]domain.RoleKeyType, error)
+	GetAncestors(roleUUID uuid.UUID) ([]*domain.Role, error)
+	GetSubtreeHeight(roleUUID uuid.UUID) (int, error)
 }
 
 type RoleService interface {
-	Create(uow AuthUnitOfWork, role domain.Role) error
+	Create(uow AuthUnitOfWork, role domain.Role, actor domain.AuditActor) error
 	Get(uow AuthUnitOfWork, uuidStr string) (*domain.Role, error)
 	List(ctx context.Context, uow AuthUnitOfWork) ([]*domain.Role, error)
-	Update(ctx context.Context, uow AuthUnitOfWork, role domain.Role, uuidStr string) error
-	Delete(uow AuthUnitOfWork, uuidStr string, deletedBy uint64) error
+	Update(ctx context.Context, uow AuthUnitOfWork, role domain.Role, uuidStr string, actor domain.AuditActor) error
+	Delete(ctx context.Context, uow AuthUnitOfWork, uuidStr string, deletedBy uint64, actor domain.AuditActor) error
 
 	GetPermissions(uow AuthUnitOfWork, uuidStr string) (*domain.Role, error)
-	SyncPermissions(uow AuthUnitOfWork, uuidStr string, permissionUUIDStr []string) error
+	GetPermissionSources(uow AuthUnitOfWork, uuidStr string) ([]domain.PermissionSource, error)
+	SyncPermissions(
+		ctx context.Context,
+		uow AuthUnitOfWork,
+		uuidStr string,
+		permissionUUIDStr []string,
+		actor domain.AuditActor,
+	) error
 }
 
 type RoleCacheService interface {
@@ -0,0 +1,17 @@
+package port
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+)
+
+type SentenceRepository interface {
+	GetByUUID(sentenceUUID uuid.UUID) (*domain.Sentence, error)
+	Update(sentence domain.Sentence) error
+	Delete(id uint64, deletedBy uint64) error
+}
+
+type SentenceService interface {
+	Update(uow UserUnitOfWork, access domain.UserAccess, sentence domain.Sentence) error
+	Delete(uow UserUnitOfWork, access domain.UserAccess, sentenceUUID uuid.UUID) error
+}
@@ -6,6 +6,8 @@ type UnitOfWork interface {
 	BeginTx(ctx context.Context) error
 	Commit() error
 	Rollback() error
+	// AfterCommit registers a hook to run once the transaction is committed, it never runs on a rollback.
+	AfterCommit(hook func() error)
 }
 
 type AuthUnitOfWork interface {
@@ -14,6 +16,9 @@ type AuthUnitOfWork interface {
 	RoleRepository() RoleRepository
 	PermissionRepository() PermissionRepository
 	ACLRepository() ACLRepository
+	AuditLogRepository() AuditLogRepository
+	PasswordHistoryRepository() PasswordHistoryRepository
+	OutboxRepository() OutboxRepository
 	// Add other repositories as needed
 }
 
@@ -21,5 +26,16 @@ type UserUnitOfWork interface {
 	UnitOfWork
 
 	UserRepository() UserRepository
+	UserInvitationRepository() UserInvitationRepository
+	UserDataRepository() UserDataRepository
+	UploadSessionRepository() UploadSessionRepository
+	NotificationPreferenceRepository() NotificationPreferenceRepository
+	NotificationRepository() NotificationRepository
+	EmailDeliveryRepository() EmailDeliveryRepository
+	EmailSuppressionRepository() EmailSuppressionRepository
+	SentenceRepository() SentenceRepository
+	AuditLogRepository() AuditLogRepository
+	PasswordHistoryRepository() PasswordHistoryRepository
+	OutboxRepository() OutboxRepository
 	// Add other repositories as needed
 }
@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+)
+
+type UploadSessionRepository interface {
+	Create(session domain.UploadSession) error
+	GetByUUID(sessionUUID uuid.UUID) (*domain.UploadSession, error)
+	MarkCompleted(id uint64, key string) error
+	MarkAbandoned(id uint64) error
+}
+
+type UploadSessionService interface {
+	Create(ctx context.Context, uow UserUnitOfWork, session domain.UploadSession) (*domain.UploadSession, string, error)
+	Complete(ctx context.Context, uow UserUnitOfWork, userID uint64, sessionUUIDStr string) (*domain.UploadSession, error)
+	Abandon(ctx context.Context, uow UserUnitOfWork, sessionUUIDStr string) error
+}
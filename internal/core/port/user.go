@@ -18,6 +18,9 @@ type UserRepository interface {
 	UpdateGoogleID(id uint64, googleID string) error
 	UpdateLastLoginTime(id uint64) error
 	UpdatePassword(id uint64, password string) error
+	Activate(id uint64, password string) error
+	UpdateAvatar(id uint64, avatar string) error
+	IsAvatarInUse(avatar string) (bool, error)
 }
 
 // UserService is an interface for interacting with user-related business logic
@@ -33,4 +36,6 @@ type UserService interface {
 	UpdateGoogleID(uow UserUnitOfWork, id uint64, googleID string) error
 	UpdateLastLoginTime(uow UserUnitOfWork, id uint64) error
 	UpdatePassword(uow UserUnitOfWork, id uint64, password string) error
+	UpdateAvatar(uow UserUnitOfWork, id uint64, avatar string) error
+	IsAvatarInUse(uow UserUnitOfWork, avatar string) (bool, error)
 }
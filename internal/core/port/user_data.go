@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+)
+
+type UserDataRepository interface {
+	GetExport(userID uint64) (*domain.UserDataExport, error)
+	Erase(userUUID uuid.UUID, erasedBy *uint64) (*domain.User, error)
+}
+
+type UserDataService interface {
+	Export(uow UserUnitOfWork, userID uint64) (*domain.UserDataExport, error)
+	RecordExport(uow UserUnitOfWork, export *domain.UserDataExport) error
+	Archive(export *domain.UserDataExport) ([]byte, error)
+	Erase(ctx context.Context, uow UserUnitOfWork, userUUIDStr string, actor domain.AuditActor) (*domain.User, error)
+}
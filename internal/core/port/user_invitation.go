@@ -0,0 +1,33 @@
+package port
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"time"
+)
+
+type UserInvitationRepository interface {
+	Create(invitation domain.UserInvitation) error
+	GetByTokenHash(tokenHash string) (*domain.UserInvitation, error)
+	GetOpenByUserUUID(userUUID uuid.UUID) (*domain.UserInvitation, error)
+	Refresh(id uint64, tokenHash string, expiresAt time.Time) error
+	Accept(id uint64) error
+	Revoke(id uint64, revokedBy uint64) error
+	AssignRoles(userID uint64, roleUUIDs []uuid.UUID) error
+	AssignDefaultRole(userID uint64) error
+}
+
+type UserInvitationService interface {
+	Invite(
+		ctx context.Context,
+		uow UserUnitOfWork,
+		user domain.User,
+		roleUUIDsStr []string,
+		access domain.UserAccess,
+	) (*domain.User, string, error)
+	Resend(uow UserUnitOfWork, userUUIDStr string) (*domain.UserInvitation, string, error)
+	Revoke(uow UserUnitOfWork, userUUIDStr string, revokedBy uint64) error
+	Open(uow UserUnitOfWork, token string) (*domain.UserInvitation, error)
+	Accept(uow UserUnitOfWork, invitation *domain.UserInvitation, hashedPassword string) error
+}
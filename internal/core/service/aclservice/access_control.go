@@ -9,11 +9,13 @@ import (
 
 type ACLService struct {
 	userClient port.UserClient
+	aclCache   port.ACLCacheService
 }
 
-func New(userClient port.UserClient) *ACLService {
+func New(userClient port.UserClient, aclCache port.ACLCacheService) *ACLService {
 	return &ACLService{
 		userClient: userClient,
+		aclCache:   aclCache,
 	}
 }
 
@@ -22,41 +24,51 @@ func (r ACLService) CheckAccess(
 	uow port.AuthUnitOfWork,
 	userUUID uuid.UUID,
 	requiredPermissions ...domain.PermissionKeyType,
-) (bool, uint64, error) {
+) (bool, uint64, []domain.PermissionKeyType, error) {
 
+	// a failing cache must not block authorization, so errors fall back to the database
+	access, _ := r.aclCache.Get(ctx, userUUID.String())
+	if access == nil {
+		var err error
+		if access, err = r.loadUserAccess(ctx, uow, userUUID); err != nil {
+			return false, 0, nil, err
+		}
+
+		_ = r.aclCache.Set(ctx, userUUID.String(), *access)
+	}
+
+	if access.IsSuperAdmin() || access.HasAnyPermission(requiredPermissions...) {
+		return true, access.UserID, access.GrantedPermissions(requiredPermissions...), nil
+	}
+
+	return false, 0, nil, nil
+}
+
+func (r ACLService) loadUserAccess(ctx context.Context, uow port.AuthUnitOfWork, userUUID uuid.UUID) (*domain.UserAccess, error) {
 	user, err := r.userClient.GetByUUID(ctx, userUUID.String())
 	if err != nil {
-		return false, 0, err
+		return nil, err
 	}
 
 	roleKeys, err := uow.RoleRepository().GetUserRoleKeys(user.Base.ID)
 	if err != nil {
-		return false, 0, err
+		return nil, err
 	}
 
-	for _, key := range roleKeys {
-		if key == domain.RoleKeySuperAdmin {
-			return true, user.Base.ID, nil
-		}
+	access := &domain.UserAccess{
+		UserID:   user.Base.ID,
+		RoleKeys: roleKeys,
 	}
 
-	permissionKeys, err := uow.PermissionRepository().GetUserPermissionKeys(user.Base.ID)
-	if err != nil {
-		return false, 0, err
+	if access.IsSuperAdmin() {
+		return access, nil
 	}
 
-	for _, requiredPermission := range requiredPermissions {
-		if requiredPermission == domain.PermissionKeyNone {
-			return true, user.Base.ID, nil
-		}
-		for _, key := range permissionKeys {
-			if requiredPermission == key {
-				return true, user.Base.ID, nil
-			}
-		}
+	if access.PermissionKeys, err = uow.PermissionRepository().GetUserPermissionKeys(user.Base.ID); err != nil {
+		return nil, err
 	}
 
-	return false, 0, nil
+	return access, nil
 }
 
 func (r ACLService) AssignUserRoleToUser(uow port.AuthUnitOfWork, userID uint64) error {
@@ -25,29 +25,41 @@ func TestACLService_CheckAccess(t *testing.T) {
 
 	ctx := context.TODO()
 
+	var noCachedAccess *domain.UserAccess
+
 	t.Run("SuperAdmin access", func(t *testing.T) {
 		mockUOW := new(authrepository.MockUnitOfWork)
 		mockUserClient := new(client.MockUserClient)
+		mockACLCache := new(aclservice.MockACLCacheService)
+		mockACLCache.On("Get", ctx, userUUID.String()).Return(noCachedAccess, nil)
 		mockRoleRepo := new(authrepository.MockRoleRepository)
 		mockUOW.On("RoleRepository").Return(mockRoleRepo)
 
 		mockUserClient.On("GetByUUID", mock.Anything, userUUID.String()).Return(user, nil)
 		mockRoleRepo.On("GetUserRoleKeys", user.Base.ID).Return([]domain.RoleKeyType{domain.RoleKeySuperAdmin}, nil)
+		mockACLCache.On("Set", ctx, userUUID.String(), domain.UserAccess{
+			UserID:   user.Base.ID,
+			RoleKeys: []domain.RoleKeyType{domain.RoleKeySuperAdmin},
+		}).Return(nil)
 
-		service := aclservice.New(mockUserClient)
-		hasAccess, userID, err := service.CheckAccess(ctx, mockUOW, userUUID, domain.PermissionKeyReadUser)
+		service := aclservice.New(mockUserClient, mockACLCache)
+		hasAccess, userID, granted, err := service.CheckAccess(ctx, mockUOW, userUUID, domain.PermissionKeyReadUser)
 
 		require.NoError(t, err)
 		require.True(t, hasAccess)
 		require.Equal(t, user.Base.ID, userID)
+		require.Equal(t, []domain.PermissionKeyType{domain.PermissionKeyReadUser}, granted)
 
 		mockUserClient.AssertExpectations(t)
+		mockACLCache.AssertExpectations(t)
 		mockRoleRepo.AssertExpectations(t)
 	})
 
 	t.Run("Access with required permissions", func(t *testing.T) {
 		mockUOW := new(authrepository.MockUnitOfWork)
 		mockUserClient := new(client.MockUserClient)
+		mockACLCache := new(aclservice.MockACLCacheService)
+		mockACLCache.On("Get", ctx, userUUID.String()).Return(noCachedAccess, nil)
 		mockRoleRepo := new(authrepository.MockRoleRepository)
 		mockPermissionRepo := new(authrepository.MockPermissionRepository)
 		mockUOW.On("RoleRepository").Return(mockRoleRepo)
@@ -57,15 +69,22 @@ func TestACLService_CheckAccess(t *testing.T) {
 		mockRoleRepo.On("GetUserRoleKeys", user.Base.ID).Return([]domain.RoleKeyType{}, nil)
 		mockPermissionRepo.On("GetUserPermissionKeys", user.Base.ID).
 			Return([]domain.PermissionKeyType{domain.PermissionKeyReadUser}, nil)
+		mockACLCache.On("Set", ctx, userUUID.String(), domain.UserAccess{
+			UserID:         user.Base.ID,
+			RoleKeys:       []domain.RoleKeyType{},
+			PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyReadUser},
+		}).Return(nil)
 
-		service := aclservice.New(mockUserClient)
-		hasAccess, userID, err := service.CheckAccess(ctx, mockUOW, userUUID, domain.PermissionKeyReadUser)
+		service := aclservice.New(mockUserClient, mockACLCache)
+		hasAccess, userID, granted, err := service.CheckAccess(ctx, mockUOW, userUUID, domain.PermissionKeyReadUser)
 
 		require.NoError(t, err)
 		require.True(t, hasAccess)
 		require.Equal(t, user.Base.ID, userID)
+		require.Equal(t, []domain.PermissionKeyType{domain.PermissionKeyReadUser}, granted)
 
 		mockUserClient.AssertExpectations(t)
+		mockACLCache.AssertExpectations(t)
 		mockRoleRepo.AssertExpectations(t)
 		mockPermissionRepo.AssertExpectations(t)
 	})
@@ -73,6 +92,8 @@ func TestACLService_CheckAccess(t *testing.T) {
 	t.Run("Access denied due to missing required permissions", func(t *testing.T) {
 		mockUOW := new(authrepository.MockUnitOfWork)
 		mockUserClient := new(client.MockUserClient)
+		mockACLCache := new(aclservice.MockACLCacheService)
+		mockACLCache.On("Get", ctx, userUUID.String()).Return(noCachedAccess, nil)
 		mockRoleRepo := new(authrepository.MockRoleRepository)
 		mockPermissionRepo := new(authrepository.MockPermissionRepository)
 		mockUOW.On("RoleRepository").Return(mockRoleRepo)
@@ -81,15 +102,22 @@ func TestACLService_CheckAccess(t *testing.T) {
 		mockUserClient.On("GetByUUID", mock.Anything, userUUID.String()).Return(user, nil)
 		mockRoleRepo.On("GetUserRoleKeys", user.Base.ID).Return([]domain.RoleKeyType{}, nil)
 		mockPermissionRepo.On("GetUserPermissionKeys", user.Base.ID).Return([]domain.PermissionKeyType{}, nil)
+		mockACLCache.On("Set", ctx, userUUID.String(), domain.UserAccess{
+			UserID:         user.Base.ID,
+			RoleKeys:       []domain.RoleKeyType{},
+			PermissionKeys: []domain.PermissionKeyType{},
+		}).Return(nil)
 
-		service := aclservice.New(mockUserClient)
-		hasAccess, userID, err := service.CheckAccess(ctx, mockUOW, userUUID, domain.PermissionKeyReadUser)
+		service := aclservice.New(mockUserClient, mockACLCache)
+		hasAccess, userID, granted, err := service.CheckAccess(ctx, mockUOW, userUUID, domain.PermissionKeyReadUser)
 
 		require.NoError(t, err)
 		require.False(t, hasAccess)
 		require.Equal(t, uint64(0), userID)
+		require.Nil(t, granted)
 
 		mockUserClient.AssertExpectations(t)
+		mockACLCache.AssertExpectations(t)
 		mockRoleRepo.AssertExpectations(t)
 		mockPermissionRepo.AssertExpectations(t)
 	})
@@ -97,6 +125,8 @@ func TestACLService_CheckAccess(t *testing.T) {
 	t.Run("Access granted due to PermissionKeyNone", func(t *testing.T) {
 		mockUOW := new(authrepository.MockUnitOfWork)
 		mockUserClient := new(client.MockUserClient)
+		mockACLCache := new(aclservice.MockACLCacheService)
+		mockACLCache.On("Get", ctx, userUUID.String()).Return(noCachedAccess, nil)
 		mockRoleRepo := new(authrepository.MockRoleRepository)
 		mockPermissionRepo := new(authrepository.MockPermissionRepository)
 		mockUOW.On("RoleRepository").Return(mockRoleRepo)
@@ -105,15 +135,22 @@ func TestACLService_CheckAccess(t *testing.T) {
 		mockUserClient.On("GetByUUID", mock.Anything, userUUID.String()).Return(user, nil)
 		mockRoleRepo.On("GetUserRoleKeys", user.Base.ID).Return([]domain.RoleKeyType{}, nil)
 		mockPermissionRepo.On("GetUserPermissionKeys", user.Base.ID).Return([]domain.PermissionKeyType{}, nil)
+		mockACLCache.On("Set", ctx, userUUID.String(), domain.UserAccess{
+			UserID:         user.Base.ID,
+			RoleKeys:       []domain.RoleKeyType{},
+			PermissionKeys: []domain.PermissionKeyType{},
+		}).Return(nil)
 
-		service := aclservice.New(mockUserClient)
-		hasAccess, userID, err := service.CheckAccess(ctx, mockUOW, userUUID, domain.PermissionKeyNone)
+		service := aclservice.New(mockUserClient, mockACLCache)
+		hasAccess, userID, granted, err := service.CheckAccess(ctx, mockUOW, userUUID, domain.PermissionKeyNone)
 
 		require.NoError(t, err)
 		require.True(t, hasAccess)
 		require.Equal(t, user.Base.ID, userID)
+		require.Nil(t, granted)
 
 		mockUserClient.AssertExpectations(t)
+		mockACLCache.AssertExpectations(t)
 		mockRoleRepo.AssertExpectations(t)
 		mockPermissionRepo.AssertExpectations(t)
 	})
@@ -121,44 +158,54 @@ func TestACLService_CheckAccess(t *testing.T) {
 	t.Run("GetByUUID error", func(t *testing.T) {
 		mockUOW := new(authrepository.MockUnitOfWork)
 		mockUserClient := new(client.MockUserClient)
+		mockACLCache := new(aclservice.MockACLCacheService)
+		mockACLCache.On("Get", ctx, userUUID.String()).Return(noCachedAccess, nil)
 
 		mockUserClient.On("GetByUUID", mock.Anything, userUUID.String()).Return(nil, serviceerror.NewServerError())
 
-		service := aclservice.New(mockUserClient)
-		hasAccess, userID, err := service.CheckAccess(ctx, mockUOW, userUUID, domain.PermissionKeyReadUser)
+		service := aclservice.New(mockUserClient, mockACLCache)
+		hasAccess, userID, granted, err := service.CheckAccess(ctx, mockUOW, userUUID, domain.PermissionKeyReadUser)
 
 		require.Error(t, err)
 		require.False(t, hasAccess)
 		require.Equal(t, uint64(0), userID)
+		require.Nil(t, granted)
 		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
 
 		mockUserClient.AssertExpectations(t)
+		mockACLCache.AssertExpectations(t)
 	})
 
 	t.Run("GetUserRoleKeys error", func(t *testing.T) {
 		mockUOW := new(authrepository.MockUnitOfWork)
 		mockUserClient := new(client.MockUserClient)
+		mockACLCache := new(aclservice.MockACLCacheService)
+		mockACLCache.On("Get", ctx, userUUID.String()).Return(noCachedAccess, nil)
 		mockRoleRepo := new(authrepository.MockRoleRepository)
 		mockUOW.On("RoleRepository").Return(mockRoleRepo)
 
 		mockUserClient.On("GetByUUID", mock.Anything, userUUID.String()).Return(user, nil)
 		mockRoleRepo.On("GetUserRoleKeys", user.Base.ID).Return([]domain.RoleKeyType{}, serviceerror.NewServerError())
 
-		service := aclservice.New(mockUserClient)
-		hasAccess, userID, err := service.CheckAccess(ctx, mockUOW, userUUID, domain.PermissionKeyReadUser)
+		service := aclservice.New(mockUserClient, mockACLCache)
+		hasAccess, userID, granted, err := service.CheckAccess(ctx, mockUOW, userUUID, domain.PermissionKeyReadUser)
 
 		require.Error(t, err)
 		require.False(t, hasAccess)
 		require.Equal(t, uint64(0), userID)
+		require.Nil(t, granted)
 		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
 
 		mockUserClient.AssertExpectations(t)
+		mockACLCache.AssertExpectations(t)
 		mockRoleRepo.AssertExpectations(t)
 	})
 
 	t.Run("GetUserPermissionKeys error", func(t *testing.T) {
 		mockUOW := new(authrepository.MockUnitOfWork)
 		mockUserClient := new(client.MockUserClient)
+		mockACLCache := new(aclservice.MockACLCacheService)
+		mockACLCache.On("Get", ctx, userUUID.String()).Return(noCachedAccess, nil)
 		mockRoleRepo := new(authrepository.MockRoleRepository)
 		mockPermissionRepo := new(authrepository.MockPermissionRepository)
 		mockUOW.On("RoleRepository").Return(mockRoleRepo)
@@ -169,18 +216,91 @@ func TestACLService_CheckAccess(t *testing.T) {
 		mockPermissionRepo.On("GetUserPermissionKeys", user.Base.ID).
 			Return([]domain.PermissionKeyType{}, serviceerror.NewServerError())
 
-		service := aclservice.New(mockUserClient)
-		hasAccess, userID, err := service.CheckAccess(ctx, mockUOW, userUUID, domain.PermissionKeyReadUser)
+		service := aclservice.New(mockUserClient, mockACLCache)
+		hasAccess, userID, granted, err := service.CheckAccess(ctx, mockUOW, userUUID, domain.PermissionKeyReadUser)
 
 		require.Error(t, err)
 		require.False(t, hasAccess)
 		require.Equal(t, uint64(0), userID)
+		require.Nil(t, granted)
 		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
 
 		mockUserClient.AssertExpectations(t)
+		mockACLCache.AssertExpectations(t)
 		mockRoleRepo.AssertExpectations(t)
 		mockPermissionRepo.AssertExpectations(t)
 	})
+
+	t.Run("Access granted from cache", func(t *testing.T) {
+		mockUOW := new(authrepository.MockUnitOfWork)
+		mockUserClient := new(client.MockUserClient)
+		mockACLCache := new(aclservice.MockACLCacheService)
+		mockACLCache.On("Get", ctx, userUUID.String()).Return(&domain.UserAccess{
+			UserID:         user.Base.ID,
+			PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyReadUser},
+		}, nil)
+
+		service := aclservice.New(mockUserClient, mockACLCache)
+		hasAccess, userID, granted, err := service.CheckAccess(ctx, mockUOW, userUUID, domain.PermissionKeyReadUser)
+
+		require.NoError(t, err)
+		require.True(t, hasAccess)
+		require.Equal(t, user.Base.ID, userID)
+		require.Equal(t, []domain.PermissionKeyType{domain.PermissionKeyReadUser}, granted)
+
+		mockUserClient.AssertNotCalled(t, "GetByUUID", mock.Anything, mock.Anything)
+		mockUOW.AssertNotCalled(t, "RoleRepository")
+		mockACLCache.AssertExpectations(t)
+	})
+
+	t.Run("Access denied from cache", func(t *testing.T) {
+		mockUOW := new(authrepository.MockUnitOfWork)
+		mockUserClient := new(client.MockUserClient)
+		mockACLCache := new(aclservice.MockACLCacheService)
+		mockACLCache.On("Get", ctx, userUUID.String()).Return(&domain.UserAccess{
+			UserID:         user.Base.ID,
+			PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyReadUser},
+		}, nil)
+
+		service := aclservice.New(mockUserClient, mockACLCache)
+		hasAccess, userID, granted, err := service.CheckAccess(ctx, mockUOW, userUUID, domain.PermissionKeyDeleteUser)
+
+		require.NoError(t, err)
+		require.False(t, hasAccess)
+		require.Equal(t, uint64(0), userID)
+		require.Nil(t, granted)
+
+		mockACLCache.AssertExpectations(t)
+	})
+
+	t.Run("Cache error falls back to database", func(t *testing.T) {
+		mockUOW := new(authrepository.MockUnitOfWork)
+		mockUserClient := new(client.MockUserClient)
+		mockRoleRepo := new(authrepository.MockRoleRepository)
+		mockUOW.On("RoleRepository").Return(mockRoleRepo)
+		mockACLCache := new(aclservice.MockACLCacheService)
+		mockACLCache.On("Get", ctx, userUUID.String()).Return(noCachedAccess, serviceerror.NewServerError())
+
+		access := domain.UserAccess{
+			UserID:   user.Base.ID,
+			RoleKeys: []domain.RoleKeyType{domain.RoleKeySuperAdmin},
+		}
+		mockUserClient.On("GetByUUID", mock.Anything, userUUID.String()).Return(user, nil)
+		mockRoleRepo.On("GetUserRoleKeys", user.Base.ID).Return(access.RoleKeys, nil)
+		mockACLCache.On("Set", ctx, userUUID.String(), access).Return(serviceerror.NewServerError())
+
+		service := aclservice.New(mockUserClient, mockACLCache)
+		hasAccess, userID, granted, err := service.CheckAccess(ctx, mockUOW, userUUID, domain.PermissionKeyReadUser)
+
+		require.NoError(t, err)
+		require.True(t, hasAccess)
+		require.Equal(t, user.Base.ID, userID)
+		require.Equal(t, []domain.PermissionKeyType{domain.PermissionKeyReadUser}, granted)
+
+		mockUserClient.AssertExpectations(t)
+		mockRoleRepo.AssertExpectations(t)
+		mockACLCache.AssertExpectations(t)
+	})
 }
 
 func TestACLService_AssignUserRoleToUser(t *testing.T) {
@@ -201,7 +321,7 @@ func TestACLService_AssignUserRoleToUser(t *testing.T) {
 		mockRoleRepo.On("GetRoleUser").Return(*role, nil)
 		mockACLRepo.On("AssignRolesToUser", uint64(1), []uint64{role.Base.ID}).Return(nil)
 
-		service := aclservice.New(nil)
+		service := aclservice.New(nil, nil)
 		err := service.AssignUserRoleToUser(mockUOW, 1)
 
 		require.NoError(t, err)
@@ -217,7 +337,7 @@ func TestACLService_AssignUserRoleToUser(t *testing.T) {
 
 		mockRoleRepo.On("GetRoleUser").Return(domain.Role{}, serviceerror.NewServerError())
 
-		service := aclservice.New(nil)
+		service := aclservice.New(nil, nil)
 		err := service.AssignUserRoleToUser(mockUOW, 1)
 
 		require.Error(t, err)
@@ -236,7 +356,7 @@ func TestACLService_AssignUserRoleToUser(t *testing.T) {
 		mockRoleRepo.On("GetRoleUser").Return(*role, nil)
 		mockACLRepo.On("AssignRolesToUser", uint64(1), []uint64{role.Base.ID}).Return(serviceerror.NewServerError())
 
-		service := aclservice.New(nil)
+		service := aclservice.New(nil, nil)
 		err := service.AssignUserRoleToUser(mockUOW, 1)
 
 		require.Error(t, err)
@@ -0,0 +1,82 @@
+package aclservice
+
+import (
+	"context"
+	"fmt"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/constant"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/metrics"
+)
+
+// ACLCacheService keeps the effective role and permission keys of users keyed by user UUID
+type ACLCacheService struct {
+	conf  config.ACL
+	cache port.ACLCache
+}
+
+func NewACLCacheService(conf config.ACL, cache port.ACLCache) ACLCacheService {
+	return ACLCacheService{
+		conf:  conf,
+		cache: cache,
+	}
+}
+
+func (r ACLCacheService) Get(ctx context.Context, userUUID string) (*domain.UserAccess, error) {
+	access, err := r.cache.Get(ctx, fmt.Sprintf("%s:%s", constant.ACLKeyPrefix, userUUID))
+	if err != nil {
+		metrics.CacheCall.WithLabelValues("acl", "Get", "Failed").Inc()
+		return nil, err
+	}
+
+	if access == nil {
+		metrics.CacheCall.WithLabelValues("acl", "Get", "Miss").Inc()
+		return nil, nil
+	}
+
+	metrics.CacheCall.WithLabelValues("acl", "Get", "Hit").Inc()
+
+	return access, nil
+}
+
+func (r ACLCacheService) Set(ctx context.Context, userUUID string, access domain.UserAccess) error {
+	key := fmt.Sprintf("%s:%s", constant.ACLKeyPrefix, userUUID)
+	if err := r.cache.Set(ctx, key, &access, r.conf.CacheExpireSecond); err != nil {
+		metrics.CacheCall.WithLabelValues("acl", "Set", "Failed").Inc()
+		return err
+	}
+
+	metrics.CacheCall.WithLabelValues("acl", "Set", "Success").Inc()
+
+	return nil
+}
+
+// Forget removes the cached access of the given users, use it when the roles or permissions granted to them change
+func (r ACLCacheService) Forget(ctx context.Context, userUUIDs ...string) error {
+	keys := make([]string, len(userUUIDs))
+	for index, userUUID := range userUUIDs {
+		keys[index] = fmt.Sprintf("%s:%s", constant.ACLKeyPrefix, userUUID)
+	}
+
+	if err := r.cache.Delete(ctx, keys...); err != nil {
+		metrics.CacheCall.WithLabelValues("acl", "Forget", "Failed").Inc()
+		return err
+	}
+
+	metrics.CacheCall.WithLabelValues("acl", "Forget", "Success").Inc()
+
+	return nil
+}
+
+// Flush removes the cached access of all users, use it when a role or the permissions of a role change
+func (r ACLCacheService) Flush(ctx context.Context) error {
+	if err := r.cache.DeleteByPattern(ctx, fmt.Sprintf("%s:*", constant.ACLKeyPrefix)); err != nil {
+		metrics.CacheCall.WithLabelValues("acl", "Flush", "Failed").Inc()
+		return err
+	}
+
+	metrics.CacheCall.WithLabelValues("acl", "Flush", "Success").Inc()
+
+	return nil
+}
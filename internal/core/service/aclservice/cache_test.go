@@ -0,0 +1,179 @@
+package aclservice_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/redis/authrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/constant"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/aclservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLCacheService_Get(t *testing.T) {
+	conf := config.ACL{CacheExpireSecond: 300 * time.Second}
+	userUUID := uuid.New().String()
+	key := fmt.Sprintf("%s:%s", constant.ACLKeyPrefix, userUUID)
+
+	ctx := context.TODO()
+
+	t.Run("Get hit", func(t *testing.T) {
+		access := &domain.UserAccess{
+			UserID:   1,
+			RoleKeys: []domain.RoleKeyType{domain.RoleKeyAdmin},
+		}
+
+		mockCache := new(authrepository.MockACLCache)
+		mockCache.On("Get", ctx, key).Return(access, nil)
+
+		service := aclservice.NewACLCacheService(conf, mockCache)
+		result, err := service.Get(ctx, userUUID)
+
+		require.NoError(t, err)
+		require.Equal(t, access, result)
+
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("Get miss", func(t *testing.T) {
+		var access *domain.UserAccess
+
+		mockCache := new(authrepository.MockACLCache)
+		mockCache.On("Get", ctx, key).Return(access, nil)
+
+		service := aclservice.NewACLCacheService(conf, mockCache)
+		result, err := service.Get(ctx, userUUID)
+
+		require.NoError(t, err)
+		require.Nil(t, result)
+
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("Get cache error", func(t *testing.T) {
+		var access *domain.UserAccess
+
+		mockCache := new(authrepository.MockACLCache)
+		mockCache.On("Get", ctx, key).Return(access, serviceerror.NewServerError())
+
+		service := aclservice.NewACLCacheService(conf, mockCache)
+		result, err := service.Get(ctx, userUUID)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+		require.Nil(t, result)
+
+		mockCache.AssertExpectations(t)
+	})
+}
+
+func TestACLCacheService_Set(t *testing.T) {
+	conf := config.ACL{CacheExpireSecond: 300 * time.Second}
+	userUUID := uuid.New().String()
+	key := fmt.Sprintf("%s:%s", constant.ACLKeyPrefix, userUUID)
+	access := domain.UserAccess{
+		UserID:         1,
+		PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyReadUser},
+	}
+
+	ctx := context.TODO()
+
+	t.Run("Set success", func(t *testing.T) {
+		mockCache := new(authrepository.MockACLCache)
+		mockCache.On("Set", ctx, key, &access, conf.CacheExpireSecond).Return(nil)
+
+		service := aclservice.NewACLCacheService(conf, mockCache)
+		err := service.Set(ctx, userUUID, access)
+
+		require.NoError(t, err)
+
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("Set cache error", func(t *testing.T) {
+		mockCache := new(authrepository.MockACLCache)
+		mockCache.On("Set", ctx, key, &access, conf.CacheExpireSecond).Return(serviceerror.NewServerError())
+
+		service := aclservice.NewACLCacheService(conf, mockCache)
+		err := service.Set(ctx, userUUID, access)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+		mockCache.AssertExpectations(t)
+	})
+}
+
+func TestACLCacheService_Forget(t *testing.T) {
+	conf := config.ACL{CacheExpireSecond: 300 * time.Second}
+	userUUIDs := []string{uuid.New().String(), uuid.New().String()}
+	keys := []string{
+		fmt.Sprintf("%s:%s", constant.ACLKeyPrefix, userUUIDs[0]),
+		fmt.Sprintf("%s:%s", constant.ACLKeyPrefix, userUUIDs[1]),
+	}
+
+	ctx := context.TODO()
+
+	t.Run("Forget success", func(t *testing.T) {
+		mockCache := new(authrepository.MockACLCache)
+		mockCache.On("Delete", ctx, keys).Return(nil)
+
+		service := aclservice.NewACLCacheService(conf, mockCache)
+		err := service.Forget(ctx, userUUIDs...)
+
+		require.NoError(t, err)
+
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("Forget cache error", func(t *testing.T) {
+		mockCache := new(authrepository.MockACLCache)
+		mockCache.On("Delete", ctx, keys).Return(serviceerror.NewServerError())
+
+		service := aclservice.NewACLCacheService(conf, mockCache)
+		err := service.Forget(ctx, userUUIDs...)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+		mockCache.AssertExpectations(t)
+	})
+}
+
+func TestACLCacheService_Flush(t *testing.T) {
+	conf := config.ACL{CacheExpireSecond: 300 * time.Second}
+	pattern := fmt.Sprintf("%s:*", constant.ACLKeyPrefix)
+
+	ctx := context.TODO()
+
+	t.Run("Flush success", func(t *testing.T) {
+		mockCache := new(authrepository.MockACLCache)
+		mockCache.On("DeleteByPattern", ctx, pattern).Return(nil)
+
+		service := aclservice.NewACLCacheService(conf, mockCache)
+		err := service.Flush(ctx)
+
+		require.NoError(t, err)
+
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("Flush cache error", func(t *testing.T) {
+		mockCache := new(authrepository.MockACLCache)
+		mockCache.On("DeleteByPattern", ctx, pattern).Return(serviceerror.NewServerError())
+
+		service := aclservice.NewACLCacheService(conf, mockCache)
+		err := service.Flush(ctx)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+		mockCache.AssertExpectations(t)
+	})
+}
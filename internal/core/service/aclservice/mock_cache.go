@@ -0,0 +1,31 @@
+package aclservice
+
+import (
+	"context"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockACLCacheService struct {
+	mock.Mock
+}
+
+func (r *MockACLCacheService) Get(ctx context.Context, userUUID string) (*domain.UserAccess, error) {
+	args := r.Called(ctx, userUUID)
+	return args.Get(0).(*domain.UserAccess), args.Error(1)
+}
+
+func (r *MockACLCacheService) Set(ctx context.Context, userUUID string, access domain.UserAccess) error {
+	args := r.Called(ctx, userUUID, access)
+	return args.Error(0)
+}
+
+func (r *MockACLCacheService) Forget(ctx context.Context, userUUIDs ...string) error {
+	args := r.Called(ctx, userUUIDs)
+	return args.Error(0)
+}
+
+func (r *MockACLCacheService) Flush(ctx context.Context) error {
+	args := r.Called(ctx)
+	return args.Error(0)
+}
@@ -0,0 +1,50 @@
+package aclservice
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+)
+
+// OwnershipPolicy guards an action that users holding Any may take on every resource,
+// while users holding only Own may take it on the resources they created.
+type OwnershipPolicy struct {
+	Any domain.PermissionKeyType
+	Own domain.PermissionKeyType
+}
+
+var (
+	UpdateSentencePolicy = OwnershipPolicy{
+		Any: domain.PermissionKeyUpdateSentence,
+		Own: domain.PermissionKeyUpdateOwnSentence,
+	}
+	DeleteSentencePolicy = OwnershipPolicy{
+		Any: domain.PermissionKeyDeleteSentence,
+		Own: domain.PermissionKeyDeleteOwnSentence,
+	}
+)
+
+// Permissions returns the keys a route guarded by the policy has to require,
+// so the gateway lets owners through and the service decides on the resource itself.
+func (r OwnershipPolicy) Permissions() []domain.PermissionKeyType {
+	return []domain.PermissionKeyType{r.Any, r.Own}
+}
+
+// CanModify reports whether the actor may take the action on the resource described by modifier,
+// a super admin or a holder of Any may take it on every resource and a holder of Own only on the ones it created.
+func (r OwnershipPolicy) CanModify(actor domain.UserAccess, resource domain.Modifier) bool {
+	if actor.IsSuperAdmin() || actor.HasAnyPermission(r.Any) {
+		return true
+	}
+
+	return actor.HasAnyPermission(r.Own) && actor.IsOwner(resource)
+}
+
+// Authorize is meant to be called by services once the resource is loaded,
+// it returns serviceerror.PermissionDenied when the user may not act on it.
+func (r OwnershipPolicy) Authorize(actor domain.UserAccess, resource domain.Modifier) error {
+	if !r.CanModify(actor, resource) {
+		return serviceerror.New(serviceerror.PermissionDenied)
+	}
+
+	return nil
+}
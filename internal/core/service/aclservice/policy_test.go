@@ -0,0 +1,124 @@
+package aclservice_test
+
+import (
+	"testing"
+
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/aclservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwnershipPolicy_Authorize(t *testing.T) {
+	var ownerID uint64 = 10
+	var otherID uint64 = 20
+
+	modifier := domain.Modifier{CreatedBy: &ownerID}
+
+	tests := []struct {
+		name     string
+		access   domain.UserAccess
+		modifier domain.Modifier
+		allowed  bool
+	}{
+		{
+			name: "broad permission on someone else's sentence",
+			access: domain.UserAccess{
+				UserID:         otherID,
+				PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyUpdateSentence},
+			},
+			modifier: modifier,
+			allowed:  true,
+		},
+		{
+			name: "own permission on own sentence",
+			access: domain.UserAccess{
+				UserID:         ownerID,
+				PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyUpdateOwnSentence},
+			},
+			modifier: modifier,
+			allowed:  true,
+		},
+		{
+			name: "own permission on someone else's sentence",
+			access: domain.UserAccess{
+				UserID:         otherID,
+				PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyUpdateOwnSentence},
+			},
+			modifier: modifier,
+			allowed:  false,
+		},
+		{
+			name: "own permission on sentence without creator",
+			access: domain.UserAccess{
+				UserID:         ownerID,
+				PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyUpdateOwnSentence},
+			},
+			modifier: domain.Modifier{},
+			allowed:  false,
+		},
+		{
+			name: "owner without any permission",
+			access: domain.UserAccess{
+				UserID: ownerID,
+			},
+			modifier: modifier,
+			allowed:  false,
+		},
+		{
+			name: "super admin",
+			access: domain.UserAccess{
+				UserID:   otherID,
+				RoleKeys: []domain.RoleKeyType{domain.RoleKeySuperAdmin},
+			},
+			modifier: modifier,
+			allowed:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := aclservice.UpdateSentencePolicy.Authorize(tt.access, tt.modifier)
+			if tt.allowed {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			require.Equal(t, serviceerror.PermissionDenied, err.(*serviceerror.ServiceError).GetErrorMessage())
+		})
+	}
+}
+
+func TestOwnershipPolicy_CanModify(t *testing.T) {
+	var ownerID uint64 = 10
+	var otherID uint64 = 20
+
+	resource := domain.Modifier{CreatedBy: &ownerID}
+
+	owner := domain.UserAccess{
+		UserID:         ownerID,
+		PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyDeleteOwnSentence},
+	}
+	nonOwner := domain.UserAccess{
+		UserID:         otherID,
+		PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyDeleteOwnSentence},
+	}
+	admin := domain.UserAccess{
+		UserID:         otherID,
+		RoleKeys:       []domain.RoleKeyType{domain.RoleKeyAdmin},
+		PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyDeleteSentence},
+	}
+
+	require.True(t, aclservice.DeleteSentencePolicy.CanModify(owner, resource))
+	require.False(t, aclservice.DeleteSentencePolicy.CanModify(nonOwner, resource))
+	require.True(t, aclservice.DeleteSentencePolicy.CanModify(admin, resource))
+	require.False(t, aclservice.UpdateSentencePolicy.CanModify(admin, resource))
+}
+
+func TestOwnershipPolicy_Permissions(t *testing.T) {
+	require.Equal(t, []domain.PermissionKeyType{
+		domain.PermissionKeyDeleteSentence,
+		domain.PermissionKeyDeleteOwnSentence,
+	}, aclservice.DeleteSentencePolicy.Permissions())
+}
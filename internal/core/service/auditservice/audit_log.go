@@ -0,0 +1,21 @@
+package auditservice
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+)
+
+type Service struct {
+}
+
+func New() *Service {
+	return &Service{}
+}
+
+func (r *Service) Record(uow port.AuditUnitOfWork, auditLog domain.AuditLog) error {
+	return uow.AuditLogRepository().Create(auditLog)
+}
+
+func (r *Service) List(uow port.AuditUnitOfWork, filter domain.AuditLogFilter) ([]*domain.AuditLog, uint64, error) {
+	return uow.AuditLogRepository().List(filter)
+}
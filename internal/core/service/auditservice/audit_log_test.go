@@ -0,0 +1,99 @@
+package auditservice_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/auditrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/authrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/auditservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAuditService_Record(t *testing.T) {
+	targetUUID := uuid.New()
+	auditLog := domain.NewAuditLog(
+		domain.AuditActor{IP: "127.0.0.1"},
+		domain.AuditActionUserLoggedIn,
+		domain.AuditTargetUser,
+		&targetUUID,
+		nil,
+		nil,
+	)
+
+	t.Run("Record success", func(t *testing.T) {
+		mockRepo := new(auditrepository.MockAuditLogRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("AuditLogRepository").Return(mockRepo)
+
+		mockRepo.On("Create", auditLog).Return(nil)
+
+		service := auditservice.New()
+		err := service.Record(mockUow, auditLog)
+
+		require.NoError(t, err)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Record error", func(t *testing.T) {
+		mockRepo := new(auditrepository.MockAuditLogRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("AuditLogRepository").Return(mockRepo)
+
+		mockRepo.On("Create", auditLog).Return(serviceerror.NewServerError())
+
+		service := auditservice.New()
+		err := service.Record(mockUow, auditLog)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestAuditService_List(t *testing.T) {
+	action := domain.AuditActionRoleUpdated
+	filter := domain.AuditLogFilter{Action: &action, Page: 2, PerPage: 10}
+	auditLogs := []*domain.AuditLog{
+		{Base: domain.Base{UUID: uuid.New()}, Action: action, TargetType: domain.AuditTargetRole},
+	}
+
+	t.Run("List success", func(t *testing.T) {
+		mockRepo := new(auditrepository.MockAuditLogRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("AuditLogRepository").Return(mockRepo)
+
+		mockRepo.On("List", filter).Return(auditLogs, uint64(11), nil)
+
+		service := auditservice.New()
+		result, total, err := service.List(mockUow, filter)
+
+		require.NoError(t, err)
+		require.Equal(t, auditLogs, result)
+		require.Equal(t, uint64(11), total)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("List error", func(t *testing.T) {
+		mockRepo := new(auditrepository.MockAuditLogRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("AuditLogRepository").Return(mockRepo)
+
+		var nilAuditLogs []*domain.AuditLog
+		mockRepo.On("List", filter).Return(nilAuditLogs, uint64(0), serviceerror.NewServerError())
+
+		service := auditservice.New()
+		result, total, err := service.List(mockUow, filter)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+		require.Nil(t, result)
+		require.Zero(t, total)
+
+		mockRepo.AssertExpectations(t)
+	})
+}
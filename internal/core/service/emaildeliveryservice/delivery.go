@@ -0,0 +1,72 @@
+package emaildeliveryservice
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+)
+
+type Service struct {
+}
+
+func New() *Service {
+	return &Service{}
+}
+
+func (r *Service) IsSuppressed(uow port.UserUnitOfWork, email string) (bool, error) {
+	return uow.EmailSuppressionRepository().IsSuppressed(email)
+}
+
+// Record stores the delivery of an email, recording the same delivery again replaces its status.
+func (r *Service) Record(uow port.UserUnitOfWork, delivery domain.EmailDelivery) error {
+	if delivery.Base.UUID == uuid.Nil || delivery.Email == "" || !delivery.Status.IsValid() {
+		return serviceerror.New(serviceerror.InvalidRequestBody)
+	}
+
+	return uow.EmailDeliveryRepository().Save(delivery)
+}
+
+// HandleEvents applies the events of the provider to their deliveries and adds the addresses the events ask
+// to suppress to the suppression list. An event of an unknown delivery still suppresses its address.
+func (r *Service) HandleEvents(uow port.UserUnitOfWork, events []domain.EmailEvent) error {
+	for _, event := range events {
+		if deliveryUUID, err := uuid.Parse(event.DeliveryUUID); err == nil {
+			delivery, err := uow.EmailDeliveryRepository().GetByUUID(deliveryUUID)
+			if err != nil {
+				return err
+			}
+
+			if delivery != nil && delivery.Status.CanBecome(event.Status) {
+				if err = uow.EmailDeliveryRepository().UpdateStatus(delivery.Base.ID, event.Status, event.Reason); err != nil {
+					return err
+				}
+			}
+		}
+
+		if event.Suppression == "" || event.Email == "" {
+			continue
+		}
+		if err := uow.EmailSuppressionRepository().Create(domain.EmailSuppression{
+			Email:       event.Email,
+			Reason:      event.Suppression,
+			Description: event.Reason,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Service) ListSuppressions(
+	uow port.UserUnitOfWork,
+	filter domain.EmailSuppressionFilter,
+) ([]*domain.EmailSuppression, uint64, error) {
+	return uow.EmailSuppressionRepository().List(filter)
+}
+
+// DeleteSuppression takes the address off the suppression list, emails are sent to it again.
+func (r *Service) DeleteSuppression(uow port.UserUnitOfWork, suppressionUUID uuid.UUID) error {
+	return uow.EmailSuppressionRepository().Delete(suppressionUUID)
+}
@@ -0,0 +1,121 @@
+package emaildeliveryservice_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/emailrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/userrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/emaildeliveryservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestService_Record(t *testing.T) {
+	t.Run("Record success", func(t *testing.T) {
+		mockDeliveryRepo := new(emailrepository.MockEmailDeliveryRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("EmailDeliveryRepository").Return(mockDeliveryRepo)
+
+		delivery := domain.EmailDelivery{
+			Base:     domain.Base{UUID: uuid.New()},
+			Email:    "john@example.com",
+			Subject:  "Welcome",
+			Provider: "sendgrid",
+			Status:   domain.EmailDeliveryStatusSent,
+		}
+		mockDeliveryRepo.On("Save", delivery).Return(nil)
+
+		require.NoError(t, emaildeliveryservice.New().Record(mockUow, delivery))
+		mockDeliveryRepo.AssertExpectations(t)
+	})
+
+	t.Run("Record invalid status", func(t *testing.T) {
+		mockUow := new(userrepository.MockUnitOfWork)
+
+		err := emaildeliveryservice.New().Record(mockUow, domain.EmailDelivery{
+			Base:   domain.Base{UUID: uuid.New()},
+			Email:  "john@example.com",
+			Status: "UNKNOWN",
+		})
+
+		require.Equal(t, serviceerror.New(serviceerror.InvalidRequestBody), err)
+		mockUow.AssertNotCalled(t, "EmailDeliveryRepository")
+	})
+}
+
+func TestService_HandleEvents(t *testing.T) {
+	t.Run("HandleEvents bounce", func(t *testing.T) {
+		mockDeliveryRepo := new(emailrepository.MockEmailDeliveryRepository)
+		mockSuppressionRepo := new(emailrepository.MockEmailSuppressionRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("EmailDeliveryRepository").Return(mockDeliveryRepo)
+		mockUow.On("EmailSuppressionRepository").Return(mockSuppressionRepo)
+
+		deliveryUUID := uuid.New()
+		mockDeliveryRepo.On("GetByUUID", deliveryUUID).Return(&domain.EmailDelivery{
+			Base:   domain.Base{ID: 7, UUID: deliveryUUID},
+			Email:  "john@example.com",
+			Status: domain.EmailDeliveryStatusSent,
+		}, nil)
+		mockDeliveryRepo.On("UpdateStatus", uint64(7), domain.EmailDeliveryStatusBounced, "550 mailbox unavailable").Return(nil)
+		mockSuppressionRepo.On("Create", domain.EmailSuppression{
+			Email:       "john@example.com",
+			Reason:      domain.EmailSuppressionReasonBounce,
+			Description: "550 mailbox unavailable",
+		}).Return(nil)
+
+		err := emaildeliveryservice.New().HandleEvents(mockUow, []domain.EmailEvent{{
+			DeliveryUUID: deliveryUUID.String(),
+			Email:        "john@example.com",
+			Status:       domain.EmailDeliveryStatusBounced,
+			Reason:       "550 mailbox unavailable",
+			Suppression:  domain.EmailSuppressionReasonBounce,
+		}})
+
+		require.NoError(t, err)
+		mockDeliveryRepo.AssertExpectations(t)
+		mockSuppressionRepo.AssertExpectations(t)
+	})
+
+	t.Run("HandleEvents delivered after spam report", func(t *testing.T) {
+		mockDeliveryRepo := new(emailrepository.MockEmailDeliveryRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("EmailDeliveryRepository").Return(mockDeliveryRepo)
+
+		deliveryUUID := uuid.New()
+		mockDeliveryRepo.On("GetByUUID", deliveryUUID).Return(&domain.EmailDelivery{
+			Base:   domain.Base{ID: 7, UUID: deliveryUUID},
+			Status: domain.EmailDeliveryStatusSpamReported,
+		}, nil)
+
+		err := emaildeliveryservice.New().HandleEvents(mockUow, []domain.EmailEvent{{
+			DeliveryUUID: deliveryUUID.String(),
+			Email:        "john@example.com",
+			Status:       domain.EmailDeliveryStatusDelivered,
+		}})
+
+		require.NoError(t, err)
+		mockDeliveryRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("HandleEvents unknown delivery", func(t *testing.T) {
+		mockSuppressionRepo := new(emailrepository.MockEmailSuppressionRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("EmailSuppressionRepository").Return(mockSuppressionRepo)
+
+		suppression := domain.EmailSuppression{Email: "john@example.com", Reason: domain.EmailSuppressionReasonSpamReport}
+		mockSuppressionRepo.On("Create", suppression).Return(nil)
+
+		err := emaildeliveryservice.New().HandleEvents(mockUow, []domain.EmailEvent{{
+			Email:       "john@example.com",
+			Status:      domain.EmailDeliveryStatusSpamReported,
+			Suppression: domain.EmailSuppressionReasonSpamReport,
+		}})
+
+		require.NoError(t, err)
+		mockUow.AssertNotCalled(t, "EmailDeliveryRepository")
+		mockSuppressionRepo.AssertExpectations(t)
+	})
+}
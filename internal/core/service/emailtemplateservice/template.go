@@ -0,0 +1,263 @@
+package emailtemplateservice
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
+	"html"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+)
+
+const layout = "base"
+
+// rtlLanguages are written right to left, their emails get the rtl direction.
+var rtlLanguages = map[string]bool{
+	"ar": true,
+	"fa": true,
+	"he": true,
+}
+
+var (
+	lineBreakRegex = regexp.MustCompile(`(?i)<br\s*/?>`)
+	paragraphRegex = regexp.MustCompile(`(?i)</?p\s*>`)
+	linkRegex      = regexp.MustCompile(`(?is)<a\s[^>]*href=['"]([^'"]*)['"][^>]*>(.*?)</a>`)
+	tagRegex       = regexp.MustCompile(`<[^>]*>`)
+	blankLineRegex = regexp.MustCompile(`\n{3,}`)
+)
+
+// templateSet is a template parsed with the layouts of one language, the text template renders the subject
+// and the plaintext part.
+type templateSet struct {
+	html       *htmltemplate.Template
+	text       *texttemplate.Template
+	htmlLayout string
+	textLayout string
+}
+
+// TemplateService renders the email templates, every template is a <name>.html file defining the content of
+// the HTML layout, a <name>.txt file defining the subject and the content of the text layout and
+// a <name>.sample.json file with the data it is previewed with. The layouts are layouts/base.html and
+// layouts/base.txt, a layouts/base.<language>.html or .txt file replaces them for that language.
+// Templates are parsed on their first use and cached per language.
+type TemplateService struct {
+	files fs.FS
+	conf  config.App
+	trans translation.Translator
+
+	paths map[string]string
+	err   error
+
+	mu        sync.RWMutex
+	templates map[string]*templateSet
+}
+
+// New looks the templates up in files, an error reading them is returned by every render.
+func New(files fs.FS, conf config.App, trans translation.Translator) *TemplateService {
+	paths := make(map[string]string)
+	err := fs.WalkDir(files, ".", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || path.Ext(filePath) != ".html" || strings.HasPrefix(filePath, "layouts/") {
+			return nil
+		}
+
+		name := strings.TrimSuffix(path.Base(filePath), ".html")
+		paths[name] = strings.TrimSuffix(filePath, ".html")
+		return nil
+	})
+
+	return &TemplateService{
+		files:     files,
+		conf:      conf,
+		trans:     trans,
+		paths:     paths,
+		err:       err,
+		templates: make(map[string]*templateSet),
+	}
+}
+
+// Templates returns the names of the templates in alphabetical order.
+func (r *TemplateService) Templates() []string {
+	names := make([]string, 0, len(r.paths))
+	for name := range r.paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Render renders the subject and both parts of the template in the language. Besides data the templates get
+// the app name, the support email, the language and its direction, a blank username falls back to the
+// translated "user".
+func (r *TemplateService) Render(name string, language string, data map[string]interface{}) (domain.Email, error) {
+	set, err := r.template(name, language)
+	if err != nil {
+		return domain.Email{}, err
+	}
+
+	values := map[string]interface{}{
+		"app":          r.trans.Lang("appName", nil, &language),
+		"supportEmail": r.conf.SupportEmail,
+	}
+	for key, value := range data {
+		values[key] = value
+	}
+	if username, _ := values["username"].(string); strings.TrimSpace(username) == "" {
+		values["username"] = r.trans.Lang("user", nil, &language)
+	}
+	values["language"] = language
+	values["direction"] = "ltr"
+	if rtlLanguages[language] {
+		values["direction"] = "rtl"
+	}
+
+	var subject bytes.Buffer
+	if err = set.text.ExecuteTemplate(&subject, "subject", values); err != nil {
+		return domain.Email{}, err
+	}
+	values["subject"] = strings.TrimSpace(subject.String())
+
+	var htmlPart bytes.Buffer
+	if err = set.html.ExecuteTemplate(&htmlPart, set.htmlLayout, values); err != nil {
+		return domain.Email{}, err
+	}
+
+	var textPart bytes.Buffer
+	if err = set.text.ExecuteTemplate(&textPart, set.textLayout, values); err != nil {
+		return domain.Email{}, err
+	}
+
+	return domain.Email{
+		Subject: values["subject"].(string),
+		HTML:    htmlPart.String(),
+		Text:    strings.TrimSpace(textPart.String()),
+	}, nil
+}
+
+// Preview renders the template with its sample data.
+func (r *TemplateService) Preview(name string, language string) (domain.Email, error) {
+	if r.err != nil {
+		return domain.Email{}, r.err
+	}
+
+	filePath, ok := r.paths[name]
+	if !ok {
+		return domain.Email{}, serviceerror.New(serviceerror.RecordNotFound)
+	}
+
+	data := make(map[string]interface{})
+	sample, err := fs.ReadFile(r.files, filePath+".sample.json")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return domain.Email{}, err
+	}
+	if err == nil {
+		if err = json.Unmarshal(sample, &data); err != nil {
+			return domain.Email{}, err
+		}
+	}
+
+	return r.Render(name, language, data)
+}
+
+func (r *TemplateService) template(name string, language string) (*templateSet, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	key := name + "." + language
+
+	r.mu.RLock()
+	set, ok := r.templates[key]
+	r.mu.RUnlock()
+	if ok {
+		return set, nil
+	}
+
+	filePath, ok := r.paths[name]
+	if !ok {
+		return nil, serviceerror.New(serviceerror.RecordNotFound)
+	}
+
+	set = &templateSet{
+		htmlLayout: r.layout(language, ".html"),
+		textLayout: r.layout(language, ".txt"),
+	}
+
+	var err error
+	set.html, err = htmltemplate.New(name).Funcs(r.funcs()).ParseFS(r.files, "layouts/"+set.htmlLayout, filePath+".html")
+	if err != nil {
+		return nil, err
+	}
+	set.text, err = texttemplate.New(name).Funcs(r.funcs()).ParseFS(r.files, "layouts/"+set.textLayout, filePath+".txt")
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.templates[key] = set
+	r.mu.Unlock()
+
+	return set, nil
+}
+
+// layout returns the file name of the layout for the language, the default one when it has none of its own.
+func (r *TemplateService) layout(language string, extension string) string {
+	localized := layout + "." + language + extension
+	if _, err := fs.Stat(r.files, "layouts/"+localized); err == nil {
+		return localized
+	}
+
+	return layout + extension
+}
+
+func (r *TemplateService) funcs() map[string]interface{} {
+	return map[string]interface{}{
+		"trans": func(language string, key string, data map[string]interface{}) string {
+			return r.trans.Lang(key, data, &language)
+		},
+		// transHTML translates a message written in HTML, the data is escaped so it cannot add markup of its own.
+		"transHTML": func(language string, key string, data map[string]interface{}) htmltemplate.HTML {
+			escaped := make(map[string]interface{}, len(data))
+			for k, value := range data {
+				if text, isString := value.(string); isString {
+					value = html.EscapeString(text)
+				}
+				escaped[k] = value
+			}
+
+			return htmltemplate.HTML(r.trans.Lang(key, escaped, &language))
+		},
+		"plain": plainText,
+	}
+}
+
+// plainText turns the HTML of a translated message into text, paragraphs and line breaks become new lines
+// and a link is followed by its address.
+func plainText(message htmltemplate.HTML) string {
+	text := lineBreakRegex.ReplaceAllString(string(message), "\n")
+	text = paragraphRegex.ReplaceAllString(text, "\n\n")
+	text = linkRegex.ReplaceAllString(text, "$2: $1")
+	text = tagRegex.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	text = blankLineRegex.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+
+	return strings.TrimSpace(text)
+}
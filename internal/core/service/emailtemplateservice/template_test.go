@@ -0,0 +1,130 @@
+package emailtemplateservice_test
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/emailtemplateservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/views"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/translation"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"testing/fstest"
+)
+
+func newService() *emailtemplateservice.TemplateService {
+	conf := config.App{
+		Locale:       "en",
+		PathLocale:   "../../../../pkg/translation",
+		SupportEmail: "support@polyglot-sentences.com",
+	}
+
+	return emailtemplateservice.New(views.EmailTemplates(), conf, translation.NewTranslation(conf))
+}
+
+func TestTemplateService_Templates(t *testing.T) {
+	require.Equal(
+		t,
+		[]string{"data_export", "invitation", "reset_password", "verify_email", "welcome"},
+		newService().Templates(),
+	)
+}
+
+func TestTemplateService_Render(t *testing.T) {
+	service := newService()
+
+	email, err := service.Render("verify_email", "en", map[string]interface{}{
+		"username":        "John <b>Doe</b>",
+		"otp":             "123456",
+		"verificationUrl": "https://example.com/verify?email=john&signature=abc",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "Verify Your Email for Polyglot Sentences", email.Subject)
+
+	require.Contains(t, email.HTML, `<html lang="en" dir="ltr">`)
+	require.Contains(t, email.HTML, "<title>Verify Your Email for Polyglot Sentences</title>")
+	require.Contains(t, email.HTML, "Dear John &lt;b&gt;Doe&lt;/b&gt;,")
+	require.Contains(t, email.HTML, "https://example.com/verify?email=john&amp;signature=abc")
+
+	require.Contains(t, email.Text, "Dear John <b>Doe</b>,")
+	require.Contains(t, email.Text, "Confirmation code: 123456")
+	require.Contains(t, email.Text, "[Link to confirm email address]: https://example.com/verify?email=john&signature=abc")
+	require.NotContains(t, email.Text, "<p>")
+}
+
+func TestTemplateService_Render_RTL(t *testing.T) {
+	email, err := newService().Render("welcome", "ar", map[string]interface{}{
+		"username": "",
+	})
+	require.NoError(t, err)
+
+	require.Contains(t, email.HTML, `<html lang="ar" dir="rtl">`)
+	require.Contains(t, email.HTML, "text-align: right")
+	require.Contains(t, email.HTML, "مستخدم")
+	require.NotEmpty(t, email.Subject)
+	require.NotEmpty(t, email.Text)
+}
+
+func TestTemplateService_Render_Unsubscribe(t *testing.T) {
+	service := newService()
+
+	email, err := service.Render("welcome", "en", map[string]interface{}{
+		"username":       "John Doe",
+		"unsubscribeUrl": "https://example.com/unsubscribe?token=abc",
+	})
+	require.NoError(t, err)
+	require.Contains(t, email.HTML, "<a href='https://example.com/unsubscribe?token=abc'>Unsubscribe</a>")
+	require.Contains(t, email.Text, "Unsubscribe: https://example.com/unsubscribe?token=abc")
+
+	email, err = service.Render("welcome", "en", map[string]interface{}{
+		"username": "John Doe",
+	})
+	require.NoError(t, err)
+	require.NotContains(t, email.HTML, "Unsubscribe")
+	require.NotContains(t, email.Text, "Unsubscribe")
+}
+
+func TestTemplateService_Render_NotFound(t *testing.T) {
+	_, err := newService().Render("unknown", "en", nil)
+	require.Equal(t, serviceerror.New(serviceerror.RecordNotFound), err)
+}
+
+func TestTemplateService_Preview(t *testing.T) {
+	service := newService()
+
+	for _, name := range service.Templates() {
+		t.Run(name, func(t *testing.T) {
+			email, err := service.Preview(name, "en")
+			require.NoError(t, err)
+			require.NotEmpty(t, email.Subject)
+			require.Contains(t, email.HTML, "John Doe")
+			require.Contains(t, email.Text, "John Doe")
+		})
+	}
+}
+
+func TestTemplateService_Cache(t *testing.T) {
+	files := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`<p>{{template "content" .}}</p>`)},
+		"layouts/base.txt":  {Data: []byte(`{{template "content" .}}`)},
+		"greeting.html":     {Data: []byte(`{{define "content"}}Hello {{.username}}{{end}}`)},
+		"greeting.txt":      {Data: []byte(`{{define "subject"}}Hi{{end}}{{define "content"}}Hello {{.username}}{{end}}`)},
+	}
+	trans := new(translation.MockTranslator)
+	trans.On("Lang", mock.Anything, mock.Anything, mock.Anything).Return("Polyglot Sentences")
+	service := emailtemplateservice.New(files, config.App{}, trans)
+
+	email, err := service.Render("greeting", "en", map[string]interface{}{"username": "John"})
+	require.NoError(t, err)
+	require.Equal(t, "<p>Hello John</p>", email.HTML)
+
+	// the parsed template is kept, a changed file is not read again
+	files["greeting.html"] = &fstest.MapFile{Data: []byte(`{{define "content"}}Bye{{end}}`)}
+
+	email, err = service.Render("greeting", "en", map[string]interface{}{"username": "John"})
+	require.NoError(t, err)
+	require.Equal(t, "<p>Hello John</p>", email.HTML)
+	require.Equal(t, "Hello John", email.Text)
+	require.Equal(t, "Hi", email.Subject)
+}
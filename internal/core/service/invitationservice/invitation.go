@@ -0,0 +1,179 @@
+package invitationservice
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"time"
+)
+
+// TokenSize is the number of random bytes of an invitation token.
+const TokenSize = 32
+
+type Service struct {
+	conf     config.Invitation
+	aclCache port.ACLCacheService
+}
+
+func New(conf config.Invitation, aclCache port.ACLCacheService) *Service {
+	return &Service{
+		conf:     conf,
+		aclCache: aclCache,
+	}
+}
+
+// Invite creates the user without a password, grants the initial roles and opens an invitation,
+// the returned token is the only copy of the set-password secret and has to be sent to the user.
+// Granting roles takes SYNC_ROLES_WITH_USER besides CREATE_USER, without roles the user gets the default
+// USER role.
+func (r *Service) Invite(
+	ctx context.Context,
+	uow port.UserUnitOfWork,
+	user domain.User,
+	roleUUIDsStr []string,
+	access domain.UserAccess,
+) (*domain.User, string, error) {
+	if !access.HasAnyPermission(domain.PermissionKeyCreateUser) {
+		return nil, "", serviceerror.New(serviceerror.PermissionDenied)
+	}
+
+	roleUUIDs, err := parseRoleUUIDs(roleUUIDsStr)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(roleUUIDs) > 0 && !access.HasAnyPermission(domain.PermissionKeySyncRolesWithUser) {
+		return nil, "", serviceerror.New(serviceerror.PermissionDenied)
+	}
+
+	user.Password = nil
+	user.Status = domain.UserStatusUnverified
+	created, err := uow.UserRepository().Save(&user)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(roleUUIDs) == 0 {
+		err = uow.UserInvitationRepository().AssignDefaultRole(created.Base.ID)
+	} else {
+		err = uow.UserInvitationRepository().AssignRoles(created.Base.ID, roleUUIDs)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	uow.AfterCommit(func() error {
+		return r.aclCache.Forget(ctx, created.Base.UUID.String())
+	})
+
+	token, tokenHash, err := newToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err = uow.UserInvitationRepository().Create(domain.UserInvitation{
+		Modifier:  domain.Modifier{CreatedBy: user.Modifier.CreatedBy},
+		UserID:    created.Base.ID,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(r.conf.ExpireSecond),
+	}); err != nil {
+		return nil, "", err
+	}
+
+	return created, token, nil
+}
+
+// Resend replaces the token of the open invitation of the user, so any link sent before stops working.
+func (r *Service) Resend(uow port.UserUnitOfWork, userUUIDStr string) (*domain.UserInvitation, string, error) {
+	invitation, err := uow.UserInvitationRepository().GetOpenByUserUUID(uuid.MustParse(userUUIDStr))
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, tokenHash, err := newToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	expiresAt := time.Now().Add(r.conf.ExpireSecond)
+	if err = uow.UserInvitationRepository().Refresh(invitation.Base.ID, tokenHash, expiresAt); err != nil {
+		return nil, "", err
+	}
+
+	invitation.TokenHash = tokenHash
+	invitation.ExpiresAt = expiresAt
+	invitation.SentCount++
+
+	return invitation, token, nil
+}
+
+func (r *Service) Revoke(uow port.UserUnitOfWork, userUUIDStr string, revokedBy uint64) error {
+	invitation, err := uow.UserInvitationRepository().GetOpenByUserUUID(uuid.MustParse(userUUIDStr))
+	if err != nil {
+		return err
+	}
+
+	return uow.UserInvitationRepository().Revoke(invitation.Base.ID, revokedBy)
+}
+
+// Open returns the pending invitation of the token together with the invited user, so the new password can be
+// checked against the user before it is hashed and handed to Accept.
+func (r *Service) Open(uow port.UserUnitOfWork, token string) (*domain.UserInvitation, error) {
+	invitation, err := uow.UserInvitationRepository().GetByTokenHash(helper.HashToken(token))
+	if err != nil {
+		if serviceErr, ok := err.(*serviceerror.ServiceError); ok && serviceErr.GetErrorMessage() == serviceerror.RecordNotFound {
+			return nil, serviceerror.New(serviceerror.InvitationInvalid)
+		}
+		return nil, err
+	}
+
+	switch invitation.Status(time.Now()) {
+	case domain.UserInvitationStatusPending:
+	case domain.UserInvitationStatusExpired:
+		return nil, serviceerror.New(serviceerror.InvitationExpired)
+	default:
+		return nil, serviceerror.New(serviceerror.InvitationInvalid)
+	}
+
+	return invitation, nil
+}
+
+// Accept sets the already hashed password, activates the user and consumes the invitation returned by Open.
+func (r *Service) Accept(uow port.UserUnitOfWork, invitation *domain.UserInvitation, hashedPassword string) error {
+	if err := uow.UserRepository().Activate(invitation.UserID, hashedPassword); err != nil {
+		return err
+	}
+
+	return uow.UserInvitationRepository().Accept(invitation.Base.ID)
+}
+
+func newToken() (string, string, error) {
+	token, err := helper.GenerateToken(TokenSize)
+	if err != nil {
+		return "", "", serviceerror.NewServerError()
+	}
+
+	return token, helper.HashToken(token), nil
+}
+
+func parseRoleUUIDs(roleUUIDsStr []string) ([]uuid.UUID, error) {
+	seen := make(map[uuid.UUID]struct{}, len(roleUUIDsStr))
+	roleUUIDs := make([]uuid.UUID, 0, len(roleUUIDsStr))
+	for _, roleUUIDStr := range roleUUIDsStr {
+		roleUUID, err := uuid.Parse(roleUUIDStr)
+		if err != nil {
+			return nil, serviceerror.New(serviceerror.InvalidRequestBody)
+		}
+
+		if _, ok := seen[roleUUID]; ok {
+			continue
+		}
+		seen[roleUUID] = struct{}{}
+		roleUUIDs = append(roleUUIDs, roleUUID)
+	}
+
+	return roleUUIDs, nil
+}
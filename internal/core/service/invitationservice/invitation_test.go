@@ -0,0 +1,381 @@
+package invitationservice_test
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/userrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/aclservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/invitationservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var conf = config.Invitation{ExpireSecond: 24 * time.Hour}
+
+func TestInvitationService_Invite(t *testing.T) {
+	ctx := context.TODO()
+	createdBy := uint64(1)
+	roleUUID := uuid.New()
+	access := domain.UserAccess{
+		UserID:         createdBy,
+		PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyCreateUser, domain.PermissionKeySyncRolesWithUser},
+	}
+
+	newUser := func() domain.User {
+		return domain.User{
+			Modifier:  domain.Modifier{CreatedBy: &createdBy},
+			FirstName: helper.StringPtr("John"),
+			LastName:  helper.StringPtr("Doe"),
+			Email:     "john.doe@example.com",
+			Password:  helper.StringPtr("ignored"),
+		}
+	}
+
+	t.Run("Invite success", func(t *testing.T) {
+		createdUUID := uuid.New()
+		mockUserRepo := new(userrepository.MockUserRepository)
+		mockInvitationRepo := new(userrepository.MockUserInvitationRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserRepository").Return(mockUserRepo)
+		mockUow.On("UserInvitationRepository").Return(mockInvitationRepo)
+
+		mockUserRepo.On("Save", mock.MatchedBy(func(user *domain.User) bool {
+			return user.Password == nil && user.Status == domain.UserStatusUnverified
+		})).Return(&domain.User{Base: domain.Base{ID: 10, UUID: createdUUID}, Email: "john.doe@example.com"}, nil)
+		mockInvitationRepo.On("AssignRoles", uint64(10), []uuid.UUID{roleUUID}).Return(nil)
+
+		var storedHash string
+		mockInvitationRepo.On("Create", mock.MatchedBy(func(invitation domain.UserInvitation) bool {
+			storedHash = invitation.TokenHash
+			return invitation.UserID == 10 &&
+				*invitation.Modifier.CreatedBy == createdBy &&
+				time.Until(invitation.ExpiresAt) > 23*time.Hour
+		})).Return(nil)
+
+		var afterCommit func() error
+		mockUow.On("AfterCommit", mock.Anything).Run(func(args mock.Arguments) {
+			afterCommit = args.Get(0).(func() error)
+		}).Return()
+
+		mockACLCacheService := new(aclservice.MockACLCacheService)
+		mockACLCacheService.On("Forget", ctx, []string{createdUUID.String()}).Return(nil)
+
+		service := invitationservice.New(conf, mockACLCacheService)
+		user, token, err := service.Invite(ctx, mockUow, newUser(), []string{roleUUID.String(), roleUUID.String()}, access)
+
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), user.Base.ID)
+		require.Len(t, token, 2*invitationservice.TokenSize)
+		require.Equal(t, helper.HashToken(token), storedHash)
+		mockACLCacheService.AssertNotCalled(t, "Forget", mock.Anything, mock.Anything)
+		require.NoError(t, afterCommit())
+		mockACLCacheService.AssertExpectations(t)
+
+		mockUserRepo.AssertExpectations(t)
+		mockInvitationRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invite without roles grants the default role", func(t *testing.T) {
+		mockUserRepo := new(userrepository.MockUserRepository)
+		mockInvitationRepo := new(userrepository.MockUserInvitationRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserRepository").Return(mockUserRepo)
+		mockUow.On("UserInvitationRepository").Return(mockInvitationRepo)
+
+		mockUserRepo.On("Save", mock.Anything).Return(&domain.User{Base: domain.Base{ID: 10}}, nil)
+		mockInvitationRepo.On("AssignDefaultRole", uint64(10)).Return(nil)
+		mockInvitationRepo.On("Create", mock.Anything).Return(nil)
+		mockUow.On("AfterCommit", mock.Anything).Return()
+
+		creator := domain.UserAccess{
+			UserID:         createdBy,
+			PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyCreateUser},
+		}
+
+		service := invitationservice.New(conf, nil)
+		_, _, err := service.Invite(ctx, mockUow, newUser(), nil, creator)
+
+		require.NoError(t, err)
+		mockInvitationRepo.AssertExpectations(t)
+		mockInvitationRepo.AssertNotCalled(t, "AssignRoles", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invite with roles without SYNC_ROLES_WITH_USER error", func(t *testing.T) {
+		mockUow := new(userrepository.MockUnitOfWork)
+
+		creator := domain.UserAccess{
+			UserID:         createdBy,
+			PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyCreateUser},
+		}
+
+		service := invitationservice.New(conf, nil)
+		user, token, err := service.Invite(ctx, mockUow, newUser(), []string{roleUUID.String()}, creator)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.PermissionDenied, err.(*serviceerror.ServiceError).GetErrorMessage())
+		require.Nil(t, user)
+		require.Empty(t, token)
+		mockUow.AssertNotCalled(t, "UserRepository")
+	})
+
+	t.Run("Invite without CREATE_USER error", func(t *testing.T) {
+		mockUow := new(userrepository.MockUnitOfWork)
+
+		syncer := domain.UserAccess{
+			UserID:         createdBy,
+			PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeySyncRolesWithUser},
+		}
+
+		service := invitationservice.New(conf, nil)
+		_, _, err := service.Invite(ctx, mockUow, newUser(), nil, syncer)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.PermissionDenied, err.(*serviceerror.ServiceError).GetErrorMessage())
+		mockUow.AssertNotCalled(t, "UserRepository")
+	})
+
+	t.Run("Invite invalid role UUID error", func(t *testing.T) {
+		mockUow := new(userrepository.MockUnitOfWork)
+
+		service := invitationservice.New(conf, nil)
+		user, token, err := service.Invite(ctx, mockUow, newUser(), []string{"invalid-uuid"}, access)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.InvalidRequestBody, err.(*serviceerror.ServiceError).GetErrorMessage())
+		require.Nil(t, user)
+		require.Empty(t, token)
+	})
+
+	t.Run("Invite role not found error", func(t *testing.T) {
+		mockUserRepo := new(userrepository.MockUserRepository)
+		mockInvitationRepo := new(userrepository.MockUserInvitationRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserRepository").Return(mockUserRepo)
+		mockUow.On("UserInvitationRepository").Return(mockInvitationRepo)
+
+		mockUserRepo.On("Save", mock.Anything).Return(&domain.User{Base: domain.Base{ID: 10}}, nil)
+		mockInvitationRepo.On("AssignRoles", uint64(10), []uuid.UUID{roleUUID}).
+			Return(serviceerror.New(serviceerror.RecordNotFound))
+
+		service := invitationservice.New(conf, nil)
+		user, token, err := service.Invite(ctx, mockUow, newUser(), []string{roleUUID.String()}, access)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.RecordNotFound, err.(*serviceerror.ServiceError).GetErrorMessage())
+		require.Nil(t, user)
+		require.Empty(t, token)
+
+		mockInvitationRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestInvitationService_Resend(t *testing.T) {
+	userUUID := uuid.New()
+
+	t.Run("Resend success", func(t *testing.T) {
+		mockInvitationRepo := new(userrepository.MockUserInvitationRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserInvitationRepository").Return(mockInvitationRepo)
+
+		invitation := &domain.UserInvitation{
+			Base:      domain.Base{ID: 3},
+			TokenHash: "old",
+			SentCount: 1,
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}
+		mockInvitationRepo.On("GetOpenByUserUUID", userUUID).Return(invitation, nil)
+		mockInvitationRepo.On("Refresh", uint64(3), mock.Anything, mock.Anything).Return(nil)
+
+		service := invitationservice.New(conf, nil)
+		result, token, err := service.Resend(mockUow, userUUID.String())
+
+		require.NoError(t, err)
+		require.Equal(t, helper.HashToken(token), result.TokenHash)
+		require.Equal(t, uint(2), result.SentCount)
+		require.Equal(t, domain.UserInvitationStatusPending, result.Status(time.Now()))
+
+		mockInvitationRepo.AssertExpectations(t)
+	})
+
+	t.Run("Resend without open invitation error", func(t *testing.T) {
+		mockInvitationRepo := new(userrepository.MockUserInvitationRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserInvitationRepository").Return(mockInvitationRepo)
+
+		var nilInvitation *domain.UserInvitation
+		mockInvitationRepo.On("GetOpenByUserUUID", userUUID).Return(nilInvitation, serviceerror.New(serviceerror.RecordNotFound))
+
+		service := invitationservice.New(conf, nil)
+		result, token, err := service.Resend(mockUow, userUUID.String())
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.RecordNotFound, err.(*serviceerror.ServiceError).GetErrorMessage())
+		require.Nil(t, result)
+		require.Empty(t, token)
+
+		mockInvitationRepo.AssertNotCalled(t, "Refresh", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestInvitationService_Revoke(t *testing.T) {
+	userUUID := uuid.New()
+
+	t.Run("Revoke success", func(t *testing.T) {
+		mockInvitationRepo := new(userrepository.MockUserInvitationRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserInvitationRepository").Return(mockInvitationRepo)
+
+		mockInvitationRepo.On("GetOpenByUserUUID", userUUID).Return(&domain.UserInvitation{Base: domain.Base{ID: 3}}, nil)
+		mockInvitationRepo.On("Revoke", uint64(3), uint64(1)).Return(nil)
+
+		service := invitationservice.New(conf, nil)
+		err := service.Revoke(mockUow, userUUID.String(), 1)
+
+		require.NoError(t, err)
+
+		mockInvitationRepo.AssertExpectations(t)
+	})
+
+	t.Run("Revoke error", func(t *testing.T) {
+		mockInvitationRepo := new(userrepository.MockUserInvitationRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserInvitationRepository").Return(mockInvitationRepo)
+
+		mockInvitationRepo.On("GetOpenByUserUUID", userUUID).Return(&domain.UserInvitation{Base: domain.Base{ID: 3}}, nil)
+		mockInvitationRepo.On("Revoke", uint64(3), uint64(1)).Return(serviceerror.New(serviceerror.InvitationInvalid))
+
+		service := invitationservice.New(conf, nil)
+		err := service.Revoke(mockUow, userUUID.String(), 1)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.InvitationInvalid, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+		mockInvitationRepo.AssertExpectations(t)
+	})
+}
+
+func TestInvitationService_Open(t *testing.T) {
+	token := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	tokenHash := helper.HashToken(token)
+	past := time.Now().Add(-time.Hour)
+
+	t.Run("Open success", func(t *testing.T) {
+		mockInvitationRepo := new(userrepository.MockUserInvitationRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserInvitationRepository").Return(mockInvitationRepo)
+
+		invitation := &domain.UserInvitation{
+			Base:      domain.Base{ID: 3},
+			UserID:    10,
+			User:      &domain.User{Email: "john.doe@gmail.com"},
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+		mockInvitationRepo.On("GetByTokenHash", tokenHash).Return(invitation, nil)
+
+		service := invitationservice.New(conf, nil)
+		result, err := service.Open(mockUow, token)
+
+		require.NoError(t, err)
+		require.Equal(t, invitation, result)
+
+		mockInvitationRepo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name          string
+		invitation    *domain.UserInvitation
+		repoErr       error
+		expectedError serviceerror.ErrorMessage
+	}{
+		{
+			name:          "Open unknown token",
+			repoErr:       serviceerror.New(serviceerror.RecordNotFound),
+			expectedError: serviceerror.InvitationInvalid,
+		},
+		{
+			name:          "Open database error",
+			repoErr:       serviceerror.NewServerError(),
+			expectedError: serviceerror.ServerError,
+		},
+		{
+			name:          "Open expired invitation",
+			invitation:    &domain.UserInvitation{ExpiresAt: past},
+			expectedError: serviceerror.InvitationExpired,
+		},
+		{
+			name:          "Open used invitation",
+			invitation:    &domain.UserInvitation{ExpiresAt: time.Now().Add(time.Hour), AcceptedAt: &past},
+			expectedError: serviceerror.InvitationInvalid,
+		},
+		{
+			name:          "Open revoked invitation",
+			invitation:    &domain.UserInvitation{ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &past},
+			expectedError: serviceerror.InvitationInvalid,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockInvitationRepo := new(userrepository.MockUserInvitationRepository)
+			mockUow := new(userrepository.MockUnitOfWork)
+			mockUow.On("UserInvitationRepository").Return(mockInvitationRepo)
+
+			mockInvitationRepo.On("GetByTokenHash", tokenHash).Return(test.invitation, test.repoErr)
+
+			service := invitationservice.New(conf, nil)
+			result, err := service.Open(mockUow, token)
+
+			require.Error(t, err)
+			require.Equal(t, test.expectedError, err.(*serviceerror.ServiceError).GetErrorMessage())
+			require.Nil(t, result)
+		})
+	}
+}
+
+func TestInvitationService_Accept(t *testing.T) {
+	invitation := &domain.UserInvitation{Base: domain.Base{ID: 3}, UserID: 10, ExpiresAt: time.Now().Add(time.Hour)}
+
+	t.Run("Accept success", func(t *testing.T) {
+		mockUserRepo := new(userrepository.MockUserRepository)
+		mockInvitationRepo := new(userrepository.MockUserInvitationRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserRepository").Return(mockUserRepo)
+		mockUow.On("UserInvitationRepository").Return(mockInvitationRepo)
+
+		mockUserRepo.On("Activate", uint64(10), "hashed").Return(nil)
+		mockInvitationRepo.On("Accept", uint64(3)).Return(nil)
+
+		service := invitationservice.New(conf, nil)
+		err := service.Accept(mockUow, invitation, "hashed")
+
+		require.NoError(t, err)
+
+		mockUserRepo.AssertExpectations(t)
+		mockInvitationRepo.AssertExpectations(t)
+	})
+
+	t.Run("Accept activate error", func(t *testing.T) {
+		mockUserRepo := new(userrepository.MockUserRepository)
+		mockInvitationRepo := new(userrepository.MockUserInvitationRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserRepository").Return(mockUserRepo)
+		mockUow.On("UserInvitationRepository").Return(mockInvitationRepo)
+
+		mockUserRepo.On("Activate", uint64(10), "hashed").Return(serviceerror.NewServerError())
+
+		service := invitationservice.New(conf, nil)
+		err := service.Accept(mockUow, invitation, "hashed")
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+		mockUserRepo.AssertExpectations(t)
+		mockInvitationRepo.AssertNotCalled(t, "Accept", mock.Anything)
+	})
+}
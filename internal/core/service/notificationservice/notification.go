@@ -0,0 +1,87 @@
+package notificationservice
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"strings"
+)
+
+type Service struct {
+	log         logger.Logger
+	broadcaster port.NotificationBroadcaster
+}
+
+func New(log logger.Logger, broadcaster port.NotificationBroadcaster) *Service {
+	return &Service{
+		log:         log,
+		broadcaster: broadcaster,
+	}
+}
+
+// Send stores the notification for its user, it reaches the open streams of the user once it is published
+// after the transaction is committed.
+func (r *Service) Send(uow port.UserUnitOfWork, notification domain.Notification) (*domain.Notification, error) {
+	if !notification.Type.IsValid() ||
+		strings.TrimSpace(notification.Title) == "" ||
+		strings.TrimSpace(notification.Body) == "" {
+		return nil, serviceerror.New(serviceerror.InvalidRequestBody)
+	}
+
+	if _, err := uow.UserRepository().GetByID(notification.UserID); err != nil {
+		return nil, err
+	}
+
+	notification.ReadAt = nil
+	if err := uow.NotificationRepository().Create(&notification); err != nil {
+		return nil, err
+	}
+
+	return &notification, nil
+}
+
+// Publish pushes a stored notification to the open streams of its user. A failure is only logged,
+// the notification is listed all the same.
+func (r *Service) Publish(ctx context.Context, notification domain.Notification) {
+	if err := r.broadcaster.Publish(ctx, notification); err != nil {
+		r.log.Warn(logger.Cache, logger.RedisPublish, "Publishing the notification failed: "+err.Error(), map[logger.ExtraKey]interface{}{
+			"UserID":       notification.UserID,
+			"Notification": notification.Base.UUID,
+		})
+	}
+}
+
+func (r *Service) List(
+	uow port.UserUnitOfWork,
+	userID uint64,
+	filter domain.NotificationFilter,
+) ([]*domain.Notification, uint64, error) {
+	return uow.NotificationRepository().List(userID, filter)
+}
+
+// MarkRead marks the given notifications of the user as read, all of them when none is given, and returns
+// how many are left unread.
+func (r *Service) MarkRead(uow port.UserUnitOfWork, userID uint64, notificationUUIDs []uuid.UUID) (uint64, error) {
+	var err error
+	if len(notificationUUIDs) == 0 {
+		err = uow.NotificationRepository().MarkAllRead(userID)
+	} else {
+		err = uow.NotificationRepository().MarkRead(userID, notificationUUIDs)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return uow.NotificationRepository().CountUnread(userID)
+}
+
+func (r *Service) CountUnread(uow port.UserUnitOfWork, userID uint64) (uint64, error) {
+	return uow.NotificationRepository().CountUnread(userID)
+}
+
+func (r *Service) Subscribe(ctx context.Context, userID uint64) (<-chan domain.Notification, error) {
+	return r.broadcaster.Subscribe(ctx, userID)
+}
@@ -0,0 +1,117 @@
+package notificationservice_test
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/userrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/redis/notificationrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/notificationservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestService_Send(t *testing.T) {
+	notification := domain.Notification{
+		UserID: 10,
+		Type:   domain.NotificationTypeStreakAtRisk,
+		Title:  "Your streak is at risk",
+		Body:   "Practice one sentence today to keep your 12 day streak.",
+	}
+
+	t.Run("Send success", func(t *testing.T) {
+		mockUserRepo := new(userrepository.MockUserRepository)
+		mockNotificationRepo := new(userrepository.MockNotificationRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserRepository").Return(mockUserRepo)
+		mockUow.On("NotificationRepository").Return(mockNotificationRepo)
+
+		mockUserRepo.On("GetByID", uint64(10)).Return(&domain.User{Base: domain.Base{ID: 10}}, nil)
+		mockNotificationRepo.On("Create", mock.AnythingOfType("*domain.Notification")).Run(func(args mock.Arguments) {
+			args.Get(0).(*domain.Notification).Base.ID = 1
+		}).Return(nil)
+
+		stored, err := notificationservice.New(new(logger.MockLogger), new(notificationrepository.MockBroadcaster)).
+			Send(mockUow, notification)
+
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), stored.Base.ID)
+		require.Equal(t, notification.Title, stored.Title)
+		mockNotificationRepo.AssertExpectations(t)
+	})
+
+	t.Run("Send invalid type", func(t *testing.T) {
+		invalid := notification
+		invalid.Type = "UNKNOWN"
+
+		_, err := notificationservice.New(new(logger.MockLogger), new(notificationrepository.MockBroadcaster)).
+			Send(new(userrepository.MockUnitOfWork), invalid)
+
+		require.Equal(t, serviceerror.New(serviceerror.InvalidRequestBody), err)
+	})
+
+	t.Run("Send to unknown user", func(t *testing.T) {
+		mockUserRepo := new(userrepository.MockUserRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserRepository").Return(mockUserRepo)
+
+		mockUserRepo.On("GetByID", uint64(10)).Return((*domain.User)(nil), serviceerror.New(serviceerror.RecordNotFound))
+
+		_, err := notificationservice.New(new(logger.MockLogger), new(notificationrepository.MockBroadcaster)).
+			Send(mockUow, notification)
+
+		require.Equal(t, serviceerror.New(serviceerror.RecordNotFound), err)
+	})
+}
+
+func TestService_MarkRead(t *testing.T) {
+	t.Run("MarkRead given notifications", func(t *testing.T) {
+		mockNotificationRepo := new(userrepository.MockNotificationRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("NotificationRepository").Return(mockNotificationRepo)
+
+		notificationUUIDs := []uuid.UUID{uuid.New()}
+		mockNotificationRepo.On("MarkRead", uint64(10), notificationUUIDs).Return(nil)
+		mockNotificationRepo.On("CountUnread", uint64(10)).Return(uint64(3), nil)
+
+		unread, err := notificationservice.New(new(logger.MockLogger), new(notificationrepository.MockBroadcaster)).
+			MarkRead(mockUow, 10, notificationUUIDs)
+
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), unread)
+		mockNotificationRepo.AssertNotCalled(t, "MarkAllRead", mock.Anything)
+	})
+
+	t.Run("MarkRead all notifications", func(t *testing.T) {
+		mockNotificationRepo := new(userrepository.MockNotificationRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("NotificationRepository").Return(mockNotificationRepo)
+
+		mockNotificationRepo.On("MarkAllRead", uint64(10)).Return(nil)
+		mockNotificationRepo.On("CountUnread", uint64(10)).Return(uint64(0), nil)
+
+		unread, err := notificationservice.New(new(logger.MockLogger), new(notificationrepository.MockBroadcaster)).
+			MarkRead(mockUow, 10, nil)
+
+		require.NoError(t, err)
+		require.Zero(t, unread)
+		mockNotificationRepo.AssertExpectations(t)
+	})
+}
+
+func TestService_Publish(t *testing.T) {
+	notification := domain.Notification{Base: domain.Base{UUID: uuid.New()}, UserID: 10}
+
+	mockLogger := new(logger.MockLogger)
+	mockBroadcaster := new(notificationrepository.MockBroadcaster)
+	mockBroadcaster.On("Publish", mock.Anything, notification).Return(serviceerror.NewServerError())
+	mockLogger.On("Warn", logger.Cache, logger.RedisPublish, mock.Anything, mock.Anything).Return()
+
+	notificationservice.New(mockLogger, mockBroadcaster).Publish(context.Background(), notification)
+
+	mockBroadcaster.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
@@ -2,7 +2,10 @@ package otpservice
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"crypto/subtle"
+	"encoding/hex"
 	"fmt"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/constant"
@@ -55,7 +58,7 @@ func (r OTPCacheService) Validate(ctx context.Context, key string, otp string) e
 	key = fmt.Sprintf("%s:%s", constant.RedisOTPPrefix, strings.ToLower(key))
 
 	otpState, err := r.otpCache.Get(ctx, key)
-	if err != nil || otpState == nil || otpState.Value == "" {
+	if err != nil || otpState == nil || otpState.Value == "" || otpState.Used {
 		return serviceerror.New(serviceerror.InvalidOTP)
 	}
 
@@ -66,6 +69,31 @@ func (r OTPCacheService) Validate(ctx context.Context, key string, otp string) e
 	return nil
 }
 
+// SignLink returns the signature embedded in the email verification link.
+// It binds the link to the current OTP, so requesting a new OTP or marking it used invalidates the link too.
+func (r OTPCacheService) SignLink(key string, otp string) string {
+	mac := hmac.New(sha256.New, []byte(r.otpConfig.LinkSecret))
+	mac.Write([]byte(strings.ToLower(key) + ":" + otp))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (r OTPCacheService) ValidateLink(ctx context.Context, key string, signature string) error {
+	cacheKey := fmt.Sprintf("%s:%s", constant.RedisOTPPrefix, strings.ToLower(key))
+
+	otpState, err := r.otpCache.Get(ctx, cacheKey)
+	if err != nil || otpState == nil || otpState.Value == "" || otpState.Used {
+		return serviceerror.New(serviceerror.InvalidVerificationLink)
+	}
+
+	expected := r.SignLink(key, otpState.Value)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(signature)) != 1 {
+		return serviceerror.New(serviceerror.InvalidVerificationLink)
+	}
+
+	return nil
+}
+
 func (r OTPCacheService) Used(ctx context.Context, key string) error {
 	key = fmt.Sprintf("%s:%s", constant.RedisOTPPrefix, strings.ToLower(key))
 
@@ -145,6 +145,118 @@ func TestOTPCacheService_Validate(t *testing.T) {
 
 		mockOTPCache.AssertExpectations(t)
 	})
+
+	t.Run("Validate failure OTP already used", func(t *testing.T) {
+		otpState := &domain.OTP{
+			Value: otpValue,
+			Used:  true,
+		}
+
+		mockOTPCache := new(authrepository.MockOTPCache)
+		mockOTPCache.On("Get", ctx, key).Return(otpState, nil)
+
+		service := otpservice.NewOTPCache(conf, mockOTPCache)
+		err := service.Validate(ctx, email, otpValue)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.InvalidOTP, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+		mockOTPCache.AssertExpectations(t)
+	})
+}
+
+func TestOTPCacheService_SignLink(t *testing.T) {
+	conf := config.OTP{
+		Digits:     6,
+		LinkSecret: "secret",
+	}
+	email := faker.Email()
+	otpValue := generateOTP(int(conf.Digits))
+
+	service := otpservice.NewOTPCache(conf, new(authrepository.MockOTPCache))
+	signature := service.SignLink(email, otpValue)
+
+	require.Len(t, signature, 64)
+	require.Equal(t, signature, service.SignLink(strings.ToUpper(email), otpValue))
+	require.NotEqual(t, signature, service.SignLink(email, "123456"))
+
+	conf.LinkSecret = "another-secret"
+	require.NotEqual(t, signature, otpservice.NewOTPCache(conf, new(authrepository.MockOTPCache)).SignLink(email, otpValue))
+}
+
+func TestOTPCacheService_ValidateLink(t *testing.T) {
+	conf := config.OTP{
+		ExpireSecond: 60,
+		Digits:       6,
+		LinkSecret:   "secret",
+	}
+	ctx := context.TODO()
+	email := faker.Email()
+	otpValue := generateOTP(int(conf.Digits))
+
+	key := fmt.Sprintf("%s:%s", constant.RedisOTPPrefix, strings.ToLower(email))
+	signature := otpservice.NewOTPCache(conf, new(authrepository.MockOTPCache)).SignLink(email, otpValue)
+
+	t.Run("ValidateLink success", func(t *testing.T) {
+		mockOTPCache := new(authrepository.MockOTPCache)
+		mockOTPCache.On("Get", ctx, key).Return(&domain.OTP{Value: otpValue}, nil)
+
+		service := otpservice.NewOTPCache(conf, mockOTPCache)
+		err := service.ValidateLink(ctx, email, signature)
+
+		require.NoError(t, err)
+
+		mockOTPCache.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name      string
+		otpState  *domain.OTP
+		cacheErr  error
+		signature string
+	}{
+		{
+			name:      "ValidateLink failure OTP rotated",
+			otpState:  &domain.OTP{Value: "123456"},
+			signature: signature,
+		},
+		{
+			name:      "ValidateLink failure OTP already used",
+			otpState:  &domain.OTP{Value: otpValue, Used: true},
+			signature: signature,
+		},
+		{
+			name:      "ValidateLink failure OTP expired",
+			otpState:  nil,
+			signature: signature,
+		},
+		{
+			name:      "ValidateLink failure cache error",
+			otpState:  &domain.OTP{},
+			cacheErr:  serviceerror.NewServerError(),
+			signature: signature,
+		},
+		{
+			name:      "ValidateLink failure tampered signature",
+			otpState:  &domain.OTP{Value: otpValue},
+			signature: strings.Repeat("0", 64),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockOTPCache := new(authrepository.MockOTPCache)
+			mockOTPCache.On("Get", ctx, key).Return(test.otpState, test.cacheErr)
+
+			service := otpservice.NewOTPCache(conf, mockOTPCache)
+			err := service.ValidateLink(ctx, email, test.signature)
+
+			require.Error(t, err)
+			require.Equal(t, serviceerror.InvalidVerificationLink, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+			mockOTPCache.AssertExpectations(t)
+		})
+	}
 }
 
 func TestOTPCacheService_Used(t *testing.T) {
@@ -0,0 +1,186 @@
+package outboxservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"time"
+)
+
+// Enqueue stores the enveloped message in the outbox of the transaction, the relay publishes it to the queue once
+// the transaction is committed, so the message is sent if and only if the change it announces is saved.
+func Enqueue(
+	ctx context.Context,
+	outbox port.OutboxRepository,
+	queue string,
+	version int,
+	message interface{},
+	delaySeconds int64,
+) error {
+	envelope, err := domain.NewEnvelope(ctx, queue, version, message)
+	if err != nil {
+		return serviceerror.NewServerError()
+	}
+
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return serviceerror.NewServerError()
+	}
+
+	return outbox.Create(domain.OutboxMessage{
+		Queue:        queue,
+		Payload:      payload,
+		DelaySeconds: delaySeconds,
+	})
+}
+
+// Publish stores the message of the event in the outbox in a transaction of its own,
+// it is meant for callers that have nothing else to save with the message.
+func Publish(ctx context.Context, uow port.OutboxUnitOfWork, event port.Event, message interface{}) error {
+	if err := uow.BeginTx(ctx); err != nil {
+		return err
+	}
+
+	if err := event.Enqueue(ctx, uow.OutboxRepository(), message); err != nil {
+		if rErr := uow.Rollback(); rErr != nil {
+			return rErr
+		}
+		return err
+	}
+
+	return uow.Commit()
+}
+
+// Relay publishes the outbox messages to the queue driver. A message is marked published in the same
+// transaction that locked it, so a crash between the publish and the commit publishes it again: consumers
+// get every message at least once.
+type Relay struct {
+	log    logger.Logger
+	conf   config.Outbox
+	driver port.Driver
+}
+
+func NewRelay(log logger.Logger, conf config.Outbox, driver port.Driver) *Relay {
+	return &Relay{
+		log:    log,
+		conf:   conf,
+		driver: driver,
+	}
+}
+
+// PublishDue publishes one batch of due messages and returns how many of them were published,
+// a message that fails to publish is retried later with a backoff.
+func (r *Relay) PublishDue(ctx context.Context, uow port.OutboxUnitOfWork) (int, error) {
+	if err := uow.BeginTx(ctx); err != nil {
+		return 0, err
+	}
+
+	published, err := r.publishDue(uow)
+	if err != nil {
+		if rErr := uow.Rollback(); rErr != nil {
+			return 0, rErr
+		}
+		return 0, err
+	}
+
+	if err = uow.Commit(); err != nil {
+		return 0, err
+	}
+
+	return published, nil
+}
+
+func (r *Relay) publishDue(uow port.OutboxUnitOfWork) (int, error) {
+	messages, err := uow.OutboxRepository().ListDue(r.conf.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, message := range messages {
+		now := time.Now()
+		if produceErr := r.driver.Produce(message.Queue, json.RawMessage(message.Payload), message.RemainingDelaySeconds(now)); produceErr != nil {
+			if err = uow.OutboxRepository().MarkFailed(message.ID, produceErr.Error(), now.Add(r.RetryDelay(message.Attempts+1))); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		if err = uow.OutboxRepository().MarkPublished(message.ID); err != nil {
+			return 0, err
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// RetryDelay is the wait before publishing a message again after its attempt-th failure.
+func (r *Relay) RetryDelay(attempt int) time.Duration {
+	delay := r.conf.RetryDelaySecond
+	for i := 1; i < attempt && delay < r.conf.RetryMaxDelaySecond; i++ {
+		delay *= 2
+	}
+
+	return min(delay, r.conf.RetryMaxDelaySecond)
+}
+
+// Cleanup removes the messages published longer than the retention ago.
+func (r *Relay) Cleanup(ctx context.Context, uow port.OutboxUnitOfWork) (int64, error) {
+	if err := uow.BeginTx(ctx); err != nil {
+		return 0, err
+	}
+
+	deleted, err := uow.OutboxRepository().DeletePublished(time.Now().Add(-r.conf.RetentionSecond))
+	if err != nil {
+		if rErr := uow.Rollback(); rErr != nil {
+			return 0, rErr
+		}
+		return 0, err
+	}
+
+	if err = uow.Commit(); err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// Run relays the outbox every RelayIntervalSecond and cleans it up every CleanupIntervalSecond until ctx is done.
+// A full batch is followed by the next one right away, so a backlog is drained without waiting for the ticker.
+func (r *Relay) Run(ctx context.Context, uowFactory func() port.OutboxUnitOfWork) {
+	relayTicker := time.NewTicker(r.conf.RelayIntervalSecond)
+	defer relayTicker.Stop()
+	cleanupTicker := time.NewTicker(r.conf.CleanupIntervalSecond)
+	defer cleanupTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-relayTicker.C:
+			for ctx.Err() == nil {
+				published, err := r.PublishDue(ctx, uowFactory())
+				if err != nil {
+					r.log.Error(logger.Queue, logger.OutboxRelay, fmt.Sprintf("Error relaying outbox: %v", err), nil)
+					break
+				}
+				if published < r.conf.BatchSize {
+					break
+				}
+			}
+		case <-cleanupTicker.C:
+			deleted, err := r.Cleanup(ctx, uowFactory())
+			if err != nil {
+				r.log.Error(logger.Queue, logger.OutboxCleanup, fmt.Sprintf("Error cleaning up outbox: %v", err), nil)
+				continue
+			}
+			r.log.Info(logger.Queue, logger.OutboxCleanup, fmt.Sprintf("%d published messages removed from the outbox", deleted), nil)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package outboxservice_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/messagebroker"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/outboxrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/userrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/event/userevent"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/outboxservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var conf = config.Outbox{
+	BatchSize:           10,
+	RetryDelaySecond:    5 * time.Second,
+	RetryMaxDelaySecond: time.Minute,
+	RetentionSecond:     24 * time.Hour,
+}
+
+func newUow(outbox *outboxrepository.MockOutboxRepository) *userrepository.MockUnitOfWork {
+	mockUow := new(userrepository.MockUnitOfWork)
+	mockUow.On("OutboxRepository").Return(outbox)
+
+	return mockUow
+}
+
+func TestEnqueue(t *testing.T) {
+	ctx := domain.WithCorrelationID(context.Background(), "request-id")
+
+	outbox := new(outboxrepository.MockOutboxRepository)
+	outbox.On("Create", mock.MatchedBy(func(message domain.OutboxMessage) bool {
+		envelope := domain.DecodeEnvelope(message.Payload)
+		return message.Queue == "send_invitation" &&
+			message.DelaySeconds == 30 &&
+			envelope.ID != "" &&
+			envelope.Type == "send_invitation" &&
+			envelope.Version == 2 &&
+			envelope.CorrelationID == "request-id" &&
+			string(envelope.Payload) == `{"to":"john.doe@example.com"}`
+	})).Return(nil)
+
+	err := outboxservice.Enqueue(ctx, outbox, "send_invitation", 2, map[string]string{"to": "john.doe@example.com"}, 30)
+
+	require.NoError(t, err)
+	outbox.AssertExpectations(t)
+}
+
+func TestPublish(t *testing.T) {
+	ctx := context.Background()
+	event := userevent.NewSendDataExport(messagebroker.NewQueue(new(logger.MockLogger), config.Config{}))
+	message := userevent.SendDataExportDto{To: "john.doe@example.com"}
+
+	t.Run("Publish success", func(t *testing.T) {
+		outbox := new(outboxrepository.MockOutboxRepository)
+		outbox.On("Create", mock.MatchedBy(func(message domain.OutboxMessage) bool {
+			return message.Queue == userevent.SendDataExportName
+		})).Return(nil)
+		mockUow := newUow(outbox)
+		mockUow.On("BeginTx", ctx).Return(nil)
+		mockUow.On("Commit").Return(nil)
+
+		require.NoError(t, outboxservice.Publish(ctx, mockUow, event, message))
+		mockUow.AssertExpectations(t)
+	})
+
+	t.Run("Publish create error rolls back", func(t *testing.T) {
+		outbox := new(outboxrepository.MockOutboxRepository)
+		outbox.On("Create", mock.Anything).Return(serviceerror.NewServerError())
+		mockUow := newUow(outbox)
+		mockUow.On("BeginTx", ctx).Return(nil)
+		mockUow.On("Rollback").Return(nil)
+
+		err := outboxservice.Publish(ctx, mockUow, event, message)
+
+		require.Error(t, err)
+		mockUow.AssertExpectations(t)
+		mockUow.AssertNotCalled(t, "Commit")
+	})
+}
+
+func TestRelay_PublishDue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("PublishDue success", func(t *testing.T) {
+		now := time.Now()
+		messages := []*domain.OutboxMessage{
+			{ID: 1, Queue: "send_email_otp", Payload: []byte(`{"to":"a"}`), CreatedAt: now},
+			{ID: 2, Queue: "send_welcome", Payload: []byte(`{"to":"b"}`), DelaySeconds: 60, CreatedAt: now.Add(-10 * time.Second)},
+			{ID: 3, Queue: "send_welcome", Payload: []byte(`{"to":"c"}`), Attempts: 2, CreatedAt: now},
+		}
+
+		outbox := new(outboxrepository.MockOutboxRepository)
+		outbox.On("ListDue", conf.BatchSize).Return(messages, nil)
+		outbox.On("MarkPublished", uint64(1)).Return(nil)
+		outbox.On("MarkPublished", uint64(2)).Return(nil)
+		outbox.On("MarkFailed", uint64(3), "connection closed", mock.MatchedBy(func(availableAt time.Time) bool {
+			return time.Until(availableAt) > 19*time.Second && time.Until(availableAt) <= 20*time.Second
+		})).Return(nil)
+		mockUow := newUow(outbox)
+		mockUow.On("BeginTx", ctx).Return(nil)
+		mockUow.On("Commit").Return(nil)
+
+		driver := new(messagebroker.MockDriver)
+		driver.On("Produce", "send_email_otp", json.RawMessage(`{"to":"a"}`), int64(0)).Return(nil)
+		driver.On("Produce", "send_welcome", json.RawMessage(`{"to":"b"}`), mock.MatchedBy(func(delaySeconds int64) bool {
+			return delaySeconds >= 49 && delaySeconds <= 50
+		})).Return(nil)
+		driver.On("Produce", "send_welcome", json.RawMessage(`{"to":"c"}`), int64(0)).Return(errors.New("connection closed"))
+
+		published, err := outboxservice.NewRelay(new(logger.MockLogger), conf, driver).PublishDue(ctx, mockUow)
+
+		require.NoError(t, err)
+		require.Equal(t, 2, published)
+		outbox.AssertExpectations(t)
+		driver.AssertExpectations(t)
+		mockUow.AssertExpectations(t)
+	})
+
+	t.Run("PublishDue mark published error rolls back", func(t *testing.T) {
+		outbox := new(outboxrepository.MockOutboxRepository)
+		outbox.On("ListDue", conf.BatchSize).Return([]*domain.OutboxMessage{
+			{ID: 1, Queue: "send_email_otp", Payload: []byte(`{}`), CreatedAt: time.Now()},
+		}, nil)
+		outbox.On("MarkPublished", uint64(1)).Return(serviceerror.NewServerError())
+		mockUow := newUow(outbox)
+		mockUow.On("BeginTx", ctx).Return(nil)
+		mockUow.On("Rollback").Return(nil)
+
+		driver := new(messagebroker.MockDriver)
+		driver.On("Produce", "send_email_otp", json.RawMessage(`{}`), int64(0)).Return(nil)
+
+		published, err := outboxservice.NewRelay(new(logger.MockLogger), conf, driver).PublishDue(ctx, mockUow)
+
+		require.Error(t, err)
+		require.Zero(t, published)
+		mockUow.AssertExpectations(t)
+		mockUow.AssertNotCalled(t, "Commit")
+	})
+
+	t.Run("PublishDue list error", func(t *testing.T) {
+		outbox := new(outboxrepository.MockOutboxRepository)
+		outbox.On("ListDue", conf.BatchSize).Return([]*domain.OutboxMessage(nil), serviceerror.NewServerError())
+		mockUow := newUow(outbox)
+		mockUow.On("BeginTx", ctx).Return(nil)
+		mockUow.On("Rollback").Return(nil)
+
+		published, err := outboxservice.NewRelay(new(logger.MockLogger), conf, new(messagebroker.MockDriver)).PublishDue(ctx, mockUow)
+
+		require.Error(t, err)
+		require.Zero(t, published)
+	})
+}
+
+func TestRelay_RetryDelay(t *testing.T) {
+	relay := outboxservice.NewRelay(new(logger.MockLogger), conf, new(messagebroker.MockDriver))
+
+	require.Equal(t, 5*time.Second, relay.RetryDelay(1))
+	require.Equal(t, 10*time.Second, relay.RetryDelay(2))
+	require.Equal(t, 40*time.Second, relay.RetryDelay(4))
+	require.Equal(t, time.Minute, relay.RetryDelay(5))
+	require.Equal(t, time.Minute, relay.RetryDelay(100))
+}
+
+func TestRelay_Cleanup(t *testing.T) {
+	ctx := context.Background()
+
+	outbox := new(outboxrepository.MockOutboxRepository)
+	outbox.On("DeletePublished", mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= 24*time.Hour && time.Since(before) < 25*time.Hour
+	})).Return(int64(3), nil)
+	mockUow := newUow(outbox)
+	mockUow.On("BeginTx", ctx).Return(nil)
+	mockUow.On("Commit").Return(nil)
+
+	deleted, err := outboxservice.NewRelay(new(logger.MockLogger), conf, new(messagebroker.MockDriver)).Cleanup(ctx, mockUow)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(3), deleted)
+	outbox.AssertExpectations(t)
+}
@@ -0,0 +1,60 @@
+package passwordservice
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+)
+
+type HistoryService struct {
+	conf config.Password
+}
+
+func NewHistoryService(conf config.Password) *HistoryService {
+	return &HistoryService{
+		conf: conf,
+	}
+}
+
+// CheckReuse rejects a password matching the current hash or one of the last HistorySize remembered hashes.
+// A HistorySize of zero disables the check.
+func (r *HistoryService) CheckReuse(
+	uow port.PasswordHistoryUnitOfWork,
+	userID uint64,
+	currentHash *string,
+	password string,
+) error {
+	if r.conf.HistorySize <= 0 {
+		return nil
+	}
+
+	hashes, err := uow.PasswordHistoryRepository().ListRecent(userID, r.conf.HistorySize)
+	if err != nil {
+		return err
+	}
+	if currentHash != nil {
+		hashes = append(hashes, *currentHash)
+	}
+
+	for _, hash := range hashes {
+		if helper.CheckPasswordHash(password, hash) {
+			return serviceerror.New(serviceerror.PasswordReused)
+		}
+	}
+
+	return nil
+}
+
+// Remember stores the hash of a newly set password and drops the entries beyond HistorySize.
+func (r *HistoryService) Remember(uow port.PasswordHistoryUnitOfWork, userID uint64, hashedPassword string) error {
+	if r.conf.HistorySize <= 0 {
+		return nil
+	}
+
+	if err := uow.PasswordHistoryRepository().Create(userID, hashedPassword); err != nil {
+		return err
+	}
+
+	return uow.PasswordHistoryRepository().Prune(userID, r.conf.HistorySize)
+}
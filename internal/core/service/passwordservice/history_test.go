@@ -0,0 +1,147 @@
+package passwordservice_test
+
+import (
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/authrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/passwordrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/passwordservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var params = helper.Argon2Params{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func hash(t *testing.T, password string) string {
+	hashed, err := helper.HashPassword(password, params)
+	require.NoError(t, err)
+	return hashed
+}
+
+func TestHistoryService_CheckReuse(t *testing.T) {
+	conf := config.Password{HistorySize: 3}
+	userID := uint64(7)
+	oldHashes := []string{hash(t, "First#Pass1"), hash(t, "Second#Pass2")}
+	currentHash := hash(t, "Current#Pass3")
+
+	tests := []struct {
+		name          string
+		password      string
+		expectedError bool
+	}{
+		{
+			name:          "CheckReuse new password",
+			password:      "Brand#New4",
+			expectedError: false,
+		},
+		{
+			name:          "CheckReuse remembered password",
+			password:      "Second#Pass2",
+			expectedError: true,
+		},
+		{
+			name:          "CheckReuse current password",
+			password:      "Current#Pass3",
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockRepo := new(passwordrepository.MockPasswordHistoryRepository)
+			mockUow := new(authrepository.MockUnitOfWork)
+			mockUow.On("PasswordHistoryRepository").Return(mockRepo)
+
+			mockRepo.On("ListRecent", userID, conf.HistorySize).Return(oldHashes, nil)
+
+			service := passwordservice.NewHistoryService(conf)
+			err := service.CheckReuse(mockUow, userID, &currentHash, test.password)
+
+			if test.expectedError {
+				require.Error(t, err)
+				require.Equal(t, serviceerror.PasswordReused, err.(*serviceerror.ServiceError).GetErrorMessage())
+			} else {
+				require.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("CheckReuse repository error", func(t *testing.T) {
+		mockRepo := new(passwordrepository.MockPasswordHistoryRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("PasswordHistoryRepository").Return(mockRepo)
+
+		var hashes []string
+		mockRepo.On("ListRecent", userID, conf.HistorySize).Return(hashes, serviceerror.NewServerError())
+
+		service := passwordservice.NewHistoryService(conf)
+		err := service.CheckReuse(mockUow, userID, nil, "Brand#New4")
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+	})
+
+	t.Run("CheckReuse disabled", func(t *testing.T) {
+		mockUow := new(authrepository.MockUnitOfWork)
+
+		service := passwordservice.NewHistoryService(config.Password{})
+		err := service.CheckReuse(mockUow, userID, &currentHash, "Current#Pass3")
+
+		require.NoError(t, err)
+		mockUow.AssertNotCalled(t, "PasswordHistoryRepository")
+	})
+}
+
+func TestHistoryService_Remember(t *testing.T) {
+	conf := config.Password{HistorySize: 3}
+	userID := uint64(7)
+
+	t.Run("Remember success", func(t *testing.T) {
+		mockRepo := new(passwordrepository.MockPasswordHistoryRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("PasswordHistoryRepository").Return(mockRepo)
+
+		mockRepo.On("Create", userID, "hashed").Return(nil)
+		mockRepo.On("Prune", userID, conf.HistorySize).Return(nil)
+
+		service := passwordservice.NewHistoryService(conf)
+		err := service.Remember(mockUow, userID, "hashed")
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Remember create error", func(t *testing.T) {
+		mockRepo := new(passwordrepository.MockPasswordHistoryRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("PasswordHistoryRepository").Return(mockRepo)
+
+		mockRepo.On("Create", userID, "hashed").Return(serviceerror.NewServerError())
+
+		service := passwordservice.NewHistoryService(conf)
+		err := service.Remember(mockUow, userID, "hashed")
+
+		require.Error(t, err)
+		mockRepo.AssertNotCalled(t, "Prune", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Remember disabled", func(t *testing.T) {
+		mockUow := new(authrepository.MockUnitOfWork)
+
+		service := passwordservice.NewHistoryService(config.Password{})
+		err := service.Remember(mockUow, userID, "hashed")
+
+		require.NoError(t, err)
+		mockUow.AssertNotCalled(t, "PasswordHistoryRepository")
+	})
+}
@@ -3,6 +3,7 @@ package permissionservice
 import (
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"slices"
 )
 
 type Service struct {
@@ -15,3 +16,67 @@ func New() *Service {
 func (r *Service) List(uow port.AuthUnitOfWork) ([]*domain.Permission, error) {
 	return uow.PermissionRepository().List()
 }
+
+// Sync writes domain.PermissionRegistry into the permissions table, with grant every permission is attached to the
+// declared roles it is missing from, so a grant skipped on an earlier sync is made up. Stored permissions missing
+// from the registry are reported as orphans.
+func (r *Service) Sync(uow port.AuthUnitOfWork, grant bool) (*domain.PermissionSyncReport, error) {
+	report := &domain.PermissionSyncReport{}
+
+	registered := make(map[domain.PermissionKeyType]struct{}, len(domain.PermissionRegistry))
+	for _, definition := range domain.PermissionRegistry {
+		registered[definition.Key] = struct{}{}
+
+		id, created, err := uow.PermissionRepository().Upsert(definition.ToPermission())
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case created:
+			report.Created = append(report.Created, definition.Key)
+		case id != 0:
+			report.Updated = append(report.Updated, definition.Key)
+		}
+
+		if !grant || len(definition.Roles) == 0 {
+			continue
+		}
+
+		attached, err := uow.RoleRepository().AttachPermission(definition.Key, definition.Roles...)
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range attached {
+			if !slices.Contains(report.Granted, role) {
+				report.Granted = append(report.Granted, role)
+			}
+		}
+	}
+
+	keys, err := uow.PermissionRepository().ListKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		if _, ok := registered[key]; !ok {
+			report.Orphans = append(report.Orphans, key)
+		}
+	}
+
+	if len(report.Created) > 0 || len(report.Updated) > 0 || len(report.Granted) > 0 {
+		if err = uow.AuditLogRepository().Create(domain.NewAuditLog(
+			domain.AuditActor{},
+			domain.AuditActionPermissionRegistrySync,
+			domain.AuditTargetPermission,
+			nil,
+			nil,
+			map[string]interface{}{"created": report.Created, "updated": report.Updated, "granted": report.Granted},
+		)); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
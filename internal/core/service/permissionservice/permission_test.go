@@ -3,11 +3,13 @@ package permissionservice_test
 import (
 	"github.com/go-faker/faker/v4"
 	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/auditrepository"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/authrepository"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/permissionservice"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -61,3 +63,174 @@ func TestPermissionService_List(t *testing.T) {
 
 	})
 }
+
+func TestPermissionService_Sync(t *testing.T) {
+	registryKeys := make([]domain.PermissionKeyType, 0, len(domain.PermissionRegistry))
+	for _, definition := range domain.PermissionRegistry {
+		registryKeys = append(registryKeys, definition.Key)
+	}
+	createdKey := domain.PermissionKeyUpdateOwnSentence
+	updatedKey := domain.PermissionRegistry[1].Key
+	// the audit log permission exists from a sync without grant, the grant is made up for it
+	missingKey := domain.PermissionKeyReadAuditLog
+
+	t.Run("Sync success", func(t *testing.T) {
+		mockPermissionRepo := new(authrepository.MockPermissionRepository)
+		mockRoleRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("PermissionRepository").Return(mockPermissionRepo)
+		mockUow.On("RoleRepository").Return(mockRoleRepo)
+
+		for _, definition := range domain.PermissionRegistry {
+			switch definition.Key {
+			case createdKey:
+				mockPermissionRepo.On("Upsert", definition.ToPermission()).Return(uint64(20), true, nil)
+			case updatedKey:
+				mockPermissionRepo.On("Upsert", definition.ToPermission()).Return(uint64(2), false, nil)
+			default:
+				mockPermissionRepo.On("Upsert", definition.ToPermission()).Return(uint64(0), false, nil)
+			}
+		}
+		for _, definition := range domain.PermissionRegistry {
+			if len(definition.Roles) == 0 {
+				continue
+			}
+			switch definition.Key {
+			case createdKey, missingKey:
+				mockRoleRepo.On("AttachPermission", definition.Key, definition.Roles).Return(definition.Roles, nil)
+			default:
+				mockRoleRepo.On("AttachPermission", definition.Key, definition.Roles).Return(nil, nil)
+			}
+		}
+		mockPermissionRepo.On("ListKeys").Return(append(registryKeys, "LEGACY_PERMISSION"), nil)
+
+		mockAuditRepo := new(auditrepository.MockAuditLogRepository)
+		mockUow.On("AuditLogRepository").Return(mockAuditRepo)
+		mockAuditRepo.On("Create", mock.MatchedBy(func(auditLog domain.AuditLog) bool {
+			return auditLog.Action == domain.AuditActionPermissionRegistrySync && auditLog.Actor.UserID == nil
+		})).Return(nil)
+
+		service := permissionservice.New()
+		report, err := service.Sync(mockUow, true)
+
+		require.NoError(t, err)
+		require.Equal(t, &domain.PermissionSyncReport{
+			Created: []domain.PermissionKeyType{createdKey},
+			Updated: []domain.PermissionKeyType{updatedKey},
+			Orphans: []domain.PermissionKeyType{"LEGACY_PERMISSION"},
+			Granted: []domain.RoleKeyType{domain.RoleKeyStaff, domain.RoleKeyAdmin},
+		}, report)
+
+		mockPermissionRepo.AssertExpectations(t)
+		mockRoleRepo.AssertExpectations(t)
+		mockAuditRepo.AssertExpectations(t)
+	})
+
+	t.Run("Sync without grant", func(t *testing.T) {
+		mockPermissionRepo := new(authrepository.MockPermissionRepository)
+		mockRoleRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("PermissionRepository").Return(mockPermissionRepo)
+		mockUow.On("RoleRepository").Return(mockRoleRepo)
+
+		mockPermissionRepo.On("Upsert", mock.Anything).Return(uint64(0), false, nil)
+		mockPermissionRepo.On("ListKeys").Return(registryKeys, nil)
+
+		service := permissionservice.New()
+		report, err := service.Sync(mockUow, false)
+
+		require.NoError(t, err)
+		require.Equal(t, &domain.PermissionSyncReport{}, report)
+
+		mockPermissionRepo.AssertExpectations(t)
+		mockRoleRepo.AssertNotCalled(t, "AttachPermission", mock.Anything, mock.Anything)
+		mockUow.AssertNotCalled(t, "AuditLogRepository")
+	})
+
+	t.Run("Sync created without grant", func(t *testing.T) {
+		mockPermissionRepo := new(authrepository.MockPermissionRepository)
+		mockRoleRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("PermissionRepository").Return(mockPermissionRepo)
+		mockUow.On("RoleRepository").Return(mockRoleRepo)
+
+		for _, definition := range domain.PermissionRegistry {
+			if definition.Key == createdKey {
+				mockPermissionRepo.On("Upsert", definition.ToPermission()).Return(uint64(20), true, nil)
+				continue
+			}
+			mockPermissionRepo.On("Upsert", definition.ToPermission()).Return(uint64(0), false, nil)
+		}
+		mockPermissionRepo.On("ListKeys").Return(registryKeys, nil)
+
+		mockAuditRepo := new(auditrepository.MockAuditLogRepository)
+		mockUow.On("AuditLogRepository").Return(mockAuditRepo)
+		mockAuditRepo.On("Create", mock.Anything).Return(nil)
+
+		service := permissionservice.New()
+		report, err := service.Sync(mockUow, false)
+
+		require.NoError(t, err)
+		require.Equal(t, &domain.PermissionSyncReport{
+			Created: []domain.PermissionKeyType{createdKey},
+		}, report)
+
+		mockRoleRepo.AssertNotCalled(t, "AttachPermission", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Sync upsert error", func(t *testing.T) {
+		mockPermissionRepo := new(authrepository.MockPermissionRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("PermissionRepository").Return(mockPermissionRepo)
+
+		mockPermissionRepo.On("Upsert", mock.Anything).Return(uint64(0), false, serviceerror.NewServerError())
+
+		service := permissionservice.New()
+		report, err := service.Sync(mockUow, true)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+		require.Nil(t, report)
+
+		mockPermissionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Sync attach permission error", func(t *testing.T) {
+		mockPermissionRepo := new(authrepository.MockPermissionRepository)
+		mockRoleRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("PermissionRepository").Return(mockPermissionRepo)
+		mockUow.On("RoleRepository").Return(mockRoleRepo)
+
+		mockPermissionRepo.On("Upsert", mock.Anything).Return(uint64(20), true, nil)
+		mockRoleRepo.On("AttachPermission", mock.Anything, mock.Anything).Return(nil, serviceerror.NewServerError())
+
+		service := permissionservice.New()
+		report, err := service.Sync(mockUow, true)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+		require.Nil(t, report)
+
+		mockRoleRepo.AssertExpectations(t)
+	})
+
+	t.Run("Sync list keys error", func(t *testing.T) {
+		mockPermissionRepo := new(authrepository.MockPermissionRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("PermissionRepository").Return(mockPermissionRepo)
+
+		var keys []domain.PermissionKeyType
+		mockPermissionRepo.On("Upsert", mock.Anything).Return(uint64(0), false, nil)
+		mockPermissionRepo.On("ListKeys").Return(keys, serviceerror.NewServerError())
+
+		service := permissionservice.New()
+		report, err := service.Sync(mockUow, false)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+		require.Nil(t, report)
+
+		mockPermissionRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,182 @@
+package preferenceservice
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"strings"
+)
+
+type Service struct {
+	conf config.Unsubscribe
+}
+
+func New(conf config.Unsubscribe) *Service {
+	return &Service{
+		conf: conf,
+	}
+}
+
+// List returns the preference of the user for every category, a category the user never changed is enabled.
+func (r *Service) List(uow port.UserUnitOfWork, userID uint64) ([]domain.NotificationPreference, error) {
+	stored, err := uow.NotificationPreferenceRepository().ListByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[domain.NotificationCategoryType]bool, len(stored))
+	for _, preference := range stored {
+		enabled[preference.Category] = preference.Enabled
+	}
+
+	preferences := make([]domain.NotificationPreference, 0, len(domain.NotificationCategories))
+	for _, category := range domain.NotificationCategories {
+		value, ok := enabled[category]
+		preferences = append(preferences, domain.NotificationPreference{
+			UserID:   userID,
+			Category: category,
+			Enabled:  !ok || value || category.IsEssential(),
+		})
+	}
+
+	return preferences, nil
+}
+
+// Update stores the given preferences and returns all of them, an essential category cannot be turned off.
+func (r *Service) Update(
+	uow port.UserUnitOfWork,
+	userID uint64,
+	preferences []domain.NotificationPreference,
+) ([]domain.NotificationPreference, error) {
+	for _, preference := range preferences {
+		if preference.Category.IsEssential() && !preference.Enabled {
+			return nil, serviceerror.New(serviceerror.NotificationCategoryEssential)
+		}
+	}
+
+	for _, preference := range preferences {
+		preference.UserID = userID
+		if err := uow.NotificationPreferenceRepository().Upsert(preference); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.List(uow, userID)
+}
+
+// GetByEmail returns the preference of the user with the email for the category together with the token of
+// the link that unsubscribes the user from it, an essential category has no unsubscribe token.
+func (r *Service) GetByEmail(
+	uow port.UserUnitOfWork,
+	email string,
+	category domain.NotificationCategoryType,
+) (*domain.NotificationPreference, string, error) {
+	if !category.IsValid() {
+		return nil, "", serviceerror.New(serviceerror.InvalidRequestBody)
+	}
+
+	user, err := uow.UserRepository().GetByEmail(email)
+	if err != nil {
+		return nil, "", err
+	}
+	if user == nil {
+		return nil, "", serviceerror.New(serviceerror.RecordNotFound)
+	}
+
+	preferences, err := r.List(uow, user.Base.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var preference domain.NotificationPreference
+	for _, item := range preferences {
+		if item.Category == category {
+			preference = item
+		}
+	}
+
+	if category.IsEssential() {
+		return &preference, "", nil
+	}
+
+	return &preference, r.UnsubscribeToken(user.Base.UUID, category), nil
+}
+
+// VerifyUnsubscribe returns the category the unsubscribe token turns off, it changes nothing.
+func (r *Service) VerifyUnsubscribe(token string) (domain.NotificationCategoryType, error) {
+	_, category, ok := r.parseToken(token)
+	if !ok || category.IsEssential() {
+		return "", serviceerror.New(serviceerror.InvalidUnsubscribeLink)
+	}
+
+	return category, nil
+}
+
+// Unsubscribe turns off the category of the unsubscribe token for its user.
+func (r *Service) Unsubscribe(uow port.UserUnitOfWork, token string) (*domain.NotificationPreference, error) {
+	userUUID, category, ok := r.parseToken(token)
+	if !ok || category.IsEssential() {
+		return nil, serviceerror.New(serviceerror.InvalidUnsubscribeLink)
+	}
+
+	user, err := uow.UserRepository().GetByUUID(userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	preference := domain.NotificationPreference{
+		UserID:   user.Base.ID,
+		Category: category,
+		Enabled:  false,
+	}
+	if err = uow.NotificationPreferenceRepository().Upsert(preference); err != nil {
+		return nil, err
+	}
+
+	return &preference, nil
+}
+
+// UnsubscribeToken returns the token of the one-click unsubscribe link, it is the user UUID and the category
+// followed by their signature.
+func (r *Service) UnsubscribeToken(userUUID uuid.UUID, category domain.NotificationCategoryType) string {
+	payload := userUUID.String() + "." + string(category)
+
+	return payload + "." + r.sign(payload)
+}
+
+func (r *Service) parseToken(token string) (uuid.UUID, domain.NotificationCategoryType, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return uuid.Nil, "", false
+	}
+
+	payload := parts[0] + "." + parts[1]
+	if subtle.ConstantTimeCompare([]byte(r.sign(payload)), []byte(parts[2])) != 1 {
+		return uuid.Nil, "", false
+	}
+
+	userUUID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, "", false
+	}
+
+	category := domain.NotificationCategoryType(parts[1])
+	if !category.IsValid() {
+		return uuid.Nil, "", false
+	}
+
+	return userUUID, category, true
+}
+
+func (r *Service) sign(payload string) string {
+	mac := hmac.New(sha256.New, []byte(r.conf.Secret))
+	mac.Write([]byte(payload))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
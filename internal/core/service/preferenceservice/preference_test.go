@@ -0,0 +1,175 @@
+package preferenceservice_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/userrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/preferenceservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+var conf = config.Unsubscribe{Secret: "secret"}
+
+func TestService_List(t *testing.T) {
+	mockPreferenceRepo := new(userrepository.MockNotificationPreferenceRepository)
+	mockUow := new(userrepository.MockUnitOfWork)
+	mockUow.On("NotificationPreferenceRepository").Return(mockPreferenceRepo)
+
+	mockPreferenceRepo.On("ListByUserID", uint64(10)).Return([]domain.NotificationPreference{
+		{UserID: 10, Category: domain.NotificationCategoryProduct, Enabled: false},
+	}, nil)
+
+	preferences, err := preferenceservice.New(conf).List(mockUow, 10)
+
+	require.NoError(t, err)
+	require.Equal(t, []domain.NotificationPreference{
+		{UserID: 10, Category: domain.NotificationCategorySecurity, Enabled: true},
+		{UserID: 10, Category: domain.NotificationCategoryProduct, Enabled: false},
+		{UserID: 10, Category: domain.NotificationCategoryLearningReminder, Enabled: true},
+	}, preferences)
+}
+
+func TestService_Update(t *testing.T) {
+	t.Run("Update success", func(t *testing.T) {
+		mockPreferenceRepo := new(userrepository.MockNotificationPreferenceRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("NotificationPreferenceRepository").Return(mockPreferenceRepo)
+
+		preference := domain.NotificationPreference{UserID: 10, Category: domain.NotificationCategoryLearningReminder}
+		mockPreferenceRepo.On("Upsert", preference).Return(nil)
+		mockPreferenceRepo.On("ListByUserID", uint64(10)).Return([]domain.NotificationPreference{preference}, nil)
+
+		preferences, err := preferenceservice.New(conf).Update(mockUow, 10, []domain.NotificationPreference{
+			{Category: domain.NotificationCategoryLearningReminder, Enabled: false},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, preferences, len(domain.NotificationCategories))
+		require.False(t, preferences[2].Enabled)
+		mockPreferenceRepo.AssertExpectations(t)
+	})
+
+	t.Run("Update essential category", func(t *testing.T) {
+		mockUow := new(userrepository.MockUnitOfWork)
+
+		_, err := preferenceservice.New(conf).Update(mockUow, 10, []domain.NotificationPreference{
+			{Category: domain.NotificationCategorySecurity, Enabled: false},
+		})
+
+		require.Equal(t, serviceerror.New(serviceerror.NotificationCategoryEssential), err)
+		mockUow.AssertNotCalled(t, "NotificationPreferenceRepository")
+	})
+}
+
+func TestService_GetByEmail(t *testing.T) {
+	userUUID := uuid.New()
+
+	newUow := func(user *domain.User) (*userrepository.MockUnitOfWork, *userrepository.MockNotificationPreferenceRepository) {
+		mockUserRepo := new(userrepository.MockUserRepository)
+		mockPreferenceRepo := new(userrepository.MockNotificationPreferenceRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserRepository").Return(mockUserRepo)
+		mockUow.On("NotificationPreferenceRepository").Return(mockPreferenceRepo)
+		mockUserRepo.On("GetByEmail", "john.doe@example.com").Return(user, nil)
+
+		return mockUow, mockPreferenceRepo
+	}
+
+	t.Run("GetByEmail product", func(t *testing.T) {
+		mockUow, mockPreferenceRepo := newUow(&domain.User{Base: domain.Base{ID: 10, UUID: userUUID}})
+		mockPreferenceRepo.On("ListByUserID", uint64(10)).Return([]domain.NotificationPreference{}, nil)
+
+		service := preferenceservice.New(conf)
+		preference, token, err := service.GetByEmail(mockUow, "john.doe@example.com", domain.NotificationCategoryProduct)
+
+		require.NoError(t, err)
+		require.True(t, preference.Enabled)
+		require.Equal(t, service.UnsubscribeToken(userUUID, domain.NotificationCategoryProduct), token)
+		require.True(t, strings.HasPrefix(token, userUUID.String()+".PRODUCT."))
+	})
+
+	t.Run("GetByEmail security has no token", func(t *testing.T) {
+		mockUow, mockPreferenceRepo := newUow(&domain.User{Base: domain.Base{ID: 10, UUID: userUUID}})
+		mockPreferenceRepo.On("ListByUserID", uint64(10)).Return([]domain.NotificationPreference{}, nil)
+
+		preference, token, err := preferenceservice.New(conf).GetByEmail(
+			mockUow,
+			"john.doe@example.com",
+			domain.NotificationCategorySecurity,
+		)
+
+		require.NoError(t, err)
+		require.True(t, preference.Enabled)
+		require.Empty(t, token)
+	})
+
+	t.Run("GetByEmail unknown user", func(t *testing.T) {
+		mockUow, _ := newUow(nil)
+
+		_, _, err := preferenceservice.New(conf).GetByEmail(mockUow, "john.doe@example.com", domain.NotificationCategoryProduct)
+
+		require.Equal(t, serviceerror.New(serviceerror.RecordNotFound), err)
+	})
+}
+
+func TestService_Unsubscribe(t *testing.T) {
+	userUUID := uuid.New()
+	service := preferenceservice.New(conf)
+
+	t.Run("Unsubscribe success", func(t *testing.T) {
+		mockUserRepo := new(userrepository.MockUserRepository)
+		mockPreferenceRepo := new(userrepository.MockNotificationPreferenceRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserRepository").Return(mockUserRepo)
+		mockUow.On("NotificationPreferenceRepository").Return(mockPreferenceRepo)
+
+		mockUserRepo.On("GetByUUID", userUUID).Return(&domain.User{Base: domain.Base{ID: 10, UUID: userUUID}}, nil)
+		mockPreferenceRepo.On("Upsert", domain.NotificationPreference{
+			UserID:   10,
+			Category: domain.NotificationCategoryProduct,
+			Enabled:  false,
+		}).Return(nil)
+
+		preference, err := service.Unsubscribe(mockUow, service.UnsubscribeToken(userUUID, domain.NotificationCategoryProduct))
+
+		require.NoError(t, err)
+		require.Equal(t, domain.NotificationCategoryProduct, preference.Category)
+		mockPreferenceRepo.AssertExpectations(t)
+	})
+
+	invalidTokens := map[string]string{
+		"malformed":      "token",
+		"tampered":       strings.Replace(service.UnsubscribeToken(userUUID, domain.NotificationCategoryProduct), "PRODUCT", "LEARNING_REMINDER", 1),
+		"other secret":   preferenceservice.New(config.Unsubscribe{Secret: "other"}).UnsubscribeToken(userUUID, domain.NotificationCategoryProduct),
+		"essential":      service.UnsubscribeToken(userUUID, domain.NotificationCategorySecurity),
+		"unknown target": service.UnsubscribeToken(userUUID, "NEWSLETTER"),
+	}
+	for name, token := range invalidTokens {
+		t.Run("Unsubscribe "+name, func(t *testing.T) {
+			mockUow := new(userrepository.MockUnitOfWork)
+
+			_, err := service.Unsubscribe(mockUow, token)
+
+			require.Equal(t, serviceerror.New(serviceerror.InvalidUnsubscribeLink), err)
+		})
+	}
+}
+
+func TestService_VerifyUnsubscribe(t *testing.T) {
+	userUUID := uuid.New()
+	service := preferenceservice.New(conf)
+
+	category, err := service.VerifyUnsubscribe(service.UnsubscribeToken(userUUID, domain.NotificationCategoryProduct))
+	require.NoError(t, err)
+	require.Equal(t, domain.NotificationCategoryProduct, category)
+
+	_, err = service.VerifyUnsubscribe(service.UnsubscribeToken(userUUID, domain.NotificationCategorySecurity))
+	require.Equal(t, serviceerror.New(serviceerror.InvalidUnsubscribeLink), err)
+
+	_, err = service.VerifyUnsubscribe("token")
+	require.Equal(t, serviceerror.New(serviceerror.InvalidUnsubscribeLink), err)
+}
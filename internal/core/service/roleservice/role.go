@@ -11,11 +11,13 @@ import (
 
 type Service struct {
 	roleCache port.RoleCacheService
+	aclCache  port.ACLCacheService
 }
 
-func New(roleCache port.RoleCacheService) *Service {
+func New(roleCache port.RoleCacheService, aclCache port.ACLCacheService) *Service {
 	return &Service{
 		roleCache: roleCache,
+		aclCache:  aclCache,
 	}
 }
 
@@ -23,14 +25,30 @@ func (r *Service) SetRoleCache(service port.RoleCacheService) {
 	r.roleCache = service
 }
 
-func (r *Service) Create(uow port.AuthUnitOfWork, role domain.Role) error {
+func (r *Service) Create(uow port.AuthUnitOfWork, role domain.Role, actor domain.AuditActor) error {
 	role.SetKey(role.Title)
 
 	if exists, err := uow.RoleRepository().ExistKey(role.Key); err != nil || exists {
 		return serviceerror.New(serviceerror.RoleExisted)
 	}
 
-	return uow.RoleRepository().Create(role)
+	if err := r.resolveParent(uow, &role, nil); err != nil {
+		return err
+	}
+
+	created, err := uow.RoleRepository().Create(role)
+	if err != nil {
+		return err
+	}
+
+	return uow.AuditLogRepository().Create(domain.NewAuditLog(
+		actor,
+		domain.AuditActionRoleCreated,
+		domain.AuditTargetRole,
+		&created.Base.UUID,
+		nil,
+		created.AuditFields(),
+	))
 }
 
 func (r *Service) Get(uow port.AuthUnitOfWork, uuidStr string) (*domain.Role, error) {
@@ -58,8 +76,13 @@ func (r *Service) List(ctx context.Context, uow port.AuthUnitOfWork) ([]*domain.
 	return roles, err
 }
 
-func (r *Service) Update(ctx context.Context, uow port.AuthUnitOfWork, role domain.Role, uuidStr string) error {
-
+func (r *Service) Update(
+	ctx context.Context,
+	uow port.AuthUnitOfWork,
+	role domain.Role,
+	uuidStr string,
+	actor domain.AuditActor,
+) error {
 	if cachedRoleKey, err := r.roleCache.Get(ctx, uuidStr); err != nil {
 		return err
 	} else {
@@ -74,18 +97,101 @@ func (r *Service) Update(ctx context.Context, uow port.AuthUnitOfWork, role doma
 		return serviceerror.New(serviceerror.RoleExisted)
 	}
 
-	return uow.RoleRepository().Update(role, uuid.MustParse(uuidStr))
+	roleUUID := uuid.MustParse(uuidStr)
+	stored, err := uow.RoleRepository().GetByUUID(roleUUID)
+	if err != nil {
+		return err
+	}
+
+	if err = r.resolveParent(uow, &role, stored); err != nil {
+		return err
+	}
+
+	if err = uow.RoleRepository().Update(role, roleUUID); err != nil {
+		return err
+	}
+
+	if err = uow.AuditLogRepository().Create(domain.NewAuditLog(
+		actor,
+		domain.AuditActionRoleUpdated,
+		domain.AuditTargetRole,
+		&roleUUID,
+		stored.AuditFields(),
+		role.AuditFields(),
+	)); err != nil {
+		return err
+	}
+
+	r.flushAccessAfterCommit(ctx, uow)
+
+	return nil
 }
 
-func (r *Service) Delete(uow port.AuthUnitOfWork, uuidStr string, deletedBy uint64) error {
-	return uow.RoleRepository().Delete(uuid.MustParse(uuidStr), deletedBy)
+func (r *Service) Delete(
+	ctx context.Context,
+	uow port.AuthUnitOfWork,
+	uuidStr string,
+	deletedBy uint64,
+	actor domain.AuditActor,
+) error {
+	roleUUID := uuid.MustParse(uuidStr)
+
+	stored, err := uow.RoleRepository().GetByUUID(roleUUID)
+	if err != nil {
+		return err
+	}
+
+	if err = uow.RoleRepository().Delete(roleUUID, deletedBy); err != nil {
+		return err
+	}
+
+	if err = uow.AuditLogRepository().Create(
+		domain.NewAuditLog(actor, domain.AuditActionRoleDeleted, domain.AuditTargetRole, &roleUUID, stored.AuditFields(), nil),
+	); err != nil {
+		return err
+	}
+
+	r.flushAccessAfterCommit(ctx, uow)
+
+	return nil
 }
 
+// GetPermissions returns the role with its effective permissions, the ones assigned to the role
+// itself followed by the ones inherited from its parents.
 func (r *Service) GetPermissions(uow port.AuthUnitOfWork, uuidStr string) (*domain.Role, error) {
-	return uow.RoleRepository().GetPermissions(uuid.MustParse(uuidStr))
+	role, sources, err := r.permissionSources(uow, uuid.MustParse(uuidStr))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(sources))
+	var permissions []*domain.Permission
+	for _, source := range sources {
+		if _, ok := seen[source.Permission.Base.UUID]; ok {
+			continue
+		}
+		seen[source.Permission.Base.UUID] = struct{}{}
+		permissions = append(permissions, source.Permission)
+	}
+
+	role.Permissions = permissions
+	return role, nil
 }
 
-func (r *Service) SyncPermissions(uow port.AuthUnitOfWork, uuidStr string, permissionUUIDsStr []string) error {
+// GetPermissionSources returns every permission reachable from the role along with the role granting it,
+// a permission granted at several levels of the hierarchy is listed once per level.
+func (r *Service) GetPermissionSources(uow port.AuthUnitOfWork, uuidStr string) ([]domain.PermissionSource, error) {
+	_, sources, err := r.permissionSources(uow, uuid.MustParse(uuidStr))
+	return sources, err
+}
+
+func (r *Service) SyncPermissions(
+	ctx context.Context,
+	uow port.AuthUnitOfWork,
+	uuidStr string,
+	permissionUUIDsStr []string,
+	actor domain.AuditActor,
+) error {
 	permissionUUIDs := make([]uuid.UUID, len(permissionUUIDsStr))
 	for index, permissionUUIDStr := range permissionUUIDsStr {
 		parsedUUID, err := uuid.Parse(permissionUUIDStr)
@@ -110,5 +216,139 @@ func (r *Service) SyncPermissions(uow port.AuthUnitOfWork, uuidStr string, permi
 		return err
 	}
 
-	return uow.RoleRepository().SyncPermissions(role.Base.ID, validPermissions)
+	before, err := uow.RoleRepository().GetPermissions(roleUUID)
+	if err != nil {
+		return err
+	}
+
+	if err = uow.RoleRepository().SyncPermissions(role.Base.ID, validPermissions); err != nil {
+		return err
+	}
+
+	after, err := uow.RoleRepository().GetPermissions(roleUUID)
+	if err != nil {
+		return err
+	}
+
+	if err = uow.AuditLogRepository().Create(domain.NewAuditLog(
+		actor,
+		domain.AuditActionRolePermissionsSynced,
+		domain.AuditTargetRole,
+		&roleUUID,
+		map[string]interface{}{"permissions": permissionKeys(before.Permissions)},
+		map[string]interface{}{"permissions": permissionKeys(after.Permissions)},
+	)); err != nil {
+		return err
+	}
+
+	r.flushAccessAfterCommit(ctx, uow)
+
+	return nil
+}
+
+// flushAccessAfterCommit drops the cached access of all users once the change is committed, flushing
+// earlier lets a concurrent CheckAccess cache the old grants again for a whole TTL.
+func (r *Service) flushAccessAfterCommit(ctx context.Context, uow port.AuthUnitOfWork) {
+	uow.AfterCommit(func() error {
+		return r.aclCache.Flush(ctx)
+	})
+}
+
+func permissionKeys(permissions []*domain.Permission) []domain.PermissionKeyType {
+	keys := make([]domain.PermissionKeyType, 0, len(permissions))
+	for _, permission := range permissions {
+		if permission.Key != nil {
+			keys = append(keys, *permission.Key)
+		}
+	}
+
+	return keys
+}
+
+func (r *Service) permissionSources(uow port.AuthUnitOfWork, roleUUID uuid.UUID) (*domain.Role, []domain.PermissionSource, error) {
+	role, err := uow.RoleRepository().GetPermissions(roleUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ancestors, err := uow.RoleRepository().GetAncestors(roleUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sources []domain.PermissionSource
+	for _, permission := range role.Permissions {
+		sources = append(sources, domain.PermissionSource{Permission: permission, Role: role})
+	}
+
+	for index, ancestor := range ancestors {
+		ancestorRole, err := uow.RoleRepository().GetPermissions(ancestor.Base.UUID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, permission := range ancestorRole.Permissions {
+			sources = append(sources, domain.PermissionSource{Permission: permission, Role: ancestor, Depth: index + 1})
+		}
+	}
+
+	return role, sources, nil
+}
+
+// resolveParent replaces the requested parent with the stored one and rejects parents that would
+// make the role its own ancestor or push the role or its descendants past domain.RoleMaxHierarchyDepth.
+// stored is nil for roles that are not created yet, they can not be part of a cycle and have no descendants.
+// A nil parent keeps the stored parent, a parent with uuid.Nil detaches the role from it.
+func (r *Service) resolveParent(uow port.AuthUnitOfWork, role *domain.Role, stored *domain.Role) error {
+	if role.Parent == nil {
+		if stored != nil {
+			role.ParentID = stored.ParentID
+			role.Parent = stored.Parent
+		} else {
+			role.ParentID = nil
+		}
+		return nil
+	}
+
+	if role.Parent.Base.UUID == uuid.Nil {
+		role.ParentID = nil
+		role.Parent = nil
+		return nil
+	}
+
+	if stored != nil && role.Parent.Base.UUID == stored.Base.UUID {
+		return serviceerror.New(serviceerror.RoleHierarchyCycle)
+	}
+
+	parent, err := uow.RoleRepository().GetByUUID(role.Parent.Base.UUID)
+	if err != nil {
+		return err
+	}
+
+	ancestors, err := uow.RoleRepository().GetAncestors(parent.Base.UUID)
+	if err != nil {
+		return err
+	}
+
+	for _, ancestor := range ancestors {
+		if stored != nil && ancestor.Base.UUID == stored.Base.UUID {
+			return serviceerror.New(serviceerror.RoleHierarchyCycle)
+		}
+	}
+
+	var height int
+	if stored != nil {
+		if height, err = uow.RoleRepository().GetSubtreeHeight(stored.Base.UUID); err != nil {
+			return err
+		}
+	}
+
+	if len(ancestors)+1+height >= domain.RoleMaxHierarchyDepth {
+		return serviceerror.New(serviceerror.RoleHierarchyTooDeep)
+	}
+
+	role.ParentID = &parent.Base.ID
+	role.Parent = parent
+
+	return nil
 }
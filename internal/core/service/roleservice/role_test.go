@@ -3,7 +3,9 @@ package roleservice_test
 import (
 	"context"
 	"github.com/go-faker/faker/v4"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/auditrepository"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/authrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/aclservice"
 	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/roleservice"
 	"github.com/mohsenabedy91/polyglot-sentences/pkg/helper"
 	"github.com/stretchr/testify/mock"
@@ -19,6 +21,8 @@ import (
 
 var wg sync.WaitGroup
 
+var actor = domain.AuditActor{IP: "127.0.0.1", UserAgent: "Go-http-client/1.1"}
+
 func TestRoleService_Create(t *testing.T) {
 	roleID := uuid.New()
 	role := domain.Role{
@@ -35,16 +39,103 @@ func TestRoleService_Create(t *testing.T) {
 		mockUow.On("RoleRepository").Return(mockRepo)
 
 		mockRepo.On("ExistKey", role.Key).Return(false, nil)
-		mockRepo.On("Create", role).Return(nil)
+		created := role
+		created.Base = domain.Base{ID: 9, UUID: uuid.New()}
+		mockRepo.On("Create", role).Return(&created, nil)
+
+		mockAuditRepo := new(auditrepository.MockAuditLogRepository)
+		mockUow.On("AuditLogRepository").Return(mockAuditRepo)
+		mockAuditRepo.On("Create", domain.NewAuditLog(
+			actor,
+			domain.AuditActionRoleCreated,
+			domain.AuditTargetRole,
+			&created.Base.UUID,
+			nil,
+			created.AuditFields(),
+		)).Return(nil)
+
+		service := roleservice.New(nil, nil)
+		err := service.Create(mockUow, role, actor)
+
+		require.NoError(t, err)
 
-		service := roleservice.New(nil)
-		err := service.Create(mockUow, role)
+		mockRepo.AssertExpectations(t)
+		mockAuditRepo.AssertExpectations(t)
+	})
+
+	t.Run("Create success with parent", func(t *testing.T) {
+		parentID := uuid.New()
+		parent := &domain.Role{Base: domain.Base{ID: 7, UUID: parentID}, Title: "Staff"}
+
+		roleWithParent := role
+		roleWithParent.Parent = &domain.Role{Base: domain.Base{UUID: parentID}}
+
+		expectedRole := role
+		expectedRole.ParentID = &parent.Base.ID
+		expectedRole.Parent = parent
+
+		mockRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("RoleRepository").Return(mockRepo)
+
+		mockRepo.On("ExistKey", role.Key).Return(false, nil)
+		mockRepo.On("GetByUUID", parentID).Return(parent, nil)
+		mockRepo.On("GetAncestors", parentID).Return([]*domain.Role{}, nil)
+		mockRepo.On("Create", expectedRole).Return(&expectedRole, nil)
+
+		mockAuditRepo := new(auditrepository.MockAuditLogRepository)
+		mockUow.On("AuditLogRepository").Return(mockAuditRepo)
+		mockAuditRepo.On("Create", mock.Anything).Return(nil)
+
+		service := roleservice.New(nil, nil)
+		err := service.Create(mockUow, roleWithParent, actor)
 
 		require.NoError(t, err)
 
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Create repository error", func(t *testing.T) {
+		mockRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("RoleRepository").Return(mockRepo)
+
+		mockRepo.On("ExistKey", role.Key).Return(false, nil)
+		mockRepo.On("Create", role).Return((*domain.Role)(nil), serviceerror.NewServerError())
+
+		service := roleservice.New(nil, nil)
+		err := service.Create(mockUow, role, actor)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+		mockRepo.AssertExpectations(t)
+		mockUow.AssertNotCalled(t, "AuditLogRepository")
+	})
+
+	t.Run("Create parent not found error", func(t *testing.T) {
+		parentID := uuid.New()
+
+		roleWithParent := role
+		roleWithParent.Parent = &domain.Role{Base: domain.Base{UUID: parentID}}
+
+		mockRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("RoleRepository").Return(mockRepo)
+
+		var nilRole *domain.Role
+		mockRepo.On("ExistKey", role.Key).Return(false, nil)
+		mockRepo.On("GetByUUID", parentID).Return(nilRole, serviceerror.New(serviceerror.RecordNotFound))
+
+		service := roleservice.New(nil, nil)
+		err := service.Create(mockUow, roleWithParent, actor)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.RecordNotFound, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("Create role exists error", func(t *testing.T) {
 		mockRepo := new(authrepository.MockRoleRepository)
 		mockUow := new(authrepository.MockUnitOfWork)
@@ -52,8 +143,8 @@ func TestRoleService_Create(t *testing.T) {
 
 		mockRepo.On("ExistKey", role.Key).Return(true, nil)
 
-		service := roleservice.New(nil)
-		err := service.Create(mockUow, role)
+		service := roleservice.New(nil, nil)
+		err := service.Create(mockUow, role, actor)
 
 		require.Error(t, err)
 		require.Equal(t, serviceerror.RoleExisted, err.(*serviceerror.ServiceError).GetErrorMessage())
@@ -79,7 +170,7 @@ func TestRoleService_Get(t *testing.T) {
 
 		mockRepo.On("GetByUUID", roleID).Return(role, nil)
 
-		service := roleservice.New(nil)
+		service := roleservice.New(nil, nil)
 		result, err := service.Get(mockUow, roleID.String())
 
 		require.NoError(t, err)
@@ -95,7 +186,7 @@ func TestRoleService_Get(t *testing.T) {
 
 		mockRepo.On("GetByUUID", roleID).Return(&domain.Role{}, serviceerror.New(serviceerror.RecordNotFound))
 
-		service := roleservice.New(nil)
+		service := roleservice.New(nil, nil)
 		result, err := service.Get(mockUow, roleID.String())
 
 		require.Error(t, err)
@@ -149,7 +240,7 @@ func TestRoleService_List(t *testing.T) {
 			}).
 			Return(nil)
 
-		service := roleservice.New(mockRoleCacheService)
+		service := roleservice.New(mockRoleCacheService, nil)
 		service.SetRoleCache(mockRoleCacheService)
 
 		result, err := service.List(ctx, mockUow)
@@ -170,7 +261,7 @@ func TestRoleService_List(t *testing.T) {
 
 		mockRepo.On("List").Return([]*domain.Role{}, serviceerror.NewServerError())
 
-		service := roleservice.New(nil)
+		service := roleservice.New(nil, nil)
 		result, err := service.List(ctx, mockUow)
 
 		require.Error(t, err)
@@ -197,7 +288,7 @@ func TestRoleService_List(t *testing.T) {
 			}).
 			Return(serviceerror.NewServerError())
 
-		service := roleservice.New(mockRoleCacheService)
+		service := roleservice.New(mockRoleCacheService, nil)
 		service.SetRoleCache(mockRoleCacheService)
 		result, err := service.List(ctx, mockUow)
 
@@ -230,18 +321,41 @@ func TestRoleService_Update(t *testing.T) {
 
 		mockRepo.On("ExistKey", role.Key).Return(false, nil)
 		mockRepo.On("Update", role, roleID).Return(nil)
+		mockRepo.On("GetByUUID", roleID).Return(&domain.Role{Base: domain.Base{UUID: roleID}, Title: "Old", Key: "OLD"}, nil)
+
+		mockAuditRepo := new(auditrepository.MockAuditLogRepository)
+		mockUow.On("AuditLogRepository").Return(mockAuditRepo)
+		mockAuditRepo.On("Create", domain.NewAuditLog(
+			actor,
+			domain.AuditActionRoleUpdated,
+			domain.AuditTargetRole,
+			&roleID,
+			map[string]interface{}{"title": "Old", "key": domain.RoleKeyType("OLD"), "description": ""},
+			role.AuditFields(),
+		)).Return(nil)
 
 		mockRoleCacheService := new(roleservice.MockRoleCacheService)
 		var roleKey = &role.Key
 		mockRoleCacheService.On("Get", ctx, roleID.String()).Return(roleKey, nil)
 
-		service := roleservice.New(mockRoleCacheService)
-		err := service.Update(ctx, mockUow, role, roleID.String())
+		var afterCommit func() error
+		mockUow.On("AfterCommit", mock.Anything).Run(func(args mock.Arguments) {
+			afterCommit = args.Get(0).(func() error)
+		}).Return()
+
+		mockACLCacheService := new(aclservice.MockACLCacheService)
+		mockACLCacheService.On("Flush", ctx).Return(nil)
+
+		service := roleservice.New(mockRoleCacheService, mockACLCacheService)
+		err := service.Update(ctx, mockUow, role, roleID.String(), actor)
 
 		require.NoError(t, err)
+		mockACLCacheService.AssertNotCalled(t, "Flush", ctx)
+		require.NoError(t, afterCommit())
 
 		mockRepo.AssertExpectations(t)
 		mockRoleCacheService.AssertExpectations(t)
+		mockACLCacheService.AssertExpectations(t)
 	})
 
 	t.Run("Update success cache return nil", func(t *testing.T) {
@@ -251,18 +365,264 @@ func TestRoleService_Update(t *testing.T) {
 
 		mockRepo.On("ExistKey", role.Key).Return(false, nil)
 		mockRepo.On("Update", role, roleID).Return(nil)
+		mockRepo.On("GetByUUID", roleID).Return(&domain.Role{Base: domain.Base{UUID: roleID}, Title: "Old", Key: "OLD"}, nil)
+
+		mockAuditRepo := new(auditrepository.MockAuditLogRepository)
+		mockUow.On("AuditLogRepository").Return(mockAuditRepo)
+		mockAuditRepo.On("Create", mock.Anything).Return(nil)
 
 		mockRoleCacheService := new(roleservice.MockRoleCacheService)
 		var roleKey *domain.RoleKeyType
 		mockRoleCacheService.On("Get", ctx, roleID.String()).Return(roleKey, nil)
 
-		service := roleservice.New(mockRoleCacheService)
-		err := service.Update(ctx, mockUow, role, roleID.String())
+		var afterCommit func() error
+		mockUow.On("AfterCommit", mock.Anything).Run(func(args mock.Arguments) {
+			afterCommit = args.Get(0).(func() error)
+		}).Return()
+
+		mockACLCacheService := new(aclservice.MockACLCacheService)
+		mockACLCacheService.On("Flush", ctx).Return(nil)
+
+		service := roleservice.New(mockRoleCacheService, mockACLCacheService)
+		err := service.Update(ctx, mockUow, role, roleID.String(), actor)
+
+		require.NoError(t, err)
+		mockACLCacheService.AssertNotCalled(t, "Flush", ctx)
+		require.NoError(t, afterCommit())
+
+		mockRepo.AssertExpectations(t)
+		mockRoleCacheService.AssertExpectations(t)
+		mockACLCacheService.AssertExpectations(t)
+	})
+
+	t.Run("Update success with parent", func(t *testing.T) {
+		parentID := uuid.New()
+		parent := &domain.Role{Base: domain.Base{ID: 3, UUID: parentID}, Title: "Manager"}
+
+		roleWithParent := role
+		roleWithParent.Parent = &domain.Role{Base: domain.Base{UUID: parentID}}
+
+		expectedRole := role
+		expectedRole.ParentID = &parent.Base.ID
+		expectedRole.Parent = parent
+
+		mockRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("RoleRepository").Return(mockRepo)
+
+		mockRepo.On("ExistKey", role.Key).Return(false, nil)
+		mockRepo.On("GetByUUID", parentID).Return(parent, nil)
+		mockRepo.On("GetAncestors", parentID).Return([]*domain.Role{{Base: domain.Base{UUID: uuid.New()}}}, nil)
+		mockRepo.On("GetSubtreeHeight", roleID).Return(2, nil)
+		mockRepo.On("Update", expectedRole, roleID).Return(nil)
+		mockRepo.On("GetByUUID", roleID).Return(&domain.Role{Base: domain.Base{UUID: roleID}, Title: "Old", Key: "OLD"}, nil)
+
+		mockAuditRepo := new(auditrepository.MockAuditLogRepository)
+		mockUow.On("AuditLogRepository").Return(mockAuditRepo)
+		mockAuditRepo.On("Create", mock.Anything).Return(nil)
+
+		mockRoleCacheService := new(roleservice.MockRoleCacheService)
+		mockRoleCacheService.On("Get", ctx, roleID.String()).Return(&role.Key, nil)
+
+		var afterCommit func() error
+		mockUow.On("AfterCommit", mock.Anything).Run(func(args mock.Arguments) {
+			afterCommit = args.Get(0).(func() error)
+		}).Return()
+
+		mockACLCacheService := new(aclservice.MockACLCacheService)
+		mockACLCacheService.On("Flush", ctx).Return(nil)
+
+		service := roleservice.New(mockRoleCacheService, mockACLCacheService)
+		err := service.Update(ctx, mockUow, roleWithParent, roleID.String(), actor)
 
 		require.NoError(t, err)
+		mockACLCacheService.AssertNotCalled(t, "Flush", ctx)
+		require.NoError(t, afterCommit())
 
 		mockRepo.AssertExpectations(t)
 		mockRoleCacheService.AssertExpectations(t)
+		mockACLCacheService.AssertExpectations(t)
+	})
+
+	t.Run("Update failed parent is itself", func(t *testing.T) {
+		roleWithParent := role
+		roleWithParent.Parent = &domain.Role{Base: domain.Base{UUID: roleID}}
+
+		mockRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("RoleRepository").Return(mockRepo)
+
+		mockRepo.On("ExistKey", role.Key).Return(false, nil)
+		mockRepo.On("GetByUUID", roleID).Return(&domain.Role{Base: domain.Base{UUID: roleID}, Title: "Old", Key: "OLD"}, nil)
+
+		mockRoleCacheService := new(roleservice.MockRoleCacheService)
+		mockRoleCacheService.On("Get", ctx, roleID.String()).Return(&role.Key, nil)
+
+		service := roleservice.New(mockRoleCacheService, nil)
+		err := service.Update(ctx, mockUow, roleWithParent, roleID.String(), actor)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.RoleHierarchyCycle, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Update failed parent inherits the role", func(t *testing.T) {
+		parentID := uuid.New()
+		parent := &domain.Role{Base: domain.Base{ID: 3, UUID: parentID}}
+
+		roleWithParent := role
+		roleWithParent.Parent = &domain.Role{Base: domain.Base{UUID: parentID}}
+
+		mockRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("RoleRepository").Return(mockRepo)
+
+		mockRepo.On("ExistKey", role.Key).Return(false, nil)
+		mockRepo.On("GetByUUID", roleID).Return(&domain.Role{Base: domain.Base{UUID: roleID}, Title: "Old", Key: "OLD"}, nil)
+		mockRepo.On("GetByUUID", parentID).Return(parent, nil)
+		mockRepo.On("GetAncestors", parentID).Return([]*domain.Role{{Base: domain.Base{UUID: uuid.New()}}, {Base: domain.Base{UUID: roleID}}}, nil)
+
+		mockRoleCacheService := new(roleservice.MockRoleCacheService)
+		mockRoleCacheService.On("Get", ctx, roleID.String()).Return(&role.Key, nil)
+
+		service := roleservice.New(mockRoleCacheService, nil)
+		err := service.Update(ctx, mockUow, roleWithParent, roleID.String(), actor)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.RoleHierarchyCycle, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Update failed hierarchy too deep", func(t *testing.T) {
+		parentID := uuid.New()
+		parent := &domain.Role{Base: domain.Base{ID: 3, UUID: parentID}}
+
+		roleWithParent := role
+		roleWithParent.Parent = &domain.Role{Base: domain.Base{UUID: parentID}}
+
+		ancestors := make([]*domain.Role, domain.RoleMaxHierarchyDepth-1)
+		for index := range ancestors {
+			ancestors[index] = &domain.Role{Base: domain.Base{UUID: uuid.New()}}
+		}
+
+		mockRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("RoleRepository").Return(mockRepo)
+
+		mockRepo.On("ExistKey", role.Key).Return(false, nil)
+		mockRepo.On("GetByUUID", roleID).Return(&domain.Role{Base: domain.Base{UUID: roleID}, Title: "Old", Key: "OLD"}, nil)
+		mockRepo.On("GetByUUID", parentID).Return(parent, nil)
+		mockRepo.On("GetAncestors", parentID).Return(ancestors, nil)
+		mockRepo.On("GetSubtreeHeight", roleID).Return(0, nil)
+
+		mockRoleCacheService := new(roleservice.MockRoleCacheService)
+		mockRoleCacheService.On("Get", ctx, roleID.String()).Return(&role.Key, nil)
+
+		service := roleservice.New(mockRoleCacheService, nil)
+		err := service.Update(ctx, mockUow, roleWithParent, roleID.String(), actor)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.RoleHierarchyTooDeep, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Update success keeps the stored parent", func(t *testing.T) {
+		var parentID uint64 = 3
+		storedParent := &domain.Role{Base: domain.Base{UUID: uuid.New()}, Title: "Manager"}
+
+		expectedRole := role
+		expectedRole.ParentID = &parentID
+		expectedRole.Parent = storedParent
+
+		mockRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("RoleRepository").Return(mockRepo)
+
+		mockRepo.On("ExistKey", role.Key).Return(false, nil)
+		mockRepo.On("GetByUUID", roleID).Return(&domain.Role{Base: domain.Base{UUID: roleID}, Title: "Old", Key: "OLD", ParentID: &parentID, Parent: storedParent}, nil)
+		mockRepo.On("Update", expectedRole, roleID).Return(nil)
+
+		mockAuditRepo := new(auditrepository.MockAuditLogRepository)
+		mockUow.On("AuditLogRepository").Return(mockAuditRepo)
+		mockAuditRepo.On("Create", mock.Anything).Return(nil)
+
+		mockRoleCacheService := new(roleservice.MockRoleCacheService)
+		mockRoleCacheService.On("Get", ctx, roleID.String()).Return(&role.Key, nil)
+
+		mockUow.On("AfterCommit", mock.Anything).Return()
+
+		service := roleservice.New(mockRoleCacheService, nil)
+		err := service.Update(ctx, mockUow, role, roleID.String(), actor)
+
+		require.NoError(t, err)
+
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "GetAncestors", mock.Anything)
+	})
+
+	t.Run("Update success detaches the parent", func(t *testing.T) {
+		var parentID uint64 = 3
+
+		roleWithoutParent := role
+		roleWithoutParent.Parent = &domain.Role{}
+
+		mockRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("RoleRepository").Return(mockRepo)
+
+		mockRepo.On("ExistKey", role.Key).Return(false, nil)
+		mockRepo.On("GetByUUID", roleID).Return(&domain.Role{Base: domain.Base{UUID: roleID}, Title: "Old", Key: "OLD", ParentID: &parentID}, nil)
+		mockRepo.On("Update", role, roleID).Return(nil)
+
+		mockAuditRepo := new(auditrepository.MockAuditLogRepository)
+		mockUow.On("AuditLogRepository").Return(mockAuditRepo)
+		mockAuditRepo.On("Create", mock.Anything).Return(nil)
+
+		mockRoleCacheService := new(roleservice.MockRoleCacheService)
+		mockRoleCacheService.On("Get", ctx, roleID.String()).Return(&role.Key, nil)
+
+		mockUow.On("AfterCommit", mock.Anything).Return()
+
+		service := roleservice.New(mockRoleCacheService, nil)
+		err := service.Update(ctx, mockUow, roleWithoutParent, roleID.String(), actor)
+
+		require.NoError(t, err)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Update failed subtree too deep", func(t *testing.T) {
+		parentID := uuid.New()
+		parent := &domain.Role{Base: domain.Base{ID: 3, UUID: parentID}}
+
+		roleWithParent := role
+		roleWithParent.Parent = &domain.Role{Base: domain.Base{UUID: parentID}}
+
+		mockRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("RoleRepository").Return(mockRepo)
+
+		mockRepo.On("ExistKey", role.Key).Return(false, nil)
+		mockRepo.On("GetByUUID", roleID).Return(&domain.Role{Base: domain.Base{UUID: roleID}, Title: "Old", Key: "OLD"}, nil)
+		mockRepo.On("GetByUUID", parentID).Return(parent, nil)
+		mockRepo.On("GetAncestors", parentID).Return([]*domain.Role{{Base: domain.Base{UUID: uuid.New()}}}, nil)
+		mockRepo.On("GetSubtreeHeight", roleID).Return(domain.RoleMaxHierarchyDepth-2, nil)
+
+		mockRoleCacheService := new(roleservice.MockRoleCacheService)
+		mockRoleCacheService.On("Get", ctx, roleID.String()).Return(&role.Key, nil)
+
+		service := roleservice.New(mockRoleCacheService, nil)
+		err := service.Update(ctx, mockUow, roleWithParent, roleID.String(), actor)
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.RoleHierarchyTooDeep, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 	})
 
 	t.Run("Update failed key exist", func(t *testing.T) {
@@ -276,8 +636,8 @@ func TestRoleService_Update(t *testing.T) {
 		var roleKey *domain.RoleKeyType
 		mockRoleCacheService.On("Get", ctx, roleID.String()).Return(roleKey, nil)
 
-		service := roleservice.New(mockRoleCacheService)
-		err := service.Update(ctx, mockUow, role, roleID.String())
+		service := roleservice.New(mockRoleCacheService, nil)
+		err := service.Update(ctx, mockUow, role, roleID.String(), actor)
 
 		require.Error(t, err)
 		require.Equal(t, serviceerror.RoleExisted, err.(*serviceerror.ServiceError).GetErrorMessage())
@@ -295,8 +655,8 @@ func TestRoleService_Update(t *testing.T) {
 		var roleKey *domain.RoleKeyType
 		mockRoleCacheService.On("Get", ctx, roleID.String()).Return(roleKey, serviceerror.New(serviceerror.RecordNotFound))
 
-		service := roleservice.New(mockRoleCacheService)
-		err := service.Update(ctx, mockUow, role, roleID.String())
+		service := roleservice.New(mockRoleCacheService, nil)
+		err := service.Update(ctx, mockUow, role, roleID.String(), actor)
 
 		require.Error(t, err)
 		require.Equal(t, serviceerror.RecordNotFound, err.(*serviceerror.ServiceError).GetErrorMessage())
@@ -308,19 +668,72 @@ func TestRoleService_Update(t *testing.T) {
 func TestRoleService_Delete(t *testing.T) {
 	roleID := uuid.New()
 
+	ctx := context.TODO()
+
 	t.Run("Delete success", func(t *testing.T) {
 		mockRepo := new(authrepository.MockRoleRepository)
 		mockUow := new(authrepository.MockUnitOfWork)
 		mockUow.On("RoleRepository").Return(mockRepo)
 
+		mockRepo.On("GetByUUID", roleID).Return(&domain.Role{Base: domain.Base{UUID: roleID}, Title: "Old", Key: "OLD"}, nil)
+
+		mockAuditRepo := new(auditrepository.MockAuditLogRepository)
+		mockUow.On("AuditLogRepository").Return(mockAuditRepo)
+		mockAuditRepo.On("Create", mock.Anything).Return(nil)
+
 		mockRepo.On("Delete", roleID, uint64(1)).Return(nil)
 
-		service := roleservice.New(nil)
-		err := service.Delete(mockUow, roleID.String(), uint64(1))
+		var afterCommit func() error
+		mockUow.On("AfterCommit", mock.Anything).Run(func(args mock.Arguments) {
+			afterCommit = args.Get(0).(func() error)
+		}).Return()
+
+		mockACLCacheService := new(aclservice.MockACLCacheService)
+		mockACLCacheService.On("Flush", ctx).Return(nil)
+
+		service := roleservice.New(nil, mockACLCacheService)
+		err := service.Delete(ctx, mockUow, roleID.String(), uint64(1), actor)
 
 		require.NoError(t, err)
+		mockACLCacheService.AssertNotCalled(t, "Flush", ctx)
+		require.NoError(t, afterCommit())
 
 		mockRepo.AssertExpectations(t)
+		mockACLCacheService.AssertExpectations(t)
+	})
+
+	t.Run("Delete cache flush error after commit", func(t *testing.T) {
+		mockRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("RoleRepository").Return(mockRepo)
+
+		mockRepo.On("GetByUUID", roleID).Return(&domain.Role{Base: domain.Base{UUID: roleID}, Title: "Old", Key: "OLD"}, nil)
+
+		mockAuditRepo := new(auditrepository.MockAuditLogRepository)
+		mockUow.On("AuditLogRepository").Return(mockAuditRepo)
+		mockAuditRepo.On("Create", mock.Anything).Return(nil)
+
+		mockRepo.On("Delete", roleID, uint64(1)).Return(nil)
+
+		var afterCommit func() error
+		mockUow.On("AfterCommit", mock.Anything).Run(func(args mock.Arguments) {
+			afterCommit = args.Get(0).(func() error)
+		}).Return()
+
+		mockACLCacheService := new(aclservice.MockACLCacheService)
+		mockACLCacheService.On("Flush", ctx).Return(serviceerror.NewServerError())
+
+		service := roleservice.New(nil, mockACLCacheService)
+		err := service.Delete(ctx, mockUow, roleID.String(), uint64(1), actor)
+
+		require.NoError(t, err)
+
+		err = afterCommit()
+		require.Error(t, err)
+		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+		mockRepo.AssertExpectations(t)
+		mockACLCacheService.AssertExpectations(t)
 	})
 
 	t.Run("Delete error", func(t *testing.T) {
@@ -328,10 +741,12 @@ func TestRoleService_Delete(t *testing.T) {
 		mockUow := new(authrepository.MockUnitOfWork)
 		mockUow.On("RoleRepository").Return(mockRepo)
 
+		mockRepo.On("GetByUUID", roleID).Return(&domain.Role{Base: domain.Base{UUID: roleID}, Title: "Old", Key: "OLD"}, nil)
+
 		mockRepo.On("Delete", roleID, uint64(1)).Return(serviceerror.New(serviceerror.IsNotDeletable))
 
-		service := roleservice.New(nil)
-		err := service.Delete(mockUow, roleID.String(), uint64(1))
+		service := roleservice.New(nil, nil)
+		err := service.Delete(ctx, mockUow, roleID.String(), uint64(1), actor)
 
 		require.Error(t, err)
 		require.Equal(t, serviceerror.IsNotDeletable, err.(*serviceerror.ServiceError).GetErrorMessage())
@@ -342,12 +757,25 @@ func TestRoleService_Delete(t *testing.T) {
 
 func TestService_GetPermissions(t *testing.T) {
 	roleID := uuid.New()
+	parentID := uuid.New()
+
+	readUser := &domain.Permission{Base: domain.Base{UUID: uuid.New()}}
+	createUser := &domain.Permission{Base: domain.Base{UUID: uuid.New()}}
+
 	role := &domain.Role{
 		Base: domain.Base{
 			UUID: roleID,
 		},
-		Title: "Admin",
-		Key:   "ADMIN",
+		Title:       "Admin",
+		Key:         "ADMIN",
+		Permissions: []*domain.Permission{readUser},
+	}
+	parent := &domain.Role{
+		Base: domain.Base{
+			UUID: parentID,
+		},
+		Title: "Manager",
+		Key:   "MANAGER",
 	}
 
 	t.Run("GetPermissions success", func(t *testing.T) {
@@ -356,8 +784,9 @@ func TestService_GetPermissions(t *testing.T) {
 		mockUow.On("RoleRepository").Return(mockRepo)
 
 		mockRepo.On("GetPermissions", roleID).Return(role, nil)
+		mockRepo.On("GetAncestors", roleID).Return([]*domain.Role{}, nil)
 
-		service := roleservice.New(nil)
+		service := roleservice.New(nil, nil)
 		result, err := service.GetPermissions(mockUow, roleID.String())
 
 		require.NoError(t, err)
@@ -366,6 +795,31 @@ func TestService_GetPermissions(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("GetPermissions with inherited permissions", func(t *testing.T) {
+		mockRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("RoleRepository").Return(mockRepo)
+
+		mockRepo.On("GetPermissions", roleID).Return(&domain.Role{
+			Base:        role.Base,
+			Title:       role.Title,
+			Permissions: []*domain.Permission{readUser},
+		}, nil)
+		mockRepo.On("GetAncestors", roleID).Return([]*domain.Role{parent}, nil)
+		mockRepo.On("GetPermissions", parentID).Return(&domain.Role{
+			Base:        parent.Base,
+			Permissions: []*domain.Permission{readUser, createUser},
+		}, nil)
+
+		service := roleservice.New(nil, nil)
+		result, err := service.GetPermissions(mockUow, roleID.String())
+
+		require.NoError(t, err)
+		require.Equal(t, []*domain.Permission{readUser, createUser}, result.Permissions)
+
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("GetPermissions error", func(t *testing.T) {
 		mockRepo := new(authrepository.MockRoleRepository)
 		mockUow := new(authrepository.MockUnitOfWork)
@@ -373,12 +827,95 @@ func TestService_GetPermissions(t *testing.T) {
 
 		mockRepo.On("GetPermissions", roleID).Return(&domain.Role{}, serviceerror.New(serviceerror.RecordNotFound))
 
-		service := roleservice.New(nil)
+		service := roleservice.New(nil, nil)
 		result, err := service.GetPermissions(mockUow, roleID.String())
 
 		require.Error(t, err)
 		require.Equal(t, serviceerror.RecordNotFound, err.(*serviceerror.ServiceError).GetErrorMessage())
-		require.Equal(t, &domain.Role{}, result)
+		require.Nil(t, result)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("GetPermissions GetAncestors error", func(t *testing.T) {
+		mockRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("RoleRepository").Return(mockRepo)
+
+		mockRepo.On("GetPermissions", roleID).Return(role, nil)
+		mockRepo.On("GetAncestors", roleID).Return([]*domain.Role{}, serviceerror.NewServerError())
+
+		service := roleservice.New(nil, nil)
+		result, err := service.GetPermissions(mockUow, roleID.String())
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+		require.Nil(t, result)
+
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestService_GetPermissionSources(t *testing.T) {
+	roleID := uuid.New()
+	parentID := uuid.New()
+
+	readUser := &domain.Permission{Base: domain.Base{UUID: uuid.New()}}
+	createUser := &domain.Permission{Base: domain.Base{UUID: uuid.New()}}
+
+	role := &domain.Role{
+		Base:        domain.Base{UUID: roleID},
+		Title:       "Admin",
+		Permissions: []*domain.Permission{readUser},
+	}
+	parent := &domain.Role{
+		Base:  domain.Base{UUID: parentID},
+		Title: "Manager",
+	}
+
+	t.Run("GetPermissionSources success", func(t *testing.T) {
+		mockRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("RoleRepository").Return(mockRepo)
+
+		mockRepo.On("GetPermissions", roleID).Return(role, nil)
+		mockRepo.On("GetAncestors", roleID).Return([]*domain.Role{parent}, nil)
+		mockRepo.On("GetPermissions", parentID).Return(&domain.Role{
+			Base:        parent.Base,
+			Permissions: []*domain.Permission{readUser, createUser},
+		}, nil)
+
+		service := roleservice.New(nil, nil)
+		result, err := service.GetPermissionSources(mockUow, roleID.String())
+
+		require.NoError(t, err)
+		require.Equal(t, []domain.PermissionSource{
+			{Permission: readUser, Role: role, Depth: 0},
+			{Permission: readUser, Role: parent, Depth: 1},
+			{Permission: createUser, Role: parent, Depth: 1},
+		}, result)
+		require.False(t, result[0].IsInherited())
+		require.True(t, result[2].IsInherited())
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("GetPermissionSources ancestor permissions error", func(t *testing.T) {
+		mockRepo := new(authrepository.MockRoleRepository)
+		mockUow := new(authrepository.MockUnitOfWork)
+		mockUow.On("RoleRepository").Return(mockRepo)
+
+		var nilRole *domain.Role
+		mockRepo.On("GetPermissions", roleID).Return(role, nil)
+		mockRepo.On("GetAncestors", roleID).Return([]*domain.Role{parent}, nil)
+		mockRepo.On("GetPermissions", parentID).Return(nilRole, serviceerror.NewServerError())
+
+		service := roleservice.New(nil, nil)
+		result, err := service.GetPermissionSources(mockUow, roleID.String())
+
+		require.Error(t, err)
+		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+		require.Nil(t, result)
 
 		mockRepo.AssertExpectations(t)
 	})
@@ -405,6 +942,8 @@ func TestService_SyncPermissions(t *testing.T) {
 		})
 	}
 
+	ctx := context.TODO()
+
 	t.Run("SyncPermissions success", func(t *testing.T) {
 		mockRoleRepo := new(authrepository.MockRoleRepository)
 		mockPermissionRepo := new(authrepository.MockPermissionRepository)
@@ -424,14 +963,29 @@ func TestService_SyncPermissions(t *testing.T) {
 		mockRoleRepo.On("GetByUUID", roleID).Return(role, nil)
 		mockPermissionRepo.On("FilterValidPermissions", permissionUUIDs).Return(validPermissionIDs, nil)
 		mockRoleRepo.On("SyncPermissions", role.Base.ID, validPermissionIDs).Return(nil)
+		mockRoleRepo.On("GetPermissions", roleID).Return(role, nil)
+		mockAuditRepo := new(auditrepository.MockAuditLogRepository)
+		mockUow.On("AuditLogRepository").Return(mockAuditRepo)
+		mockAuditRepo.On("Create", mock.Anything).Return(nil)
+
+		var afterCommit func() error
+		mockUow.On("AfterCommit", mock.Anything).Run(func(args mock.Arguments) {
+			afterCommit = args.Get(0).(func() error)
+		}).Return()
+
+		mockACLCacheService := new(aclservice.MockACLCacheService)
+		mockACLCacheService.On("Flush", ctx).Return(nil)
 
-		service := roleservice.New(nil)
-		err := service.SyncPermissions(mockUow, roleID.String(), permissionUUIDStr)
+		service := roleservice.New(nil, mockACLCacheService)
+		err := service.SyncPermissions(ctx, mockUow, roleID.String(), permissionUUIDStr, actor)
 
 		require.NoError(t, err)
+		mockACLCacheService.AssertNotCalled(t, "Flush", ctx)
+		require.NoError(t, afterCommit())
 
 		mockRoleRepo.AssertExpectations(t)
 		mockPermissionRepo.AssertExpectations(t)
+		mockACLCacheService.AssertExpectations(t)
 	})
 
 	t.Run("SyncPermissions invalid permission UUID error", func(t *testing.T) {
@@ -439,8 +993,8 @@ func TestService_SyncPermissions(t *testing.T) {
 
 		permissionUUIDStr := []string{"invalid-uuid"}
 
-		service := roleservice.New(nil)
-		err := service.SyncPermissions(mockUow, roleID.String(), permissionUUIDStr)
+		service := roleservice.New(nil, nil)
+		err := service.SyncPermissions(ctx, mockUow, roleID.String(), permissionUUIDStr, actor)
 
 		require.Error(t, err)
 		require.Equal(t, serviceerror.InvalidRequestBody, err.(*serviceerror.ServiceError).GetErrorMessage())
@@ -462,8 +1016,8 @@ func TestService_SyncPermissions(t *testing.T) {
 
 		mockPermissionRepo.On("FilterValidPermissions", permissionUUIDs).Return([]uint64{}, serviceerror.NewServerError())
 
-		service := roleservice.New(nil)
-		err := service.SyncPermissions(mockUow, roleID.String(), permissionUUIDStr)
+		service := roleservice.New(nil, nil)
+		err := service.SyncPermissions(ctx, mockUow, roleID.String(), permissionUUIDStr, actor)
 
 		require.Error(t, err)
 		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
@@ -487,8 +1041,8 @@ func TestService_SyncPermissions(t *testing.T) {
 
 		mockPermissionRepo.On("FilterValidPermissions", permissionUUIDs).Return(validPermissionIDs, nil)
 
-		service := roleservice.New(nil)
-		err := service.SyncPermissions(mockUow, "invalid-uuid", permissionUUIDStr)
+		service := roleservice.New(nil, nil)
+		err := service.SyncPermissions(ctx, mockUow, "invalid-uuid", permissionUUIDStr, actor)
 
 		require.Error(t, err)
 		require.Equal(t, serviceerror.InvalidRequestBody, err.(*serviceerror.ServiceError).GetErrorMessage())
@@ -515,8 +1069,8 @@ func TestService_SyncPermissions(t *testing.T) {
 		mockPermissionRepo.On("FilterValidPermissions", permissionUUIDs).Return(validPermissionIDs, nil)
 		mockRoleRepo.On("GetByUUID", roleID).Return(&domain.Role{}, serviceerror.New(serviceerror.RecordNotFound))
 
-		service := roleservice.New(nil)
-		err := service.SyncPermissions(mockUow, roleID.String(), permissionUUIDStr)
+		service := roleservice.New(nil, nil)
+		err := service.SyncPermissions(ctx, mockUow, roleID.String(), permissionUUIDStr, actor)
 
 		require.Error(t, err)
 		require.Equal(t, serviceerror.RecordNotFound, err.(*serviceerror.ServiceError).GetErrorMessage())
@@ -542,11 +1096,12 @@ func TestService_SyncPermissions(t *testing.T) {
 		}
 
 		mockRoleRepo.On("GetByUUID", roleID).Return(role, nil)
+		mockRoleRepo.On("GetPermissions", roleID).Return(role, nil)
 		mockPermissionRepo.On("FilterValidPermissions", permissionUUIDs).Return(validPermissionIDs, nil)
 		mockRoleRepo.On("SyncPermissions", role.Base.ID, validPermissionIDs).Return(serviceerror.NewServerError())
 
-		service := roleservice.New(nil)
-		err := service.SyncPermissions(mockUow, roleID.String(), permissionUUIDStr)
+		service := roleservice.New(nil, nil)
+		err := service.SyncPermissions(ctx, mockUow, roleID.String(), permissionUUIDStr, actor)
 
 		require.Error(t, err)
 		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
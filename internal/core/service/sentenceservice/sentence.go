@@ -0,0 +1,48 @@
+package sentenceservice
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/aclservice"
+)
+
+type Service struct {
+}
+
+func New() *Service {
+	return &Service{}
+}
+
+// Update changes the text and the level of the sentence, a user holding only UPDATE_OWN_SENTENCE
+// may change the sentences it created.
+func (r *Service) Update(uow port.UserUnitOfWork, access domain.UserAccess, sentence domain.Sentence) error {
+	stored, err := uow.SentenceRepository().GetByUUID(sentence.Base.UUID)
+	if err != nil {
+		return err
+	}
+
+	if err = aclservice.UpdateSentencePolicy.Authorize(access, stored.Modifier); err != nil {
+		return err
+	}
+
+	stored.Text = sentence.Text
+	stored.Level = sentence.Level
+	stored.Modifier.UpdatedBy = access.UserID
+
+	return uow.SentenceRepository().Update(*stored)
+}
+
+// Delete removes the sentence, a user holding only DELETE_OWN_SENTENCE may remove the sentences it created.
+func (r *Service) Delete(uow port.UserUnitOfWork, access domain.UserAccess, sentenceUUID uuid.UUID) error {
+	stored, err := uow.SentenceRepository().GetByUUID(sentenceUUID)
+	if err != nil {
+		return err
+	}
+
+	if err = aclservice.DeleteSentencePolicy.Authorize(access, stored.Modifier); err != nil {
+		return err
+	}
+
+	return uow.SentenceRepository().Delete(stored.Base.ID, access.UserID)
+}
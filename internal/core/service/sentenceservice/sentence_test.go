@@ -0,0 +1,168 @@
+package sentenceservice_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/sentencerepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/userrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/sentenceservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var (
+	ownerID    uint64 = 10
+	nonOwnerID uint64 = 20
+	adminID    uint64 = 30
+)
+
+func storedSentence(sentenceUUID uuid.UUID) *domain.Sentence {
+	return &domain.Sentence{
+		Base:     domain.Base{ID: 7, UUID: sentenceUUID},
+		Modifier: domain.Modifier{CreatedBy: &ownerID},
+		Text:     "I have been living here for ten years.",
+		Grammar:  domain.Grammar{Base: domain.Base{ID: 3}},
+		Level:    domain.SentenceLevelEasy,
+		Status:   domain.StatusActive,
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	tests := []struct {
+		name    string
+		access  domain.UserAccess
+		allowed bool
+	}{
+		{
+			name: "owner",
+			access: domain.UserAccess{
+				UserID:         ownerID,
+				PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyUpdateOwnSentence},
+			},
+			allowed: true,
+		},
+		{
+			name: "non-owner",
+			access: domain.UserAccess{
+				UserID:         nonOwnerID,
+				PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyUpdateOwnSentence},
+			},
+			allowed: false,
+		},
+		{
+			name: "admin",
+			access: domain.UserAccess{
+				UserID:         adminID,
+				PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyUpdateSentence},
+			},
+			allowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSentenceRepo := new(sentencerepository.MockSentenceRepository)
+			mockUow := new(userrepository.MockUnitOfWork)
+			mockUow.On("SentenceRepository").Return(mockSentenceRepo)
+
+			sentenceUUID := uuid.New()
+			mockSentenceRepo.On("GetByUUID", sentenceUUID).Return(storedSentence(sentenceUUID), nil)
+
+			expected := *storedSentence(sentenceUUID)
+			expected.Text = "I have lived here for ten years."
+			expected.Level = domain.SentenceLevelNormal
+			expected.Modifier.UpdatedBy = tt.access.UserID
+			mockSentenceRepo.On("Update", expected).Return(nil)
+
+			err := sentenceservice.New().Update(mockUow, tt.access, domain.Sentence{
+				Base:  domain.Base{UUID: sentenceUUID},
+				Text:  "I have lived here for ten years.",
+				Level: domain.SentenceLevelNormal,
+			})
+
+			if tt.allowed {
+				require.NoError(t, err)
+				mockSentenceRepo.AssertExpectations(t)
+				return
+			}
+
+			require.Equal(t, serviceerror.New(serviceerror.PermissionDenied), err)
+			mockSentenceRepo.AssertNotCalled(t, "Update", mock.Anything)
+		})
+	}
+
+	t.Run("Update sentence not found", func(t *testing.T) {
+		mockSentenceRepo := new(sentencerepository.MockSentenceRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("SentenceRepository").Return(mockSentenceRepo)
+
+		sentenceUUID := uuid.New()
+		mockSentenceRepo.On("GetByUUID", sentenceUUID).Return(nil, serviceerror.New(serviceerror.RecordNotFound))
+
+		err := sentenceservice.New().Update(mockUow, domain.UserAccess{
+			UserID:         adminID,
+			PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyUpdateSentence},
+		}, domain.Sentence{Base: domain.Base{UUID: sentenceUUID}, Text: "text", Level: domain.SentenceLevelEasy})
+
+		require.Equal(t, serviceerror.New(serviceerror.RecordNotFound), err)
+		mockSentenceRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
+
+func TestService_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		access  domain.UserAccess
+		allowed bool
+	}{
+		{
+			name: "owner",
+			access: domain.UserAccess{
+				UserID:         ownerID,
+				PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyDeleteOwnSentence},
+			},
+			allowed: true,
+		},
+		{
+			name: "non-owner",
+			access: domain.UserAccess{
+				UserID:         nonOwnerID,
+				PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyDeleteOwnSentence},
+			},
+			allowed: false,
+		},
+		{
+			name: "admin",
+			access: domain.UserAccess{
+				UserID:         adminID,
+				PermissionKeys: []domain.PermissionKeyType{domain.PermissionKeyDeleteSentence},
+			},
+			allowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSentenceRepo := new(sentencerepository.MockSentenceRepository)
+			mockUow := new(userrepository.MockUnitOfWork)
+			mockUow.On("SentenceRepository").Return(mockSentenceRepo)
+
+			sentenceUUID := uuid.New()
+			mockSentenceRepo.On("GetByUUID", sentenceUUID).Return(storedSentence(sentenceUUID), nil)
+			mockSentenceRepo.On("Delete", uint64(7), tt.access.UserID).Return(nil)
+
+			err := sentenceservice.New().Delete(mockUow, tt.access, sentenceUUID)
+
+			if tt.allowed {
+				require.NoError(t, err)
+				mockSentenceRepo.AssertExpectations(t)
+				return
+			}
+
+			require.Equal(t, serviceerror.New(serviceerror.PermissionDenied), err)
+			mockSentenceRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package uploadservice
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"io"
+	"strings"
+	"time"
+)
+
+type Service struct {
+	conf    config.Upload
+	storage port.ObjectStorage
+}
+
+func New(conf config.Upload, storage port.ObjectStorage) *Service {
+	return &Service{
+		conf:    conf,
+		storage: storage,
+	}
+}
+
+// Create opens a pending upload session and returns the presigned link the client uploads the file to,
+// the link only accepts the declared content type and size and writes to the staging key of the session.
+func (r *Service) Create(
+	ctx context.Context,
+	uow port.UserUnitOfWork,
+	session domain.UploadSession,
+) (*domain.UploadSession, string, error) {
+	if !session.Purpose.Allows(session.ContentType) {
+		return nil, "", serviceerror.New(serviceerror.UploadContentTypeNotAllowed, map[string]interface{}{
+			"contentTypes": strings.Join(session.Purpose.ContentTypes(), ", "),
+		})
+	}
+	if maxSize := r.maxSize(session.Purpose); session.Size > maxSize {
+		return nil, "", serviceerror.New(serviceerror.UploadTooLarge, map[string]interface{}{
+			"maxSize": maxSize >> 20,
+		})
+	}
+
+	session.Base.UUID = uuid.New()
+	session.Key = domain.UploadStagingKey(session.Purpose, session.Base.UUID)
+	session.Checksum = strings.ToLower(session.Checksum)
+	session.Status = domain.UploadSessionStatusPending
+	session.ExpiresAt = time.Now().Add(r.conf.URLExpireSecond)
+
+	if err := uow.UploadSessionRepository().Create(session); err != nil {
+		return nil, "", err
+	}
+
+	link, err := r.storage.PresignPut(ctx, session.Key, r.conf.URLExpireSecond, session.ContentType, session.Size)
+	if err != nil {
+		return nil, "", serviceerror.NewServerError()
+	}
+
+	return &session, link, nil
+}
+
+// Complete copies the uploaded object to its final key, checks the copy against the declared size and checksum
+// and marks the session completed. The presigned link keeps accepting uploads until it expires, so only the copy
+// is verified and kept, and the staging object is removed once the session is stored.
+// A mismatching object is removed, so the client can upload it again while the link is valid.
+// Completing an already completed session returns it as it is.
+func (r *Service) Complete(
+	ctx context.Context,
+	uow port.UserUnitOfWork,
+	userID uint64,
+	sessionUUIDStr string,
+) (*domain.UploadSession, error) {
+	session, err := r.get(uow, sessionUUIDStr)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserID != userID {
+		return nil, serviceerror.New(serviceerror.RecordNotFound)
+	}
+
+	switch session.Status {
+	case domain.UploadSessionStatusCompleted:
+		return session, nil
+	case domain.UploadSessionStatusAbandoned:
+		return nil, serviceerror.New(serviceerror.UploadSessionExpired)
+	}
+
+	key := domain.UploadKey(session.Purpose, session.Base.UUID)
+	if err = r.storage.Copy(ctx, session.Key, key); err != nil {
+		if serviceErr, ok := err.(*serviceerror.ServiceError); ok && serviceErr.GetErrorMessage() == serviceerror.RecordNotFound {
+			if session.IsExpired(time.Now()) {
+				return nil, serviceerror.New(serviceerror.UploadSessionExpired)
+			}
+			return nil, serviceerror.New(serviceerror.UploadMissing)
+		}
+		return nil, serviceerror.NewServerError()
+	}
+
+	size, checksum, err := r.digest(ctx, key, session.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	if size != session.Size || checksum != session.Checksum {
+		if err = r.storage.Delete(ctx, key); err != nil {
+			return nil, serviceerror.NewServerError()
+		}
+		if err = r.storage.Delete(ctx, session.Key); err != nil {
+			return nil, serviceerror.NewServerError()
+		}
+		return nil, serviceerror.New(serviceerror.UploadChecksumMismatch)
+	}
+
+	if err = uow.UploadSessionRepository().MarkCompleted(session.Base.ID, key); err != nil {
+		return nil, err
+	}
+
+	stagingKey := session.Key
+	uow.AfterCommit(func() error {
+		return r.storage.Delete(ctx, stagingKey)
+	})
+
+	completedAt := time.Now()
+	session.Key = key
+	session.Status = domain.UploadSessionStatusCompleted
+	session.CompletedAt = &completedAt
+
+	return session, nil
+}
+
+// Abandon removes the staging object of a session once its link expired, whatever the status of the session,
+// since the link keeps accepting uploads after Complete removed it. A session that was not completed is marked
+// abandoned and a copy left behind by a failed Complete is removed as well. Not yet expired sessions are left as they are.
+func (r *Service) Abandon(ctx context.Context, uow port.UserUnitOfWork, sessionUUIDStr string) error {
+	session, err := r.get(uow, sessionUUIDStr)
+	if err != nil {
+		return err
+	}
+	if !session.IsExpired(time.Now()) {
+		return nil
+	}
+
+	keys := []string{domain.UploadStagingKey(session.Purpose, session.Base.UUID)}
+	if session.Status == domain.UploadSessionStatusPending {
+		keys = append(keys, domain.UploadKey(session.Purpose, session.Base.UUID))
+	}
+	for _, key := range keys {
+		if err = r.storage.Delete(ctx, key); err != nil {
+			return serviceerror.NewServerError()
+		}
+	}
+
+	if session.Status != domain.UploadSessionStatusPending {
+		return nil
+	}
+
+	return uow.UploadSessionRepository().MarkAbandoned(session.Base.ID)
+}
+
+func (r *Service) get(uow port.UserUnitOfWork, sessionUUIDStr string) (*domain.UploadSession, error) {
+	sessionUUID, err := uuid.Parse(sessionUUIDStr)
+	if err != nil {
+		return nil, serviceerror.New(serviceerror.RecordNotFound)
+	}
+
+	return uow.UploadSessionRepository().GetByUUID(sessionUUID)
+}
+
+// digest returns the size and the hex encoded SHA-256 of the stored object,
+// reading stops one byte after the declared size since a larger object never matches.
+func (r *Service) digest(ctx context.Context, key string, declaredSize int64) (int64, string, error) {
+	reader, err := r.storage.Get(ctx, key)
+	if err != nil {
+		return 0, "", serviceerror.NewServerError()
+	}
+	defer func(reader io.ReadCloser) {
+		_ = reader.Close()
+	}(reader)
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, io.LimitReader(reader, declaredSize+1))
+	if err != nil {
+		return 0, "", serviceerror.NewServerError()
+	}
+
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (r *Service) maxSize(purpose domain.UploadPurposeType) int64 {
+	if purpose == domain.UploadPurposeSentenceAudio {
+		return r.conf.AudioMaxSize
+	}
+
+	return r.conf.ImportMaxSize
+}
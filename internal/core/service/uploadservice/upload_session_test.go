@@ -0,0 +1,391 @@
+package uploadservice_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/localstorage"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/userrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/config"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/uploadservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/logger"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+var conf = config.Upload{
+	URLExpireSecond: 15 * time.Minute,
+	AudioMaxSize:    1 << 20,
+	ImportMaxSize:   2 << 20,
+}
+
+const content = "sentence,translation\nhello,bonjour\n"
+
+func checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func newStorage(t *testing.T) *localstorage.Storage {
+	mockLogger := new(logger.MockLogger)
+	mockLogger.On("Info", logger.Storage, logger.Startup, mock.Anything, mock.Anything).Return()
+
+	storage, err := localstorage.NewLocalStorage(mockLogger, config.Storage{
+		LocalPath:   t.TempDir(),
+		LocalURL:    "http://localhost:2535/storage",
+		LocalSecret: "Qm7Tz2Vw9KcR4nXb8LsHd1YpF6GeJ3Ua",
+	})
+	require.NoError(t, err)
+
+	return storage
+}
+
+func newUow(repo *userrepository.MockUploadSessionRepository) *userrepository.MockUnitOfWork {
+	mockUow := new(userrepository.MockUnitOfWork)
+	mockUow.On("UploadSessionRepository").Return(repo)
+
+	return mockUow
+}
+
+func pendingSession(expiresAt time.Time) *domain.UploadSession {
+	sessionUUID := uuid.New()
+	return &domain.UploadSession{
+		Base:        domain.Base{ID: 7, UUID: sessionUUID},
+		UserID:      1,
+		Purpose:     domain.UploadPurposeImportFile,
+		Key:         domain.UploadStagingKey(domain.UploadPurposeImportFile, sessionUUID),
+		ContentType: "text/csv",
+		Size:        int64(len(content)),
+		Checksum:    checksum(content),
+		Status:      domain.UploadSessionStatusPending,
+		ExpiresAt:   expiresAt,
+	}
+}
+
+func requireErrorMessage(t *testing.T, expected serviceerror.ErrorMessage, err error) {
+	require.Error(t, err)
+	require.Equal(t, expected, err.(*serviceerror.ServiceError).GetErrorMessage())
+}
+
+func TestUploadService_Create(t *testing.T) {
+	t.Run("Create success", func(t *testing.T) {
+		repo := new(userrepository.MockUploadSessionRepository)
+		repo.On("Create", mock.MatchedBy(func(session domain.UploadSession) bool {
+			return session.UserID == 1 &&
+				session.Status == domain.UploadSessionStatusPending &&
+				session.Key == domain.UploadStagingKey(domain.UploadPurposeImportFile, session.Base.UUID) &&
+				session.Checksum == checksum(content) &&
+				time.Until(session.ExpiresAt) > 14*time.Minute
+		})).Return(nil)
+
+		service := uploadservice.New(conf, newStorage(t))
+		session, link, err := service.Create(context.Background(), newUow(repo), domain.UploadSession{
+			UserID:      1,
+			Purpose:     domain.UploadPurposeImportFile,
+			ContentType: "text/csv",
+			Size:        int64(len(content)),
+			Checksum:    strings.ToUpper(checksum(content)),
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, domain.UploadSessionStatusPending, session.Status)
+		require.True(t, strings.HasPrefix(link, "http://localhost:2535/storage/"+session.Key+"?"))
+		require.Contains(t, link, "contentType=text%2Fcsv")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Create content type not allowed error", func(t *testing.T) {
+		service := uploadservice.New(conf, newStorage(t))
+		session, link, err := service.Create(context.Background(), new(userrepository.MockUnitOfWork), domain.UploadSession{
+			Purpose:     domain.UploadPurposeSentenceAudio,
+			ContentType: "text/csv",
+			Size:        10,
+		})
+
+		requireErrorMessage(t, serviceerror.UploadContentTypeNotAllowed, err)
+		require.Contains(t, err.(*serviceerror.ServiceError).GetAttributes()["contentTypes"], "audio/mpeg")
+		require.Nil(t, session)
+		require.Empty(t, link)
+	})
+
+	t.Run("Create too large error", func(t *testing.T) {
+		service := uploadservice.New(conf, newStorage(t))
+		session, link, err := service.Create(context.Background(), new(userrepository.MockUnitOfWork), domain.UploadSession{
+			Purpose:     domain.UploadPurposeSentenceAudio,
+			ContentType: "audio/mpeg",
+			Size:        conf.AudioMaxSize + 1,
+		})
+
+		requireErrorMessage(t, serviceerror.UploadTooLarge, err)
+		require.Equal(t, int64(1), err.(*serviceerror.ServiceError).GetAttributes()["maxSize"])
+		require.Nil(t, session)
+		require.Empty(t, link)
+	})
+
+	t.Run("Create repository error", func(t *testing.T) {
+		repo := new(userrepository.MockUploadSessionRepository)
+		repo.On("Create", mock.Anything).Return(serviceerror.NewServerError())
+
+		service := uploadservice.New(conf, newStorage(t))
+		session, link, err := service.Create(context.Background(), newUow(repo), domain.UploadSession{
+			Purpose:     domain.UploadPurposeImportFile,
+			ContentType: "text/csv",
+			Size:        10,
+		})
+
+		requireErrorMessage(t, serviceerror.ServerError, err)
+		require.Nil(t, session)
+		require.Empty(t, link)
+	})
+}
+
+func TestUploadService_Complete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Complete success", func(t *testing.T) {
+		storage := newStorage(t)
+		session := pendingSession(time.Now().Add(time.Minute))
+		require.NoError(t, storage.Put(ctx, session.Key, strings.NewReader(content), session.Size, session.ContentType))
+
+		stagingKey := session.Key
+		key := domain.UploadKey(session.Purpose, session.Base.UUID)
+
+		repo := new(userrepository.MockUploadSessionRepository)
+		repo.On("GetByUUID", session.Base.UUID).Return(session, nil)
+		repo.On("MarkCompleted", session.Base.ID, key).Return(nil)
+
+		mockUow := newUow(repo)
+		var afterCommit func() error
+		mockUow.On("AfterCommit", mock.Anything).Run(func(args mock.Arguments) {
+			afterCommit = args.Get(0).(func() error)
+		}).Return()
+
+		completed, err := uploadservice.New(conf, storage).Complete(ctx, mockUow, 1, session.Base.UUID.String())
+
+		require.NoError(t, err)
+		require.Equal(t, domain.UploadSessionStatusCompleted, completed.Status)
+		require.Equal(t, key, completed.Key)
+		require.NotNil(t, completed.CompletedAt)
+		repo.AssertExpectations(t)
+
+		_, err = storage.Get(ctx, stagingKey)
+		require.NoError(t, err)
+		require.NotNil(t, afterCommit)
+		require.NoError(t, afterCommit())
+		_, err = storage.Get(ctx, stagingKey)
+		requireErrorMessage(t, serviceerror.RecordNotFound, err)
+
+		// the presigned link still accepts uploads, they land in the staging key and never reach the verified copy
+		tampered := strings.Replace(content, "bonjour", "bonsoir", 1)
+		require.NoError(t, storage.Put(ctx, stagingKey, strings.NewReader(tampered), session.Size, session.ContentType))
+		reader, err := storage.Get(ctx, key)
+		require.NoError(t, err)
+		defer func() {
+			_ = reader.Close()
+		}()
+		stored, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.Equal(t, content, string(stored))
+	})
+
+	t.Run("Complete after the link expired success", func(t *testing.T) {
+		storage := newStorage(t)
+		session := pendingSession(time.Now().Add(-time.Minute))
+		require.NoError(t, storage.Put(ctx, session.Key, strings.NewReader(content), session.Size, session.ContentType))
+
+		repo := new(userrepository.MockUploadSessionRepository)
+		repo.On("GetByUUID", session.Base.UUID).Return(session, nil)
+		repo.On("MarkCompleted", session.Base.ID, domain.UploadKey(session.Purpose, session.Base.UUID)).Return(nil)
+
+		mockUow := newUow(repo)
+		mockUow.On("AfterCommit", mock.Anything).Return()
+
+		completed, err := uploadservice.New(conf, storage).Complete(ctx, mockUow, 1, session.Base.UUID.String())
+
+		require.NoError(t, err)
+		require.Equal(t, domain.UploadSessionStatusCompleted, completed.Status)
+	})
+
+	t.Run("Complete already completed success", func(t *testing.T) {
+		session := pendingSession(time.Now().Add(time.Minute))
+		session.Status = domain.UploadSessionStatusCompleted
+
+		repo := new(userrepository.MockUploadSessionRepository)
+		repo.On("GetByUUID", session.Base.UUID).Return(session, nil)
+
+		completed, err := uploadservice.New(conf, newStorage(t)).Complete(ctx, newUow(repo), 1, session.Base.UUID.String())
+
+		require.NoError(t, err)
+		require.Equal(t, session, completed)
+		repo.AssertNotCalled(t, "MarkCompleted", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Complete invalid UUID error", func(t *testing.T) {
+		completed, err := uploadservice.New(conf, newStorage(t)).Complete(ctx, new(userrepository.MockUnitOfWork), 1, "invalid-uuid")
+
+		requireErrorMessage(t, serviceerror.RecordNotFound, err)
+		require.Nil(t, completed)
+	})
+
+	t.Run("Complete session of another user error", func(t *testing.T) {
+		session := pendingSession(time.Now().Add(time.Minute))
+
+		repo := new(userrepository.MockUploadSessionRepository)
+		repo.On("GetByUUID", session.Base.UUID).Return(session, nil)
+
+		completed, err := uploadservice.New(conf, newStorage(t)).Complete(ctx, newUow(repo), 2, session.Base.UUID.String())
+
+		requireErrorMessage(t, serviceerror.RecordNotFound, err)
+		require.Nil(t, completed)
+	})
+
+	t.Run("Complete abandoned session error", func(t *testing.T) {
+		session := pendingSession(time.Now().Add(-time.Minute))
+		session.Status = domain.UploadSessionStatusAbandoned
+
+		repo := new(userrepository.MockUploadSessionRepository)
+		repo.On("GetByUUID", session.Base.UUID).Return(session, nil)
+
+		completed, err := uploadservice.New(conf, newStorage(t)).Complete(ctx, newUow(repo), 1, session.Base.UUID.String())
+
+		requireErrorMessage(t, serviceerror.UploadSessionExpired, err)
+		require.Nil(t, completed)
+	})
+
+	t.Run("Complete missing upload error", func(t *testing.T) {
+		session := pendingSession(time.Now().Add(time.Minute))
+
+		repo := new(userrepository.MockUploadSessionRepository)
+		repo.On("GetByUUID", session.Base.UUID).Return(session, nil)
+
+		completed, err := uploadservice.New(conf, newStorage(t)).Complete(ctx, newUow(repo), 1, session.Base.UUID.String())
+
+		requireErrorMessage(t, serviceerror.UploadMissing, err)
+		require.Nil(t, completed)
+	})
+
+	t.Run("Complete missing upload after the link expired error", func(t *testing.T) {
+		session := pendingSession(time.Now().Add(-time.Minute))
+
+		repo := new(userrepository.MockUploadSessionRepository)
+		repo.On("GetByUUID", session.Base.UUID).Return(session, nil)
+
+		completed, err := uploadservice.New(conf, newStorage(t)).Complete(ctx, newUow(repo), 1, session.Base.UUID.String())
+
+		requireErrorMessage(t, serviceerror.UploadSessionExpired, err)
+		require.Nil(t, completed)
+	})
+
+	t.Run("Complete checksum mismatch error", func(t *testing.T) {
+		storage := newStorage(t)
+		session := pendingSession(time.Now().Add(time.Minute))
+		tampered := strings.Replace(content, "bonjour", "bonsoir", 1)
+		require.NoError(t, storage.Put(ctx, session.Key, strings.NewReader(tampered), session.Size, session.ContentType))
+
+		repo := new(userrepository.MockUploadSessionRepository)
+		repo.On("GetByUUID", session.Base.UUID).Return(session, nil)
+
+		completed, err := uploadservice.New(conf, storage).Complete(ctx, newUow(repo), 1, session.Base.UUID.String())
+
+		requireErrorMessage(t, serviceerror.UploadChecksumMismatch, err)
+		require.Nil(t, completed)
+
+		_, err = storage.Get(ctx, session.Key)
+		requireErrorMessage(t, serviceerror.RecordNotFound, err)
+		_, err = storage.Get(ctx, domain.UploadKey(session.Purpose, session.Base.UUID))
+		requireErrorMessage(t, serviceerror.RecordNotFound, err)
+	})
+
+	t.Run("Complete size mismatch error", func(t *testing.T) {
+		storage := newStorage(t)
+		session := pendingSession(time.Now().Add(time.Minute))
+		larger := content + "bye,au revoir\n"
+		require.NoError(t, storage.Put(ctx, session.Key, strings.NewReader(larger), int64(len(larger)), session.ContentType))
+
+		repo := new(userrepository.MockUploadSessionRepository)
+		repo.On("GetByUUID", session.Base.UUID).Return(session, nil)
+
+		completed, err := uploadservice.New(conf, storage).Complete(ctx, newUow(repo), 1, session.Base.UUID.String())
+
+		requireErrorMessage(t, serviceerror.UploadChecksumMismatch, err)
+		require.Nil(t, completed)
+	})
+}
+
+func TestUploadService_Abandon(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Abandon expired session success", func(t *testing.T) {
+		storage := newStorage(t)
+		session := pendingSession(time.Now().Add(-time.Minute))
+		key := domain.UploadKey(session.Purpose, session.Base.UUID)
+		require.NoError(t, storage.Put(ctx, session.Key, strings.NewReader(content), session.Size, session.ContentType))
+		require.NoError(t, storage.Put(ctx, key, strings.NewReader(content), session.Size, session.ContentType))
+
+		repo := new(userrepository.MockUploadSessionRepository)
+		repo.On("GetByUUID", session.Base.UUID).Return(session, nil)
+		repo.On("MarkAbandoned", session.Base.ID).Return(nil)
+
+		err := uploadservice.New(conf, storage).Abandon(ctx, newUow(repo), session.Base.UUID.String())
+
+		require.NoError(t, err)
+		_, err = storage.Get(ctx, session.Key)
+		requireErrorMessage(t, serviceerror.RecordNotFound, err)
+		_, err = storage.Get(ctx, key)
+		requireErrorMessage(t, serviceerror.RecordNotFound, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Abandon completed session removes a later upload", func(t *testing.T) {
+		storage := newStorage(t)
+		session := pendingSession(time.Now().Add(-time.Minute))
+		stagingKey := session.Key
+		session.Key = domain.UploadKey(session.Purpose, session.Base.UUID)
+		session.Status = domain.UploadSessionStatusCompleted
+		require.NoError(t, storage.Put(ctx, session.Key, strings.NewReader(content), session.Size, session.ContentType))
+		// uploaded again through the link after the session was completed
+		require.NoError(t, storage.Put(ctx, stagingKey, strings.NewReader(content), session.Size, session.ContentType))
+
+		repo := new(userrepository.MockUploadSessionRepository)
+		repo.On("GetByUUID", session.Base.UUID).Return(session, nil)
+
+		err := uploadservice.New(conf, storage).Abandon(ctx, newUow(repo), session.Base.UUID.String())
+
+		require.NoError(t, err)
+		_, err = storage.Get(ctx, session.Key)
+		require.NoError(t, err)
+		_, err = storage.Get(ctx, stagingKey)
+		requireErrorMessage(t, serviceerror.RecordNotFound, err)
+		repo.AssertNotCalled(t, "MarkAbandoned", mock.Anything)
+	})
+
+	t.Run("Abandon not expired session is ignored", func(t *testing.T) {
+		session := pendingSession(time.Now().Add(time.Minute))
+
+		repo := new(userrepository.MockUploadSessionRepository)
+		repo.On("GetByUUID", session.Base.UUID).Return(session, nil)
+
+		err := uploadservice.New(conf, newStorage(t)).Abandon(ctx, newUow(repo), session.Base.UUID.String())
+
+		require.NoError(t, err)
+		repo.AssertNotCalled(t, "MarkAbandoned", mock.Anything)
+	})
+
+	t.Run("Abandon not found error", func(t *testing.T) {
+		sessionUUID := uuid.New()
+
+		repo := new(userrepository.MockUploadSessionRepository)
+		repo.On("GetByUUID", sessionUUID).Return((*domain.UploadSession)(nil), serviceerror.New(serviceerror.RecordNotFound))
+
+		err := uploadservice.New(conf, newStorage(t)).Abandon(ctx, newUow(repo), sessionUUID.String())
+
+		requireErrorMessage(t, serviceerror.RecordNotFound, err)
+	})
+}
@@ -0,0 +1,104 @@
+package userdataservice
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/port"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+)
+
+// ArchiveDataFile is the name of the JSON document inside the export archive.
+const ArchiveDataFile = "data.json"
+
+type Service struct {
+	aclCache port.ACLCacheService
+}
+
+func New(aclCache port.ACLCacheService) *Service {
+	return &Service{
+		aclCache: aclCache,
+	}
+}
+
+// Export gathers the data of the user.
+func (r *Service) Export(uow port.UserUnitOfWork, userID uint64) (*domain.UserDataExport, error) {
+	return uow.UserDataRepository().GetExport(userID)
+}
+
+// RecordExport records the export in the audit log, it is called once the archive is stored.
+func (r *Service) RecordExport(uow port.UserUnitOfWork, export *domain.UserDataExport) error {
+	return uow.AuditLogRepository().Create(domain.NewAuditLog(
+		domain.AuditActor{UserID: &export.Profile.ID},
+		domain.AuditActionUserDataExported,
+		domain.AuditTargetUser,
+		&export.Profile.UUID,
+		nil,
+		nil,
+	))
+}
+
+// Archive zips the export as a single indented JSON document.
+func (r *Service) Archive(export *domain.UserDataExport) ([]byte, error) {
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, serviceerror.NewServerError()
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := zip.NewWriter(buffer)
+
+	file, err := writer.Create(ArchiveDataFile)
+	if err != nil {
+		return nil, serviceerror.NewServerError()
+	}
+
+	if _, err = file.Write(data); err != nil {
+		return nil, serviceerror.NewServerError()
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, serviceerror.NewServerError()
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Erase anonymizes the user and records who erased it, the returned user holds the avatar to remove from storage.
+// The cached access of the user is dropped once the erasure is committed.
+func (r *Service) Erase(
+	ctx context.Context,
+	uow port.UserUnitOfWork,
+	userUUIDStr string,
+	actor domain.AuditActor,
+) (*domain.User, error) {
+	userUUID, err := uuid.Parse(userUUIDStr)
+	if err != nil {
+		return nil, serviceerror.New(serviceerror.RecordNotFound)
+	}
+
+	user, err := uow.UserDataRepository().Erase(userUUID, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = uow.AuditLogRepository().Create(domain.NewAuditLog(
+		actor,
+		domain.AuditActionUserErased,
+		domain.AuditTargetUser,
+		&user.Base.UUID,
+		nil,
+		nil,
+	)); err != nil {
+		return nil, err
+	}
+
+	uow.AfterCommit(func() error {
+		return r.aclCache.Forget(ctx, user.Base.UUID.String())
+	})
+
+	return user, nil
+}
@@ -0,0 +1,190 @@
+package userdataservice_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/auditrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/adapter/storage/postgres/userrepository"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/domain"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/aclservice"
+	"github.com/mohsenabedy91/polyglot-sentences/internal/core/service/userdataservice"
+	"github.com/mohsenabedy91/polyglot-sentences/pkg/serviceerror"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestService_Export(t *testing.T) {
+	userID := uint64(7)
+	export := &domain.UserDataExport{
+		Profile: domain.UserDataProfile{ID: userID, UUID: uuid.New(), Email: "john@example.com"},
+	}
+
+	t.Run("Export success", func(t *testing.T) {
+		mockDataRepo := new(userrepository.MockUserDataRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserDataRepository").Return(mockDataRepo)
+
+		mockDataRepo.On("GetExport", userID).Return(export, nil)
+
+		result, err := userdataservice.New(nil).Export(mockUow, userID)
+
+		require.NoError(t, err)
+		require.Equal(t, export, result)
+		mockDataRepo.AssertExpectations(t)
+		mockUow.AssertNotCalled(t, "AuditLogRepository")
+	})
+
+	t.Run("Export user not found", func(t *testing.T) {
+		mockDataRepo := new(userrepository.MockUserDataRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserDataRepository").Return(mockDataRepo)
+
+		mockDataRepo.On("GetExport", userID).Return((*domain.UserDataExport)(nil), serviceerror.New(serviceerror.RecordNotFound))
+
+		result, err := userdataservice.New(nil).Export(mockUow, userID)
+
+		require.Error(t, err)
+		require.Nil(t, result)
+	})
+}
+
+func TestService_RecordExport(t *testing.T) {
+	userID := uint64(7)
+	export := &domain.UserDataExport{
+		Profile: domain.UserDataProfile{ID: userID, UUID: uuid.New(), Email: "john@example.com"},
+	}
+
+	mockAuditRepo := new(auditrepository.MockAuditLogRepository)
+	mockUow := new(userrepository.MockUnitOfWork)
+	mockUow.On("AuditLogRepository").Return(mockAuditRepo)
+
+	mockAuditRepo.On("Create", mock.MatchedBy(func(auditLog domain.AuditLog) bool {
+		return auditLog.Action == domain.AuditActionUserDataExported &&
+			*auditLog.Actor.UserID == userID &&
+			*auditLog.TargetUUID == export.Profile.UUID
+	})).Return(nil)
+
+	require.NoError(t, userdataservice.New(nil).RecordExport(mockUow, export))
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestService_Archive(t *testing.T) {
+	firstName := "John"
+	export := &domain.UserDataExport{
+		Profile: domain.UserDataProfile{UUID: uuid.New(), FirstName: &firstName, Email: "john@example.com"},
+		Roles:   []domain.UserDataRole{{Key: "USER", Title: "User"}},
+		Sentences: []domain.UserDataSentence{
+			{UUID: uuid.New(), Text: "I have been learning.", Grammar: "Present Perfect Continuous", Level: domain.SentenceLevelEasy},
+		},
+		Notifications: []domain.UserDataNotification{
+			{UUID: uuid.New(), Type: domain.NotificationTypeAssignment, Title: "New assignment", Body: "Translate ten sentences."},
+		},
+		NotificationPreferences: []domain.UserDataNotificationPreference{
+			{Category: domain.NotificationCategoryProduct, Enabled: false},
+		},
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	archive, err := userdataservice.New(nil).Archive(export)
+	require.NoError(t, err)
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+	require.Len(t, reader.File, 1)
+	require.Equal(t, userdataservice.ArchiveDataFile, reader.File[0].Name)
+
+	file, err := reader.File[0].Open()
+	require.NoError(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+
+	data, err := io.ReadAll(file)
+	require.NoError(t, err)
+
+	var result domain.UserDataExport
+	require.NoError(t, json.Unmarshal(data, &result))
+	require.Equal(t, export.Profile.UUID, result.Profile.UUID)
+	require.Equal(t, firstName, *result.Profile.FirstName)
+	require.Equal(t, export.Roles, result.Roles)
+	require.Equal(t, export.Sentences, result.Sentences)
+	require.Equal(t, export.Notifications, result.Notifications)
+	require.Equal(t, export.NotificationPreferences, result.NotificationPreferences)
+	require.True(t, export.GeneratedAt.Equal(result.GeneratedAt))
+	require.NotContains(t, string(data), `"ID"`)
+}
+
+func TestService_Erase(t *testing.T) {
+	adminID := uint64(1)
+	userUUID := uuid.New()
+	avatar := "http://minio:9000/bucket/avatar.png"
+	actor := domain.AuditActor{UserID: &adminID, IP: "127.0.0.1", UserAgent: "test"}
+	ctx := context.TODO()
+
+	t.Run("Erase success", func(t *testing.T) {
+		mockDataRepo := new(userrepository.MockUserDataRepository)
+		mockAuditRepo := new(auditrepository.MockAuditLogRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserDataRepository").Return(mockDataRepo)
+		mockUow.On("AuditLogRepository").Return(mockAuditRepo)
+
+		erased := &domain.User{Base: domain.Base{ID: 7, UUID: userUUID}, Avatar: &avatar}
+		mockDataRepo.On("Erase", userUUID, &adminID).Return(erased, nil)
+		mockAuditRepo.On("Create", mock.MatchedBy(func(auditLog domain.AuditLog) bool {
+			return auditLog.Action == domain.AuditActionUserErased &&
+				auditLog.Actor == actor &&
+				*auditLog.TargetUUID == userUUID &&
+				len(auditLog.Changes) == 0
+		})).Return(nil)
+
+		var afterCommit func() error
+		mockUow.On("AfterCommit", mock.Anything).Run(func(args mock.Arguments) {
+			afterCommit = args.Get(0).(func() error)
+		}).Return()
+
+		mockACLCacheService := new(aclservice.MockACLCacheService)
+		mockACLCacheService.On("Forget", ctx, []string{userUUID.String()}).Return(nil)
+
+		user, err := userdataservice.New(mockACLCacheService).Erase(ctx, mockUow, userUUID.String(), actor)
+
+		require.NoError(t, err)
+		require.Equal(t, erased, user)
+		mockACLCacheService.AssertNotCalled(t, "Forget", mock.Anything, mock.Anything)
+		require.NoError(t, afterCommit())
+
+		mockDataRepo.AssertExpectations(t)
+		mockAuditRepo.AssertExpectations(t)
+		mockACLCacheService.AssertExpectations(t)
+	})
+
+	t.Run("Erase invalid uuid", func(t *testing.T) {
+		mockUow := new(userrepository.MockUnitOfWork)
+
+		user, err := userdataservice.New(nil).Erase(ctx, mockUow, "invalid", actor)
+
+		require.Error(t, err)
+		require.Nil(t, user)
+		require.Equal(t, serviceerror.RecordNotFound, err.(*serviceerror.ServiceError).GetErrorMessage())
+		mockUow.AssertNotCalled(t, "UserDataRepository")
+	})
+
+	t.Run("Erase repository error", func(t *testing.T) {
+		mockDataRepo := new(userrepository.MockUserDataRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserDataRepository").Return(mockDataRepo)
+
+		mockDataRepo.On("Erase", userUUID, &adminID).Return((*domain.User)(nil), serviceerror.NewServerError())
+
+		user, err := userdataservice.New(nil).Erase(ctx, mockUow, userUUID.String(), actor)
+
+		require.Error(t, err)
+		require.Nil(t, user)
+		mockUow.AssertNotCalled(t, "AuditLogRepository")
+	})
+}
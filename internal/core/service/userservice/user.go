@@ -81,3 +81,11 @@ func (r *UserService) UpdateLastLoginTime(uow port.UserUnitOfWork, id uint64) er
 func (r *UserService) UpdatePassword(uow port.UserUnitOfWork, id uint64, password string) error {
 	return uow.UserRepository().UpdatePassword(id, password)
 }
+
+func (r *UserService) UpdateAvatar(uow port.UserUnitOfWork, id uint64, avatar string) error {
+	return uow.UserRepository().UpdateAvatar(id, avatar)
+}
+
+func (r *UserService) IsAvatarInUse(uow port.UserUnitOfWork, avatar string) (bool, error) {
+	return uow.UserRepository().IsAvatarInUse(avatar)
+}
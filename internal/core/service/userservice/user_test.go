@@ -525,3 +525,87 @@ func TestUserService_UpdatePassword(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestUserService_UpdateAvatar(t *testing.T) {
+	mockLogger := new(logger.MockLogger)
+	id := uint64(1)
+	avatar := "avatars/0a1b2c.png"
+
+	t.Run("UpdateAvatar success", func(t *testing.T) {
+		mockRepo := new(userrepository.MockUserRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+
+		mockUow.On("UserRepository").Return(mockRepo)
+
+		mockRepo.On("UpdateAvatar", id, avatar).Return(nil)
+
+		service := userservice.New(mockLogger)
+		err := service.UpdateAvatar(mockUow, id, avatar)
+
+		require.NoError(t, err)
+
+		mockUow.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("UpdateAvatar repository error", func(t *testing.T) {
+		mockRepo := new(userrepository.MockUserRepository)
+
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserRepository").Return(mockRepo)
+
+		mockRepo.On("UpdateAvatar", id, avatar).Return(serviceerror.NewServerError())
+
+		service := userservice.New(mockLogger)
+		err := service.UpdateAvatar(mockUow, id, avatar)
+
+		require.Error(t, err)
+		require.IsType(t, &serviceerror.ServiceError{}, err)
+		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+		mockUow.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserService_IsAvatarInUse(t *testing.T) {
+	mockLogger := new(logger.MockLogger)
+	avatar := "avatars/0a1b2c.png"
+
+	t.Run("IsAvatarInUse success", func(t *testing.T) {
+		mockRepo := new(userrepository.MockUserRepository)
+		mockUow := new(userrepository.MockUnitOfWork)
+
+		mockUow.On("UserRepository").Return(mockRepo)
+
+		mockRepo.On("IsAvatarInUse", avatar).Return(true, nil)
+
+		service := userservice.New(mockLogger)
+		inUse, err := service.IsAvatarInUse(mockUow, avatar)
+
+		require.NoError(t, err)
+		require.True(t, inUse)
+
+		mockUow.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("IsAvatarInUse repository error", func(t *testing.T) {
+		mockRepo := new(userrepository.MockUserRepository)
+
+		mockUow := new(userrepository.MockUnitOfWork)
+		mockUow.On("UserRepository").Return(mockRepo)
+
+		mockRepo.On("IsAvatarInUse", avatar).Return(false, serviceerror.NewServerError())
+
+		service := userservice.New(mockLogger)
+		inUse, err := service.IsAvatarInUse(mockUow, avatar)
+
+		require.Error(t, err)
+		require.False(t, inUse)
+		require.Equal(t, serviceerror.ServerError, err.(*serviceerror.ServiceError).GetErrorMessage())
+
+		mockUow.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+}
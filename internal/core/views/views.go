@@ -0,0 +1,20 @@
+package views
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed email
+var email embed.FS
+
+// EmailTemplates returns the email templates rooted at the email directory, so they are read from the binary
+// whatever directory it runs from.
+func EmailTemplates() fs.FS {
+	templates, err := fs.Sub(email, "email")
+	if err != nil {
+		panic(err)
+	}
+
+	return templates
+}
@@ -1,21 +1,130 @@
 package helper
 
 import (
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 	"math"
 	"math/rand"
 	"strconv"
+	"strings"
 	"time"
 )
 
-func HashPassword(password string, cost int) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), cost)
-	return string(bytes), err
+const argon2IDPrefix = "$argon2id$"
+
+var ErrInvalidArgon2Params = errors.New("invalid argon2id parameters")
+
+// Argon2Params are the Argon2id costs encoded into every password hash.
+// Memory is expressed in KiB.
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// HashPassword hashes the password with Argon2id and returns it in the PHC string format:
+// $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>
+func HashPassword(password string, params Argon2Params) (string, error) {
+	if params.Memory == 0 || params.Iterations == 0 || params.Parallelism == 0 ||
+		params.SaltLength == 0 || params.KeyLength == 0 {
+		return "", ErrInvalidArgon2Params
+	}
+
+	salt := make([]byte, params.SaltLength)
+	if _, err := cryptorand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return fmt.Sprintf(
+		"%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2IDPrefix,
+		argon2.Version,
+		params.Memory,
+		params.Iterations,
+		params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
 }
 
+// CheckPasswordHash reports whether the password matches the hash.
+// Both Argon2id hashes and legacy bcrypt hashes are supported.
 func CheckPasswordHash(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+	if !strings.HasPrefix(hash, argon2IDPrefix) {
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		return err == nil
+	}
+
+	params, salt, key, err := decodeArgon2Hash(hash)
+	if err != nil {
+		return false
+	}
+
+	otherKey := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return subtle.ConstantTimeCompare(key, otherKey) == 1
+}
+
+// PasswordNeedsRehash reports whether the hash was not produced by Argon2id with the given params,
+// e.g. a legacy bcrypt hash or an Argon2id hash created before the costs were raised.
+func PasswordNeedsRehash(hash string, params Argon2Params) bool {
+	if !strings.HasPrefix(hash, argon2IDPrefix) {
+		return true
+	}
+
+	current, _, _, err := decodeArgon2Hash(hash)
+	if err != nil {
+		return true
+	}
+
+	return current != params
+}
+
+func decodeArgon2Hash(hash string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, ErrInvalidArgon2Params
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrInvalidArgon2Params
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, ErrInvalidArgon2Params
+	}
+	if params.Memory == 0 || params.Iterations == 0 || params.Parallelism == 0 {
+		return params, nil, nil, ErrInvalidArgon2Params
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(salt) == 0 {
+		return params, nil, nil, ErrInvalidArgon2Params
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrInvalidArgon2Params
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
 }
 
 func GenerateOTP(digits int8) string {
@@ -27,3 +136,19 @@ func GenerateOTP(digits int8) string {
 
 	return strconv.Itoa(otp)
 }
+
+// GenerateToken returns a hex encoded random token built from size random bytes.
+func GenerateToken(size int) (string, error) {
+	bytes := make([]byte, size)
+	if _, err := cryptorand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
+
+// HashToken returns the hex encoded SHA-256 of the token, so only the hash has to be stored.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
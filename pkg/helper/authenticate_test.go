@@ -6,61 +6,63 @@ import (
 	"golang.org/x/crypto/bcrypt"
 	"math"
 	"strconv"
+	"strings"
 	"testing"
 )
 
+var argon2Params = helper.Argon2Params{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
 func TestHashPassword(t *testing.T) {
 	tests := []struct {
 		name          string
 		password      string
-		cost          int
+		params        helper.Argon2Params
 		expectedError bool
 	}{
 		{
-			name:          "Successful hashing with default cost",
+			name:          "Successful hashing",
 			password:      "mySecurePassword",
-			cost:          bcrypt.DefaultCost,
+			params:        argon2Params,
 			expectedError: false,
 		},
 		{
-			name:          "Successful hashing with higher cost",
-			password:      "mySecurePassword",
-			cost:          bcrypt.DefaultCost + 2,
+			name:          "Empty password",
+			password:      "",
+			params:        argon2Params,
 			expectedError: false,
 		},
 		{
-			name:          "Empty password with default cost",
-			password:      "",
-			cost:          bcrypt.DefaultCost,
+			name:          "Password length more than 72 characters",
+			password:      "thisisaverylongpasswordthatexceedsthebcryptpasswordlengthlimitof72charactersandshouldbehandledcorrectly",
+			params:        argon2Params,
 			expectedError: false,
 		},
 		{
-			name:          "Invalid cost",
+			name:          "Invalid params",
 			password:      "mySecurePassword",
-			cost:          bcrypt.MaxCost + 1,
-			expectedError: true,
-		},
-		{
-			name:          "Password length more than 72 characters",
-			password:      "thisisaverylongpasswordthatexceedsthebcryptpasswordlengthlimitof72charactersandshouldbehandledcorrectly",
-			cost:          bcrypt.DefaultCost,
+			params:        helper.Argon2Params{Memory: 1024, SaltLength: 16, KeyLength: 32},
 			expectedError: true,
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			hash, err := helper.HashPassword(test.password, test.cost)
+			hash, err := helper.HashPassword(test.password, test.params)
 
 			if test.expectedError {
 				require.Error(t, err)
 				require.Equal(t, "", hash)
 			} else {
 				require.NoError(t, err)
-				require.NotEmpty(t, hash)
-
-				err = bcrypt.CompareHashAndPassword([]byte(hash), []byte(test.password))
-				require.NoError(t, err)
+				require.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+				require.True(t, helper.CheckPasswordHash(test.password, hash))
+				require.False(t, helper.PasswordNeedsRehash(hash, test.params))
 			}
 		})
 	}
@@ -106,6 +108,30 @@ func TestCheckPasswordHash(t *testing.T) {
 			hashedPassword: "",
 			expectedResult: false,
 		},
+		{
+			name:     "Correct password and argon2id hash",
+			password: "mySecurePassword",
+			hashedPassword: func() string {
+				h, _ := helper.HashPassword("mySecurePassword", argon2Params)
+				return h
+			}(),
+			expectedResult: true,
+		},
+		{
+			name:     "Incorrect password and argon2id hash",
+			password: "wrongPassword",
+			hashedPassword: func() string {
+				h, _ := helper.HashPassword("mySecurePassword", argon2Params)
+				return h
+			}(),
+			expectedResult: false,
+		},
+		{
+			name:           "Malformed argon2id hash",
+			password:       "mySecurePassword",
+			hashedPassword: "$argon2id$v=19$m=1024,t=1$c2FsdA$a2V5",
+			expectedResult: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -116,6 +142,22 @@ func TestCheckPasswordHash(t *testing.T) {
 	}
 }
 
+func TestPasswordNeedsRehash(t *testing.T) {
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("mySecurePassword"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	argon2Hash, err := helper.HashPassword("mySecurePassword", argon2Params)
+	require.NoError(t, err)
+
+	strongerParams := argon2Params
+	strongerParams.Iterations++
+
+	require.True(t, helper.PasswordNeedsRehash(string(bcryptHash), argon2Params))
+	require.False(t, helper.PasswordNeedsRehash(argon2Hash, argon2Params))
+	require.True(t, helper.PasswordNeedsRehash(argon2Hash, strongerParams))
+	require.True(t, helper.PasswordNeedsRehash("$argon2id$invalid", argon2Params))
+}
+
 func TestGenerateOTP(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -152,3 +194,21 @@ func TestGenerateOTP(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateToken(t *testing.T) {
+	token, err := helper.GenerateToken(32)
+	require.NoError(t, err)
+	require.Len(t, token, 64)
+
+	other, err := helper.GenerateToken(32)
+	require.NoError(t, err)
+	require.NotEqual(t, token, other)
+}
+
+func TestHashToken(t *testing.T) {
+	hash := helper.HashToken("token")
+
+	require.Len(t, hash, 64)
+	require.Equal(t, hash, helper.HashToken("token"))
+	require.NotEqual(t, hash, helper.HashToken("other-token"))
+}
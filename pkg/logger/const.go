@@ -36,6 +36,7 @@ const (
 	Apple           Category = "Apple"
 	Queue           Category = "Queue"
 	Minio           Category = "Minio"
+	Storage         Category = "Storage"
 )
 
 const (
@@ -57,9 +58,11 @@ const (
 	DatabaseBeginTransaction SubCategory = "DatabaseBeginTransaction"
 	DatabaseCommit           SubCategory = "DatabaseCommit"
 	DatabaseRollback         SubCategory = "DatabaseRollback"
+	DatabaseAfterCommit      SubCategory = "DatabaseAfterCommit"
 	DatabasePrepare          SubCategory = "DatabasePrepare"
 	MigrationUp              SubCategory = "MigrationUp"
 	MigrationDown            SubCategory = "MigrationDown"
+	MigrationPermissions     SubCategory = "MigrationPermissions"
 
 	Redis         SubCategory = "Redis"
 	RedisRemember SubCategory = "RedisRemember"
@@ -93,6 +96,8 @@ const (
 	VonageRetrySMS    SubCategory = "VonageRetrySMS"
 	VonageUpdateSMS   SubCategory = "VonageUpdateSMS"
 	SendGridSendEmail SubCategory = "SendGridSendEmail"
+	SMTPSendEmail     SubCategory = "SMTPSendEmail"
+	FileSendEmail     SubCategory = "FileSendEmail"
 	SendEmail         SubCategory = "SendEmail"
 
 	GoogleLogin   SubCategory = "GoogleLogin"
@@ -106,9 +111,40 @@ const (
 	RabbitMQPublish          SubCategory = "RabbitMQPublish"
 	RabbitMQConsume          SubCategory = "RabbitMQConsume"
 	RabbitMQRegisterConsumer SubCategory = "RabbitMQRegisterConsumer"
+	RabbitMQRetry            SubCategory = "RabbitMQRetry"
+	RabbitMQDeadLetter       SubCategory = "RabbitMQDeadLetter"
+	RabbitMQReconnect        SubCategory = "RabbitMQReconnect"
+
+	QueueDuplicate SubCategory = "QueueDuplicate"
+
+	MemoryQueue           SubCategory = "MemoryQueue"
+	MemoryQueueRetry      SubCategory = "MemoryQueueRetry"
+	MemoryQueueDeadLetter SubCategory = "MemoryQueueDeadLetter"
+
+	RedisStreamProduce    SubCategory = "RedisStreamProduce"
+	RedisStreamConsume    SubCategory = "RedisStreamConsume"
+	RedisStreamRetry      SubCategory = "RedisStreamRetry"
+	RedisStreamDeadLetter SubCategory = "RedisStreamDeadLetter"
+	RedisStreamClaim      SubCategory = "RedisStreamClaim"
+
+	RedisPublish   SubCategory = "RedisPublish"
+	RedisSubscribe SubCategory = "RedisSubscribe"
+
+	OutboxRelay   SubCategory = "OutboxRelay"
+	OutboxCleanup SubCategory = "OutboxCleanup"
 
 	MinioCreateBucket SubCategory = "MinioCreateBucket"
 	MinioUpload       SubCategory = "MinioUpload"
+	MinioPresign      SubCategory = "MinioPresign"
+	MinioRemove       SubCategory = "MinioRemove"
+	MinioGet          SubCategory = "MinioGet"
+	MinioList         SubCategory = "MinioList"
+	MinioCopy         SubCategory = "MinioCopy"
+
+	StorageWrite  SubCategory = "StorageWrite"
+	StorageRead   SubCategory = "StorageRead"
+	StorageRemove SubCategory = "StorageRemove"
+	StorageList   SubCategory = "StorageList"
 )
 
 const (
@@ -8,3 +8,10 @@ var DbCall = prometheus.NewCounterVec(
 		Help: "Number of database calls",
 	}, []string{"type_name", "operation_name", "status"},
 )
+
+var CacheCall = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "cache_calls_total",
+		Help: "Number of cache calls, status is Hit, Miss or Failed for reads",
+	}, []string{"type_name", "operation_name", "status"},
+)
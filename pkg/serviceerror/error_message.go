@@ -21,10 +21,12 @@ var (
 	CredentialInvalid ErrorMessage = "errors.credentialInvalid"
 	UserLogout        ErrorMessage = "errors.userLogout"
 	PasswordIsNull    ErrorMessage = "errors.passwordIsNull"
+	PasswordReused    ErrorMessage = "errors.passwordReused"
 
 	// OTP
-	InvalidOTP ErrorMessage = "errors.invalidOTP"
-	OTPExpired ErrorMessage = "errors.OTPExpired"
+	InvalidOTP              ErrorMessage = "errors.invalidOTP"
+	OTPExpired              ErrorMessage = "errors.OTPExpired"
+	InvalidVerificationLink ErrorMessage = "errors.invalidVerificationLink"
 
 	// Token
 	InvalidToken ErrorMessage = "errors.invalidToken"
@@ -34,5 +36,29 @@ var (
 	InvalidRequestBody ErrorMessage = "errors.invalidRequestBody"
 
 	// Role
-	RoleExisted ErrorMessage = "errors.roleExisted"
+	RoleExisted          ErrorMessage = "errors.roleExisted"
+	RoleHierarchyCycle   ErrorMessage = "errors.roleHierarchyCycle"
+	RoleHierarchyTooDeep ErrorMessage = "errors.roleHierarchyTooDeep"
+
+	// Invitation
+	InvitationInvalid ErrorMessage = "errors.invitationInvalid"
+	InvitationExpired ErrorMessage = "errors.invitationExpired"
+
+	// Notification preference
+	NotificationCategoryEssential ErrorMessage = "errors.notificationCategoryEssential"
+	InvalidUnsubscribeLink        ErrorMessage = "errors.invalidUnsubscribeLink"
+
+	// Email delivery
+	InvalidWebhookSignature ErrorMessage = "errors.invalidWebhookSignature"
+
+	// Avatar
+	AvatarInvalid  ErrorMessage = "errors.avatarInvalid"
+	AvatarTooLarge ErrorMessage = "errors.avatarTooLarge"
+
+	// Upload
+	UploadContentTypeNotAllowed ErrorMessage = "errors.uploadContentTypeNotAllowed"
+	UploadTooLarge              ErrorMessage = "errors.uploadTooLarge"
+	UploadSessionExpired        ErrorMessage = "errors.uploadSessionExpired"
+	UploadMissing               ErrorMessage = "errors.uploadMissing"
+	UploadChecksumMismatch      ErrorMessage = "errors.uploadChecksumMismatch"
 )